@@ -22,8 +22,13 @@ import (
 	"net/http"
 	"os"
 
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/aws"        // Register the KMS providers of the VerificationPolicy keys.
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/azure"      // Register the KMS providers of the VerificationPolicy keys.
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/gcp"        // Register the KMS providers of the VerificationPolicy keys.
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/hashivault" // Register the KMS providers of the VerificationPolicy keys.
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/approval"
 	"github.com/tektoncd/pipeline/pkg/reconciler/customrun"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun"
 	"github.com/tektoncd/pipeline/pkg/reconciler/resolutionrequest"
@@ -63,12 +68,21 @@ func main() {
 	flag.StringVar(&opts.Images.ImageDigestExporterImage, "imagedigest-exporter-image", "", "The container image containing our image digest exporter binary.")
 	flag.StringVar(&opts.Images.WorkingDirInitImage, "workingdirinit-image", "", "The container image containing our working dir init binary.")
 
+	// These flags configure the SPIRE client of the controller, used when the enable-spire feature flag is on.
+	flag.StringVar(&opts.SpireConfig.TrustDomain, "spire-trust-domain", "example.org", "Experimental: The SPIRE Trust domain to use.")
+	flag.StringVar(&opts.SpireConfig.SocketPath, "spire-socket-path", "unix:///spiffe-workload-api/spire-agent.sock", "Experimental: The SPIRE agent socket for SPIFFE workload API.")
+	flag.StringVar(&opts.SpireConfig.ServerAddr, "spire-server-addr", "spire-server.spire.svc.cluster.local:8081", "Experimental: The SPIRE server address for workload/node registration.")
+	flag.StringVar(&opts.SpireConfig.NodeAliasPrefix, "spire-node-alias-prefix", "/tekton-node/", "Experimental: The SPIRE node alias prefix to use.")
+
 	// This parses flags.
 	cfg := injection.ParseAndGetRESTConfigOrDie()
 
 	if err := opts.Images.Validate(); err != nil {
 		log.Fatal(err)
 	}
+	if err := opts.SpireConfig.Validate(); err != nil {
+		log.Fatal(err)
+	}
 	if cfg.QPS == 0 {
 		cfg.QPS = 2 * rest.DefaultQPS
 	}
@@ -110,6 +124,7 @@ func main() {
 		run.NewController(),
 		resolutionrequest.NewController(clock.RealClock{}),
 		customrun.NewController(),
+		approval.NewController(clock.RealClock{}),
 	)
 }
 
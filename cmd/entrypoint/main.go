@@ -31,9 +31,15 @@ import (
 	"github.com/tektoncd/pipeline/cmd/entrypoint/subcommands"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/credentials"
-	"github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
-	"github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
+	// Register the credential types creds-init can initialize.
+	_ "github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/mavencreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/netrccreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/npmcreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/pipcreds"
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	"github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/pkg/spire/config"
 	"github.com/tektoncd/pipeline/pkg/termination"
@@ -43,6 +49,7 @@ var (
 	ep                  = flag.String("entrypoint", "", "Original specified entrypoint to execute")
 	waitFiles           = flag.String("wait_file", "", "Comma-separated list of paths to wait for")
 	waitFileContent     = flag.Bool("wait_file_content", false, "If specified, expect wait_file to have content")
+	waitReadyFiles      = flag.String("wait_ready_file", "", "Comma-separated list of paths to wait for until they have content, after wait_file")
 	postFile            = flag.String("post_file", "", "If specified, file to write upon completion")
 	terminationPath     = flag.String("termination_path", "/tekton/termination", "If specified, file to write upon termination")
 	results             = flag.String("results", "", "If specified, list of file names that might contain task results")
@@ -55,6 +62,8 @@ var (
 	stepMetadataDir = flag.String("step_metadata_dir", "", "If specified, create directory to store the step metadata e.g. /tekton/steps/<step-name>/")
 	enableSpire     = flag.Bool("enable_spire", false, "If specified by configmap, this enables spire signing and verification")
 	socketPath      = flag.String("spire_socket_path", "unix:///spiffe-workload-api/spire-agent.sock", "Experimental: The SPIRE agent socket for SPIFFE workload API.")
+	cgroupDir       = flag.String("cgroup_dir", "/sys/fs/cgroup", "The cgroup v2 directory of the step's container, sampled to report the resource usage of the step. Set to \"\" to disable.")
+	secretMaskDir   = flag.String("secret_mask_dir", "", "If specified, directory of the files holding the secret values to redact from stdout, stderr and the results")
 )
 
 const (
@@ -81,8 +90,7 @@ func checkForBreakpointOnFailure(e entrypoint.Entrypointer, breakpointExitPostFi
 func main() {
 	// Add credential flags originally introduced with our legacy credentials helper
 	// image (creds-init).
-	gitcreds.AddFlags(flag.CommandLine)
-	dockercreds.AddFlags(flag.CommandLine)
+	credentials.AddFlags(flag.CommandLine)
 
 	// Split args with `--` for the entrypoint and what it should execute
 	args, commandArgs := extractArgs(os.Args[1:])
@@ -107,7 +115,7 @@ func main() {
 	// from secret volume mounts to /tekton/creds. This is done to support the expansion
 	// of a variable, $(credentials.path), that resolves to a single place with all the
 	// stored credentials.
-	builders := []credentials.Builder{dockercreds.NewBuilder(), gitcreds.NewBuilder()}
+	builders := credentials.Builders()
 	for _, c := range builders {
 		if err := c.Write(pipeline.CredsDir); err != nil {
 			log.Printf("Error initializing credentials: %s", err)
@@ -143,16 +151,26 @@ func main() {
 		spireWorkloadAPI = spire.NewEntrypointerAPIClient(&spireConfig)
 	}
 
+	var secretMasker *entrypoint.SecretMasker
+	if *secretMaskDir != "" {
+		var err error
+		if secretMasker, err = entrypoint.NewSecretMaskerFromDir(*secretMaskDir); err != nil {
+			log.Fatalf("Error reading the secrets to mask: %v", err)
+		}
+	}
+
 	e := entrypoint.Entrypointer{
 		Command:         append(cmd, commandArgs...),
 		WaitFiles:       strings.Split(*waitFiles, ","),
 		WaitFileContent: *waitFileContent,
+		WaitReadyFiles:  strings.Split(*waitReadyFiles, ","),
 		PostFile:        *postFile,
 		TerminationPath: *terminationPath,
 		Waiter:          &realWaiter{waitPollingInterval: defaultWaitPollingInterval, breakpointOnFailure: *breakpointOnFailure},
 		Runner: &realRunner{
-			stdoutPath: *stdoutPath,
-			stderrPath: *stderrPath,
+			stdoutPath:   *stdoutPath,
+			stderrPath:   *stderrPath,
+			secretMasker: secretMasker,
 		},
 		PostWriter:          &realPostWriter{},
 		Results:             strings.Split(*results, ","),
@@ -161,11 +179,14 @@ func main() {
 		OnError:             *onError,
 		StepMetadataDir:     *stepMetadataDir,
 		SpireWorkloadAPI:    spireWorkloadAPI,
+		CgroupDir:           *cgroupDir,
+		SecretMasker:        secretMasker,
+		Hermetic:            os.Getenv("TEKTON_RESOURCE_NAME") == "" && os.Getenv(pod.TektonHermeticEnvVar) == "1",
 	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
 	// user so that they're discoverable by git / ssh.
-	if err := credentials.CopyCredsToHome(credentials.CredsInitCredentials); err != nil {
+	if err := credentials.CopyCredsToHome(credentials.Paths()); err != nil {
 		log.Printf("non-fatal error copying credentials: %q", err)
 	}
 
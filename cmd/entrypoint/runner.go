@@ -44,6 +44,9 @@ type realRunner struct {
 	signalsClosed bool
 	stdoutPath    string
 	stderrPath    string
+	// secretMasker redacts the values of the Secrets of the TaskRun from
+	// stdout and stderr, when set.
+	secretMasker *entrypoint.SecretMasker
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
@@ -89,21 +92,29 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	// empty and contents will not be copied.
 	var readers []*namedReader
 	if rr.stdoutPath != "" {
-		stdout, err := newTeeReader(cmd.StdoutPipe, rr.stdoutPath)
+		stdout, err := newTeeReader(cmd.StdoutPipe, rr.stdoutPath, rr.secretMasker)
 		if err != nil {
 			return err
 		}
 		readers = append(readers, stdout)
+	} else if rr.secretMasker != nil {
+		stdout := rr.secretMasker.NewWriter(os.Stdout)
+		defer flush("stdout", stdout)
+		cmd.Stdout = stdout
 	} else {
 		// This needs to be set in an else since StdoutPipe will fail if cmd.Stdout is already set.
 		cmd.Stdout = os.Stdout
 	}
 	if rr.stderrPath != "" {
-		stderr, err := newTeeReader(cmd.StderrPipe, rr.stderrPath)
+		stderr, err := newTeeReader(cmd.StderrPipe, rr.stderrPath, rr.secretMasker)
 		if err != nil {
 			return err
 		}
 		readers = append(readers, stderr)
+	} else if rr.secretMasker != nil {
+		stderr := rr.secretMasker.NewWriter(os.Stderr)
+		defer flush("stderr", stderr)
+		cmd.Stderr = stderr
 	} else {
 		cmd.Stderr = os.Stderr
 	}
@@ -144,6 +155,9 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 			if _, err := io.ReadAll(r); err != nil {
 				log.Printf("error reading to %s: %v", r.name, err)
 			}
+			if r.masked != nil {
+				flush(r.name, r.masked)
+			}
 		}(r)
 	}
 
@@ -167,8 +181,9 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 // override any existing content in the path. This means that the same file can
 // be used for multiple streams if desired.
 // The behavior of the Reader is the same as io.TeeReader - reads from the pipe
-// will be written to the file.
-func newTeeReader(pipe func() (io.ReadCloser, error), path string) (*namedReader, error) {
+// will be written to the file. If secretMasker is not nil, the secret values
+// are masked before being written to the file.
+func newTeeReader(pipe func() (io.ReadCloser, error), path string, secretMasker *entrypoint.SecretMasker) (*namedReader, error) {
 	in, err := pipe()
 	if err != nil {
 		return nil, fmt.Errorf("error creating pipe: %w", err)
@@ -182,9 +197,17 @@ func newTeeReader(pipe func() (io.ReadCloser, error), path string) (*namedReader
 		return nil, fmt.Errorf("error opening %s: %w", path, err)
 	}
 
+	if secretMasker == nil {
+		return &namedReader{
+			name:   path,
+			Reader: io.TeeReader(in, f),
+		}, nil
+	}
+	masked := secretMasker.NewWriter(f)
 	return &namedReader{
 		name:   path,
-		Reader: io.TeeReader(in, f),
+		Reader: io.TeeReader(in, masked),
+		masked: masked,
 	}, nil
 }
 
@@ -193,4 +216,14 @@ func newTeeReader(pipe func() (io.ReadCloser, error), path string) (*namedReader
 type namedReader struct {
 	io.Reader
 	name string
+	// masked is the writer masking the secret values copied from the reader,
+	// flushed once the reader is read entirely.
+	masked *entrypoint.MaskingWriter
+}
+
+// flush writes the last line of the masked output of the command.
+func flush(name string, w *entrypoint.MaskingWriter) {
+	if err := w.Flush(); err != nil {
+		log.Printf("error writing to %s: %v", name, err)
+	}
 }
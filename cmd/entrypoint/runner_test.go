@@ -11,6 +11,8 @@ import (
 	"syscall"
 	"testing"
 	"time"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 )
 
 // TestRealRunnerSignalForwarding will artificially put an interrupt signal (SIGINT) in the rr.signals chan.
@@ -53,6 +55,35 @@ func TestRealRunnerStdoutAndStderrPaths(t *testing.T) {
 	}
 }
 
+func TestRealRunnerMasksSecrets(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	secretMasker := entrypoint.NewSecretMasker("hunter2")
+	rr := realRunner{
+		stdoutPath:   filepath.Join(tmp, "stdout"),
+		stderrPath:   filepath.Join(tmp, "stderr"),
+		secretMasker: secretMasker,
+	}
+	if err := rr.Run(context.Background(), "sh", "-c", "echo password: hunter2 && printf hunter2 >&2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for path, want := range map[string]string{"stdout": "password: ***\n", "stderr": "***"} {
+		if got, err := ioutil.ReadFile(filepath.Join(tmp, path)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		} else if string(got) != want {
+			t.Errorf("%v: got: %q, wanted: %q", path, got, want)
+		}
+	}
+	if got := secretMasker.Redactions(); got != 2 {
+		t.Errorf("got %d redactions, wanted 2", got)
+	}
+}
+
 func TestRealRunnerStdoutAndStderrSamePath(t *testing.T) {
 	tmp, err := ioutil.TempDir("", "")
 	if err != nil {
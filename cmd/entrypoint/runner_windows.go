@@ -22,6 +22,7 @@ package main
 import (
 	"context"
 	"errors"
+	"log"
 	"os"
 	"os/exec"
 
@@ -35,6 +36,9 @@ import (
 type realRunner struct {
 	stdoutPath string
 	stderrPath string
+	// secretMasker redacts the values of the Secrets of the TaskRun from
+	// stdout and stderr, when set.
+	secretMasker *entrypoint.SecretMasker
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
@@ -49,8 +53,17 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	name, args := args[0], args[1:]
 
 	cmd := exec.CommandContext(ctx, name, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	if rr.secretMasker != nil {
+		stdout := rr.secretMasker.NewWriter(os.Stdout)
+		defer flush("stdout", stdout)
+		cmd.Stdout = stdout
+		stderr := rr.secretMasker.NewWriter(os.Stderr)
+		defer flush("stderr", stderr)
+		cmd.Stderr = stderr
+	} else {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
 
 	// Run the defined command
 	if err := cmd.Run(); err != nil {
@@ -58,3 +71,10 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	}
 	return ctx.Err()
 }
+
+// flush writes the last line of the masked output of the command.
+func flush(name string, w *entrypoint.MaskingWriter) {
+	if err := w.Flush(); err != nil {
+		log.Printf("error writing to %s: %v", name, err)
+	}
+}
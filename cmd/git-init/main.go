@@ -40,6 +40,11 @@ func init() {
 	flag.UintVar(&fetchSpec.Depth, "depth", 1, "Perform a shallow clone to this depth")
 	flag.StringVar(&terminationMessagePath, "terminationMessagePath", "/tekton/termination", "Location of file containing termination message")
 	flag.StringVar(&fetchSpec.SparseCheckoutDirectories, "sparseCheckoutDirectories", "", "String of directory patterns separated by a comma")
+	flag.StringVar(&fetchSpec.Filter, "filter", "", "Partial clone filter, e.g. blob:none for a blobless or tree:0 for a treeless clone (optional)")
+	flag.BoolVar(&fetchSpec.LFS, "lfs", false, "Fetch Git LFS objects")
+	flag.StringVar(&fetchSpec.SubmodulePaths, "submodulePaths", "", "String of submodule paths separated by a comma; when set only these submodules are initialized and fetched")
+	flag.StringVar(&fetchSpec.ReferenceCache, "referenceCache", "", "Path of a directory in which to cache a reference repository to speed up fetches (optional)")
+	flag.BoolVar(&fetchSpec.DissociateReferenceCache, "dissociateReferenceCache", false, "Copy the objects borrowed from the reference cache into the repository, for it to be usable without the cache")
 }
 
 func main() {
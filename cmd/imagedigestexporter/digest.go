@@ -1,9 +1,82 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const (
+	// dockerReferenceTypeAnnotation and dockerReferenceDigestAnnotation are set by BuildKit on
+	// the index entries of attestation manifests, which are attached to the image manifest
+	// with the digest given in dockerReferenceDigestAnnotation.
+	dockerReferenceTypeAnnotation   = "vnd.docker.reference.type"
+	dockerReferenceDigestAnnotation = "vnd.docker.reference.digest"
+	dockerAttestationManifest       = "attestation-manifest"
+
+	// inTotoPredicateTypeAnnotation is set on in-toto attestation layers.
+	inTotoPredicateTypeAnnotation = "in-toto.io/predicate-type"
+)
+
+var (
+	// sbomMediaTypes are the media types of the SBOMs attached to images, as written by e.g.
+	// `cosign attach sbom`, Syft and ORAS.
+	sbomMediaTypes = map[types.MediaType]bool{
+		"application/spdx+json":          true,
+		"text/spdx":                      true,
+		"text/spdx+json":                 true,
+		"text/spdx+xml":                  true,
+		"application/vnd.cyclonedx":      true,
+		"application/vnd.cyclonedx+json": true,
+		"application/vnd.cyclonedx+xml":  true,
+		"application/vnd.syft+json":      true,
+	}
+
+	// attestationMediaTypes are the media types of in-toto statements and of the DSSE envelopes
+	// signing them, as written by e.g. BuildKit and `cosign attest`.
+	attestationMediaTypes = map[types.MediaType]bool{
+		"application/vnd.in-toto+json":          true,
+		"application/vnd.dsse.envelope.v1+json": true,
+	}
 )
 
+// errNoImage is returned when a directory contains neither an OCI image layout nor a Docker
+// archive tarball.
+var errNoImage = errors.New("no OCI image layout or image tarball found")
+
+// artifactKind is the kind of a manifest found in an image index.
+type artifactKind string
+
+const (
+	kindImage       artifactKind = "image"
+	kindSBOM        artifactKind = "sbom"
+	kindAttestation artifactKind = "attestation"
+)
+
+// artifact is a manifest found in an image index, or the image found in an image tarball.
+type artifact struct {
+	Kind     artifactKind
+	Digest   v1.Hash
+	Platform string
+}
+
+// exportedImage describes the image, or image index, found in an output image directory.
+type exportedImage struct {
+	// Digest is the digest exported as the legacy "digest" PipelineResource result, see GetDigest.
+	Digest v1.Hash
+	// Platform is the platform of Digest, if it is the digest of a single image.
+	Platform string
+	// Artifacts are the platform images, SBOMs and attestations of an image index.
+	Artifacts []artifact
+}
+
 // GetDigest returns the digest of an OCI image index. If there is only one image in the index, the
 // digest of the image is returned; otherwise, the digest of the whole index is returned.
 func GetDigest(ii v1.ImageIndex) (v1.Hash, error) {
@@ -16,3 +89,178 @@ func GetDigest(ii v1.ImageIndex) (v1.Hash, error) {
 	}
 	return ii.Digest()
 }
+
+// exportImage reads the image written to dir, either as an OCI image layout or as a Docker archive
+// tarball (as written by e.g. `docker save`). dir may also be the path of the tarball itself.
+func exportImage(dir string) (*exportedImage, error) {
+	fi, err := os.Stat(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errNoImage
+		}
+		return nil, err
+	}
+	if !fi.IsDir() {
+		return exportTarball(dir)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "index.json")); err == nil {
+		ii, err := layout.ImageIndexFromPath(dir)
+		if err != nil {
+			return nil, err
+		}
+		return exportIndex(ii)
+	}
+	tarballs, err := filepath.Glob(filepath.Join(dir, "*.tar"))
+	if err != nil {
+		return nil, err
+	}
+	switch len(tarballs) {
+	case 0:
+		return nil, errNoImage
+	case 1:
+		return exportTarball(tarballs[0])
+	default:
+		return nil, fmt.Errorf("found %d image tarballs in %s, expected one", len(tarballs), dir)
+	}
+}
+
+// exportTarball reads the single image of a Docker archive tarball.
+func exportTarball(path string) (*exportedImage, error) {
+	img, err := tarball.ImageFromPath(path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading image tarball %s: %w", path, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, err
+	}
+	cf, err := img.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	return &exportedImage{
+		Digest:   digest,
+		Platform: configPlatform(cf).String(),
+	}, nil
+}
+
+// exportIndex reads an image index, classifying each manifest in it as a platform image, an SBOM or
+// an attestation.
+func exportIndex(ii v1.ImageIndex) (*exportedImage, error) {
+	digest, err := GetDigest(ii)
+	if err != nil {
+		return nil, err
+	}
+	im, err := ii.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	// OCI image layouts of multi-platform images usually hold a single manifest, which is the
+	// image index of the platform images.
+	if len(im.Manifests) == 1 && im.Manifests[0].MediaType.IsIndex() {
+		if ii, err = ii.ImageIndex(im.Manifests[0].Digest); err != nil {
+			return nil, err
+		}
+		if im, err = ii.IndexManifest(); err != nil {
+			return nil, err
+		}
+	}
+
+	e := &exportedImage{Digest: digest}
+	platforms := map[string]string{}
+	for _, desc := range im.Manifests {
+		kind, err := classify(ii, desc)
+		if err != nil {
+			return nil, fmt.Errorf("reading manifest %s: %w", desc.Digest, err)
+		}
+		a := artifact{Kind: kind, Digest: desc.Digest}
+		if kind == kindImage && desc.Platform != nil {
+			a.Platform = desc.Platform.String()
+			platforms[desc.Digest.String()] = a.Platform
+		}
+		e.Artifacts = append(e.Artifacts, a)
+	}
+
+	// Attestations are stored for the "unknown/unknown" platform; report the platform of the
+	// image they are attached to instead.
+	for i, a := range e.Artifacts {
+		if a.Kind == kindImage {
+			continue
+		}
+		if subject, ok := im.Manifests[i].Annotations[dockerReferenceDigestAnnotation]; ok {
+			e.Artifacts[i].Platform = platforms[subject]
+		}
+	}
+
+	if len(im.Manifests) == 1 && e.Artifacts[0].Kind == kindImage {
+		e.Platform = e.Artifacts[0].Platform
+	}
+	return e, nil
+}
+
+// classify returns whether the manifest described by desc is an image, an SBOM or an attestation.
+// SBOMs and attestations are recognized by BuildKit's reference annotations, by in-toto predicate
+// types, and by the well-known media types of the manifest's config and layers.
+func classify(ii v1.ImageIndex, desc v1.Descriptor) (artifactKind, error) {
+	if !desc.MediaType.IsImage() {
+		return kindImage, nil
+	}
+	img, err := ii.Image(desc.Digest)
+	if err != nil {
+		return "", err
+	}
+	m, err := img.Manifest()
+	if err != nil {
+		return "", err
+	}
+
+	isAttestation := desc.Annotations[dockerReferenceTypeAnnotation] == dockerAttestationManifest
+	hasSBOM, hasOther := false, false
+	switch {
+	case isSBOMMediaType(m.Config.MediaType):
+		hasSBOM = true
+	case isAttestationMediaType(m.Config.MediaType):
+		isAttestation = true
+	}
+	for _, l := range m.Layers {
+		predicateType, isInToto := l.Annotations[inTotoPredicateTypeAnnotation]
+		switch {
+		case isSBOMMediaType(l.MediaType), isInToto && isSBOMPredicateType(predicateType):
+			hasSBOM = true
+		case isInToto, isAttestationMediaType(l.MediaType):
+			isAttestation = true
+			hasOther = true
+		default:
+			hasOther = true
+		}
+	}
+
+	switch {
+	case hasSBOM && !hasOther:
+		return kindSBOM, nil
+	case isAttestation:
+		return kindAttestation, nil
+	}
+	return kindImage, nil
+}
+
+func isSBOMMediaType(mt types.MediaType) bool {
+	return sbomMediaTypes[mt]
+}
+
+func isAttestationMediaType(mt types.MediaType) bool {
+	return attestationMediaTypes[mt]
+}
+
+func isSBOMPredicateType(predicateType string) bool {
+	return strings.HasPrefix(predicateType, "https://spdx.dev/") || strings.HasPrefix(predicateType, "https://cyclonedx.org/")
+}
+
+func configPlatform(cf *v1.ConfigFile) v1.Platform {
+	return v1.Platform{
+		OS:           cf.OS,
+		Architecture: cf.Architecture,
+		Variant:      cf.Variant,
+		OSVersion:    cf.OSVersion,
+	}
+}
@@ -1,12 +1,22 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 )
 
 func TestGetDigest(t *testing.T) {
@@ -63,3 +73,304 @@ func TestGetDigest(t *testing.T) {
 		})
 	}
 }
+
+func TestExportIndex(t *testing.T) {
+	amd64 := mustRandomImage(t)
+	arm := mustRandomImage(t)
+	amd64Digest := mustDigest(t, amd64)
+
+	// An attestation manifest as written by BuildKit, with an SBOM and a provenance layer.
+	attestation := mustAppendLayers(t, mutate.MediaType(empty.Image, types.OCIManifestSchema1),
+		inTotoLayer(t, "https://spdx.dev/Document"), inTotoLayer(t, "https://slsa.dev/provenance/v0.2"))
+	// An SBOM only manifest.
+	sbom := mustAppendLayers(t, mutate.MediaType(empty.Image, types.OCIManifestSchema1), mutate.Addendum{
+		Layer:     mustRandomLayer(t, "application/spdx+json"),
+		MediaType: "application/spdx+json",
+	})
+
+	ii := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+		Add:        amd64,
+		Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}},
+	}, mutate.IndexAddendum{
+		Add:        arm,
+		Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+	}, mutate.IndexAddendum{
+		Add: attestation,
+		Descriptor: v1.Descriptor{
+			Platform: &v1.Platform{OS: "unknown", Architecture: "unknown"},
+			Annotations: map[string]string{
+				dockerReferenceTypeAnnotation:   dockerAttestationManifest,
+				dockerReferenceDigestAnnotation: amd64Digest.String(),
+			},
+		},
+	}, mutate.IndexAddendum{
+		Add: sbom,
+	})
+
+	dir := t.TempDir()
+	if _, err := layout.Write(dir, empty.Index); err != nil {
+		t.Fatal(err)
+	}
+	p, err := layout.FromPath(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AppendIndex(ii); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := exportImage(dir)
+	if err != nil {
+		t.Fatalf("exportImage() = %v", err)
+	}
+	iiDigest, err := ii.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &exportedImage{
+		// The layout index holds a single manifest, the image index.
+		Digest: iiDigest,
+		Artifacts: []artifact{{
+			Kind:     kindImage,
+			Digest:   amd64Digest,
+			Platform: "linux/amd64",
+		}, {
+			Kind:     kindImage,
+			Digest:   mustDigest(t, arm),
+			Platform: "linux/arm/v7",
+		}, {
+			Kind:     kindAttestation,
+			Digest:   mustDigest(t, attestation),
+			Platform: "linux/amd64",
+		}, {
+			Kind:   kindSBOM,
+			Digest: mustDigest(t, sbom),
+		}},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("exportImage() -want +got: %s", d)
+	}
+}
+
+func TestExportLayoutWithIndexManifests(t *testing.T) {
+	img := mustRandomImage(t)
+	dir := t.TempDir()
+	if _, err := layout.Write(dir, mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+		Add:        img,
+		Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}},
+	})); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := exportImage(dir)
+	if err != nil {
+		t.Fatalf("exportImage() = %v", err)
+	}
+	want := &exportedImage{
+		Digest:   mustDigest(t, img),
+		Platform: "linux/amd64",
+		Artifacts: []artifact{{
+			Kind:     kindImage,
+			Digest:   mustDigest(t, img),
+			Platform: "linux/amd64",
+		}},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("exportImage() -want +got: %s", d)
+	}
+}
+
+func TestExportTarball(t *testing.T) {
+	img, err := mutate.ConfigFile(mustRandomImage(t), &v1.ConfigFile{OS: "linux", Architecture: "arm64"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := name.NewTag("example.com/image:latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "image.tar")
+	if err := tarball.WriteToFile(path, ref, img); err != nil {
+		t.Fatal(err)
+	}
+
+	// The digest is the one of the image as read from the tarball, whose layers are compressed
+	// when they are read.
+	fromTarball, err := tarball.ImageFromPath(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both the tarball itself and the directory containing it can be given.
+	for _, p := range []string{path, dir} {
+		got, err := exportImage(p)
+		if err != nil {
+			t.Fatalf("exportImage(%s) = %v", p, err)
+		}
+		want := &exportedImage{
+			Digest:   mustDigest(t, fromTarball),
+			Platform: "linux/arm64",
+		}
+		if d := cmp.Diff(want, got); d != "" {
+			t.Errorf("exportImage(%s) -want +got: %s", p, d)
+		}
+	}
+}
+
+func TestExportNoImage(t *testing.T) {
+	for _, dir := range []string{t.TempDir(), filepath.Join(t.TempDir(), "missing")} {
+		if _, err := exportImage(dir); !errors.Is(err, errNoImage) {
+			t.Errorf("exportImage(%s) = %v, want %v", dir, err, errNoImage)
+		}
+	}
+}
+
+func mustRandomImage(t *testing.T) v1.Image {
+	t.Helper()
+	img, err := random.Image(1024, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func mustRandomLayer(t *testing.T, mt types.MediaType) v1.Layer {
+	t.Helper()
+	l, err := random.Layer(64, mt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func mustDigest(t *testing.T, img v1.Image) v1.Hash {
+	t.Helper()
+	h, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func mustAppendLayers(t *testing.T, base v1.Image, adds ...mutate.Addendum) v1.Image {
+	t.Helper()
+	img, err := mutate.Append(base, adds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func inTotoLayer(t *testing.T, predicateType string) mutate.Addendum {
+	t.Helper()
+	return mutate.Addendum{
+		Layer:       mustRandomLayer(t, "application/vnd.in-toto+json"),
+		MediaType:   "application/vnd.in-toto+json",
+		Annotations: map[string]string{inTotoPredicateTypeAnnotation: predicateType},
+	}
+}
+
+func TestTypedResults(t *testing.T) {
+	index := v1.Hash{Algorithm: "sha256", Hex: "0000"}
+	amd64 := v1.Hash{Algorithm: "sha256", Hex: "1111"}
+	windows := v1.Hash{Algorithm: "sha256", Hex: "2222"}
+	att := v1.Hash{Algorithm: "sha256", Hex: "3333"}
+	sbom := v1.Hash{Algorithm: "sha256", Hex: "4444"}
+	e := &exportedImage{
+		Digest: index,
+		Artifacts: []artifact{
+			{Kind: kindImage, Digest: amd64, Platform: "linux/amd64"},
+			{Kind: kindImage, Digest: windows, Platform: "windows/amd64:10.0.17763.1879"},
+			{Kind: kindAttestation, Digest: att, Platform: "linux/amd64"},
+			{Kind: kindSBOM, Digest: sbom},
+		},
+	}
+
+	results, err := typedResults("builtImage", "gcr.io/foo/bar", e)
+	if err != nil {
+		t.Fatalf("typedResults() = %v", err)
+	}
+
+	// The results are parsed as object results, as they would be from the termination message.
+	got := map[string]map[string]string{}
+	for _, r := range results {
+		if r.ResultType != v1beta1.TaskRunResultType {
+			t.Errorf("result %s has type %v, want %v", r.Key, r.ResultType, v1beta1.TaskRunResultType)
+		}
+		v := v1beta1.ResultValue{}
+		if err := v.UnmarshalJSON([]byte(r.Value)); err != nil {
+			t.Fatalf("result %s: %v", r.Key, err)
+		}
+		if v.Type != v1beta1.ParamTypeObject {
+			t.Errorf("result %s has value type %s, want object", r.Key, v.Type)
+		}
+		got[r.Key] = v.ObjectVal
+	}
+	object := func(digest v1.Hash, platform string) map[string]string {
+		return map[string]string{"url": "gcr.io/foo/bar", "digest": digest.String(), "platform": platform}
+	}
+	want := map[string]map[string]string{
+		"builtImage-image":                               object(index, ""),
+		"builtImage-image-linux-amd64":                   object(amd64, "linux/amd64"),
+		"builtImage-image-windows-amd64-10-0-17763-1879": object(windows, "windows/amd64:10.0.17763.1879"),
+		"builtImage-attestation-0":                       object(att, "linux/amd64"),
+		"builtImage-sbom-0":                              object(sbom, ""),
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("typedResults() -want +got: %s", d)
+	}
+}
+
+func TestMediaTypes(t *testing.T) {
+	for _, tc := range []struct {
+		mediaType       types.MediaType
+		wantSBOM        bool
+		wantAttestation bool
+	}{
+		{mediaType: "application/spdx+json", wantSBOM: true},
+		{mediaType: "application/vnd.cyclonedx+json", wantSBOM: true},
+		{mediaType: "application/vnd.syft+json", wantSBOM: true},
+		{mediaType: "application/vnd.in-toto+json", wantAttestation: true},
+		{mediaType: "application/vnd.dsse.envelope.v1+json", wantAttestation: true},
+		{mediaType: types.OCILayer},
+		{mediaType: "application/vnd.example.spdx-tools.config+json"},
+		{mediaType: "application/vnd.example.in-toto-like+json"},
+	} {
+		t.Run(string(tc.mediaType), func(t *testing.T) {
+			if got := isSBOMMediaType(tc.mediaType); got != tc.wantSBOM {
+				t.Errorf("isSBOMMediaType() = %t, want %t", got, tc.wantSBOM)
+			}
+			if got := isAttestationMediaType(tc.mediaType); got != tc.wantAttestation {
+				t.Errorf("isAttestationMediaType() = %t, want %t", got, tc.wantAttestation)
+			}
+		})
+	}
+}
+
+func TestCapResults(t *testing.T) {
+	legacy := []v1beta1.PipelineResourceResult{{Key: "digest", Value: "sha256:0000", ResourceName: "builtImage"}}
+	var typed []v1beta1.PipelineResourceResult
+	for i := 0; i < 3; i++ {
+		typed = append(typed, v1beta1.PipelineResourceResult{
+			Key:        fmt.Sprintf("builtImage-sbom-%d", i),
+			Value:      `{"url":"gcr.io/foo/bar","digest":"sha256:1111","platform":""}`,
+			ResultType: v1beta1.TaskRunResultType,
+		})
+	}
+	b, err := json.Marshal(append(legacy[:1:1], typed[:2]...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, dropped, err := capResults(legacy, typed, len(b))
+	if err != nil {
+		t.Fatalf("capResults() = %v", err)
+	}
+	if d := cmp.Diff(append(legacy[:1:1], typed[:2]...), got); d != "" {
+		t.Errorf("capResults() -want +got: %s", d)
+	}
+	if dropped != 1 {
+		t.Errorf("capResults() dropped %d results, want 1", dropped)
+	}
+}
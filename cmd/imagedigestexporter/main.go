@@ -18,12 +18,14 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/termination"
 	"knative.dev/pkg/logging"
 
-	"github.com/google/go-containerregistry/pkg/v1/layout"
 	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1/image"
 )
@@ -33,13 +35,15 @@ var (
 	terminationMessagePath = flag.String("terminationMessagePath", "/tekton/termination", "Location of file containing termination message")
 )
 
-/* The input of this go program will be a JSON string with all the output PipelineResources of type
-Image, which will include the path to where the index.json file will be located. The program will
-read the related index.json file(s) and log another JSON string including the name of the image resource
-and the digests.
-The input is an array of ImageResource, ex: [{"name":"srcimg1","type":"image","url":"gcr.io/some-image-1","digest":""}]
-The output is an array of PipelineResourceResult, ex: [{"name":"image","digest":"sha256:eed29..660"}]
-*/
+// The input of this go program will be a JSON string with all the output PipelineResources of type
+// Image, which will include the path to where the index.json file, or a Docker archive tarball, will be
+// located. The program will read the related index.json file(s) or tarball(s) and log another JSON string
+// including the name of the image resource and the digests.
+// The input is an array of ImageResource, ex: [{"name":"srcimg1","type":"image","url":"gcr.io/some-image-1","digest":""}]
+// The output is an array of PipelineResourceResult, ex: [{"name":"image","digest":"sha256:eed29..660"}]
+// In addition to these legacy resource results, an object result with the keys url, digest and platform is
+// emitted for the image, for each platform image of an index and for each SBOM and attestation attached to
+// it, see typedResults, as long as they fit in the termination message, see capResults.
 func main() {
 	flag.Parse()
 	logger, _ := logging.NewLogger("", "image-digest-exporter")
@@ -53,19 +57,21 @@ func main() {
 	}
 
 	output := []v1beta1.PipelineResourceResult{}
+	// The object results of the images come first, so that they are kept over the results of
+	// their platform images, SBOMs and attestations if they don't all fit.
+	var imageResults, artifactResults []v1beta1.PipelineResourceResult
 	for _, imageResource := range imageResources {
-		ii, err := layout.ImageIndexFromPath(imageResource.OutputImageDir)
-		if err != nil {
-			logger.Infof("No index.json found for: %s", imageResource.Name)
+		exported, err := exportImage(imageResource.OutputImageDir)
+		if errors.Is(err, errNoImage) {
+			logger.Infof("No index.json or image tarball found for: %s", imageResource.Name)
 			continue
 		}
-		digest, err := GetDigest(ii)
 		if err != nil {
 			logger.Fatalf("Unexpected error getting image digest for %s: %v", imageResource.Name, err)
 		}
 		output = append(output, v1beta1.PipelineResourceResult{
 			Key:          "digest",
-			Value:        digest.String(),
+			Value:        exported.Digest.String(),
 			ResourceName: imageResource.Name,
 		})
 		output = append(output, v1beta1.PipelineResourceResult{
@@ -73,10 +79,100 @@ func main() {
 			Value:        imageResource.URL,
 			ResourceName: imageResource.Name,
 		})
+		typed, err := typedResults(imageResource.Name, imageResource.URL, exported)
+		if err != nil {
+			logger.Fatalf("Unexpected error creating results for %s: %v", imageResource.Name, err)
+		}
+		imageResults = append(imageResults, typed[0])
+		artifactResults = append(artifactResults, typed[1:]...)
+	}
 
+	output, dropped, err := capResults(output, append(imageResults, artifactResults...), termination.MaxContainerTerminationMessageLength)
+	if err != nil {
+		logger.Fatalf("Unexpected error creating results: %v", err)
+	}
+	if dropped > 0 {
+		logger.Warnf("Left out %d image results which don't fit in the termination message", dropped)
 	}
 
 	if err := termination.WriteMessage(*terminationMessagePath, output); err != nil {
 		logger.Fatalf("Unexpected error writing message %s to %s", *terminationMessagePath, err)
 	}
 }
+
+// typedResults returns the object results describing an exported image. They are named after the
+// image resource:
+//   - <name>-image: the image, or the index, also exported as the legacy digest resource result.
+//   - <name>-image-<os>-<architecture>[-<variant>]: each platform image of an index.
+//   - <name>-sbom-<n> and <name>-attestation-<n>: each SBOM and attestation of an index. Their
+//     platform is the platform of the image they are attached to, if known.
+//
+// Each result is an object with the keys url, digest and platform.
+func typedResults(name, url string, e *exportedImage) ([]v1beta1.PipelineResourceResult, error) {
+	var results []v1beta1.PipelineResourceResult
+	add := func(key string, digest fmt.Stringer, platform string) error {
+		value, err := json.Marshal(v1beta1.NewObject(map[string]string{
+			"url":      url,
+			"digest":   digest.String(),
+			"platform": platform,
+		}))
+		if err != nil {
+			return err
+		}
+		results = append(results, v1beta1.PipelineResourceResult{
+			Key:        key,
+			Value:      string(value),
+			ResultType: v1beta1.TaskRunResultType,
+		})
+		return nil
+	}
+
+	if err := add(name+"-image", e.Digest, e.Platform); err != nil {
+		return nil, err
+	}
+	counts := map[artifactKind]int{}
+	for _, a := range e.Artifacts {
+		var key string
+		switch a.Kind {
+		case kindImage:
+			if a.Platform == "" {
+				continue
+			}
+			key = fmt.Sprintf("%s-image-%s", name, strings.Map(platformKeyRune, a.Platform))
+		default:
+			key = fmt.Sprintf("%s-%s-%d", name, a.Kind, counts[a.Kind])
+			counts[a.Kind]++
+		}
+		if err := add(key, a.Digest, a.Platform); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// capResults returns the legacy results followed by as many of the object results as fit, in order,
+// in a termination message of limit bytes, and the number of object results left out. The legacy
+// results are always kept since the image PipelineResources depend on them.
+func capResults(legacy, typed []v1beta1.PipelineResourceResult, limit int) ([]v1beta1.PipelineResourceResult, int, error) {
+	output := legacy
+	for i, r := range typed {
+		b, err := json.Marshal(append(output[:len(output):len(output)], r))
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(b) > limit {
+			return output, len(typed) - i, nil
+		}
+		output = append(output, r)
+	}
+	return output, 0, nil
+}
+
+// platformKeyRune replaces the separators of a platform string, e.g. "linux/arm/v7" or
+// "windows/amd64:10.0.17763.1879", so that it can be used in a result name.
+func platformKeyRune(r rune) rune {
+	if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+		return r
+	}
+	return '-'
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+The sign command signs the Tasks and Pipelines of a YAML file, for their
+verification by trusted resources. It writes the YAML documents back with the
+signature annotation set:
+
+	sign -key signer.key -f task.yaml -o signed-task.yaml
+	sign -key softkms:///etc/keys/signer.key -f pipeline.yaml
+
+The key is either the path of a PEM encoded private key file, decrypted with
+the password of the SIGNING_PASSWORD environment variable if it is encrypted,
+or the url of a key in a KMS.
+*/
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/aws"        // Register the KMS providers of the signing keys.
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/azure"      // Register the KMS providers of the signing keys.
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/gcp"        // Register the KMS providers of the signing keys.
+	_ "github.com/sigstore/sigstore/pkg/signature/kms/hashivault" // Register the KMS providers of the signing keys.
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	_ "github.com/tektoncd/pipeline/pkg/trustedresources/softkms" // Register the software KMS provider.
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+// passwordEnvVar is the environment variable holding the password of
+// encrypted private key files.
+const passwordEnvVar = "SIGNING_PASSWORD"
+
+var (
+	keyRef = flag.String("key", "", "Path of the PEM encoded private key file, or url of the KMS key, to sign with")
+	input  = flag.String("f", "-", "YAML file of the Tasks and Pipelines to sign, - for stdin")
+	output = flag.String("o", "-", "File to write the signed Tasks and Pipelines to, - for stdout")
+)
+
+func main() {
+	flag.Parse()
+	if *keyRef == "" {
+		log.Fatal("-key must be specified")
+	}
+	ctx := context.Background()
+
+	var pf cryptoutils.PassFunc
+	if pw, ok := os.LookupEnv(passwordEnvVar); ok {
+		pf = cryptoutils.StaticPasswordFunc([]byte(pw))
+	}
+	signer, err := trustedresources.LoadSigner(ctx, *keyRef, crypto.SHA256, pf)
+	if err != nil {
+		log.Fatalf("Error loading the signing key %s: %v", *keyRef, err)
+	}
+
+	in := os.Stdin
+	if *input != "-" {
+		if in, err = os.Open(*input); err != nil {
+			log.Fatalf("Error opening %s: %v", *input, err)
+		}
+		defer in.Close()
+	}
+	signed, err := signDocuments(ctx, in, signer)
+	if err != nil {
+		log.Fatalf("Error signing %s: %v", *input, err)
+	}
+
+	if *output == "-" {
+		_, err = os.Stdout.Write(signed)
+	} else {
+		err = os.WriteFile(*output, signed, 0644)
+	}
+	if err != nil {
+		log.Fatalf("Error writing the signed resources: %v", err)
+	}
+}
+
+// signDocuments signs the Task or Pipeline of each of the YAML documents read
+// from in, and returns the signed YAML documents.
+func signDocuments(ctx context.Context, in io.Reader, signer signature.Signer) ([]byte, error) {
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(in))
+	var out bytes.Buffer
+	for i := 0; ; {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, err := decode(doc)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		if err := trustedresources.Sign(ctx, obj, signer); err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		signed, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(signed)
+		i++
+	}
+	return out.Bytes(), nil
+}
+
+// decode decodes a YAML document into a v1beta1 or v1 Task or Pipeline.
+func decode(doc []byte) (metav1.Object, error) {
+	tm := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(doc, &tm); err != nil {
+		return nil, err
+	}
+	var obj metav1.Object
+	switch tm.GroupVersionKind() {
+	case v1beta1.SchemeGroupVersion.WithKind("Task"):
+		obj = &v1beta1.Task{}
+	case v1beta1.SchemeGroupVersion.WithKind("Pipeline"):
+		obj = &v1beta1.Pipeline{}
+	case v1.SchemeGroupVersion.WithKind("Task"):
+		obj = &v1.Task{}
+	case v1.SchemeGroupVersion.WithKind("Pipeline"):
+		obj = &v1.Pipeline{}
+	default:
+		return nil, fmt.Errorf("%s %s can't be signed, only v1beta1 and v1 Tasks and Pipelines can be signed", tm.APIVersion, tm.Kind)
+	}
+	if err := yaml.UnmarshalStrict(doc, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
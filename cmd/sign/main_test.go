@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	"github.com/tektoncd/pipeline/test"
+	"go.uber.org/zap/zaptest"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"knative.dev/pkg/logging"
+)
+
+const resources = `apiVersion: tekton.dev/v1beta1
+kind: Task
+metadata:
+  name: echo
+  annotations:
+    tekton.dev/categories: Test
+spec:
+  params:
+  - name: message
+  steps:
+  - name: echo
+    image: ubuntu
+    script: echo $(params.message)
+---
+apiVersion: tekton.dev/v1
+kind: Pipeline
+metadata:
+  name: echo
+spec:
+  tasks:
+  - name: echo
+    taskRef:
+      name: echo
+    params:
+    - name: message
+      value: hello
+`
+
+func TestSignDocuments(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	signer, keypath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = test.SetupTrustedResourceConfig(ctx, keypath, config.EnforceResourceVerificationMode)
+
+	signed, err := signDocuments(ctx, strings.NewReader(resources), signer)
+	if err != nil {
+		t.Fatalf("signDocuments() got err %v", err)
+	}
+
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(signed)))
+	var docs [][]byte
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs = append(docs, doc)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("signDocuments() got %d documents, want 2:\n%s", len(docs), signed)
+	}
+
+	task, err := decode(docs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vr := trustedresources.VerifyTask(ctx, task.(*v1beta1.Task), nil, "", nil); vr.VerificationResultType != trustedresources.VerificationPass {
+		t.Errorf("VerifyTask() of the signed task got result type %d, err: %v", vr.VerificationResultType, vr.Err)
+	}
+
+	pipeline, err := decode(docs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vr := trustedresources.VerifyResource(ctx, pipeline, nil, "", nil); vr.VerificationResultType != trustedresources.VerificationPass {
+		t.Errorf("VerifyResource() of the signed v1 pipeline got result type %d, err: %v", vr.VerificationResultType, vr.Err)
+	}
+}
+
+func TestSignDocuments_Unsupported(t *testing.T) {
+	ctx := context.Background()
+	signer, _, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, doc := range []string{
+		"apiVersion: tekton.dev/v1beta1\nkind: TaskRun\nmetadata:\n  name: run\n",
+		"apiVersion: tekton.dev/v1beta1\nkind: Task\nmetadata:\n  name: task\nspec:\n  unknown: field\n",
+	} {
+		if _, err := signDocuments(ctx, strings.NewReader(doc), signer); err == nil {
+			t.Errorf("signDocuments() of %q should fail", doc)
+		}
+	}
+}
@@ -31,6 +31,7 @@ import (
 	resolutionv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
 	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/policy"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -51,8 +52,9 @@ import (
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// v1alpha1
-	v1alpha1.SchemeGroupVersion.WithKind("PipelineResource"): &resourcev1alpha1.PipelineResource{},
-	v1alpha1.SchemeGroupVersion.WithKind("Run"):              &v1alpha1.Run{},
+	v1alpha1.SchemeGroupVersion.WithKind("PipelineResource"):   &resourcev1alpha1.PipelineResource{},
+	v1alpha1.SchemeGroupVersion.WithKind("Run"):                &v1alpha1.Run{},
+	v1alpha1.SchemeGroupVersion.WithKind("VerificationPolicy"): &v1alpha1.VerificationPolicy{},
 	// v1beta1
 	v1beta1.SchemeGroupVersion.WithKind("Pipeline"):    &v1beta1.Pipeline{},
 	v1beta1.SchemeGroupVersion.WithKind("Task"):        &v1beta1.Task{},
@@ -73,6 +75,15 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	resolutionv1beta1.SchemeGroupVersion.WithKind("ResolutionRequest"): &resolutionv1beta1.ResolutionRequest{},
 }
 
+// callbacks validate the Task specs embedded in the runs against the policy of config-policy
+// when they are created, the Tasks and Pipelines they reference are validated once resolved.
+var callbacks = map[schema.GroupVersionKind]validation.Callback{
+	v1beta1.SchemeGroupVersion.WithKind("TaskRun"):     validation.NewCallback(policy.ValidateTaskRun, webhook.Create),
+	v1beta1.SchemeGroupVersion.WithKind("PipelineRun"): validation.NewCallback(policy.ValidatePipelineRun, webhook.Create),
+	v1.SchemeGroupVersion.WithKind("TaskRun"):          validation.NewCallback(policy.ValidateTaskRun, webhook.Create),
+	v1.SchemeGroupVersion.WithKind("PipelineRun"):      validation.NewCallback(policy.ValidatePipelineRun, webhook.Create),
+}
+
 func newDefaultingAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 	// Decorate contexts with the current state of the config.
 	store := defaultconfig.NewStore(logging.FromContext(ctx).Named("config-store"))
@@ -121,6 +132,9 @@ func newValidationAdmissionController(ctx context.Context, cmw configmap.Watcher
 
 		// Whether to disallow unknown fields.
 		true,
+
+		// Extra validating callbacks to be applied to resources.
+		callbacks,
 	)
 }
 
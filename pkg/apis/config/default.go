@@ -20,11 +20,14 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/yaml"
 )
 
@@ -41,6 +44,18 @@ const (
 	DefaultCloudEventSinkValue = ""
 	// DefaultMaxMatrixCombinationsCount is used when no max matrix combinations count is specified.
 	DefaultMaxMatrixCombinationsCount = 256
+	// PVCRetentionPolicyDelete is the PVC retention policy value which deletes a PersistentVolumeClaim
+	// created from a volumeClaimTemplate as soon as the PipelineRun owning it completes.
+	PVCRetentionPolicyDelete = "Delete"
+	// PVCRetentionPolicyRetain is the PVC retention policy value which deletes a PersistentVolumeClaim
+	// created from a volumeClaimTemplate when the PipelineRun owning it succeeds, and retains it otherwise.
+	PVCRetentionPolicyRetain = "Retain"
+	// CloudEventsFormatTekton is the format of the cloud events with Tekton event types,
+	// and the TaskRun, PipelineRun, Run or CustomRun the event is about as payload.
+	CloudEventsFormatTekton = "tekton"
+	// CloudEventsFormatCDEvents is the format of the cloud events that follow the
+	// CDEvents specification, see https://cdevents.dev.
+	CloudEventsFormatCDEvents = "cdevents"
 
 	defaultTimeoutMinutesKey             = "default-timeout-minutes"
 	defaultServiceAccountKey             = "default-service-account"
@@ -48,8 +63,18 @@ const (
 	defaultPodTemplateKey                = "default-pod-template"
 	defaultAAPodTemplateKey              = "default-affinity-assistant-pod-template"
 	defaultCloudEventsSinkKey            = "default-cloud-events-sink"
+	defaultCloudEventsSinksKey           = "default-cloud-events-sinks"
+	defaultCloudEventsFormatsKey         = "default-cloud-events-formats"
 	defaultTaskRunWorkspaceBinding       = "default-task-run-workspace-binding"
 	defaultMaxMatrixCombinationsCountKey = "default-max-matrix-combinations-count"
+	defaultPVCRetentionPolicyKey         = "default-pvc-retention-policy"
+
+	defaultCustomTaskCancellationGracePeriodKey = "default-custom-task-cancellation-grace-period"
+	defaultMemoryRetryMultiplierKey             = "default-memory-retry-multiplier"
+	defaultMemoryRetryMaxLimitKey               = "default-memory-retry-max-limit"
+	defaultDeadlineWarningThresholdKey          = "default-deadline-warning-threshold"
+	defaultSecurityProfileKey                   = "default-security-profile"
+	defaultNotificationAllowedHostsKey          = "default-notification-allowed-hosts"
 )
 
 // Defaults holds the default configurations
@@ -61,8 +86,86 @@ type Defaults struct {
 	DefaultPodTemplate                *pod.Template
 	DefaultAAPodTemplate              *pod.AffinityAssistantTemplate
 	DefaultCloudEventsSink            string
+	DefaultCloudEventsSinks           []CloudEventsSink
+	DefaultCloudEventsFormats         []string
 	DefaultTaskRunWorkspaceBinding    string
 	DefaultMaxMatrixCombinationsCount int
+	DefaultPVCRetentionPolicy         string
+	// DefaultCustomTaskCancellationGracePeriod is the cancellationGracePeriod
+	// of the Runs created for custom tasks. Zero means no grace period.
+	DefaultCustomTaskCancellationGracePeriod time.Duration
+	// DefaultMemoryRetryMultiplier is the factor the memory limit of a TaskRun
+	// is multiplied by when it is retried after failing for OOM or eviction.
+	// Zero means the memory limit is not changed on retries.
+	DefaultMemoryRetryMultiplier float64
+	// DefaultMemoryRetryMaxLimit is the ceiling of the memory limit of a TaskRun
+	// retried with bumped memory. Nil means there is no ceiling.
+	DefaultMemoryRetryMaxLimit *resource.Quantity
+	// DefaultDeadlineWarningThreshold is the time left before the deadline of
+	// a PipelineRun or TaskRun below which the DeadlineApproaching condition
+	// is set. Zero means the condition is never set.
+	DefaultDeadlineWarningThreshold time.Duration
+	// DefaultSecurityProfile is the security profile the containers of
+	// TaskRun pods are made to comply with: "none", "baseline" or
+	// "restricted". Empty means "none".
+	DefaultSecurityProfile string
+	// DefaultNotificationAllowedHosts are the hosts the notifications of the
+	// PipelineRuns can be sent to, such as "hooks.slack.com" or
+	// "*.corp.example.com". No notifications are sent to HTTP and Slack
+	// targets when it is empty.
+	DefaultNotificationAllowedHosts []string
+}
+
+// CloudEventsSink is a CloudEvents sink, along with the types of the
+// events that are sent to it.
+// +k8s:deepcopy-gen=true
+type CloudEventsSink struct {
+	// URI is the address of the sink.
+	URI string `json:"uri"`
+	// Types are the types of the events sent to the sink. A type ending
+	// with "*" matches all the types that start with the rest of it.
+	// All events are sent to a sink with no types.
+	Types []string `json:"types,omitempty"`
+	// Formats are the formats of the events sent to the sink, "tekton" and
+	// "cdevents". Sinks with no formats get the default formats.
+	Formats []string `json:"formats,omitempty"`
+}
+
+// Accepts returns true if events of the given type are sent to the sink.
+func (s CloudEventsSink) Accepts(eventType string) bool {
+	if len(s.Types) == 0 {
+		return true
+	}
+	for _, t := range s.Types {
+		if strings.HasSuffix(t, "*") && strings.HasPrefix(eventType, strings.TrimSuffix(t, "*")) {
+			return true
+		}
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// CloudEventsSinks returns all the CloudEvents sinks configured: the
+// default sink, which accepts all events, followed by the other sinks.
+// Sinks with no formats are given the default formats.
+func (cfg *Defaults) CloudEventsSinks() []CloudEventsSink {
+	formats := cfg.DefaultCloudEventsFormats
+	if len(formats) == 0 {
+		formats = []string{CloudEventsFormatTekton}
+	}
+	var sinks []CloudEventsSink
+	if cfg.DefaultCloudEventsSink != "" {
+		sinks = append(sinks, CloudEventsSink{URI: cfg.DefaultCloudEventsSink, Formats: formats})
+	}
+	for _, sink := range cfg.DefaultCloudEventsSinks {
+		if len(sink.Formats) == 0 {
+			sink.Formats = formats
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -90,8 +193,24 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultPodTemplate.Equals(cfg.DefaultPodTemplate) &&
 		other.DefaultAAPodTemplate.Equals(cfg.DefaultAAPodTemplate) &&
 		other.DefaultCloudEventsSink == cfg.DefaultCloudEventsSink &&
+		reflect.DeepEqual(other.DefaultCloudEventsSinks, cfg.DefaultCloudEventsSinks) &&
+		reflect.DeepEqual(other.DefaultCloudEventsFormats, cfg.DefaultCloudEventsFormats) &&
 		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding &&
-		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount
+		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount &&
+		other.DefaultPVCRetentionPolicy == cfg.DefaultPVCRetentionPolicy &&
+		other.DefaultCustomTaskCancellationGracePeriod == cfg.DefaultCustomTaskCancellationGracePeriod &&
+		other.DefaultMemoryRetryMultiplier == cfg.DefaultMemoryRetryMultiplier &&
+		quantityEquals(other.DefaultMemoryRetryMaxLimit, cfg.DefaultMemoryRetryMaxLimit) &&
+		other.DefaultDeadlineWarningThreshold == cfg.DefaultDeadlineWarningThreshold &&
+		other.DefaultSecurityProfile == cfg.DefaultSecurityProfile &&
+		reflect.DeepEqual(other.DefaultNotificationAllowedHosts, cfg.DefaultNotificationAllowedHosts)
+}
+
+func quantityEquals(a, b *resource.Quantity) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Cmp(*b) == 0
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -140,6 +259,35 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultCloudEventsSink = defaultCloudEventsSink
 	}
 
+	if defaultCloudEventsSinks, ok := cfgMap[defaultCloudEventsSinksKey]; ok {
+		var sinks []CloudEventsSink
+		if err := yamlUnmarshal(defaultCloudEventsSinks, defaultCloudEventsSinksKey, &sinks); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", defaultCloudEventsSinks)
+		}
+		for i, sink := range sinks {
+			if sink.URI == "" {
+				return nil, fmt.Errorf("invalid value for %q: sink %d has no uri", defaultCloudEventsSinksKey, i)
+			}
+			if err := validateCloudEventsFormats(sink.Formats); err != nil {
+				return nil, fmt.Errorf("invalid value for %q: sink %d: %w", defaultCloudEventsSinksKey, i, err)
+			}
+		}
+		tc.DefaultCloudEventsSinks = sinks
+	}
+
+	if defaultCloudEventsFormats, ok := cfgMap[defaultCloudEventsFormatsKey]; ok {
+		var formats []string
+		for _, format := range strings.Split(defaultCloudEventsFormats, ",") {
+			if format = strings.TrimSpace(format); format != "" {
+				formats = append(formats, format)
+			}
+		}
+		if err := validateCloudEventsFormats(formats); err != nil {
+			return nil, fmt.Errorf("invalid value for %q: %w", defaultCloudEventsFormatsKey, err)
+		}
+		tc.DefaultCloudEventsFormats = formats
+	}
+
 	if bindingYAML, ok := cfgMap[defaultTaskRunWorkspaceBinding]; ok {
 		tc.DefaultTaskRunWorkspaceBinding = bindingYAML
 	}
@@ -152,9 +300,91 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultMaxMatrixCombinationsCount = int(matrixCombinationsCount)
 	}
 
+	if defaultPVCRetentionPolicy, ok := cfgMap[defaultPVCRetentionPolicyKey]; ok {
+		switch defaultPVCRetentionPolicy {
+		case "", PVCRetentionPolicyDelete, PVCRetentionPolicyRetain:
+			tc.DefaultPVCRetentionPolicy = defaultPVCRetentionPolicy
+		default:
+			return nil, fmt.Errorf("invalid value for %q: %q, must be one of %q or %q",
+				defaultPVCRetentionPolicyKey, defaultPVCRetentionPolicy, PVCRetentionPolicyDelete, PVCRetentionPolicyRetain)
+		}
+	}
+
+	if gracePeriod, ok := cfgMap[defaultCustomTaskCancellationGracePeriodKey]; ok {
+		d, err := time.ParseDuration(gracePeriod)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing %q: %w", defaultCustomTaskCancellationGracePeriodKey, err)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("invalid value for %q: %q, must not be negative", defaultCustomTaskCancellationGracePeriodKey, gracePeriod)
+		}
+		tc.DefaultCustomTaskCancellationGracePeriod = d
+	}
+
+	if multiplier, ok := cfgMap[defaultMemoryRetryMultiplierKey]; ok {
+		f, err := strconv.ParseFloat(multiplier, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing %q: %w", defaultMemoryRetryMultiplierKey, err)
+		}
+		if f != 0 && f <= 1 {
+			return nil, fmt.Errorf("invalid value for %q: %q, must be greater than 1, or 0 to disable it", defaultMemoryRetryMultiplierKey, multiplier)
+		}
+		tc.DefaultMemoryRetryMultiplier = f
+	}
+
+	if maxLimit, ok := cfgMap[defaultMemoryRetryMaxLimitKey]; ok {
+		q, err := resource.ParseQuantity(maxLimit)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing %q: %w", defaultMemoryRetryMaxLimitKey, err)
+		}
+		tc.DefaultMemoryRetryMaxLimit = &q
+	}
+
+	if threshold, ok := cfgMap[defaultDeadlineWarningThresholdKey]; ok {
+		d, err := time.ParseDuration(threshold)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing %q: %w", defaultDeadlineWarningThresholdKey, err)
+		}
+		if d < 0 {
+			return nil, fmt.Errorf("invalid value for %q: %q, must not be negative", defaultDeadlineWarningThresholdKey, threshold)
+		}
+		tc.DefaultDeadlineWarningThreshold = d
+	}
+
+	if profile, ok := cfgMap[defaultSecurityProfileKey]; ok {
+		switch profile {
+		case "", pod.SecurityProfileNone, pod.SecurityProfileBaseline, pod.SecurityProfileRestricted:
+			tc.DefaultSecurityProfile = profile
+		default:
+			return nil, fmt.Errorf("invalid value for %q: %q, must be one of %q, %q or %q",
+				defaultSecurityProfileKey, profile, pod.SecurityProfileNone, pod.SecurityProfileBaseline, pod.SecurityProfileRestricted)
+		}
+	}
+
+	if allowedHosts, ok := cfgMap[defaultNotificationAllowedHostsKey]; ok {
+		var hosts []string
+		for _, host := range strings.Split(allowedHosts, ",") {
+			if host = strings.TrimSpace(host); host != "" {
+				hosts = append(hosts, host)
+			}
+		}
+		tc.DefaultNotificationAllowedHosts = hosts
+	}
+
 	return &tc, nil
 }
 
+func validateCloudEventsFormats(formats []string) error {
+	for _, format := range formats {
+		switch format {
+		case CloudEventsFormatTekton, CloudEventsFormatCDEvents:
+		default:
+			return fmt.Errorf("unknown format %q, must be %q or %q", format, CloudEventsFormatTekton, CloudEventsFormatCDEvents)
+		}
+	}
+	return nil
+}
+
 func yamlUnmarshal(s string, key string, o interface{}) error {
 	b := []byte(s)
 	if err := yaml.UnmarshalStrict(b, o); err != nil {
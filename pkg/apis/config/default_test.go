@@ -18,14 +18,18 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
+var memoryRetryMaxLimit = resource.MustParse("4Gi")
+
 func TestNewDefaultsFromConfigMap(t *testing.T) {
 	type testCase struct {
 		expectedConfig *config.Defaults
@@ -105,6 +109,121 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
 			},
 		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-pvc-retention-policy",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultPVCRetentionPolicy:         config.PVCRetentionPolicyRetain,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-pvc-retention-policy-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-custom-task-cancellation-grace-period",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:                    60,
+				DefaultServiceAccount:                    "default",
+				DefaultManagedByLabelValue:               config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:        256,
+				DefaultCustomTaskCancellationGracePeriod: 30 * time.Second,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-custom-task-cancellation-grace-period-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-memory-retry",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultMemoryRetryMultiplier:      1.5,
+				DefaultMemoryRetryMaxLimit:        &memoryRetryMaxLimit,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-memory-retry-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-deadline-warning-threshold",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultDeadlineWarningThreshold:   10 * time.Minute,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-deadline-warning-threshold-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-security-profile",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultSecurityProfile:            "restricted",
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-security-profile-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-notification-allowed-hosts",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultNotificationAllowedHosts:   []string{"hooks.slack.com", "*.corp.example.com"},
+			},
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-cloud-events-sinks",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCloudEventsSink:            "http://sink.example.com",
+				DefaultCloudEventsSinks: []config.CloudEventsSink{{
+					URI:   "http://pipelineruns.example.com",
+					Types: []string{"dev.tekton.event.pipelinerun.*"},
+				}, {
+					URI:     "http://failures.example.com",
+					Types:   []string{"dev.tekton.event.taskrun.failed.v1", "dev.tekton.event.pipelinerun.failed.v1"},
+					Formats: []string{config.CloudEventsFormatCDEvents},
+				}},
+				DefaultCloudEventsFormats: []string{config.CloudEventsFormatTekton, config.CloudEventsFormatCDEvents},
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-cloud-events-sinks-err",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-cloud-events-formats-err",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -254,6 +373,82 @@ func TestEquals(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "different default pvc retention policy",
+			left: &config.Defaults{
+				DefaultPVCRetentionPolicy: config.PVCRetentionPolicyDelete,
+			},
+			right: &config.Defaults{
+				DefaultPVCRetentionPolicy: config.PVCRetentionPolicyRetain,
+			},
+			expected: false,
+		},
+		{
+			name: "different default custom task cancellation grace period",
+			left: &config.Defaults{
+				DefaultCustomTaskCancellationGracePeriod: 30 * time.Second,
+			},
+			right: &config.Defaults{
+				DefaultCustomTaskCancellationGracePeriod: time.Minute,
+			},
+			expected: false,
+		},
+		{
+			name: "different default memory retry max limit",
+			left: &config.Defaults{
+				DefaultMemoryRetryMaxLimit: &memoryRetryMaxLimit,
+			},
+			right:    &config.Defaults{},
+			expected: false,
+		},
+		{
+			name: "same default memory retry max limit",
+			left: &config.Defaults{
+				DefaultMemoryRetryMultiplier: 2,
+				DefaultMemoryRetryMaxLimit:   &memoryRetryMaxLimit,
+			},
+			right: &config.Defaults{
+				DefaultMemoryRetryMultiplier: 2,
+				DefaultMemoryRetryMaxLimit:   resource.NewQuantity(4*1024*1024*1024, resource.BinarySI),
+			},
+			expected: true,
+		},
+		{
+			name: "different default deadline warning threshold",
+			left: &config.Defaults{
+				DefaultDeadlineWarningThreshold: 10 * time.Minute,
+			},
+			right:    &config.Defaults{},
+			expected: false,
+		},
+		{
+			name: "different default security profile",
+			left: &config.Defaults{
+				DefaultSecurityProfile: "restricted",
+			},
+			right:    &config.Defaults{},
+			expected: false,
+		},
+		{
+			name: "different default notification allowed hosts",
+			left: &config.Defaults{
+				DefaultNotificationAllowedHosts: []string{"hooks.slack.com"},
+			},
+			right: &config.Defaults{
+				DefaultNotificationAllowedHosts: []string{"*.corp.example.com"},
+			},
+			expected: false,
+		},
+		{
+			name: "different default cloud events sinks",
+			left: &config.Defaults{
+				DefaultCloudEventsSinks: []config.CloudEventsSink{{URI: "http://sink", Types: []string{"dev.tekton.event.taskrun.*"}}},
+			},
+			right: &config.Defaults{
+				DefaultCloudEventsSinks: []config.CloudEventsSink{{URI: "http://sink"}},
+			},
+			expected: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -284,3 +479,80 @@ func verifyConfigFileWithExpectedError(t *testing.T, fileName string) {
 		t.Errorf("NewDefaultsFromConfigMap(actual) was expected to return an error")
 	}
 }
+
+func TestCloudEventsSinksFormats(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		defaults *config.Defaults
+		want     []config.CloudEventsSink
+	}{{
+		name: "tekton format by default",
+		defaults: &config.Defaults{
+			DefaultCloudEventsSink:  "http://sink",
+			DefaultCloudEventsSinks: []config.CloudEventsSink{{URI: "http://other"}},
+		},
+		want: []config.CloudEventsSink{
+			{URI: "http://sink", Formats: []string{config.CloudEventsFormatTekton}},
+			{URI: "http://other", Formats: []string{config.CloudEventsFormatTekton}},
+		},
+	}, {
+		name: "default formats",
+		defaults: &config.Defaults{
+			DefaultCloudEventsSink: "http://sink",
+			DefaultCloudEventsSinks: []config.CloudEventsSink{
+				{URI: "http://other"},
+				{URI: "http://cdevents", Formats: []string{config.CloudEventsFormatCDEvents}},
+			},
+			DefaultCloudEventsFormats: []string{config.CloudEventsFormatTekton, config.CloudEventsFormatCDEvents},
+		},
+		want: []config.CloudEventsSink{
+			{URI: "http://sink", Formats: []string{config.CloudEventsFormatTekton, config.CloudEventsFormatCDEvents}},
+			{URI: "http://other", Formats: []string{config.CloudEventsFormatTekton, config.CloudEventsFormatCDEvents}},
+			{URI: "http://cdevents", Formats: []string{config.CloudEventsFormatCDEvents}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, tc.defaults.CloudEventsSinks()); d != "" {
+				t.Errorf("Unexpected sinks %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestCloudEventsSinks(t *testing.T) {
+	defaults := &config.Defaults{
+		DefaultCloudEventsSink: "http://sink",
+		DefaultCloudEventsSinks: []config.CloudEventsSink{{
+			URI:   "http://pipelineruns",
+			Types: []string{"dev.tekton.event.pipelinerun.*"},
+		}, {
+			URI:   "http://failures",
+			Types: []string{"dev.tekton.event.taskrun.failed.v1"},
+		}},
+	}
+	for _, tc := range []struct {
+		eventType string
+		want      []string
+	}{{
+		eventType: "dev.tekton.event.taskrun.started.v1",
+		want:      []string{"http://sink"},
+	}, {
+		eventType: "dev.tekton.event.taskrun.failed.v1",
+		want:      []string{"http://sink", "http://failures"},
+	}, {
+		eventType: "dev.tekton.event.pipelinerun.task.skipped.v1",
+		want:      []string{"http://sink", "http://pipelineruns"},
+	}} {
+		t.Run(tc.eventType, func(t *testing.T) {
+			var got []string
+			for _, sink := range defaults.CloudEventsSinks() {
+				if sink.Accepts(tc.eventType) {
+					got = append(got, sink.URI)
+				}
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Unexpected sinks %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -76,6 +76,8 @@ const (
 	DefaultResourceVerificationMode = SkipResourceVerificationMode
 	// DefaultEnableProvenanceInStatus is the default value for "enable-provenance-status".
 	DefaultEnableProvenanceInStatus = false
+	// DefaultEnableSLSAProvenance is the default value for "enable-slsa-provenance".
+	DefaultEnableSLSAProvenance = false
 
 	disableAffinityAssistantKey         = "disable-affinity-assistant"
 	disableCredsInitKey                 = "disable-creds-init"
@@ -90,6 +92,7 @@ const (
 	enableSpire                         = "enable-spire"
 	verificationMode                    = "resource-verification-mode"
 	enableProvenanceInStatus            = "enable-provenance-in-status"
+	enableSLSAProvenance                = "enable-slsa-provenance"
 )
 
 // FeatureFlags holds the features configurations
@@ -109,6 +112,7 @@ type FeatureFlags struct {
 	EnableSpire                      bool
 	ResourceVerificationMode         string
 	EnableProvenanceInStatus         bool
+	EnableSLSAProvenance             bool
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -166,6 +170,9 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setFeature(enableProvenanceInStatus, DefaultEnableProvenanceInStatus, &tc.EnableProvenanceInStatus); err != nil {
 		return nil, err
 	}
+	if err := setFeature(enableSLSAProvenance, DefaultEnableSLSAProvenance, &tc.EnableSLSAProvenance); err != nil {
+		return nil, err
+	}
 
 	// Given that they are alpha features, Tekton Bundles and Custom Tasks should be switched on if
 	// enable-api-fields is "alpha". If enable-api-fields is not "alpha" then fall back to the value of
@@ -176,7 +183,6 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if tc.EnableAPIFields == AlphaAPIFields {
 		tc.EnableTektonOCIBundles = true
 		tc.EnableCustomTasks = true
-		tc.EnableSpire = true
 	} else {
 		if err := setFeature(enableTektonOCIBundles, DefaultEnableTektonOciBundles, &tc.EnableTektonOCIBundles); err != nil {
 			return nil, err
@@ -184,9 +190,11 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 		if err := setFeature(enableCustomTasks, DefaultEnableCustomTasks, &tc.EnableCustomTasks); err != nil {
 			return nil, err
 		}
-		if err := setFeature(enableSpire, DefaultEnableSpire, &tc.EnableSpire); err != nil {
-			return nil, err
-		}
+	}
+	// Spire is not enabled by "alpha" API fields, since it requires SPIRE and the SPIFFE CSI
+	// driver to be installed in the cluster.
+	if err := setFeature(enableSpire, DefaultEnableSpire, &tc.EnableSpire); err != nil {
+		return nil, err
 	}
 	return &tc, nil
 }
@@ -47,6 +47,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				EmbeddedStatus:           config.DefaultEmbeddedStatus,
 				ResourceVerificationMode: config.DefaultResourceVerificationMode,
 				EnableProvenanceInStatus: config.DefaultEnableProvenanceInStatus,
+				EnableSLSAProvenance:     config.DefaultEnableSLSAProvenance,
 			},
 			fileName: config.GetFeatureFlagsConfigName(),
 		},
@@ -64,6 +65,7 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				EnableSpire:                      true,
 				ResourceVerificationMode:         "enforce",
 				EnableProvenanceInStatus:         true,
+				EnableSLSAProvenance:             true,
 			},
 			fileName: "feature-flags-all-flags-set",
 		},
@@ -74,7 +76,6 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				// if the submitted text value is "false".
 				EnableTektonOCIBundles: true,
 				EnableCustomTasks:      true,
-				EnableSpire:            true,
 
 				DisableAffinityAssistant:         config.DefaultDisableAffinityAssistant,
 				DisableCredsInit:                 config.DefaultDisableCredsInit,
@@ -159,6 +160,7 @@ func TestNewFeatureFlagsFromEmptyConfigMap(t *testing.T) {
 		EnableSpire:                      config.DefaultEnableSpire,
 		ResourceVerificationMode:         config.DefaultResourceVerificationMode,
 		EnableProvenanceInStatus:         config.DefaultEnableProvenanceInStatus,
+		EnableSLSAProvenance:             config.DefaultEnableSLSAProvenance,
 	}
 	verifyConfigFileWithExpectedFeatureFlagsConfig(t, FeatureFlagsConfigEmptyName, expectedConfig)
 }
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// PolicyConfigName is the name of the policy configmap
+	PolicyConfigName = "config-policy"
+
+	allowedImagePrefixesKey = "allowed-image-prefixes"
+	requireImageDigestKey   = "require-image-digest"
+	disallowPrivilegedKey   = "disallow-privileged"
+	celRulesKey             = "cel-rules"
+)
+
+// Policy holds the rules the specs of the TaskRuns and PipelineRuns must
+// follow, enforced when they are created and once their Tasks and Pipelines
+// are resolved.
+// +k8s:deepcopy-gen=true
+type Policy struct {
+	// AllowedImagePrefixes are the prefixes the images of the Steps and
+	// Sidecars must start with, such as "registry.corp/". All the images are
+	// allowed when it is empty.
+	AllowedImagePrefixes []string
+	// RequireImageDigest requires the images of the Steps and Sidecars to be
+	// pinned by digest.
+	RequireImageDigest bool
+	// DisallowPrivileged forbids the Steps and Sidecars from running privileged.
+	DisallowPrivileged bool
+	// CELRules are the CEL expressions the Task specs must satisfy.
+	CELRules []CELRule
+}
+
+// CELRule is a CEL expression a Task spec must satisfy.
+// +k8s:deepcopy-gen=true
+type CELRule struct {
+	// Name identifies the rule in the violations.
+	Name string `json:"name"`
+	// Expression is a CEL expression evaluating to true when the Task spec,
+	// available as the "taskSpec" variable, follows the rule. The namespace
+	// of the run is available as the "runNamespace" variable.
+	Expression string `json:"expression"`
+	// Message describes the violation when the expression evaluates to false.
+	Message string `json:"message,omitempty"`
+}
+
+// IsEmpty returns true if the policy has no rules.
+func (cfg *Policy) IsEmpty() bool {
+	return len(cfg.AllowedImagePrefixes) == 0 && !cfg.RequireImageDigest && !cfg.DisallowPrivileged && len(cfg.CELRules) == 0
+}
+
+// NewPolicyFromMap returns a Policy given a map corresponding to a ConfigMap
+func NewPolicyFromMap(cfgMap map[string]string) (*Policy, error) {
+	cfg := &Policy{}
+
+	if prefixes, ok := cfgMap[allowedImagePrefixesKey]; ok {
+		var p []string
+		if err := yamlUnmarshal(prefixes, allowedImagePrefixesKey, &p); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", prefixes)
+		}
+		cfg.AllowedImagePrefixes = p
+	}
+
+	if requireDigest, ok := cfgMap[requireImageDigestKey]; ok {
+		b, err := strconv.ParseBool(requireDigest)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing %q: %w", requireImageDigestKey, err)
+		}
+		cfg.RequireImageDigest = b
+	}
+
+	if disallowPrivileged, ok := cfgMap[disallowPrivilegedKey]; ok {
+		b, err := strconv.ParseBool(disallowPrivileged)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing %q: %w", disallowPrivilegedKey, err)
+		}
+		cfg.DisallowPrivileged = b
+	}
+
+	if rules, ok := cfgMap[celRulesKey]; ok {
+		var r []CELRule
+		if err := yamlUnmarshal(rules, celRulesKey, &r); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", rules)
+		}
+		names := map[string]bool{}
+		for _, rule := range r {
+			if rule.Name == "" || rule.Expression == "" {
+				return nil, fmt.Errorf("invalid value for %q: the rules must have a name and an expression", celRulesKey)
+			}
+			if names[rule.Name] {
+				return nil, fmt.Errorf("invalid value for %q: duplicate rule %q", celRulesKey, rule.Name)
+			}
+			names[rule.Name] = true
+		}
+		cfg.CELRules = r
+	}
+
+	return cfg, nil
+}
+
+// NewPolicyFromConfigMap returns a Policy for the given configmap
+func NewPolicyFromConfigMap(config *corev1.ConfigMap) (*Policy, error) {
+	return NewPolicyFromMap(config.Data)
+}
+
+// GetPolicyConfigName returns the name of the configmap containing the
+// policy the runs must follow.
+func GetPolicyConfigName() string {
+	if e := os.Getenv("CONFIG_POLICY_NAME"); e != "" {
+		return e
+	}
+	return PolicyConfigName
+}
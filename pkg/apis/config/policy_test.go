@@ -0,0 +1,78 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewPolicyFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		fileName       string
+		expectedConfig *config.Policy
+		expectedEmpty  bool
+	}{{
+		fileName: config.GetPolicyConfigName(),
+		expectedConfig: &config.Policy{
+			AllowedImagePrefixes: []string{"registry.corp/", "gcr.io/tekton-releases/"},
+			RequireImageDigest:   true,
+			DisallowPrivileged:   true,
+			CELRules: []config.CELRule{{
+				Name:       "run-as-non-root",
+				Expression: "!has(taskSpec.stepTemplate) || !has(taskSpec.stepTemplate.securityContext) || taskSpec.stepTemplate.securityContext.runAsNonRoot",
+				Message:    "the steps must run as non-root",
+			}},
+		},
+	}, {
+		fileName:       "config-policy-empty",
+		expectedConfig: &config.Policy{},
+		expectedEmpty:  true,
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			got, err := config.NewPolicyFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("NewPolicyFromConfigMap() = %v", err)
+			}
+			if d := cmp.Diff(tc.expectedConfig, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+			if got.IsEmpty() != tc.expectedEmpty {
+				t.Errorf("IsEmpty() = %t, want %t", got.IsEmpty(), tc.expectedEmpty)
+			}
+		})
+	}
+}
+
+func TestNewPolicyFromConfigMapError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-policy-invalid-digest",
+		"config-policy-invalid-rule",
+	} {
+		t.Run(fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, fileName)
+			if _, err := config.NewPolicyFromConfigMap(cm); err == nil {
+				t.Error("NewPolicyFromConfigMap() = nil, wanted error")
+			}
+		})
+	}
+}
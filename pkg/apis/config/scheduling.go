@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SchedulingConfigName is the name of the scheduling configmap
+	SchedulingConfigName = "config-scheduling"
+
+	maxRunningTaskRunsKey = "max-running-taskruns"
+	tenantLabelKey        = "tenant-label"
+	tenantWeightsKey      = "tenant-weights"
+	prioritiesKey         = "priorities"
+)
+
+// Scheduling holds the configuration of the queue the pods of the TaskRuns
+// are admitted from, and of the priorities of the TaskRuns.
+// +k8s:deepcopy-gen=true
+type Scheduling struct {
+	// MaxRunningTaskRuns is the maximum number of TaskRuns with a pod that
+	// can run at the same time. The other TaskRuns wait in the queue. Zero
+	// means there is no queue.
+	MaxRunningTaskRuns int
+	// TenantLabel is the label of the TaskRuns holding their tenant. The
+	// tenant of a TaskRun is its namespace when it is empty.
+	TenantLabel string
+	// TenantWeights are the weights of the tenants in the fair share of
+	// the running TaskRuns. Tenants have a weight of 1 by default.
+	TenantWeights map[string]int
+	// Priorities are the priorities that can be set on PipelineRuns and
+	// TaskRuns, by name.
+	Priorities map[string]Priority
+}
+
+// Priority is a priority of PipelineRuns and TaskRuns.
+// +k8s:deepcopy-gen=true
+type Priority struct {
+	// Value orders the TaskRuns in the queue, higher values first. TaskRuns
+	// without a priority have a value of 0.
+	Value int `json:"value"`
+	// PriorityClassName is the PriorityClass of the pods of the TaskRuns.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// TenantOf returns the tenant of the TaskRun with the given namespace and labels.
+func (cfg *Scheduling) TenantOf(namespace string, labels map[string]string) string {
+	if cfg.TenantLabel != "" {
+		if tenant, ok := labels[cfg.TenantLabel]; ok {
+			return tenant
+		}
+	}
+	return namespace
+}
+
+// TenantWeight returns the weight of the tenant.
+func (cfg *Scheduling) TenantWeight(tenant string) int {
+	if weight, ok := cfg.TenantWeights[tenant]; ok {
+		return weight
+	}
+	return 1
+}
+
+// NewSchedulingFromMap returns a Scheduling given a map corresponding to a ConfigMap
+func NewSchedulingFromMap(cfgMap map[string]string) (*Scheduling, error) {
+	cfg := &Scheduling{}
+
+	if maxRunning, ok := cfgMap[maxRunningTaskRunsKey]; ok {
+		n, err := strconv.Atoi(maxRunning)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing %q: %w", maxRunningTaskRunsKey, err)
+		}
+		if n < 0 {
+			return nil, fmt.Errorf("invalid value for %q: %q, must not be negative", maxRunningTaskRunsKey, maxRunning)
+		}
+		cfg.MaxRunningTaskRuns = n
+	}
+
+	if tenantLabel, ok := cfgMap[tenantLabelKey]; ok {
+		cfg.TenantLabel = tenantLabel
+	}
+
+	if tenantWeights, ok := cfgMap[tenantWeightsKey]; ok {
+		weights := map[string]int{}
+		if err := yamlUnmarshal(tenantWeights, tenantWeightsKey, &weights); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", tenantWeights)
+		}
+		for tenant, weight := range weights {
+			if weight <= 0 {
+				return nil, fmt.Errorf("invalid value for %q: the weight of tenant %q must be greater than 0", tenantWeightsKey, tenant)
+			}
+		}
+		cfg.TenantWeights = weights
+	}
+
+	if priorities, ok := cfgMap[prioritiesKey]; ok {
+		p := map[string]Priority{}
+		if err := yamlUnmarshal(priorities, prioritiesKey, &p); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", priorities)
+		}
+		cfg.Priorities = p
+	}
+
+	return cfg, nil
+}
+
+// NewSchedulingFromConfigMap returns a Scheduling for the given configmap
+func NewSchedulingFromConfigMap(config *corev1.ConfigMap) (*Scheduling, error) {
+	return NewSchedulingFromMap(config.Data)
+}
+
+// GetSchedulingConfigName returns the name of the configmap containing the
+// scheduling configuration.
+func GetSchedulingConfigName() string {
+	if e := os.Getenv("CONFIG_SCHEDULING_NAME"); e != "" {
+		return e
+	}
+	return SchedulingConfigName
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewSchedulingFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		fileName       string
+		expectedConfig *config.Scheduling
+	}{{
+		fileName: config.GetSchedulingConfigName(),
+		expectedConfig: &config.Scheduling{
+			MaxRunningTaskRuns: 20,
+			TenantLabel:        "example.com/team",
+			TenantWeights:      map[string]int{"frontend": 3, "batch": 1},
+			Priorities: map[string]config.Priority{
+				"interactive": {Value: 100, PriorityClassName: "tekton-interactive"},
+				"batch":       {Value: 10},
+			},
+		},
+	}, {
+		fileName:       "config-scheduling-empty",
+		expectedConfig: &config.Scheduling{},
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			got, err := config.NewSchedulingFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("NewSchedulingFromConfigMap() = %v", err)
+			}
+			if d := cmp.Diff(tc.expectedConfig, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewSchedulingFromConfigMapError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-scheduling-invalid-weight",
+		"config-scheduling-invalid-max-running",
+	} {
+		t.Run(fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, fileName)
+			if _, err := config.NewSchedulingFromConfigMap(cm); err == nil {
+				t.Error("NewSchedulingFromConfigMap() = nil, wanted error")
+			}
+		})
+	}
+}
+
+func TestSchedulingTenant(t *testing.T) {
+	cfg := &config.Scheduling{
+		TenantLabel:   "example.com/team",
+		TenantWeights: map[string]int{"frontend": 3},
+	}
+	if got := cfg.TenantOf("ns", map[string]string{"example.com/team": "frontend"}); got != "frontend" {
+		t.Errorf("TenantOf() = %q, want %q", got, "frontend")
+	}
+	if got := cfg.TenantOf("ns", nil); got != "ns" {
+		t.Errorf("TenantOf() = %q, want %q", got, "ns")
+	}
+	if got := cfg.TenantWeight("frontend"); got != 3 {
+		t.Errorf("TenantWeight() = %d, want 3", got)
+	}
+	if got := cfg.TenantWeight("ns"); got != 1 {
+		t.Errorf("TenantWeight() = %d, want 1", got)
+	}
+}
@@ -33,6 +33,8 @@ type Config struct {
 	ArtifactPVC      *ArtifactPVC
 	Metrics          *Metrics
 	TrustedResources *TrustedResources
+	Scheduling       *Scheduling
+	Policy           *Policy
 }
 
 // FromContext extracts a Config from the provided context.
@@ -56,6 +58,8 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 	artifactPVC, _ := NewArtifactPVCFromMap(map[string]string{})
 	metrics, _ := newMetricsFromMap(map[string]string{})
 	trustedresources, _ := NewTrustedResourcesConfigFromMap(map[string]string{})
+	scheduling, _ := NewSchedulingFromMap(map[string]string{})
+	policy, _ := NewPolicyFromMap(map[string]string{})
 	return &Config{
 		Defaults:         defaults,
 		FeatureFlags:     featureFlags,
@@ -63,6 +67,8 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 		ArtifactPVC:      artifactPVC,
 		Metrics:          metrics,
 		TrustedResources: trustedresources,
+		Scheduling:       scheduling,
+		Policy:           policy,
 	}
 }
 
@@ -91,6 +97,8 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 				GetArtifactPVCConfigName():      NewArtifactPVCFromConfigMap,
 				GetMetricsConfigName():          NewMetricsFromConfigMap,
 				GetTrustedResourcesConfigName(): NewTrustedResourcesConfigFromConfigMap,
+				GetSchedulingConfigName():       NewSchedulingFromConfigMap,
+				GetPolicyConfigName():           NewPolicyFromConfigMap,
 			},
 			onAfterStore...,
 		),
@@ -131,6 +139,14 @@ func (s *Store) Load() *Config {
 	if trustedresources == nil {
 		trustedresources, _ = NewTrustedResourcesConfigFromMap(map[string]string{})
 	}
+	scheduling := s.UntypedLoad(GetSchedulingConfigName())
+	if scheduling == nil {
+		scheduling, _ = NewSchedulingFromMap(map[string]string{})
+	}
+	policy := s.UntypedLoad(GetPolicyConfigName())
+	if policy == nil {
+		policy, _ = NewPolicyFromMap(map[string]string{})
+	}
 
 	return &Config{
 		Defaults:         defaults.(*Defaults).DeepCopy(),
@@ -139,5 +155,7 @@ func (s *Store) Load() *Config {
 		ArtifactPVC:      artifactPVC.(*ArtifactPVC).DeepCopy(),
 		Metrics:          metrics.(*Metrics).DeepCopy(),
 		TrustedResources: trustedresources.(*TrustedResources).DeepCopy(),
+		Scheduling:       scheduling.(*Scheduling).DeepCopy(),
+		Policy:           policy.(*Policy).DeepCopy(),
 	}
 }
@@ -35,6 +35,8 @@ func TestStoreLoadWithContext(t *testing.T) {
 	artifactPVCConfig := test.ConfigMapFromTestFile(t, "config-artifact-pvc")
 	metricsConfig := test.ConfigMapFromTestFile(t, "config-observability")
 	trustedresourcesConfig := test.ConfigMapFromTestFile(t, "config-trusted-resources")
+	schedulingConfig := test.ConfigMapFromTestFile(t, "config-scheduling")
+	policyConfig := test.ConfigMapFromTestFile(t, "config-policy")
 
 	expectedDefaults, _ := config.NewDefaultsFromConfigMap(defaultConfig)
 	expectedFeatures, _ := config.NewFeatureFlagsFromConfigMap(featuresConfig)
@@ -42,6 +44,8 @@ func TestStoreLoadWithContext(t *testing.T) {
 	expectedArtifactPVC, _ := config.NewArtifactPVCFromConfigMap(artifactPVCConfig)
 	metrics, _ := config.NewMetricsFromConfigMap(metricsConfig)
 	expectedTrustedResources, _ := config.NewTrustedResourcesConfigFromConfigMap(trustedresourcesConfig)
+	expectedScheduling, _ := config.NewSchedulingFromConfigMap(schedulingConfig)
+	expectedPolicy, _ := config.NewPolicyFromConfigMap(policyConfig)
 
 	expected := &config.Config{
 		Defaults:         expectedDefaults,
@@ -50,6 +54,8 @@ func TestStoreLoadWithContext(t *testing.T) {
 		ArtifactPVC:      expectedArtifactPVC,
 		Metrics:          metrics,
 		TrustedResources: expectedTrustedResources,
+		Scheduling:       expectedScheduling,
+		Policy:           expectedPolicy,
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
@@ -59,6 +65,8 @@ func TestStoreLoadWithContext(t *testing.T) {
 	store.OnConfigChanged(artifactPVCConfig)
 	store.OnConfigChanged(metricsConfig)
 	store.OnConfigChanged(trustedresourcesConfig)
+	store.OnConfigChanged(schedulingConfig)
+	store.OnConfigChanged(policyConfig)
 
 	cfg := config.FromContext(store.ToContext(context.Background()))
 
@@ -74,6 +82,8 @@ func TestStoreLoadWithContext_Empty(t *testing.T) {
 	artifactPVC, _ := config.NewArtifactPVCFromMap(map[string]string{})
 	metrics, _ := config.NewMetricsFromConfigMap(&corev1.ConfigMap{Data: map[string]string{}})
 	trustedresources, _ := config.NewTrustedResourcesConfigFromMap(map[string]string{})
+	scheduling, _ := config.NewSchedulingFromMap(map[string]string{})
+	policy, _ := config.NewPolicyFromMap(map[string]string{})
 
 	expected := &config.Config{
 		Defaults:         defaults,
@@ -82,6 +92,8 @@ func TestStoreLoadWithContext_Empty(t *testing.T) {
 		ArtifactPVC:      artifactPVC,
 		Metrics:          metrics,
 		TrustedResources: trustedresources,
+		Scheduling:       scheduling,
+		Policy:           policy,
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
@@ -58,6 +58,48 @@ func (in *ArtifactPVC) DeepCopy() *ArtifactPVC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CELRule) DeepCopyInto(out *CELRule) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CELRule.
+func (in *CELRule) DeepCopy() *CELRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CELRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudEventsSink) DeepCopyInto(out *CloudEventsSink) {
+	*out = *in
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudEventsSink.
+func (in *CloudEventsSink) DeepCopy() *CloudEventsSink {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudEventsSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Defaults) DeepCopyInto(out *Defaults) {
 	*out = *in
@@ -71,6 +113,28 @@ func (in *Defaults) DeepCopyInto(out *Defaults) {
 		*out = new(pod.AffinityAssistantTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DefaultCloudEventsSinks != nil {
+		in, out := &in.DefaultCloudEventsSinks, &out.DefaultCloudEventsSinks
+		*out = make([]CloudEventsSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DefaultCloudEventsFormats != nil {
+		in, out := &in.DefaultCloudEventsFormats, &out.DefaultCloudEventsFormats
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultMemoryRetryMaxLimit != nil {
+		in, out := &in.DefaultMemoryRetryMaxLimit, &out.DefaultMemoryRetryMaxLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DefaultNotificationAllowedHosts != nil {
+		in, out := &in.DefaultNotificationAllowedHosts, &out.DefaultNotificationAllowedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -116,6 +180,78 @@ func (in *Metrics) DeepCopy() *Metrics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Policy) DeepCopyInto(out *Policy) {
+	*out = *in
+	if in.AllowedImagePrefixes != nil {
+		in, out := &in.AllowedImagePrefixes, &out.AllowedImagePrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CELRules != nil {
+		in, out := &in.CELRules, &out.CELRules
+		*out = make([]CELRule, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Policy.
+func (in *Policy) DeepCopy() *Policy {
+	if in == nil {
+		return nil
+	}
+	out := new(Policy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Priority) DeepCopyInto(out *Priority) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Priority.
+func (in *Priority) DeepCopy() *Priority {
+	if in == nil {
+		return nil
+	}
+	out := new(Priority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Scheduling) DeepCopyInto(out *Scheduling) {
+	*out = *in
+	if in.TenantWeights != nil {
+		in, out := &in.TenantWeights, &out.TenantWeights
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Priorities != nil {
+		in, out := &in.Priorities, &out.Priorities
+		*out = make(map[string]Priority, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scheduling.
+func (in *Scheduling) DeepCopy() *Scheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(Scheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TrustedResources) DeepCopyInto(out *TrustedResources) {
 	*out = *in
@@ -39,4 +39,7 @@ const (
 
 	// CustomRunControllerName holds the name of the CustomRun controller
 	CustomRunControllerName = "CustomRun"
+
+	// ApprovalControllerName holds the name of the controller of the ApprovalTask custom task
+	ApprovalControllerName = "ApprovalTask"
 )
@@ -0,0 +1,142 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// SecurityProfileNone leaves the security context of the containers of TaskRun pods as it is
+	SecurityProfileNone = "none"
+	// SecurityProfileBaseline makes the containers of TaskRun pods comply with the
+	// "baseline" Pod Security Standard
+	SecurityProfileBaseline = "baseline"
+	// SecurityProfileRestricted makes the containers of TaskRun pods comply with the
+	// "restricted" Pod Security Standard
+	SecurityProfileRestricted = "restricted"
+)
+
+// baselineCapabilities are the capabilities the "baseline" Pod Security Standard allows to add
+var baselineCapabilities = map[corev1.Capability]bool{
+	"AUDIT_WRITE":      true,
+	"CHOWN":            true,
+	"DAC_OVERRIDE":     true,
+	"FOWNER":           true,
+	"FSETID":           true,
+	"KILL":             true,
+	"MKNOD":            true,
+	"NET_BIND_SERVICE": true,
+	"SETFCAP":          true,
+	"SETGID":           true,
+	"SETPCAP":          true,
+	"SETUID":           true,
+	"SYS_CHROOT":       true,
+}
+
+// ApplySecurityProfile sets the fields of the security context of a container that the
+// security profile requires and that are not set already. The security context is
+// returned unchanged for the "none" and "baseline" profiles, since the default security
+// context of containers complies with the "baseline" Pod Security Standard.
+func ApplySecurityProfile(profile string, sc *corev1.SecurityContext) *corev1.SecurityContext {
+	if profile != SecurityProfileRestricted {
+		return sc
+	}
+	if sc == nil {
+		sc = &corev1.SecurityContext{}
+	} else {
+		sc = sc.DeepCopy()
+	}
+	if sc.AllowPrivilegeEscalation == nil {
+		allowPrivilegeEscalation := false
+		sc.AllowPrivilegeEscalation = &allowPrivilegeEscalation
+	}
+	if sc.RunAsNonRoot == nil {
+		runAsNonRoot := true
+		sc.RunAsNonRoot = &runAsNonRoot
+	}
+	if sc.Capabilities == nil {
+		sc.Capabilities = &corev1.Capabilities{}
+	}
+	if len(sc.Capabilities.Drop) == 0 {
+		sc.Capabilities.Drop = []corev1.Capability{"ALL"}
+	}
+	if sc.SeccompProfile == nil {
+		sc.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+	}
+	return sc
+}
+
+// SecurityProfileViolations returns the fields of the security context of a container
+// that do not comply with the security profile, along with the reason why.
+func SecurityProfileViolations(profile string, sc *corev1.SecurityContext) map[string]string {
+	violations := map[string]string{}
+	if sc == nil || (profile != SecurityProfileBaseline && profile != SecurityProfileRestricted) {
+		return violations
+	}
+
+	// The "baseline" Pod Security Standard
+	if sc.Privileged != nil && *sc.Privileged {
+		violations["privileged"] = "privileged containers are not allowed"
+	}
+	if sc.WindowsOptions != nil && sc.WindowsOptions.HostProcess != nil && *sc.WindowsOptions.HostProcess {
+		violations["windowsOptions.hostProcess"] = "host process containers are not allowed"
+	}
+	if sc.ProcMount != nil && *sc.ProcMount != corev1.DefaultProcMount {
+		violations["procMount"] = fmt.Sprintf("the %s proc mount is not allowed", *sc.ProcMount)
+	}
+	if sc.SeccompProfile != nil && sc.SeccompProfile.Type == corev1.SeccompProfileTypeUnconfined {
+		violations["seccompProfile.type"] = "the Unconfined seccomp profile is not allowed"
+	}
+	if sc.Capabilities != nil {
+		for _, c := range sc.Capabilities.Add {
+			if !baselineCapabilities[c] || (profile == SecurityProfileRestricted && c != "NET_BIND_SERVICE") {
+				violations["capabilities.add"] = fmt.Sprintf("adding the %s capability is not allowed", c)
+				break
+			}
+		}
+	}
+	if profile == SecurityProfileBaseline {
+		return violations
+	}
+
+	// The "restricted" Pod Security Standard
+	if sc.AllowPrivilegeEscalation != nil && *sc.AllowPrivilegeEscalation {
+		violations["allowPrivilegeEscalation"] = "privilege escalation is not allowed"
+	}
+	if sc.RunAsNonRoot != nil && !*sc.RunAsNonRoot {
+		violations["runAsNonRoot"] = "containers must run as non-root users"
+	}
+	if sc.RunAsUser != nil && *sc.RunAsUser == 0 {
+		violations["runAsUser"] = "containers must not run as the root user"
+	}
+	if sc.Capabilities != nil && len(sc.Capabilities.Drop) > 0 && !dropsAll(sc.Capabilities.Drop) {
+		violations["capabilities.drop"] = "all the capabilities must be dropped"
+	}
+	return violations
+}
+
+func dropsAll(capabilities []corev1.Capability) bool {
+	for _, c := range capabilities {
+		if c == "ALL" {
+			return true
+		}
+	}
+	return false
+}
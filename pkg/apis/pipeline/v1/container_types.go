@@ -136,6 +136,17 @@ type Step struct {
 	// Stores configuration for the stderr stream of the step.
 	// +optional
 	StderrConfig *StepOutputConfig `json:"stderrConfig,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// WaitForSidecars is a list of names of Sidecars of the Task that must be
+	// ready before this Step starts. When a Step of the Task sets it, Steps only
+	// wait for the Sidecars they list, instead of the first Step waiting for all
+	// the Sidecars.
+	// +optional
+	// +listType=atomic
+	WaitForSidecars []string `json:"waitForSidecars,omitempty"`
 }
 
 // OnErrorType defines a list of supported exiting behavior of a container on error
@@ -489,6 +500,14 @@ type Sidecar struct {
 	// +optional
 	// +listType=atomic
 	Workspaces []WorkspaceUsage `json:"workspaces,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// StartOrder orders the start of the Sidecars of the Task: a Sidecar only
+	// starts once the Sidecars with a lower StartOrder are ready. Defaults to 0.
+	// +optional
+	StartOrder int `json:"startOrder,omitempty"`
 }
 
 // ToK8sContainer converts the Sidecar to a Kubernetes Container struct
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+var (
+	validNotificationTriggers = sets.NewString(
+		string(NotificationTriggerSucceeded),
+		string(NotificationTriggerFailed),
+		string(NotificationTriggerAlways),
+	)
+	validCommitStatusProviders = sets.NewString("github", "gitlab", "gitea", "bitbucket-server")
+)
+
+// validateNotifications validates the notifications of a PipelineRun: names must be unique,
+// and each notification must have exactly one valid target.
+func validateNotifications(notifications []PipelineRunNotification) (errs *apis.FieldError) {
+	names := map[string]int{}
+	for idx, n := range notifications {
+		errs = errs.Also(n.validate().ViaIndex(idx))
+		if prevIdx, alreadyExists := names[n.Name]; alreadyExists {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("notification %q provided more than once, at index %d and %d", n.Name, prevIdx, idx), "name").ViaIndex(idx))
+		}
+		names[n.Name] = idx
+	}
+	return errs
+}
+
+func (n PipelineRunNotification) validate() (errs *apis.FieldError) {
+	if n.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	if n.Trigger != "" && !validNotificationTriggers.Has(string(n.Trigger)) {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be one of %v", n.Trigger, validNotificationTriggers.List()), "trigger"))
+	}
+	if n.SecretRef != nil && (n.SecretRef.Name == "" || n.SecretRef.Key == "") {
+		errs = errs.Also(apis.ErrMissingField("secretRef.name", "secretRef.key"))
+	}
+
+	targets := 0
+	if n.HTTP != nil {
+		targets++
+		if n.HTTP.URL == "" {
+			errs = errs.Also(apis.ErrMissingField("http.url"))
+		}
+	}
+	if n.Slack != nil {
+		targets++
+		// The webhook URL of Slack targets is a credential, read from the Secret.
+		if n.SecretRef == nil {
+			errs = errs.Also(apis.ErrMissingField("secretRef"))
+		}
+	}
+	if n.CommitStatus != nil {
+		targets++
+		errs = errs.Also(n.CommitStatus.validate().ViaField("commitStatus"))
+	}
+	switch {
+	case targets == 0:
+		errs = errs.Also(apis.ErrMissingOneOf("http", "slack", "commitStatus"))
+	case targets > 1:
+		errs = errs.Also(apis.ErrMultipleOneOf("http", "slack", "commitStatus"))
+	}
+	return errs
+}
+
+func (cs CommitStatusNotification) validate() (errs *apis.FieldError) {
+	if cs.Provider != "" && !validCommitStatusProviders.Has(cs.Provider) {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be one of %v", cs.Provider, validCommitStatusProviders.List()), "provider"))
+	}
+	if (cs.Provider == "gitea" || cs.Provider == "bitbucket-server") && cs.ServerURL == "" {
+		errs = errs.Also(apis.ErrMissingField("serverURL"))
+	}
+	if cs.Repository == "" {
+		errs = errs.Also(apis.ErrMissingField("repository"))
+	}
+	if cs.SHA == "" {
+		errs = errs.Also(apis.ErrMissingField("sha"))
+	}
+	return errs
+}
@@ -109,6 +109,17 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	for idx, trs := range ps.TaskRunSpecs {
 		errs = errs.Also(validateTaskRunSpec(ctx, trs).ViaIndex(idx).ViaField("taskRunSpecs"))
 	}
+	if ps.Notifications != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "notifications", config.AlphaAPIFields).ViaField("notifications"))
+		errs = errs.Also(validateNotifications(ps.Notifications).ViaField("notifications"))
+	}
+	if ps.Priority != "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "priority", config.AlphaAPIFields).ViaField("priority"))
+		errs = errs.Also(validatePriority(ctx, ps.Priority).ViaField("priority"))
+	}
+	if ps.Deadline != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "deadline", config.AlphaAPIFields).ViaField("deadline"))
+	}
 
 	return errs
 }
@@ -21,10 +21,12 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"github.com/tektoncd/pipeline/pkg/substitution"
@@ -82,6 +84,7 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(ValidateVolumes(ts.Volumes).ViaField("volumes"))
 	errs = errs.Also(validateDeclaredWorkspaces(ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces"))
 	errs = errs.Also(validateWorkspaceUsages(ctx, ts))
+	errs = errs.Also(validateSidecarLifecycle(ctx, ts))
 	mergedSteps, err := MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
 	if err != nil {
 		errs = errs.Also(&apis.FieldError{
@@ -92,6 +95,7 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(validateSteps(ctx, mergedSteps).ViaField("steps"))
+	errs = errs.Also(validateSecurityProfile(ctx, mergedSteps, ts.Sidecars))
 	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
 	errs = errs.Also(ValidateParameterVariables(ctx, ts.Steps, ts.Params))
 	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps))
@@ -179,6 +183,67 @@ func validateWorkspaceUsages(ctx context.Context, ts *TaskSpec) (errs *apis.Fiel
 	return errs
 }
 
+// validateSidecarLifecycle validates the ordering of Sidecars and the Sidecars
+// Steps wait for, making sure Steps only refer to Sidecars defined in the Task.
+//
+// This is an alpha feature and will fail validation if it's used by a step
+// or sidecar when the enable-api-fields feature gate is anything but "alpha".
+func validateSidecarLifecycle(ctx context.Context, ts *TaskSpec) (errs *apis.FieldError) {
+	sidecarNames := sets.NewString()
+	for sidecarIdx, sidecar := range ts.Sidecars {
+		sidecarNames.Insert(sidecar.Name)
+		if sidecar.StartOrder != 0 {
+			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "sidecar startOrder", config.AlphaAPIFields).ViaIndex(sidecarIdx).ViaField("sidecars"))
+		}
+		if sidecar.StartOrder < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(sidecar.StartOrder, "startOrder", "startOrder must not be negative").ViaIndex(sidecarIdx).ViaField("sidecars"))
+		}
+	}
+
+	for stepIdx, step := range ts.Steps {
+		if len(step.WaitForSidecars) != 0 {
+			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step waitForSidecars", config.AlphaAPIFields).ViaIndex(stepIdx).ViaField("steps"))
+		}
+		for sidecarIdx, name := range step.WaitForSidecars {
+			if !sidecarNames.Has(name) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("undefined sidecar %q", name), "").ViaFieldIndex("waitForSidecars", sidecarIdx).ViaIndex(stepIdx).ViaField("steps"))
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateSecurityProfile validates that the security context of the Steps and Sidecars does not
+// conflict with the security profile of the TaskRun pods set in the config-defaults ConfigMap.
+func validateSecurityProfile(ctx context.Context, steps []Step, sidecars []Sidecar) (errs *apis.FieldError) {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	if defaults == nil {
+		return nil
+	}
+	profile := defaults.DefaultSecurityProfile
+	for stepIdx, step := range steps {
+		errs = errs.Also(securityProfileViolations(profile, step.SecurityContext).ViaIndex(stepIdx).ViaField("steps"))
+	}
+	for sidecarIdx, sidecar := range sidecars {
+		errs = errs.Also(securityProfileViolations(profile, sidecar.SecurityContext).ViaIndex(sidecarIdx).ViaField("sidecars"))
+	}
+	return errs
+}
+
+func securityProfileViolations(profile string, sc *corev1.SecurityContext) (errs *apis.FieldError) {
+	violations := pod.SecurityProfileViolations(profile, sc)
+	fields := make([]string, 0, len(violations))
+	for field := range violations {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%s by the %q security profile", violations[field], profile), "securityContext."+field))
+	}
+	return errs
+}
+
 // ValidateVolumes validates a slice of volumes to make sure there are no dupilcate names
 func ValidateVolumes(volumes []corev1.Volume) (errs *apis.FieldError) {
 	// Task must not have duplicate volume names.
@@ -1486,6 +1486,119 @@ func TestStepOnError(t *testing.T) {
 
 // TestIncompatibleAPIVersions exercises validation of fields that
 // require a specific feature gate version in order to work.
+func TestSidecarLifecycleErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1.Step
+		sidecars      []v1.Sidecar
+		expectedError apis.FieldError
+	}{{
+		name: "negative sidecar startOrder fails",
+		steps: []v1.Step{{
+			Image: "foo",
+		}},
+		sidecars: []v1.Sidecar{{
+			Name:       "db",
+			Image:      "foo",
+			StartOrder: -1,
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: -1`,
+			Paths:   []string{"sidecars[0].startOrder"},
+			Details: "startOrder must not be negative",
+		},
+	}, {
+		name: "step waiting for non-existent sidecar fails",
+		steps: []v1.Step{{
+			Image:           "foo",
+			WaitForSidecars: []string{"db", "cache"},
+		}},
+		sidecars: []v1.Sidecar{{
+			Name:  "db",
+			Image: "foo",
+		}},
+		expectedError: apis.FieldError{
+			Message: `undefined sidecar "cache"`,
+			Paths:   []string{"steps[0].waitForSidecars[1]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:    tt.steps,
+				Sidecars: tt.sidecars,
+			}
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSecurityProfileErrors(t *testing.T) {
+	privileged := true
+	root := int64(0)
+	tests := []struct {
+		name          string
+		profile       string
+		steps         []v1.Step
+		sidecars      []v1.Sidecar
+		expectedError apis.FieldError
+	}{{
+		name:    "privileged step with the baseline profile fails",
+		profile: "baseline",
+		steps: []v1.Step{{
+			Image:           "foo",
+			SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+		}},
+		expectedError: apis.FieldError{
+			Message: `privileged containers are not allowed by the "baseline" security profile`,
+			Paths:   []string{"steps[0].securityContext.privileged"},
+		},
+	}, {
+		name:    "root sidecar with the restricted profile fails",
+		profile: "restricted",
+		steps: []v1.Step{{
+			Image: "foo",
+		}},
+		sidecars: []v1.Sidecar{{
+			Name:  "db",
+			Image: "foo",
+			SecurityContext: &corev1.SecurityContext{
+				RunAsUser:    &root,
+				Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_ADMIN"}},
+			},
+		}},
+		expectedError: *apis.ErrGeneric(`adding the NET_ADMIN capability is not allowed by the "restricted" security profile`, "sidecars[0].securityContext.capabilities.add").Also(
+			apis.ErrGeneric(`containers must not run as the root user by the "restricted" security profile`, "sidecars[0].securityContext.runAsUser")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:    tt.steps,
+				Sidecars: tt.sidecars,
+			}
+			cfg := config.FromContextOrDefaults(context.Background())
+			cfg.Defaults.DefaultSecurityProfile = tt.profile
+			ctx := config.ToContext(context.Background(), cfg)
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestIncompatibleAPIVersions(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -1554,6 +1667,30 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 					Path: "/tmp/stderr.txt",
 				},
 			}},
+		}}, {
+		name:            "sidecar startOrder requires alpha",
+		requiredVersion: "alpha",
+		spec: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image: "foo",
+			}},
+			Sidecars: []v1.Sidecar{{
+				Name:       "db",
+				Image:      "foo",
+				StartOrder: 1,
+			}},
+		}}, {
+		name:            "step waitForSidecars requires alpha",
+		requiredVersion: "alpha",
+		spec: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image:           "foo",
+				WaitForSidecars: []string{"db"},
+			}},
+			Sidecars: []v1.Sidecar{{
+				Name:  "db",
+				Image: "foo",
+			}},
 		}},
 	}
 	versions := []string{"alpha", "stable"}
@@ -26,6 +26,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -81,8 +82,38 @@ type TaskRunSpec struct {
 	SidecarSpecs []TaskRunSidecarSpec `json:"sidecarSpecs,omitempty"`
 	// Compute resources to use for this TaskRun
 	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+	// Priority is the name of one of the priorities of the config-scheduling
+	// ConfigMap. It orders the TaskRun in the queue its pod is admitted from,
+	// and sets the PriorityClass of the pod.
+	// +optional
+	Priority string `json:"priority,omitempty"`
+	// Deadline is the time by which the TaskRun must finish. When both a
+	// deadline and a timeout are set, the TaskRun times out at whichever
+	// comes first.
+	// +optional
+	Deadline *metav1.Time `json:"deadline,omitempty"`
+	// ExecutionMode is the mode the Steps of the TaskRun are executed in.
+	// The only supported mode is "hermetic".
+	// This field is only supported when the alpha feature gate is enabled.
+	// +optional
+	ExecutionMode ExecutionMode `json:"executionMode,omitempty"`
+	// MaskSecrets redacts the values of the Secrets used by the Steps from
+	// their logs and results. It defaults to true in namespaces enforcing the
+	// "restricted" Pod Security Standard, and to false otherwise.
+	// This field is only supported when the alpha feature gate is enabled.
+	// +optional
+	MaskSecrets *bool `json:"maskSecrets,omitempty"`
 }
 
+// ExecutionMode is the mode the Steps of a TaskRun are executed in
+type ExecutionMode string
+
+const (
+	// ExecutionModeHermetic executes the Steps without network access, without
+	// capabilities and with a read-only root filesystem
+	ExecutionModeHermetic ExecutionMode = "hermetic"
+)
+
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
 type TaskRunSpecStatus string
 
@@ -150,6 +181,9 @@ const (
 	TaskRunReasonResolvingTaskRef = "ResolvingTaskRef"
 	// TaskRunReasonImagePullFailed is the reason set when the step of a task fails due to image not being pulled
 	TaskRunReasonImagePullFailed TaskRunReason = "TaskRunImagePullFailed"
+	// TaskRunReasonHermeticStepFailed is the reason set when a step of a TaskRun executed in
+	// hermetic mode fails, for instance because it needs the network
+	TaskRunReasonHermeticStepFailed TaskRunReason = "HermeticStepFailed"
 )
 
 func (t TaskRunReason) String() string {
@@ -234,6 +268,11 @@ type TaskRunStatusFields struct {
 
 	// Provenance contains some key authenticated metadata about how a software artifact was built (what sources, what inputs/outputs, etc.).
 	Provenance *Provenance `json:"provenance,omitempty"`
+
+	// ComputeResources are the task-level compute resources the TaskRun was run with.
+	// It is only recorded in RetriesStatus, to keep the history of the resources of each attempt.
+	// +optional
+	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
 }
 
 // TaskRunStepSpec is used to override the values of a Step in the corresponding Task.
@@ -299,6 +338,25 @@ type StepState struct {
 	Name                  string `json:"name,omitempty"`
 	Container             string `json:"container,omitempty"`
 	ImageID               string `json:"imageID,omitempty"`
+	// ResourceUsage is the resource usage of the step, when it could be read
+	// from the cgroup v2 of its container.
+	// +optional
+	ResourceUsage *StepResourceUsage `json:"resourceUsage,omitempty"`
+	// Redactions is the number of secret values redacted from the logs and
+	// results of the step, when the Secrets of the TaskRun are masked.
+	// +optional
+	Redactions int64 `json:"redactions,omitempty"`
+}
+
+// StepResourceUsage is the resource usage of a step, as sampled by the
+// entrypoint from the cgroup of the step's container.
+type StepResourceUsage struct {
+	// PeakMemory is the peak memory usage of the step's container.
+	// +optional
+	PeakMemory *resource.Quantity `json:"peakMemory,omitempty"`
+	// CPUTime is the user and system CPU time used by the step's processes.
+	// +optional
+	CPUTime *metav1.Duration `json:"cpuTime,omitempty"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
@@ -383,11 +441,15 @@ func (tr *TaskRun) IsCancelled() bool {
 	return tr.Spec.Status == TaskRunSpecStatusCancelled
 }
 
-// HasTimedOut returns true if the TaskRun runtime is beyond the allowed timeout
+// HasTimedOut returns true if the TaskRun runtime is beyond the allowed timeout,
+// or its deadline has passed
 func (tr *TaskRun) HasTimedOut(ctx context.Context, c clock.PassiveClock) bool {
 	if tr.Status.StartTime.IsZero() {
 		return false
 	}
+	if tr.HasPassedDeadline(c) {
+		return true
+	}
 	timeout := tr.GetTimeout(ctx)
 	// If timeout is set to 0 or defaulted to 0, there is no timeout.
 	if timeout == apisconfig.NoTimeoutDuration {
@@ -397,6 +459,11 @@ func (tr *TaskRun) HasTimedOut(ctx context.Context, c clock.PassiveClock) bool {
 	return runtime > timeout
 }
 
+// HasPassedDeadline returns true if the deadline of the TaskRun has passed
+func (tr *TaskRun) HasPassedDeadline(c clock.PassiveClock) bool {
+	return tr.Spec.Deadline != nil && c.Now().After(tr.Spec.Deadline.Time)
+}
+
 // GetTimeout returns the timeout for the TaskRun, or the default if not specified
 func (tr *TaskRun) GetTimeout(ctx context.Context) time.Duration {
 	// Use the platform default is no timeout is set
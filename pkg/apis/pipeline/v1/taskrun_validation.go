@@ -86,6 +86,20 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "computeResources", config.AlphaAPIFields).ViaField("computeResources"))
 		errs = errs.Also(validateTaskRunComputeResources(ts.ComputeResources, ts.StepSpecs))
 	}
+	if ts.Priority != "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "priority", config.AlphaAPIFields).ViaField("priority"))
+		errs = errs.Also(validatePriority(ctx, ts.Priority).ViaField("priority"))
+	}
+	if ts.Deadline != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "deadline", config.AlphaAPIFields).ViaField("deadline"))
+	}
+	if ts.ExecutionMode != "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "executionMode", config.AlphaAPIFields).ViaField("executionMode"))
+		errs = errs.Also(validateExecutionMode(ts.ExecutionMode).ViaField("executionMode"))
+	}
+	if ts.MaskSecrets != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "maskSecrets", config.AlphaAPIFields).ViaField("maskSecrets"))
+	}
 
 	if ts.Status != "" {
 		if ts.Status != TaskRunSpecStatusCancelled {
@@ -246,6 +260,24 @@ func validateStepSpecs(specs []TaskRunStepSpec) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateExecutionMode ensures that the execution mode is a supported one
+func validateExecutionMode(mode ExecutionMode) *apis.FieldError {
+	if mode != ExecutionModeHermetic {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be %s", mode, ExecutionModeHermetic), "")
+	}
+	return nil
+}
+
+// validatePriority ensures that the priority is one of the priorities of the config-scheduling ConfigMap
+func validatePriority(ctx context.Context, priority string) *apis.FieldError {
+	if scheduling := config.FromContextOrDefaults(ctx).Scheduling; scheduling != nil {
+		if _, ok := scheduling.Priorities[priority]; ok {
+			return nil
+		}
+	}
+	return apis.ErrInvalidValue(fmt.Sprintf("%s is not one of the priorities of the %s ConfigMap", priority, config.GetSchedulingConfigName()), "")
+}
+
 // validateTaskRunComputeResources ensures that compute resources are not configured at both the step level and the task level
 func validateTaskRunComputeResources(computeResources *corev1.ResourceRequirements, specs []TaskRunStepSpec) (errs *apis.FieldError) {
 	for _, spec := range specs {
@@ -92,6 +92,22 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 
+	// A retention policy only applies to claims created from a volumeClaimTemplate,
+	// and is only supported when the alpha feature gate is enabled.
+	if b.RetentionPolicy != "" {
+		if errs := version.ValidateEnabledAPIFields(ctx, "retentionPolicy", config.AlphaAPIFields).ViaField("retentionPolicy"); errs != nil {
+			return errs
+		}
+		if b.VolumeClaimTemplate == nil {
+			return apis.ErrGeneric("retentionPolicy can only be specified with a volumeClaimTemplate", "retentionPolicy")
+		}
+		switch b.RetentionPolicy {
+		case PVCRetentionPolicyDelete, PVCRetentionPolicyRetain:
+		default:
+			return apis.ErrInvalidValue(b.RetentionPolicy, "retentionPolicy")
+		}
+	}
+
 	return nil
 }
 
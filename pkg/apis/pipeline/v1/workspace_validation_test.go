@@ -58,6 +58,18 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "Valid volumeClaimTemplate with retention policy",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mypvc",
+				},
+			},
+			RetentionPolicy: v1.PVCRetentionPolicyRetain,
+		},
+		wc: config.EnableAlphaAPIFields,
 	}, {
 		name: "Valid emptyDir",
 		binding: &v1.WorkspaceBinding{
@@ -196,6 +208,29 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 			},
 		},
 		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide retention policy without volumeClaimTemplate",
+		binding: &v1.WorkspaceBinding{
+			Name:            "beth",
+			EmptyDir:        &corev1.EmptyDirVolumeSource{},
+			RetentionPolicy: v1.PVCRetentionPolicyDelete,
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "Provide invalid retention policy",
+		binding: &v1.WorkspaceBinding{
+			Name:                "beth",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+			RetentionPolicy:     "Sometimes",
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "Provide retention policy without alpha feature gate",
+		binding: &v1.WorkspaceBinding{
+			Name:                "beth",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+			RetentionPolicy:     v1.PVCRetentionPolicyDelete,
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
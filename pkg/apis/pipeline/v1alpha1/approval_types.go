@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+const (
+	// ApprovalTaskKind is the kind of the custom task of the manual approval
+	// gates, which are executed by the approval controller bundled with Tekton.
+	// Its apiVersion is tekton.dev/v1alpha1.
+	ApprovalTaskKind = "ApprovalTask"
+
+	// ApprovalTaskApproversParam is the name of the array param listing the
+	// users allowed to approve or reject an ApprovalTask.
+	ApprovalTaskApproversParam = "approvers"
+	// ApprovalTaskRequiredApprovalsParam is the name of the string param with
+	// the number of approvals required for an ApprovalTask to succeed. It
+	// defaults to 1.
+	ApprovalTaskRequiredApprovalsParam = "numberOfApprovalsRequired"
+
+	// ApprovalTaskDecisionResult is the name of the result with the outcome of
+	// an ApprovalTask, "approved" or "rejected".
+	ApprovalTaskDecisionResult = "decision"
+	// ApprovalTaskApproversResult is the name of the result with the
+	// comma-separated list of the users who approved an ApprovalTask.
+	ApprovalTaskApproversResult = "approvers"
+	// ApprovalTaskCommentsResult is the name of the result with the comments
+	// of the decisions, one "approver: comment" line per decision.
+	ApprovalTaskCommentsResult = "comments"
+)
+
+// ApprovalDecisionType is the decision of an approver.
+type ApprovalDecisionType string
+
+const (
+	// ApprovalDecisionApprove approves an ApprovalTask.
+	ApprovalDecisionApprove ApprovalDecisionType = "approve"
+	// ApprovalDecisionReject rejects an ApprovalTask, which then fails.
+	ApprovalDecisionReject ApprovalDecisionType = "reject"
+)
+
+const (
+	// RunReasonWaitingForApproval is the reason of the Succeeded condition of
+	// the Runs of ApprovalTasks that are waiting for decisions.
+	RunReasonWaitingForApproval RunReason = "WaitingForApproval"
+	// RunReasonApproved is the reason of the Succeeded condition of the Runs
+	// of ApprovalTasks that got the required number of approvals.
+	RunReasonApproved RunReason = "Approved"
+	// RunReasonRejected is the reason of the Succeeded condition of the Runs
+	// of ApprovalTasks that were rejected by an approver.
+	RunReasonRejected RunReason = "Rejected"
+	// RunReasonInvalidApprovalParams is the reason of the Succeeded condition
+	// of the Runs of ApprovalTasks with invalid params.
+	RunReasonInvalidApprovalParams RunReason = "InvalidApprovalParams"
+)
+
+// ApprovalTaskStatus holds the decisions accepted by the approval controller
+// for the Run of an ApprovalTask, in the extraFields of its status. Approvers
+// record their decisions in the approvalDecisions of the spec of the Run,
+// which requires the "patch" verb on "runs".
+type ApprovalTaskStatus struct {
+	// Decisions are the decisions recorded by the approvers, as copied from
+	// the spec of the Run by the approval controller.
+	// +listType=atomic
+	Decisions []ApprovalDecision `json:"decisions"`
+}
+
+// ApprovalDecision is the decision of an approver about an ApprovalTask.
+type ApprovalDecision struct {
+	// Approver is the name of the user recording the decision. It must match
+	// the user making the request.
+	Approver string `json:"approver"`
+	// Decision is either "approve" or "reject".
+	Decision ApprovalDecisionType `json:"decision"`
+	// Comment is an optional comment about the decision.
+	// +optional
+	Comment string `json:"comment,omitempty"`
+}
+
+// IsApprovalTask returns true if the Run executes an ApprovalTask.
+func (r *Run) IsApprovalTask() bool {
+	return r.Spec.Ref != nil && r.Spec.Ref.APIVersion == SchemeGroupVersion.String() && r.Spec.Ref.Kind == ApprovalTaskKind
+}
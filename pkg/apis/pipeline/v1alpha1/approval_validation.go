@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"knative.dev/pkg/apis"
+)
+
+// validateApprovalDecisions validates the approval decisions in the spec of a
+// Run. They can only be set on the Runs of ApprovalTasks, once they exist:
+// approvers can only append their own decisions, while the Run is not done,
+// and cannot change anything else in its spec but cancelling it.
+func (r *Run) validateApprovalDecisions(ctx context.Context) *apis.FieldError {
+	old, _ := apis.GetBaseline(ctx).(*Run)
+	if !apis.IsInUpdate(ctx) || old == nil {
+		if len(r.Spec.ApprovalDecisions) > 0 {
+			return apis.ErrGeneric("approval decisions can only be added to existing Runs", "spec.approvalDecisions")
+		}
+		return nil
+	}
+	if !r.IsApprovalTask() && !old.IsApprovalTask() {
+		if len(r.Spec.ApprovalDecisions) > 0 {
+			return apis.ErrGeneric("approval decisions can only be added to the Runs of ApprovalTasks", "spec.approvalDecisions")
+		}
+		return nil
+	}
+
+	spec, oldSpec := r.Spec.DeepCopy(), old.Spec.DeepCopy()
+	if spec.Status != oldSpec.Status && spec.Status != RunSpecStatusCancelled {
+		return apis.ErrInvalidValue(fmt.Sprintf("the Run of an ApprovalTask can only be cancelled, got %q", spec.Status), "spec.status")
+	}
+	spec.ApprovalDecisions, oldSpec.ApprovalDecisions = nil, nil
+	spec.Status, oldSpec.Status = "", ""
+	spec.StatusMessage, oldSpec.StatusMessage = "", ""
+	if !equality.Semantic.DeepEqual(spec, oldSpec) {
+		return apis.ErrGeneric("the spec of the Run of an ApprovalTask can only be changed to add approval decisions or to cancel it", "spec")
+	}
+
+	decisions, oldDecisions := r.Spec.ApprovalDecisions, old.Spec.ApprovalDecisions
+	if len(decisions) < len(oldDecisions) ||
+		!equality.Semantic.DeepEqual(oldDecisions, decisions[:len(oldDecisions)]) {
+		return apis.ErrGeneric("approval decisions cannot be changed or removed", "spec.approvalDecisions")
+	}
+	added := decisions[len(oldDecisions):]
+	if len(added) == 0 {
+		return nil
+	}
+	if old.IsDone() {
+		return apis.ErrGeneric("approval decisions cannot be added once the Run is done", "spec.approvalDecisions")
+	}
+
+	var username string
+	if userInfo := apis.GetUserInfo(ctx); userInfo != nil {
+		username = userInfo.Username
+	}
+	seen := map[string]bool{}
+	for _, d := range oldDecisions {
+		seen[d.Approver] = true
+	}
+	var errs *apis.FieldError
+	for i, d := range added {
+		idx := len(oldDecisions) + i
+		switch d.Decision {
+		case ApprovalDecisionApprove, ApprovalDecisionReject:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q must be %q or %q", d.Decision, ApprovalDecisionApprove, ApprovalDecisionReject), "decision").ViaFieldIndex("spec.approvalDecisions", idx))
+		}
+		if d.Approver == "" || d.Approver != username {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q can only be recorded by the user making the request, %q", d.Approver, username), "approver").ViaFieldIndex("spec.approvalDecisions", idx))
+		}
+		if seen[d.Approver] {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%q has already recorded a decision", d.Approver), "approver").ViaFieldIndex("spec.approvalDecisions", idx))
+		}
+		seen[d.Approver] = true
+	}
+	return errs
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func approvalRun(decisions ...v1alpha1.ApprovalDecision) *v1alpha1.Run {
+	return &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{Name: "approve-release"},
+		Spec: v1alpha1.RunSpec{
+			Ref:               &v1beta1.TaskRef{APIVersion: "tekton.dev/v1alpha1", Kind: v1alpha1.ApprovalTaskKind},
+			ApprovalDecisions: decisions,
+		},
+	}
+}
+
+func TestRun_ApprovalDecisions(t *testing.T) {
+	alice := v1alpha1.ApprovalDecision{Approver: "alice", Decision: v1alpha1.ApprovalDecisionApprove}
+	bob := v1alpha1.ApprovalDecision{Approver: "bob", Decision: v1alpha1.ApprovalDecisionReject, Comment: "no"}
+	for _, tc := range []struct {
+		name    string
+		user    string
+		old     *v1alpha1.Run
+		run     *v1alpha1.Run
+		wantErr bool
+	}{{
+		name: "decision recorded by its approver",
+		user: "bob",
+		old:  approvalRun(alice),
+		run:  approvalRun(alice, bob),
+	}, {
+		name: "update without new decisions",
+		user: "system:serviceaccount:tekton-pipelines:tekton-pipelines-controller",
+		old:  approvalRun(alice),
+		run:  approvalRun(alice),
+	}, {
+		name:    "decision recorded by another user",
+		user:    "alice",
+		old:     approvalRun(alice),
+		run:     approvalRun(alice, bob),
+		wantErr: true,
+	}, {
+		name:    "decision removed",
+		user:    "bob",
+		old:     approvalRun(alice, bob),
+		run:     approvalRun(bob),
+		wantErr: true,
+	}, {
+		name:    "second decision of an approver",
+		user:    "alice",
+		old:     approvalRun(alice),
+		run:     approvalRun(alice, v1alpha1.ApprovalDecision{Approver: "alice", Decision: v1alpha1.ApprovalDecisionReject}),
+		wantErr: true,
+	}, {
+		name:    "invalid decision",
+		user:    "bob",
+		old:     approvalRun(),
+		run:     approvalRun(v1alpha1.ApprovalDecision{Approver: "bob", Decision: "maybe"}),
+		wantErr: true,
+	}, {
+		name: "decision on a done run",
+		user: "bob",
+		old: func() *v1alpha1.Run {
+			r := approvalRun()
+			r.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+			return r
+		}(),
+		run:     approvalRun(bob),
+		wantErr: true,
+	}, {
+		name: "cancelled by an approver",
+		user: "bob",
+		old:  approvalRun(alice),
+		run: func() *v1alpha1.Run {
+			r := approvalRun(alice)
+			r.Spec.Status = v1alpha1.RunSpecStatusCancelled
+			r.Spec.StatusMessage = "not needed"
+			return r
+		}(),
+	}, {
+		name: "params changed",
+		user: "bob",
+		old:  approvalRun(alice),
+		run: func() *v1alpha1.Run {
+			r := approvalRun(alice)
+			r.Spec.Params = []v1beta1.Param{{Name: v1alpha1.ApprovalTaskApproversParam, Value: *v1beta1.NewStructuredValues("bob")}}
+			return r
+		}(),
+		wantErr: true,
+	}, {
+		name: "ref changed",
+		user: "bob",
+		old:  approvalRun(alice),
+		run: func() *v1alpha1.Run {
+			r := approvalRun(alice)
+			r.Spec.Ref = &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"}
+			return r
+		}(),
+		wantErr: true,
+	}, {
+		name: "decisions on the Run of another custom task",
+		user: "bob",
+		old: func() *v1alpha1.Run {
+			r := approvalRun()
+			r.Spec.Ref = &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"}
+			return r
+		}(),
+		run: func() *v1alpha1.Run {
+			r := approvalRun(bob)
+			r.Spec.Ref = &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Example"}
+			return r
+		}(),
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := apis.WithinUpdate(context.Background(), tc.old)
+			ctx = apis.WithUserInfo(ctx, &authenticationv1.UserInfo{Username: tc.user})
+			err := tc.run.Validate(ctx)
+			if tc.wantErr && err == nil {
+				t.Error("expected an error, got none")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRun_ApprovalDecisionsOnCreate(t *testing.T) {
+	ctx := apis.WithinCreate(context.Background())
+	ctx = apis.WithUserInfo(ctx, &authenticationv1.UserInfo{Username: "alice"})
+	run := approvalRun(v1alpha1.ApprovalDecision{Approver: "alice", Decision: v1alpha1.ApprovalDecisionApprove})
+	if err := run.Validate(ctx); err == nil {
+		t.Error("expected an error, got none")
+	}
+	if err := approvalRun().Validate(ctx); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -84,6 +84,20 @@ type RunSpec struct {
 	// Workspaces is a list of WorkspaceBindings from volumes to workspaces.
 	// +optional
 	Workspaces []v1beta1.WorkspaceBinding `json:"workspaces,omitempty"`
+
+	// CancellationGracePeriod is the time the PipelineRun waits for the custom task
+	// controller to acknowledge the cancellation of the Run, by marking it as done.
+	// After it elapses, the Run is marked as forcibly cancelled, so that the
+	// PipelineRun can proceed. Defaults to waiting with no bound.
+	// +optional
+	CancellationGracePeriod *metav1.Duration `json:"cancellationGracePeriod,omitempty"`
+
+	// ApprovalDecisions are the decisions recorded by the approvers of an
+	// ApprovalTask. It can only be set on the Runs of ApprovalTasks, where
+	// approvers can only append their own decisions to it.
+	// +optional
+	// +listType=atomic
+	ApprovalDecisions []ApprovalDecision `json:"approvalDecisions,omitempty"`
 }
 
 // RunSpecStatus defines the taskrun spec status the user can provide
@@ -133,6 +147,10 @@ const (
 	RunReasonCancelled RunReason = "RunCancelled"
 	// RunReasonTimedOut must be used in the Condition Reason to indicate that a Run was timed out.
 	RunReasonTimedOut RunReason = "RunTimedOut"
+	// RunReasonForciblyCancelled is the reason set by the PipelineRun controller when the
+	// custom task controller did not acknowledge the cancellation of a Run within its
+	// cancellation grace period.
+	RunReasonForciblyCancelled RunReason = "RunForciblyCancelled"
 	// RunReasonWorkspaceNotSupported can be used in the Condition Reason to indicate that the
 	// Run contains a workspace which is not supported by this custom task.
 	RunReasonWorkspaceNotSupported RunReason = "RunWorkspaceNotSupported"
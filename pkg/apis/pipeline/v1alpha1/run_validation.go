@@ -41,6 +41,9 @@ func (r *Run) Validate(ctx context.Context) *apis.FieldError {
 	if err := validate.ObjectMetadata(r.GetObjectMeta()).ViaField("metadata"); err != nil {
 		return err
 	}
+	if err := r.validateApprovalDecisions(ctx); err != nil {
+		return err
+	}
 	return r.Spec.Validate(ctx)
 }
 
@@ -78,6 +81,9 @@ func (rs *RunSpec) Validate(ctx context.Context) *apis.FieldError {
 			return apis.ErrInvalidValue(fmt.Sprintf("statusMessage should not be set if status is not set, but it is currently set to %s", rs.StatusMessage), "statusMessage")
 		}
 	}
+	if rs.CancellationGracePeriod != nil && rs.CancellationGracePeriod.Duration < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", rs.CancellationGracePeriod.Duration), "spec.cancellationGracePeriod")
+	}
 	if err := v1beta1.ValidateParameters(ctx, rs.Params).ViaField("spec.params"); err != nil {
 		return err
 	}
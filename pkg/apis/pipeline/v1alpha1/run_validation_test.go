@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
@@ -181,6 +182,21 @@ func TestRun_Invalid(t *testing.T) {
 			},
 		},
 		want: apis.ErrMultipleOneOf("spec.params[foo].name"),
+	}, {
+		name: "negative cancellation grace period",
+		run: &v1alpha1.Run{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "temp",
+			},
+			Spec: v1alpha1.RunSpec{
+				Ref: &v1beta1.TaskRef{
+					APIVersion: "blah",
+					Kind:       "blah",
+				},
+				CancellationGracePeriod: &metav1.Duration{Duration: -time.Second},
+			},
+		},
+		want: apis.ErrInvalidValue("-1s should be >= 0", "spec.cancellationGracePeriod"),
 	}} {
 		t.Run(c.name, func(t *testing.T) {
 			err := c.run.Validate(context.Background())
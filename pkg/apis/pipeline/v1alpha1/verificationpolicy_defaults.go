@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// DefaultHashAlgorithm is the hash algorithm of the keys of the authorities
+// that don't specify one.
+const DefaultHashAlgorithm HashAlgorithm = "sha256"
+
+var _ apis.Defaultable = (*VerificationPolicy)(nil)
+
+// SetDefaults implements apis.Defaultable
+func (v *VerificationPolicy) SetDefaults(ctx context.Context) {
+	v.Spec.SetDefaults(apis.WithinSpec(ctx))
+}
+
+// SetDefaults implements apis.Defaultable
+func (vs *VerificationPolicySpec) SetDefaults(ctx context.Context) {
+	if vs.Mode == "" {
+		vs.Mode = ModeEnforce
+	}
+	for i := range vs.Authorities {
+		if vs.Authorities[i].Key != nil && vs.Authorities[i].Key.HashAlgorithm == "" {
+			vs.Authorities[i].Key.HashAlgorithm = DefaultHashAlgorithm
+		}
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VerificationPolicy defines the rules to verify Tekton resources.
+// VerificationPolicy can config the mapping from resources to a list of public
+// keys, so when verifying the resources we can use the corresponding public keys.
+// +k8s:openapi-gen=true
+type VerificationPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the VerificationPolicy.
+	Spec VerificationPolicySpec `json:"spec"`
+}
+
+// VerificationPolicyList contains a list of VerificationPolicy
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type VerificationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VerificationPolicy `json:"items"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*VerificationPolicy) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("VerificationPolicy")
+}
+
+var _ kmeta.OwnerRefable = (*VerificationPolicy)(nil)
+
+// VerificationPolicySpec defines the patterns and authorities.
+type VerificationPolicySpec struct {
+	// Resources defines the patterns of resources sources that should be subject to this policy.
+	// For example, we may want to apply this Policy from a certain GitHub repo.
+	// Then the ResourcesPattern should be valid regex. E.g. If using gitresolver, and we want to config keys from a certain git repo.
+	// `ResourcesPattern` can be `git\+https://github.com/tektoncd/catalog.git`, we will use regex to filter out those resources.
+	// +listType=atomic
+	Resources []ResourcePattern `json:"resources"`
+	// Authorities defines the rules for validating signatures.
+	// +listType=atomic
+	Authorities []Authority `json:"authorities"`
+	// Mode controls whether a failing policy will fail the taskrun/pipelinerun, or only log the warnings
+	// enforce - fail the taskrun/pipelinerun if verification fails (default)
+	// warn - don't fail the taskrun/pipelinerun if verification fails but log warnings
+	// +optional
+	Mode ModeType `json:"mode,omitempty"`
+}
+
+// ResourcePattern defines the pattern of the resource source
+type ResourcePattern struct {
+	// Pattern defines a resource pattern. Regex is created to filter resources based on `Pattern`,
+	// which must match the whole source of the resource.
+	// Example patterns:
+	// GitHub resource: git\+https://github.com/tektoncd/catalog.git, git\+https://github.com/tektoncd/.*
+	// Bundle resource: gcr.io/tekton-releases/catalog/upstream/git-clone, gcr.io/tekton-releases/catalog/upstream/.*
+	// Hub resource: https://artifacthub.io/.*
+	Pattern string `json:"pattern"`
+}
+
+// Authority defines the rules for validating signatures.
+type Authority struct {
+	// Name is the name for this authority.
+	Name string `json:"name"`
+	// Key contains the public key to validate the resource.
+	Key *KeyRef `json:"key,omitempty"`
+	// Keyless contains the configuration to validate the resources signed
+	// with short-lived certificates issued to the identity of the signer,
+	// instead of long-lived keys.
+	// +optional
+	Keyless *KeylessRef `json:"keyless,omitempty"`
+}
+
+// ModeType indicates the type of a mode for VerificationPolicy
+type ModeType string
+
+// Valid ModeType:
+const (
+	// ModeWarn only logs the warnings of the failing verifications.
+	ModeWarn ModeType = "warn"
+	// ModeEnforce fails the resources failing verification.
+	ModeEnforce ModeType = "enforce"
+)
+
+// KeyRef defines the reference to a public key
+type KeyRef struct {
+	// SecretRef sets a reference to a secret with the key.
+	// +optional
+	SecretRef *v1.SecretReference `json:"secretRef,omitempty"`
+	// Data contains the inline public key.
+	// +optional
+	Data string `json:"data,omitempty"`
+	// KMS contains the KMS url of the public key
+	// Supported formats differ based on the KMS system used.
+	// One example of a KMS url could be:
+	// gcpkms://projects/[PROJECT]/locations/[LOCATION]>/keyRings/[KEYRING]/cryptoKeys/[KEY]/cryptoKeyVersions/[KEY_VERSION]
+	// For more examples please refer https://docs.sigstore.dev/cosign/kms_support.
+	// The key is loaded with the sigstore KMS provider registered for the url scheme.
+	// +optional
+	KMS string `json:"kms,omitempty"`
+	// HashAlgorithm always defaults to sha256 if the algorithm hasn't been explicitly set
+	// +optional
+	HashAlgorithm HashAlgorithm `json:"hashAlgorithm,omitempty"`
+}
+
+// HashAlgorithm defines the hash algorithm used for the public key
+type HashAlgorithm string
+
+// KeylessRef defines how to validate the resources signed with a certificate
+// issued by a certificate authority to the identity of the signer, such as
+// Fulcio. The certificate chain is stored in the annotations of the resource
+// along with the signature.
+type KeylessRef struct {
+	// CARoots contains the PEM encoded root certificates of the certificate
+	// authority issuing the signing certificates.
+	CARoots string `json:"caRoots"`
+	// Identities are the signer identities allowed to sign the resources. The
+	// signing certificate must match one of them.
+	// +listType=atomic
+	Identities []Identity `json:"identities"`
+	// TLog sets the transparency log the signature must be included in, such
+	// as Rekor. The signing certificate is then validated at the time the
+	// signature was integrated in the log, rather than at the current time.
+	// +optional
+	TLog *TLogRef `json:"tlog,omitempty"`
+}
+
+// Identity defines the identity of the signer of a keyless signature, as
+// recorded in the signing certificate.
+type Identity struct {
+	// Issuer is a regex matching the whole OIDC issuer the signer
+	// authenticated with. Any issuer is accepted if it is empty.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+	// Subject is a regex matching the whole email or URI subject alternative
+	// name of the signing certificate.
+	Subject string `json:"subject"`
+}
+
+// TLogRef defines the transparency log keyless signatures are included in.
+type TLogRef struct {
+	// PublicKey contains the PEM encoded public key of the transparency log,
+	// validating the signed entry timestamps of the log entries.
+	PublicKey string `json:"publicKey"`
+}
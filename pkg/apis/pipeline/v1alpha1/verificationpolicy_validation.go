@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*VerificationPolicy)(nil)
+
+// SupportedHashAlgorithms are the hash algorithms the keys of the
+// authorities can use.
+var SupportedHashAlgorithms = sets.NewString("sha224", "sha256", "sha384", "sha512")
+
+// Validate VerificationPolicy
+func (v *VerificationPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validate.ObjectMetadata(v.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(v.Spec.Validate(ctx).ViaField("spec"))
+	return errs
+}
+
+// Validate VerificationPolicySpec, including that the patterns of the resources
+// are valid regexes and that each authority has exactly one key or keyless
+// configuration.
+func (vs *VerificationPolicySpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if len(vs.Resources) == 0 {
+		errs = errs.Also(apis.ErrMissingField("resources"))
+	}
+	for i, r := range vs.Resources {
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(r.Pattern, "pattern", fmt.Sprintf("pattern must be a valid regex: %v", err)).ViaFieldIndex("resources", i))
+		}
+	}
+	if len(vs.Authorities) == 0 {
+		errs = errs.Also(apis.ErrMissingField("authorities"))
+	}
+	names := sets.NewString()
+	for i, a := range vs.Authorities {
+		if a.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("name").ViaFieldIndex("authorities", i))
+		} else if names.Has(a.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("duplicate authority name %q", a.Name), "name").ViaFieldIndex("authorities", i))
+		}
+		names.Insert(a.Name)
+		switch {
+		case a.Key != nil && a.Keyless != nil:
+			errs = errs.Also(apis.ErrMultipleOneOf("key", "keyless").ViaFieldIndex("authorities", i))
+		case a.Key != nil:
+			errs = errs.Also(a.Key.validate().ViaField("key").ViaFieldIndex("authorities", i))
+		case a.Keyless != nil:
+			errs = errs.Also(a.Keyless.validate().ViaField("keyless").ViaFieldIndex("authorities", i))
+		default:
+			errs = errs.Also(apis.ErrMissingOneOf("key", "keyless").ViaFieldIndex("authorities", i))
+		}
+	}
+	switch vs.Mode {
+	case "", ModeEnforce, ModeWarn:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(vs.Mode, "mode", fmt.Sprintf("mode must be one of %q or %q", ModeEnforce, ModeWarn)))
+	}
+	return errs
+}
+
+// validate checks that exactly one source of the key is set, and that its
+// hash algorithm is supported.
+func (key *KeyRef) validate() (errs *apis.FieldError) {
+	var sources []string
+	if key.SecretRef != nil {
+		sources = append(sources, "secretRef")
+	}
+	if key.Data != "" {
+		sources = append(sources, "data")
+	}
+	if key.KMS != "" {
+		sources = append(sources, "kms")
+	}
+	switch len(sources) {
+	case 0:
+		errs = errs.Also(apis.ErrMissingOneOf("secretRef", "data", "kms"))
+	case 1:
+	default:
+		errs = errs.Also(apis.ErrMultipleOneOf(sources...))
+	}
+	if key.HashAlgorithm != "" && !SupportedHashAlgorithms.Has(strings.ToLower(string(key.HashAlgorithm))) {
+		errs = errs.Also(apis.ErrInvalidValue(key.HashAlgorithm, "hashAlgorithm", fmt.Sprintf("hashAlgorithm must be one of %s", strings.Join(SupportedHashAlgorithms.List(), ", "))))
+	}
+	return errs
+}
+
+// validate checks that the CA roots and the public key of the transparency log
+// can be parsed, and that the identities are valid regexes.
+func (keyless *KeylessRef) validate() (errs *apis.FieldError) {
+	if keyless.CARoots == "" {
+		errs = errs.Also(apis.ErrMissingField("caRoots"))
+	} else if _, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(keyless.CARoots)); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue("invalid PEM certificates", "caRoots", err.Error()))
+	}
+	if len(keyless.Identities) == 0 {
+		errs = errs.Also(apis.ErrMissingField("identities"))
+	}
+	for i, id := range keyless.Identities {
+		if id.Subject == "" {
+			errs = errs.Also(apis.ErrMissingField("subject").ViaFieldIndex("identities", i))
+		} else if _, err := regexp.Compile(id.Subject); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(id.Subject, "subject", fmt.Sprintf("subject must be a valid regex: %v", err)).ViaFieldIndex("identities", i))
+		}
+		if _, err := regexp.Compile(id.Issuer); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(id.Issuer, "issuer", fmt.Sprintf("issuer must be a valid regex: %v", err)).ViaFieldIndex("identities", i))
+		}
+	}
+	if keyless.TLog != nil {
+		if keyless.TLog.PublicKey == "" {
+			errs = errs.Also(apis.ErrMissingField("publicKey").ViaField("tlog"))
+		} else if _, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(keyless.TLog.PublicKey)); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue("invalid PEM public key", "publicKey", err.Error()).ViaField("tlog"))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	caRoot = `-----BEGIN CERTIFICATE-----
+MIIBYjCCAQmgAwIBAgIBATAKBggqhkjOPQQDAjAZMRcwFQYDVQQDEw50ZWt0b24t
+dGVzdC1jYTAeFw0yMjEwMTgwMDAwMDBaFw0zMjEwMTgwMDAwMDBaMBkxFzAVBgNV
+BAMTDnRla3Rvbi10ZXN0LWNhMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEg4/j
+NW7oYgVYSPCUhTWNPNXX0RHALVkq51wai0OUDwTEBQOfu5zoZlcBj+0lj5HVU5iY
+revBwjMLgtSsRft1L6NCMEAwDgYDVR0PAQH/BAQDAgIEMA8GA1UdEwEB/wQFMAMB
+Af8wHQYDVR0OBBYEFBFZiBKmkoDsbpAw0qvnye8rDwPpMAoGCCqGSM49BAMCA0cA
+MEQCIDk5TnmsP8aj9lBufCkdBkYW3NZ3abnmPmnLbjDhrOBrAiBhlrvv+lI4hs8N
+ot4+tANLBnK7VWdQt6Jh1uUQG4C9vg==
+-----END CERTIFICATE-----`
+	tlogPublicKey = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEg4/jNW7oYgVYSPCUhTWNPNXX0RHA
+LVkq51wai0OUDwTEBQOfu5zoZlcBj+0lj5HVU5iYrevBwjMLgtSsRft1Lw==
+-----END PUBLIC KEY-----`
+)
+
+func TestVerificationPolicy_Valid(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		spec v1alpha1.VerificationPolicySpec
+	}{{
+		name: "key data",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources:   []v1alpha1.ResourcePattern{{Pattern: "https://github.com/tektoncd/catalog.git"}},
+			Authorities: []v1alpha1.Authority{{Name: "key", Key: &v1alpha1.KeyRef{Data: "-----BEGIN PUBLIC KEY-----"}}},
+		},
+	}, {
+		name: "secret and kms keys in warn mode",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources: []v1alpha1.ResourcePattern{{Pattern: "gcr.io/tekton-releases/catalog/upstream/.*"}},
+			Authorities: []v1alpha1.Authority{{
+				Name: "secret",
+				Key:  &v1alpha1.KeyRef{SecretRef: &corev1.SecretReference{Name: "verification-secrets"}},
+			}, {
+				Name: "kms",
+				Key:  &v1alpha1.KeyRef{KMS: "gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/k", HashAlgorithm: "sha512"},
+			}},
+			Mode: v1alpha1.ModeWarn,
+		},
+	}, {
+		name: "keyless with transparency log",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources: []v1alpha1.ResourcePattern{{Pattern: "https://github.com/tektoncd/catalog.git"}},
+			Authorities: []v1alpha1.Authority{{
+				Name: "fulcio",
+				Keyless: &v1alpha1.KeylessRef{
+					CARoots:    caRoot,
+					Identities: []v1alpha1.Identity{{Subject: ".*@tekton.dev", Issuer: "https://accounts.google.com"}},
+					TLog:       &v1alpha1.TLogRef{PublicKey: tlogPublicKey},
+				},
+			}},
+		},
+	}} {
+		t.Run(c.name, func(t *testing.T) {
+			vp := &v1alpha1.VerificationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Spec:       c.spec,
+			}
+			if err := vp.Validate(context.Background()); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestVerificationPolicy_Invalid(t *testing.T) {
+	validResources := []v1alpha1.ResourcePattern{{Pattern: ".*"}}
+	validAuthorities := []v1alpha1.Authority{{Name: "key", Key: &v1alpha1.KeyRef{Data: "key"}}}
+	for _, c := range []struct {
+		name string
+		spec v1alpha1.VerificationPolicySpec
+		want *apis.FieldError
+	}{{
+		name: "missing resources and authorities",
+		spec: v1alpha1.VerificationPolicySpec{},
+		want: apis.ErrMissingField("spec.resources", "spec.authorities"),
+	}, {
+		name: "invalid pattern",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources:   []v1alpha1.ResourcePattern{{Pattern: "^[a-z"}},
+			Authorities: validAuthorities,
+		},
+		want: apis.ErrInvalidValue("^[a-z", "spec.resources[0].pattern", "pattern must be a valid regex: error parsing regexp: missing closing ]: `[a-z`"),
+	}, {
+		name: "missing and duplicate authority names",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources: validResources,
+			Authorities: []v1alpha1.Authority{{
+				Key: &v1alpha1.KeyRef{Data: "key"},
+			}, {
+				Name: "key",
+				Key:  &v1alpha1.KeyRef{Data: "key"},
+			}, {
+				Name: "key",
+				Key:  &v1alpha1.KeyRef{Data: "key"},
+			}},
+		},
+		want: apis.ErrMissingField("spec.authorities[0].name").Also(
+			apis.ErrGeneric(`duplicate authority name "key"`, "spec.authorities[2].name")),
+	}, {
+		name: "missing key",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources:   validResources,
+			Authorities: []v1alpha1.Authority{{Name: "key"}},
+		},
+		want: apis.ErrMissingOneOf("spec.authorities[0].key", "spec.authorities[0].keyless"),
+	}, {
+		name: "key and keyless",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources: validResources,
+			Authorities: []v1alpha1.Authority{{
+				Name:    "key",
+				Key:     &v1alpha1.KeyRef{Data: "key"},
+				Keyless: &v1alpha1.KeylessRef{CARoots: caRoot, Identities: []v1alpha1.Identity{{Subject: "release@tekton.dev"}}},
+			}},
+		},
+		want: apis.ErrMultipleOneOf("spec.authorities[0].key", "spec.authorities[0].keyless"),
+	}, {
+		name: "keyless without CA roots and identities",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources:   validResources,
+			Authorities: []v1alpha1.Authority{{Name: "fulcio", Keyless: &v1alpha1.KeylessRef{}}},
+		},
+		want: apis.ErrMissingField("spec.authorities[0].keyless.caRoots", "spec.authorities[0].keyless.identities"),
+	}, {
+		name: "keyless with invalid CA roots, identities and transparency log",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources: validResources,
+			Authorities: []v1alpha1.Authority{{Name: "fulcio", Keyless: &v1alpha1.KeylessRef{
+				CARoots:    "root",
+				Identities: []v1alpha1.Identity{{Issuer: "[a-z"}},
+				TLog:       &v1alpha1.TLogRef{PublicKey: "key"},
+			}}},
+		},
+		want: apis.ErrInvalidValue("invalid PEM certificates", "spec.authorities[0].keyless.caRoots", "error during PEM decoding").Also(
+			apis.ErrMissingField("spec.authorities[0].keyless.identities[0].subject")).Also(
+			apis.ErrInvalidValue("[a-z", "spec.authorities[0].keyless.identities[0].issuer", "issuer must be a valid regex: error parsing regexp: missing closing ]: `[a-z`")).Also(
+			apis.ErrInvalidValue("invalid PEM public key", "spec.authorities[0].keyless.tlog.publicKey", "PEM decoding failed")),
+	}, {
+		name: "key without source",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources:   validResources,
+			Authorities: []v1alpha1.Authority{{Name: "key", Key: &v1alpha1.KeyRef{}}},
+		},
+		want: apis.ErrMissingOneOf("spec.authorities[0].key.secretRef", "spec.authorities[0].key.data", "spec.authorities[0].key.kms"),
+	}, {
+		name: "key with multiple sources",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources: validResources,
+			Authorities: []v1alpha1.Authority{{Name: "key", Key: &v1alpha1.KeyRef{
+				SecretRef: &corev1.SecretReference{Name: "verification-secrets"},
+				KMS:       "hashivault://key",
+			}}},
+		},
+		want: apis.ErrMultipleOneOf("spec.authorities[0].key.secretRef", "spec.authorities[0].key.kms"),
+	}, {
+		name: "unsupported hash algorithm",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources:   validResources,
+			Authorities: []v1alpha1.Authority{{Name: "key", Key: &v1alpha1.KeyRef{Data: "key", HashAlgorithm: "md5"}}},
+		},
+		want: apis.ErrInvalidValue("md5", "spec.authorities[0].key.hashAlgorithm", "hashAlgorithm must be one of sha224, sha256, sha384, sha512"),
+	}, {
+		name: "invalid mode",
+		spec: v1alpha1.VerificationPolicySpec{
+			Resources:   validResources,
+			Authorities: validAuthorities,
+			Mode:        "audit",
+		},
+		want: apis.ErrInvalidValue("audit", "spec.mode", `mode must be one of "enforce" or "warn"`),
+	}} {
+		t.Run(c.name, func(t *testing.T) {
+			vp := &v1alpha1.VerificationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+				Spec:       c.spec,
+			}
+			err := vp.Validate(context.Background())
+			if d := cmp.Diff(c.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestVerificationPolicy_SetDefaults(t *testing.T) {
+	vp := &v1alpha1.VerificationPolicy{
+		Spec: v1alpha1.VerificationPolicySpec{
+			Authorities: []v1alpha1.Authority{{
+				Name: "default",
+				Key:  &v1alpha1.KeyRef{Data: "key"},
+			}, {
+				Name: "explicit",
+				Key:  &v1alpha1.KeyRef{Data: "key", HashAlgorithm: "sha512"},
+			}},
+		},
+	}
+	want := &v1alpha1.VerificationPolicy{
+		Spec: v1alpha1.VerificationPolicySpec{
+			Authorities: []v1alpha1.Authority{{
+				Name: "default",
+				Key:  &v1alpha1.KeyRef{Data: "key", HashAlgorithm: v1alpha1.DefaultHashAlgorithm},
+			}, {
+				Name: "explicit",
+				Key:  &v1alpha1.KeyRef{Data: "key", HashAlgorithm: "sha512"},
+			}},
+			Mode: v1alpha1.ModeEnforce,
+		},
+	}
+	vp.SetDefaults(context.Background())
+	if d := cmp.Diff(want, vp); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
@@ -80,6 +80,13 @@ type CustomRunSpec struct {
 	// +optional
 	// +listType=atomic
 	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+
+	// CancellationGracePeriod is the time the PipelineRun waits for the custom task
+	// controller to acknowledge the cancellation of the CustomRun, by marking it as done.
+	// After it elapses, the CustomRun is marked as forcibly cancelled, so that the
+	// PipelineRun can proceed. Defaults to waiting with no bound.
+	// +optional
+	CancellationGracePeriod *metav1.Duration `json:"cancellationGracePeriod,omitempty"`
 }
 
 // CustomRunSpecStatus defines the taskrun spec status the user can provide
@@ -129,6 +136,10 @@ const (
 	CustomRunReasonCancelled CustomRunReason = "CustomRunCancelled"
 	// CustomRunReasonTimedOut must be used in the Condition Reason to indicate that a CustomRun was timed out.
 	CustomRunReasonTimedOut CustomRunReason = "CustomRunTimedOut"
+	// CustomRunReasonForciblyCancelled is the reason set by the PipelineRun controller when the
+	// custom task controller did not acknowledge the cancellation of a CustomRun within its
+	// cancellation grace period.
+	CustomRunReasonForciblyCancelled CustomRunReason = "CustomRunForciblyCancelled"
 	// CustomRunReasonWorkspaceNotSupported can be used in the Condition Reason to indicate that the
 	// CustomRun contains a workspace which is not supported by this custom task.
 	CustomRunReasonWorkspaceNotSupported CustomRunReason = "CustomRunWorkspaceNotSupported"
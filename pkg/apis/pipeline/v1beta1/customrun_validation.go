@@ -77,6 +77,9 @@ func (rs *CustomRunSpec) Validate(ctx context.Context) *apis.FieldError {
 			return apis.ErrInvalidValue(fmt.Sprintf("statusMessage should not be set if status is not set, but it is currently set to %s", rs.StatusMessage), "statusMessage")
 		}
 	}
+	if rs.CancellationGracePeriod != nil && rs.CancellationGracePeriod.Duration < 0 {
+		return apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", rs.CancellationGracePeriod.Duration), "spec.cancellationGracePeriod")
+	}
 	if err := ValidateParameters(ctx, rs.Params).ViaField("spec.params"); err != nil {
 		return err
 	}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
@@ -180,6 +181,21 @@ func TestCustomRun_Invalid(t *testing.T) {
 			},
 		},
 		want: apis.ErrMultipleOneOf("spec.params[foo].name"),
+	}, {
+		name: "negative cancellation grace period",
+		customRun: &v1beta1.CustomRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "temp",
+			},
+			Spec: v1beta1.CustomRunSpec{
+				CustomRef: &v1beta1.TaskRef{
+					APIVersion: "blah",
+					Kind:       "blah",
+				},
+				CancellationGracePeriod: &metav1.Duration{Duration: -time.Second},
+			},
+		},
+		want: apis.ErrInvalidValue("-1s should be >= 0", "spec.cancellationGracePeriod"),
 	}} {
 		t.Run(c.name, func(t *testing.T) {
 			err := c.customRun.Validate(context.Background())
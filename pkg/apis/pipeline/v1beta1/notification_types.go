@@ -0,0 +1,125 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PipelineRunNotification is a notification sent once when a PipelineRun completes.
+// Exactly one of HTTP, Slack and CommitStatus must be set.
+type PipelineRunNotification struct {
+	// Name identifies the notification in the status of the PipelineRun.
+	Name string `json:"name"`
+	// Trigger is the outcome of the PipelineRun the notification is sent for:
+	// succeeded, failed or always. Defaults to always.
+	// +optional
+	Trigger NotificationTrigger `json:"trigger,omitempty"`
+	// SecretRef selects a key of a Secret, in the namespace of the PipelineRun,
+	// holding the credentials for the target: a bearer token for HTTP targets,
+	// the webhook URL for Slack targets and an access token for commit status targets.
+	// +optional
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+	// HTTP posts a JSON body to a URL.
+	// +optional
+	HTTP *HTTPNotification `json:"http,omitempty"`
+	// Slack posts a message to a Slack-compatible incoming webhook.
+	// +optional
+	Slack *SlackNotification `json:"slack,omitempty"`
+	// CommitStatus sets the status of a commit on a source code management system.
+	// +optional
+	CommitStatus *CommitStatusNotification `json:"commitStatus,omitempty"`
+}
+
+// NotificationTrigger is the outcome of a PipelineRun a notification is sent for.
+type NotificationTrigger string
+
+const (
+	// NotificationTriggerSucceeded sends the notification if the PipelineRun succeeded.
+	NotificationTriggerSucceeded NotificationTrigger = "succeeded"
+	// NotificationTriggerFailed sends the notification if the PipelineRun failed,
+	// was cancelled or timed out.
+	NotificationTriggerFailed NotificationTrigger = "failed"
+	// NotificationTriggerAlways sends the notification whatever the outcome of the PipelineRun.
+	NotificationTriggerAlways NotificationTrigger = "always"
+)
+
+// HTTPNotification posts a JSON body to a URL.
+type HTTPNotification struct {
+	// URL is the URL the body is posted to.
+	URL string `json:"url"`
+	// Body is the template of the JSON body. It defaults to an object with the
+	// name, namespace, status, reason and message of the PipelineRun.
+	// +optional
+	Body string `json:"body,omitempty"`
+}
+
+// SlackNotification posts a message to a Slack-compatible incoming webhook, whose
+// URL is read from the Secret of the notification.
+type SlackNotification struct {
+	// Text is the template of the message. It defaults to the name, status
+	// and message of the PipelineRun.
+	// +optional
+	Text string `json:"text,omitempty"`
+}
+
+// CommitStatusNotification sets the status of a commit to the outcome of the PipelineRun.
+type CommitStatusNotification struct {
+	// Provider is the source code management system: github, gitlab, gitea or
+	// bitbucket-server. Defaults to github.
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// ServerURL is the URL of the API of the provider, required for gitea and
+	// bitbucket-server and for self-hosted github and gitlab instances.
+	// +optional
+	ServerURL string `json:"serverURL,omitempty"`
+	// Repository is the full name of the repository, e.g. tektoncd/pipeline.
+	Repository string `json:"repository"`
+	// SHA is the commit the status is set on.
+	SHA string `json:"sha"`
+	// Context is the label distinguishing the status from the other statuses of
+	// the commit. Defaults to tekton/<name of the notification>.
+	// +optional
+	Context string `json:"context,omitempty"`
+	// TargetURL is the URL linked from the status, e.g. the PipelineRun in a dashboard.
+	// +optional
+	TargetURL string `json:"targetURL,omitempty"`
+}
+
+// PipelineRunNotificationStatus records the delivery of a notification of a PipelineRun.
+type PipelineRunNotificationStatus struct {
+	// Name is the name of the notification.
+	Name string `json:"name"`
+	// Outcome is whether the notification is being sent, was delivered or failed.
+	Outcome NotificationOutcome `json:"outcome"`
+	// Message is the reason the notification could not be delivered.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// NotificationOutcome is the outcome of the delivery of a notification.
+type NotificationOutcome string
+
+const (
+	// NotificationOutcomeSending means the notification is being sent. It is recorded
+	// before the notification is sent, so that it is not sent again by the next reconcile.
+	NotificationOutcomeSending NotificationOutcome = "Sending"
+	// NotificationOutcomeDelivered means the target accepted the notification.
+	NotificationOutcomeDelivered NotificationOutcome = "Delivered"
+	// NotificationOutcomeFailed means the notification could not be delivered. It is not sent again.
+	NotificationOutcomeFailed NotificationOutcome = "Failed"
+)
@@ -184,6 +184,14 @@ func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask) err
 	}
 
 	sink.Timeout = pt.Timeout
+	sink.ExecutionMode = v1.ExecutionMode(pt.ExecutionMode)
+	sink.MemoryRetry = nil
+	if pt.MemoryRetry != nil {
+		sink.MemoryRetry = &v1.MemoryRetryPolicy{
+			Multiplier: pt.MemoryRetry.Multiplier,
+			MaxLimit:   pt.MemoryRetry.MaxLimit,
+		}
+	}
 	return nil
 }
 
@@ -230,6 +238,14 @@ func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask)
 	}
 
 	pt.Timeout = source.Timeout
+	pt.ExecutionMode = ExecutionMode(source.ExecutionMode)
+	pt.MemoryRetry = nil
+	if source.MemoryRetry != nil {
+		pt.MemoryRetry = &MemoryRetryPolicy{
+			Multiplier: source.MemoryRetry.Multiplier,
+			MaxLimit:   source.MemoryRetry.MaxLimit,
+		}
+	}
 	return nil
 }
 
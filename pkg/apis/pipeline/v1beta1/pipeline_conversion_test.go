@@ -25,6 +25,7 @@ import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
 	"knative.dev/pkg/apis"
@@ -43,6 +44,7 @@ func TestPipelineConversionBadType(t *testing.T) {
 }
 
 func TestPipelineConversion(t *testing.T) {
+	maxLimit := resource.MustParse("8Gi")
 	tests := []struct {
 		name string
 		in   *v1beta1.Pipeline
@@ -119,7 +121,12 @@ func TestPipelineConversion(t *testing.T) {
 						Name:      "my-task-workspace",
 						Workspace: "source",
 					}},
-					Timeout: &metav1.Duration{Duration: 5 * time.Minute},
+					Timeout:       &metav1.Duration{Duration: 5 * time.Minute},
+					ExecutionMode: v1beta1.ExecutionModeHermetic,
+					MemoryRetry: &v1beta1.MemoryRetryPolicy{
+						Multiplier: "1.5",
+						MaxLimit:   &maxLimit,
+					},
 				},
 				},
 				Params: []v1beta1.ParamSpec{{
@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
@@ -27,6 +28,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/version"
 
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -227,6 +229,35 @@ type PipelineTask struct {
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// ExecutionMode is the mode the Steps of the TaskRun created for this
+	// PipelineTask are executed in. The only supported mode is "hermetic".
+	// This field is only supported when the alpha feature gate is enabled.
+	// +optional
+	ExecutionMode ExecutionMode `json:"executionMode,omitempty"`
+
+	// MemoryRetry overrides, for the TaskRun created for this PipelineTask, the
+	// default-memory-retry-multiplier and default-memory-retry-max-limit of the
+	// config-defaults ConfigMap, with which TaskRuns that failed for OOM or
+	// eviction are retried with a higher memory limit.
+	// This field is only supported when the alpha feature gate is enabled.
+	// +optional
+	MemoryRetry *MemoryRetryPolicy `json:"memoryRetry,omitempty"`
+}
+
+// MemoryRetryPolicy is the policy of retrying a TaskRun that failed for OOM
+// or eviction with a higher memory limit
+type MemoryRetryPolicy struct {
+	// Multiplier is the factor the memory limit is multiplied by on each
+	// retry, as a decimal number greater than 1, or "0" to retry without
+	// changing the memory limit. Defaults to default-memory-retry-multiplier.
+	// +optional
+	Multiplier string `json:"multiplier,omitempty"`
+
+	// MaxLimit is the highest memory limit a retry can get.
+	// Defaults to default-memory-retry-max-limit.
+	// +optional
+	MaxLimit *resource.Quantity `json:"maxLimit,omitempty"`
 }
 
 // validateRefOrSpec validates at least one of taskRef or taskSpec is specified
@@ -462,6 +493,16 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	errs = errs.Also(pt.validateEmbeddedOrType())
 
+	if pt.ExecutionMode != "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "executionMode", config.AlphaAPIFields).ViaField("executionMode"))
+		errs = errs.Also(validateExecutionMode(pt.ExecutionMode).ViaField("executionMode"))
+	}
+
+	if pt.MemoryRetry != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "memoryRetry", config.AlphaAPIFields).ViaField("memoryRetry"))
+		errs = errs.Also(validateMemoryRetry(pt.MemoryRetry).ViaField("memoryRetry"))
+	}
+
 	cfg := config.FromContextOrDefaults(ctx)
 	// If EnableCustomTasks feature flag is on, validate custom task specifications
 	// pipeline task having taskRef with APIVersion is classified as custom task
@@ -479,6 +520,16 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return
 }
 
+// validateMemoryRetry ensures that the multiplier of the memory retry policy is greater than 1, or 0
+func validateMemoryRetry(policy *MemoryRetryPolicy) (errs *apis.FieldError) {
+	if policy.Multiplier != "" {
+		if f, err := strconv.ParseFloat(policy.Multiplier, 64); err != nil || (f != 0 && f <= 1) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be a number greater than 1, or 0", policy.Multiplier), "multiplier"))
+		}
+	}
+	return errs
+}
+
 // Deps returns all other PipelineTask dependencies of this PipelineTask, based on resource usage or ordering
 func (pt PipelineTask) Deps() []string {
 	// hold the list of dependencies in a set to avoid duplicates
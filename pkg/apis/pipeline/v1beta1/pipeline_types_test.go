@@ -371,6 +371,40 @@ func TestPipelineTask_Validate_Failure(t *testing.T) {
 			Paths:   []string{"taskRef.name"},
 		},
 		wc: enableFeatures(t, []string{"enable-tekton-oci-bundles"}),
+	}, {
+		name: "invalid executionMode",
+		p: PipelineTask{
+			Name:          "invalid-execution-mode",
+			TaskRef:       &TaskRef{Name: "foo"},
+			ExecutionMode: "sandboxed",
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: sandboxed should be hermetic`,
+			Paths:   []string{"executionMode"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "invalid memoryRetry multiplier",
+		p: PipelineTask{
+			Name:        "invalid-memory-retry",
+			TaskRef:     &TaskRef{Name: "foo"},
+			MemoryRetry: &MemoryRetryPolicy{Multiplier: "0.5"},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: 0.5 should be a number greater than 1, or 0`,
+			Paths:   []string{"memoryRetry.multiplier"},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "memoryRetry without alpha",
+		p: PipelineTask{
+			Name:        "memory-retry",
+			TaskRef:     &TaskRef{Name: "foo"},
+			MemoryRetry: &MemoryRetryPolicy{Multiplier: "2"},
+		},
+		expectedError: apis.FieldError{
+			Message: `memoryRetry requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -180,6 +180,7 @@ func validatePipelineContextVariables(tasks []PipelineTask) *apis.FieldError {
 		"name",
 		"namespace",
 		"uid",
+		"deadlineApproaching",
 	)
 	pipelineContextNames := sets.NewString().Insert(
 		"name",
@@ -2912,6 +2912,15 @@ func TestContextValid(t *testing.T) {
 					Name: "a-param-mat", Value: ParamValue{ArrayVal: []string{"$(context.pipelineRun.uid)"}},
 				}}},
 		}},
+	}, {
+		name: "valid string context variable for PipelineRun deadlineApproaching",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: []Param{{
+				Name: "a-param", Value: ParamValue{StringVal: "$(context.pipelineRun.deadlineApproaching)"},
+			}},
+		}},
 	}, {
 		name: "valid array context variables for Pipeline and PipelineRun names",
 		tasks: []PipelineTask{{
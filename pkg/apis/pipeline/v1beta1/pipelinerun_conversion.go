@@ -88,6 +88,14 @@ func (prs PipelineRunSpec) ConvertTo(ctx context.Context, sink *v1.PipelineRunSp
 		ptrs.convertTo(ctx, &new)
 		sink.TaskRunSpecs = append(sink.TaskRunSpecs, new)
 	}
+	sink.Notifications = nil
+	for _, n := range prs.Notifications {
+		new := v1.PipelineRunNotification{}
+		n.convertTo(ctx, &new)
+		sink.Notifications = append(sink.Notifications, new)
+	}
+	sink.Priority = prs.Priority
+	sink.Deadline = prs.Deadline
 	return nil
 }
 
@@ -146,6 +154,14 @@ func (prs *PipelineRunSpec) ConvertFrom(ctx context.Context, source *v1.Pipeline
 		new.convertFrom(ctx, trs)
 		prs.TaskRunSpecs = append(prs.TaskRunSpecs, new)
 	}
+	prs.Notifications = nil
+	for _, n := range source.Notifications {
+		new := PipelineRunNotification{}
+		new.convertFrom(ctx, n)
+		prs.Notifications = append(prs.Notifications, new)
+	}
+	prs.Priority = source.Priority
+	prs.Deadline = source.Deadline
 	return nil
 }
 
@@ -208,6 +224,38 @@ func (ptrs *PipelineTaskRunSpec) convertFrom(ctx context.Context, source v1.Pipe
 	ptrs.ComputeResources = source.ComputeResources
 }
 
+func (n PipelineRunNotification) convertTo(ctx context.Context, sink *v1.PipelineRunNotification) {
+	sink.Name = n.Name
+	sink.Trigger = v1.NotificationTrigger(n.Trigger)
+	sink.SecretRef = n.SecretRef
+	if n.HTTP != nil {
+		sink.HTTP = &v1.HTTPNotification{URL: n.HTTP.URL, Body: n.HTTP.Body}
+	}
+	if n.Slack != nil {
+		sink.Slack = &v1.SlackNotification{Text: n.Slack.Text}
+	}
+	if n.CommitStatus != nil {
+		cs := v1.CommitStatusNotification(*n.CommitStatus)
+		sink.CommitStatus = &cs
+	}
+}
+
+func (n *PipelineRunNotification) convertFrom(ctx context.Context, source v1.PipelineRunNotification) {
+	n.Name = source.Name
+	n.Trigger = NotificationTrigger(source.Trigger)
+	n.SecretRef = source.SecretRef
+	if source.HTTP != nil {
+		n.HTTP = &HTTPNotification{URL: source.HTTP.URL, Body: source.HTTP.Body}
+	}
+	if source.Slack != nil {
+		n.Slack = &SlackNotification{Text: source.Slack.Text}
+	}
+	if source.CommitStatus != nil {
+		cs := CommitStatusNotification(*source.CommitStatus)
+		n.CommitStatus = &cs
+	}
+}
+
 func serializePipelineRunResources(meta *metav1.ObjectMeta, spec *PipelineRunSpec) error {
 	if spec.Resources == nil {
 		return nil
@@ -100,6 +100,14 @@ func TestPipelineRunConversion(t *testing.T) {
 				Workspaces: []v1beta1.WorkspaceBinding{{
 					Name:     "workspace",
 					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}, {
+					Name: "workspace-volumeclaimtemplate",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "pvc",
+						},
+					},
+					RetentionPolicy: v1beta1.PVCRetentionPolicyRetain,
 				}},
 				TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{
 					{
@@ -142,6 +150,25 @@ func TestPipelineRunConversion(t *testing.T) {
 						},
 					},
 				},
+				Priority: "interactive",
+				Deadline: &metav1.Time{Time: time.Date(2022, time.December, 1, 18, 0, 0, 0, time.UTC)},
+				Notifications: []v1beta1.PipelineRunNotification{{
+					Name:    "webhook",
+					Trigger: v1beta1.NotificationTriggerFailed,
+					HTTP:    &v1beta1.HTTPNotification{URL: "https://example.com/hook", Body: "{}"},
+				}, {
+					Name:      "chat",
+					SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "slack"}, Key: "url"},
+					Slack:     &v1beta1.SlackNotification{Text: "done"},
+				}, {
+					Name: "github",
+					CommitStatus: &v1beta1.CommitStatusNotification{
+						Provider:   "github",
+						Repository: "tektoncd/pipeline",
+						SHA:        "abc",
+						Context:    "tekton/ci",
+					},
+				}},
 			},
 		},
 	}}
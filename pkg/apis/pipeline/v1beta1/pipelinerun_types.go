@@ -157,12 +157,16 @@ func (pr *PipelineRun) GetNamespacedName() types.NamespacedName {
 	return types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name}
 }
 
-// HasTimedOut returns true if a pipelinerun has exceeded its spec.Timeout based on its status.Timeout
+// HasTimedOut returns true if a pipelinerun has exceeded its spec.Timeout based on its status.Timeout,
+// or its spec.Deadline has passed
 func (pr *PipelineRun) HasTimedOut(ctx context.Context, c clock.PassiveClock) bool {
 	timeout := pr.PipelineTimeout(ctx)
 	startTime := pr.Status.StartTime
 
 	if !startTime.IsZero() {
+		if pr.HasPassedDeadline(c) {
+			return true
+		}
 		if timeout == config.NoTimeoutDuration {
 			return false
 		}
@@ -174,6 +178,21 @@ func (pr *PipelineRun) HasTimedOut(ctx context.Context, c clock.PassiveClock) bo
 	return false
 }
 
+// HasPassedDeadline returns true if the spec.Deadline of a pipelinerun has passed
+func (pr *PipelineRun) HasPassedDeadline(c clock.PassiveClock) bool {
+	return pr.Spec.Deadline != nil && c.Now().After(pr.Spec.Deadline.Time)
+}
+
+// IsDeadlineApproaching returns true if the time left before the spec.Deadline of a pipelinerun
+// is below the default-deadline-warning-threshold of the config-defaults ConfigMap
+func (pr *PipelineRun) IsDeadlineApproaching(ctx context.Context, c clock.PassiveClock) bool {
+	threshold := config.FromContextOrDefaults(ctx).Defaults.DefaultDeadlineWarningThreshold
+	if pr.Spec.Deadline == nil || threshold == 0 {
+		return false
+	}
+	return pr.Spec.Deadline.Sub(c.Now()) < threshold
+}
+
 // HaveTasksTimedOut returns true if a pipelinerun has exceeded its spec.Timeouts.Tasks
 func (pr *PipelineRun) HaveTasksTimedOut(ctx context.Context, c clock.PassiveClock) bool {
 	timeout := pr.TasksTimeout()
@@ -262,6 +281,19 @@ type PipelineRunSpec struct {
 	// +optional
 	// +listType=atomic
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// Notifications are sent once when the PipelineRun completes.
+	// +optional
+	// +listType=atomic
+	Notifications []PipelineRunNotification `json:"notifications,omitempty"`
+	// Priority is the name of one of the priorities of the config-scheduling
+	// ConfigMap. It is set on the TaskRuns of the PipelineRun.
+	// +optional
+	Priority string `json:"priority,omitempty"`
+	// Deadline is the time by which the PipelineRun must finish. When both a
+	// deadline and timeouts are set, the PipelineRun times out at whichever
+	// comes first. It is set on the TaskRuns of the PipelineRun.
+	// +optional
+	Deadline *metav1.Time `json:"deadline,omitempty"`
 }
 
 // TimeoutFields allows granular specification of pipeline, task, and finally timeouts
@@ -304,6 +336,22 @@ type PipelineRunStatus struct {
 	PipelineRunStatusFields `json:",inline"`
 }
 
+// PipelineRunConditionType is an enum used to store PipelineRun custom conditions
+type PipelineRunConditionType string
+
+const (
+	// PipelineRunConditionDeadlineApproaching is a Condition Type that indicates that the
+	// time left before the deadline of the PipelineRun is below the configured threshold
+	PipelineRunConditionDeadlineApproaching PipelineRunConditionType = "DeadlineApproaching"
+	// PipelineRunConditionTrustedResourcesVerified is a Condition Type that indicates
+	// whether the referenced Pipeline passed the trusted resources verification
+	PipelineRunConditionTrustedResourcesVerified PipelineRunConditionType = "TrustedResourcesVerified"
+)
+
+func (t PipelineRunConditionType) String() string {
+	return string(t)
+}
+
 // PipelineRunReason represents a reason for the pipeline run "Succeeded" condition
 type PipelineRunReason string
 
@@ -459,6 +507,17 @@ type PipelineRunStatusFields struct {
 
 	// Provenance contains some key authenticated metadata about how a software artifact was built (what sources, what inputs/outputs, etc.).
 	Provenance *Provenance `json:"provenance,omitempty"`
+
+	// VolumeClaims records what happened to the PersistentVolumeClaims created from
+	// volumeClaimTemplates to which a retention policy was applied on completion.
+	// +optional
+	// +listType=atomic
+	VolumeClaims []VolumeClaimStatus `json:"volumeClaims,omitempty"`
+
+	// Notifications records the delivery of the notifications of the PipelineRun.
+	// +optional
+	// +listType=atomic
+	Notifications []PipelineRunNotificationStatus `json:"notifications,omitempty"`
 }
 
 // SkippedTask is used to describe the Tasks that were skipped due to their When Expressions
@@ -473,6 +532,10 @@ type SkippedTask struct {
 	// +optional
 	// +listType=atomic
 	WhenExpressions []WhenExpression `json:"whenExpressions,omitempty"`
+	// CloudEventSent is true once the cloud event about the PipelineTask being
+	// skipped was sent, so that it is not sent again.
+	// +optional
+	CloudEventSent bool `json:"cloudEventSent,omitempty"`
 }
 
 // SkippingReason explains why a PipelineTask was skipped.
@@ -501,6 +564,29 @@ const (
 	None SkippingReason = "None"
 )
 
+// VolumeClaimStatus records the outcome of applying a retention policy to a
+// PersistentVolumeClaim created from a volumeClaimTemplate.
+type VolumeClaimStatus struct {
+	// WorkspaceName is the name of the workspace binding the claim was created for.
+	WorkspaceName string `json:"workspaceName"`
+	// ClaimName is the name of the PersistentVolumeClaim.
+	ClaimName string `json:"claimName"`
+	// RetentionPolicy is the retention policy that was applied to the claim.
+	RetentionPolicy PVCRetentionPolicy `json:"retentionPolicy"`
+	// Outcome is what happened to the claim when the retention policy was applied.
+	Outcome VolumeClaimOutcome `json:"outcome"`
+}
+
+// VolumeClaimOutcome is what happened to a PersistentVolumeClaim when its retention policy was applied.
+type VolumeClaimOutcome string
+
+const (
+	// VolumeClaimOutcomeDeleted means the claim was deleted.
+	VolumeClaimOutcomeDeleted VolumeClaimOutcome = "Deleted"
+	// VolumeClaimOutcomeRetained means the claim was kept for debugging.
+	VolumeClaimOutcomeRetained VolumeClaimOutcome = "Retained"
+)
+
 // PipelineRunResult used to describe the results of a pipeline
 type PipelineRunResult struct {
 	// Name is the result's name as declared by the Pipeline
@@ -320,6 +320,51 @@ func TestPipelineRunHasTimedOut(t *testing.T) {
 	}
 }
 
+func TestPipelineRunHasPassedDeadline(t *testing.T) {
+	tcs := []struct {
+		name     string
+		timeout  time.Duration
+		deadline time.Time
+		expected bool
+	}{{
+		name:     "deadline passed",
+		timeout:  25 * time.Hour,
+		deadline: now.Add(-time.Second),
+		expected: true,
+	}, {
+		name:     "deadline passed without timeout",
+		timeout:  0 * time.Second,
+		deadline: now.Add(-time.Second),
+		expected: true,
+	}, {
+		name:     "deadline not passed",
+		timeout:  25 * time.Hour,
+		deadline: now.Add(time.Minute),
+		expected: false,
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: v1beta1.PipelineRunSpec{
+					Timeouts: &v1beta1.TimeoutFields{Pipeline: &metav1.Duration{Duration: tc.timeout}},
+					Deadline: &metav1.Time{Time: tc.deadline},
+				},
+				Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+					StartTime: &metav1.Time{Time: now.AddDate(0, 0, -1)},
+				}},
+			}
+			if pr.HasPassedDeadline(testClock) != tc.expected {
+				t.Errorf("Expected HasPassedDeadline to be %t", tc.expected)
+			}
+			if pr.HasTimedOut(context.Background(), testClock) != tc.expected {
+				t.Errorf("Expected HasTimedOut to be %t", tc.expected)
+			}
+		})
+	}
+}
+
 func TestPipelineRunTimeouts(t *testing.T) {
 	tcs := []struct {
 		name                   string
@@ -1145,6 +1145,68 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 			},
 		},
 		wantErr: apis.ErrGeneric("computeResources requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaIndex(0).ViaField("taskRunSpecs"),
+	}, {
+		name: "notifications disallowed without alpha feature gate",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			Notifications: []v1beta1.PipelineRunNotification{{
+				Name: "chat",
+				HTTP: &v1beta1.HTTPNotification{URL: "https://chat.example.com"},
+			}},
+		},
+		wantErr: apis.ErrGeneric("notifications requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaField("notifications"),
+	}, {
+		name: "notifications with invalid targets",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			Notifications: []v1beta1.PipelineRunNotification{{
+				Name:    "no-target",
+				Trigger: "sometimes",
+			}, {
+				Name:  "two-targets",
+				HTTP:  &v1beta1.HTTPNotification{},
+				Slack: &v1beta1.SlackNotification{},
+			}, {
+				Name: "commit-status",
+				CommitStatus: &v1beta1.CommitStatusNotification{
+					Provider: "gitea",
+				},
+			}, {
+				Name: "commit-status",
+				CommitStatus: &v1beta1.CommitStatusNotification{
+					Provider:   "svn",
+					Repository: "tektoncd/pipeline",
+					SHA:        "abc",
+				},
+			}},
+		},
+		wantErr: apis.ErrInvalidValue("sometimes should be one of [always failed succeeded]", "notifications[0].trigger").Also(
+			apis.ErrMissingOneOf("notifications[0].http", "notifications[0].slack", "notifications[0].commitStatus")).Also(
+			apis.ErrMissingField("notifications[1].http.url", "notifications[1].secretRef")).Also(
+			apis.ErrMultipleOneOf("notifications[1].http", "notifications[1].slack", "notifications[1].commitStatus")).Also(
+			apis.ErrMissingField("notifications[2].commitStatus.serverURL", "notifications[2].commitStatus.repository", "notifications[2].commitStatus.sha")).Also(
+			apis.ErrInvalidValue("svn should be one of [bitbucket-server gitea github gitlab]", "notifications[3].commitStatus.provider")).Also(
+			apis.ErrGeneric(`notification "commit-status" provided more than once, at index 2 and 3`, "notifications[3].name")),
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "priority not configured",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name: "foo",
+			},
+			Priority: "interactive",
+		},
+		wantErr:     apis.ErrInvalidValue("interactive is not one of the priorities of the config-scheduling ConfigMap", "priority"),
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "deadline disallowed without alpha feature gate",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name: "foo",
+			},
+			Deadline: &metav1.Time{Time: time.Date(2022, time.December, 1, 18, 0, 0, 0, time.UTC)},
+		},
+		wantErr: apis.ErrGeneric("deadline requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
 	}}
 
 	for _, ps := range tests {
@@ -1216,6 +1278,29 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 			}},
 		},
 		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "valid notifications",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"},
+			Notifications: []v1beta1.PipelineRunNotification{{
+				Name:    "webhook",
+				Trigger: v1beta1.NotificationTriggerAlways,
+				HTTP:    &v1beta1.HTTPNotification{URL: "https://example.com/hook", Body: `{"run": "$(context.pipelineRun.name)"}`},
+			}, {
+				Name:      "chat",
+				Trigger:   v1beta1.NotificationTriggerFailed,
+				SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "slack"}, Key: "url"},
+				Slack:     &v1beta1.SlackNotification{},
+			}, {
+				Name:      "github",
+				SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "github"}, Key: "token"},
+				CommitStatus: &v1beta1.CommitStatusNotification{
+					Repository: "tektoncd/pipeline",
+					SHA:        "$(params.revision)",
+				},
+			}},
+		},
+		withContext: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ps := range tests {
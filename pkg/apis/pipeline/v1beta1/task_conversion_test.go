@@ -96,6 +96,7 @@ func TestTaskConversion(t *testing.T) {
 					OnError:         v1beta1.Continue,
 					StdoutConfig:    &v1beta1.StepOutputConfig{Path: "/path"},
 					StderrConfig:    &v1beta1.StepOutputConfig{Path: "/another-path"},
+					WaitForSidecars: []string{"step"},
 				}},
 				StepTemplate: &v1beta1.StepTemplate{
 					Image:           "foo",
@@ -135,6 +136,7 @@ func TestTaskConversion(t *testing.T) {
 					TTY:                      true,
 					Script:                   "echo hello",
 					Workspaces:               []v1beta1.WorkspaceUsage{{Name: "workspace"}},
+					StartOrder:               1,
 				}},
 				Volumes: []corev1.Volume{{Name: "volume"}},
 				Params: []v1beta1.ParamSpec{{
@@ -92,6 +92,10 @@ func (trs *TaskRunSpec) ConvertTo(ctx context.Context, sink *v1.TaskRunSpec) err
 		sink.SidecarSpecs = append(sink.SidecarSpecs, new)
 	}
 	sink.ComputeResources = trs.ComputeResources
+	sink.Priority = trs.Priority
+	sink.Deadline = trs.Deadline
+	sink.ExecutionMode = v1.ExecutionMode(trs.ExecutionMode)
+	sink.MaskSecrets = trs.MaskSecrets
 	return nil
 }
 
@@ -162,6 +166,10 @@ func (trs *TaskRunSpec) ConvertFrom(ctx context.Context, source *v1.TaskRunSpec)
 		trs.SidecarOverrides = append(trs.SidecarOverrides, new)
 	}
 	trs.ComputeResources = source.ComputeResources
+	trs.Priority = source.Priority
+	trs.Deadline = source.Deadline
+	trs.ExecutionMode = ExecutionMode(source.ExecutionMode)
+	trs.MaskSecrets = source.MaskSecrets
 	return nil
 }
 
@@ -49,6 +49,7 @@ func TestTaskRunConversionBadType(t *testing.T) {
 }
 
 func TestTaskrunConversion(t *testing.T) {
+	maskSecrets := true
 	tests := []struct {
 		name string
 		in   *v1beta1.TaskRun
@@ -168,6 +169,10 @@ func TestTaskrunConversion(t *testing.T) {
 						corev1.ResourceMemory: corev1resources.MustParse("1Gi"),
 					},
 				},
+				Priority:      "interactive",
+				Deadline:      &metav1.Time{Time: time.Date(2022, time.December, 1, 18, 0, 0, 0, time.UTC)},
+				ExecutionMode: v1beta1.ExecutionModeHermetic,
+				MaskSecrets:   &maskSecrets,
 			},
 		},
 	}}
@@ -345,6 +345,50 @@ func TestHasTimedOut(t *testing.T) {
 			},
 		},
 		expectedStatus: true,
+	}, {
+		name: "TaskRun deadline passed",
+		taskRun: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{
+				Timeout: &metav1.Duration{
+					Duration: 0 * time.Minute,
+				},
+				Deadline: &metav1.Time{Time: now.Add(-time.Second)},
+			},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: []apis.Condition{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionFalse,
+					}},
+				},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					StartTime: &metav1.Time{Time: now.Add(-15 * time.Second)},
+				},
+			},
+		},
+		expectedStatus: true,
+	}, {
+		name: "TaskRun deadline not passed",
+		taskRun: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{
+				Timeout: &metav1.Duration{
+					Duration: time.Hour,
+				},
+				Deadline: &metav1.Time{Time: now.Add(time.Minute)},
+			},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: []apis.Condition{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionFalse,
+					}},
+				},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					StartTime: &metav1.Time{Time: now.Add(-15 * time.Second)},
+				},
+			},
+		},
+		expectedStatus: false,
 	}}
 
 	for _, tc := range testCases {
@@ -495,6 +495,7 @@ func TestTaskRun_Workspaces_Invalid(t *testing.T) {
 
 func TestTaskRunSpec_Invalidate(t *testing.T) {
 	invalidStatusMessage := "status message without status"
+	trueValue := true
 	tests := []struct {
 		name    string
 		spec    v1beta1.TaskRunSpec
@@ -747,6 +748,63 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			},
 		},
 		wantErr: apis.ErrGeneric("computeResources requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "priority disallowed without alpha feature gate",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "foo",
+			},
+			Priority: "interactive",
+		},
+		wantErr: apis.ErrGeneric("priority requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").Also(
+			apis.ErrInvalidValue("interactive is not one of the priorities of the config-scheduling ConfigMap", "priority")),
+	}, {
+		name: "priority not configured",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "foo",
+			},
+			Priority: "interactive",
+		},
+		wantErr: apis.ErrInvalidValue("interactive is not one of the priorities of the config-scheduling ConfigMap", "priority"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "deadline disallowed without alpha feature gate",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "foo",
+			},
+			Deadline: &metav1.Time{Time: time.Date(2022, time.December, 1, 18, 0, 0, 0, time.UTC)},
+		},
+		wantErr: apis.ErrGeneric("deadline requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "executionMode disallowed without alpha feature gate",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "foo",
+			},
+			ExecutionMode: v1beta1.ExecutionModeHermetic,
+		},
+		wantErr: apis.ErrGeneric("executionMode requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "invalid executionMode",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "foo",
+			},
+			ExecutionMode: "sandboxed",
+		},
+		wantErr: apis.ErrInvalidValue("sandboxed should be hermetic", "executionMode"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "maskSecrets disallowed without alpha feature gate",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "foo",
+			},
+			MaskSecrets: &trueValue,
+		},
+		wantErr: apis.ErrGeneric("maskSecrets requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
 	}}
 
 	for _, ts := range tests {
@@ -764,6 +822,7 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 }
 
 func TestTaskRunSpec_Validate(t *testing.T) {
+	trueValue := true
 	tests := []struct {
 		name string
 		spec v1beta1.TaskRunSpec
@@ -845,6 +904,42 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 			}},
 		},
 		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "configured priority",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "foo",
+			},
+			Priority: "interactive",
+		},
+		wc: enableAlphaAPIFieldsWithPriorities,
+	}, {
+		name: "deadline",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "foo",
+			},
+			Deadline: &metav1.Time{Time: time.Date(2022, time.December, 1, 18, 0, 0, 0, time.UTC)},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "hermetic executionMode",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "foo",
+			},
+			ExecutionMode: v1beta1.ExecutionModeHermetic,
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "maskSecrets",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "foo",
+			},
+			MaskSecrets: &trueValue,
+		},
+		wc: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ts := range tests {
@@ -1092,3 +1187,11 @@ func TestResources_Invalidate(t *testing.T) {
 		})
 	}
 }
+
+func enableAlphaAPIFieldsWithPriorities(ctx context.Context) context.Context {
+	cfg := config.FromContextOrDefaults(config.EnableAlphaAPIFields(ctx))
+	cfg.Scheduling = &config.Scheduling{
+		Priorities: map[string]config.Priority{"interactive": {Value: 100}},
+	}
+	return config.ToContext(ctx, cfg)
+}
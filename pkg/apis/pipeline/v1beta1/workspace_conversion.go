@@ -60,6 +60,7 @@ func (w WorkspaceBinding) convertTo(ctx context.Context, sink *v1.WorkspaceBindi
 	sink.Name = w.Name
 	sink.SubPath = w.SubPath
 	sink.VolumeClaimTemplate = w.VolumeClaimTemplate
+	sink.RetentionPolicy = v1.PVCRetentionPolicy(w.RetentionPolicy)
 	sink.PersistentVolumeClaim = w.PersistentVolumeClaim
 	sink.EmptyDir = w.EmptyDir
 	sink.ConfigMap = w.ConfigMap
@@ -72,6 +73,7 @@ func (w *WorkspaceBinding) convertFrom(ctx context.Context, source v1.WorkspaceB
 	w.Name = source.Name
 	w.SubPath = source.SubPath
 	w.VolumeClaimTemplate = source.VolumeClaimTemplate
+	w.RetentionPolicy = PVCRetentionPolicy(source.RetentionPolicy)
 	w.PersistentVolumeClaim = source.PersistentVolumeClaim
 	w.EmptyDir = source.EmptyDir
 	w.ConfigMap = source.ConfigMap
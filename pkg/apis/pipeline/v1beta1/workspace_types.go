@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"path/filepath"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -62,6 +63,11 @@ type WorkspaceBinding struct {
 	// The PipelineRun controller is responsible for creating a unique claim for each instance of PipelineRun.
 	// +optional
 	VolumeClaimTemplate *corev1.PersistentVolumeClaim `json:"volumeClaimTemplate,omitempty"`
+	// RetentionPolicy is the policy applied to the claim created from VolumeClaimTemplate
+	// once the PipelineRun completes. When empty, the default from config-defaults is used,
+	// and if none is configured the claim is kept until the PipelineRun is deleted.
+	// +optional
+	RetentionPolicy PVCRetentionPolicy `json:"retentionPolicy,omitempty"`
 	// PersistentVolumeClaimVolumeSource represents a reference to a
 	// PersistentVolumeClaim in the same namespace. Either this OR EmptyDir can be used.
 	// +optional
@@ -85,6 +91,19 @@ type WorkspaceBinding struct {
 	CSI *corev1.CSIVolumeSource `json:"csi,omitempty"`
 }
 
+// PVCRetentionPolicy describes what happens to a PersistentVolumeClaim created from
+// a volumeClaimTemplate once the PipelineRun owning it completes.
+type PVCRetentionPolicy string
+
+const (
+	// PVCRetentionPolicyDelete deletes the claim when the PipelineRun completes,
+	// whether it succeeded or failed.
+	PVCRetentionPolicyDelete PVCRetentionPolicy = config.PVCRetentionPolicyDelete
+	// PVCRetentionPolicyRetain deletes the claim when the PipelineRun succeeds and
+	// retains it when the PipelineRun fails, so that its content can be inspected.
+	PVCRetentionPolicyRetain PVCRetentionPolicy = config.PVCRetentionPolicyRetain
+)
+
 // WorkspacePipelineDeclaration creates a named slot in a Pipeline that a PipelineRun
 // is expected to populate with a workspace binding.
 // Deprecated: use PipelineWorkspaceDeclaration type instead
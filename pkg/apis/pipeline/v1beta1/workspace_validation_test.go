@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -57,6 +58,18 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "Valid volumeClaimTemplate with retention policy",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "mypvc",
+				},
+			},
+			RetentionPolicy: v1beta1.PVCRetentionPolicyRetain,
+		},
+		wc: config.EnableAlphaAPIFields,
 	}, {
 		name: "Valid emptyDir",
 		binding: &v1beta1.WorkspaceBinding{
@@ -177,6 +190,29 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 				Driver: "",
 			},
 		},
+	}, {
+		name: "Provide retention policy without volumeClaimTemplate",
+		binding: &v1beta1.WorkspaceBinding{
+			Name:            "beth",
+			EmptyDir:        &corev1.EmptyDirVolumeSource{},
+			RetentionPolicy: v1beta1.PVCRetentionPolicyDelete,
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "Provide invalid retention policy",
+		binding: &v1beta1.WorkspaceBinding{
+			Name:                "beth",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+			RetentionPolicy:     "Sometimes",
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "Provide retention policy without alpha feature gate",
+		binding: &v1beta1.WorkspaceBinding{
+			Name:                "beth",
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+			RetentionPolicy:     v1beta1.PVCRetentionPolicyDelete,
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
@@ -26,6 +26,8 @@ import (
 type Interface interface {
 	// Runs returns a RunInformer.
 	Runs() RunInformer
+	// VerificationPolicies returns a VerificationPolicyInformer.
+	VerificationPolicies() VerificationPolicyInformer
 }
 
 type version struct {
@@ -43,3 +45,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) Runs() RunInformer {
 	return &runInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// VerificationPolicies returns a VerificationPolicyInformer.
+func (v *version) VerificationPolicies() VerificationPolicyInformer {
+	return &verificationPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -25,3 +25,11 @@ type RunListerExpansion interface{}
 // RunNamespaceListerExpansion allows custom methods to be added to
 // RunNamespaceLister.
 type RunNamespaceListerExpansion interface{}
+
+// VerificationPolicyListerExpansion allows custom methods to be added to
+// VerificationPolicyLister.
+type VerificationPolicyListerExpansion interface{}
+
+// VerificationPolicyNamespaceListerExpansion allows custom methods to be added to
+// VerificationPolicyNamespaceLister.
+type VerificationPolicyNamespaceListerExpansion interface{}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// BasicAuthEntry holds the username and password read from a
+// kubernetes.io/basic-auth Secret for a single target, e.g. a host,
+// a registry URL or a Maven server id.
+type BasicAuthEntry struct {
+	Secret   string
+	Target   string
+	Username string
+	Password string
+}
+
+// BasicAuthConfig collects BasicAuthEntries from "secret=target" flag
+// values. It implements flag.Value.
+type BasicAuthConfig struct {
+	Entries map[string]BasicAuthEntry
+	// The order we see things, for iterating over the above.
+	Order []string
+}
+
+// NewBasicAuthConfig returns an empty BasicAuthConfig.
+func NewBasicAuthConfig() *BasicAuthConfig {
+	return &BasicAuthConfig{Entries: make(map[string]BasicAuthEntry)}
+}
+
+func (bc *BasicAuthConfig) String() string {
+	if bc == nil {
+		// According to flag.Value this can happen.
+		return ""
+	}
+	var targets []string
+	for _, k := range bc.Order {
+		targets = append(targets, fmt.Sprintf("%s=%s", bc.Entries[k].Secret, k))
+	}
+	return strings.Join(targets, ",")
+}
+
+// Set reads the credentials of a secret for a target from a
+// "secret=target" value.
+func (bc *BasicAuthConfig) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("expect entries of the form secret=target, got: %v", value)
+	}
+	secret := parts[0]
+	target := parts[1]
+
+	if _, ok := bc.Entries[target]; ok {
+		return fmt.Errorf("multiple entries for target: %v", target)
+	}
+
+	secretPath := VolumeName(secret)
+	ub, err := ioutil.ReadFile(filepath.Join(secretPath, corev1.BasicAuthUsernameKey))
+	if err != nil {
+		return err
+	}
+	pb, err := ioutil.ReadFile(filepath.Join(secretPath, corev1.BasicAuthPasswordKey))
+	if err != nil {
+		return err
+	}
+
+	bc.Entries[target] = BasicAuthEntry{
+		Secret:   secret,
+		Target:   target,
+		Username: string(ub),
+		Password: string(pb),
+	}
+	bc.Order = append(bc.Order, target)
+	return nil
+}
+
+// BasicAuthMatchingAnnotations returns a "-flagName=secret=target" argument
+// for every annotation of a kubernetes.io/basic-auth Secret whose key has the
+// given prefix. Secrets of any other type yield no arguments.
+func BasicAuthMatchingAnnotations(secret *corev1.Secret, annotationPrefix, flagName string) []string {
+	var flags []string
+	if secret.Type != corev1.SecretTypeBasicAuth {
+		return flags
+	}
+	for _, v := range SortAnnotations(secret.Annotations, annotationPrefix) {
+		flags = append(flags, fmt.Sprintf("-%s=%s=%s", flagName, secret.Name, v))
+	}
+	return flags
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestBasicAuthConfigSet(t *testing.T) {
+	VolumePath = t.TempDir()
+	dir := VolumeName("foo")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("os.MkdirAll(%s) = %v", dir, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, corev1.BasicAuthUsernameKey), []byte("bar"), 0777); err != nil {
+		t.Fatalf("ioutil.WriteFile(username) = %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, corev1.BasicAuthPasswordKey), []byte("baz"), 0777); err != nil {
+		t.Fatalf("ioutil.WriteFile(password) = %v", err)
+	}
+
+	bc := NewBasicAuthConfig()
+	if err := bc.Set("foo=https://example.com/?a=b"); err != nil {
+		t.Fatalf("Set() = %v", err)
+	}
+	want := BasicAuthEntry{Secret: "foo", Target: "https://example.com/?a=b", Username: "bar", Password: "baz"}
+	if d := cmp.Diff(want, bc.Entries["https://example.com/?a=b"]); d != "" {
+		t.Errorf("Set() stored unexpected entry %s", diff.PrintWantGot(d))
+	}
+	if got := bc.String(); got != "foo=https://example.com/?a=b" {
+		t.Errorf("String() = %q", got)
+	}
+
+	for _, value := range []string{"foo=https://example.com/?a=b", "foo", "=target", "missing=target"} {
+		if err := bc.Set(value); err == nil {
+			t.Errorf("Set(%q) expected an error", value)
+		}
+	}
+}
+
+func TestBasicAuthMatchingAnnotations(t *testing.T) {
+	secret := &corev1.Secret{
+		Type: corev1.SecretTypeBasicAuth,
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "creds",
+			Annotations: map[string]string{
+				"tekton.dev/netrc-1": "b.example.com",
+				"tekton.dev/netrc-0": "a.example.com",
+				"tekton.dev/git-0":   "https://github.com",
+			},
+		},
+	}
+	want := []string{"-basic-netrc=creds=a.example.com", "-basic-netrc=creds=b.example.com"}
+	if d := cmp.Diff(want, BasicAuthMatchingAnnotations(secret, "tekton.dev/netrc-", "basic-netrc")); d != "" {
+		t.Errorf("BasicAuthMatchingAnnotations() %s", diff.PrintWantGot(d))
+	}
+
+	secret.Type = corev1.SecretTypeSSHAuth
+	if got := BasicAuthMatchingAnnotations(secret, "tekton.dev/netrc-", "basic-netrc"); len(got) != 0 {
+		t.Errorf("BasicAuthMatchingAnnotations() for ssh-auth secret = %v, want none", got)
+	}
+}
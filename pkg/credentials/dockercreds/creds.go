@@ -37,6 +37,15 @@ var config basicDocker
 var dockerConfig arrayArg
 var dockerCfg arrayArg
 
+func init() {
+	credentials.Register(credentials.Registration{
+		Name:       "docker",
+		AddFlags:   AddFlags,
+		NewBuilder: NewBuilder,
+		Paths:      []string{".docker"},
+	})
+}
+
 // AddFlags adds CLI flags that dockercreds supports to a given flag.FlagSet.
 func AddFlags(flagSet *flag.FlagSet) {
 	flags(flagSet)
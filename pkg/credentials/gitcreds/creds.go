@@ -36,6 +36,15 @@ var (
 	sshConfig   sshGitConfig
 )
 
+func init() {
+	credentials.Register(credentials.Registration{
+		Name:       "git",
+		AddFlags:   AddFlags,
+		NewBuilder: NewBuilder,
+		Paths:      []string{".gitconfig", ".git-credentials", ".ssh"},
+	})
+}
+
 // AddFlags adds CLI flags that gitcreds supports to a given flag.FlagSet.
 func AddFlags(flagSet *flag.FlagSet) {
 	flags(flagSet)
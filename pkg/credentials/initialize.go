@@ -17,6 +17,7 @@ limitations under the License.
 package credentials
 
 import (
+	"flag"
 	"fmt"
 	"io"
 	"log"
@@ -24,6 +25,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -40,8 +42,10 @@ const (
 	credsFilePermissions = 0600
 )
 
-// CredsInitCredentials is the complete list of credentials that the legacy credentials
-// helper (aka "creds-init") can write to /tekton/creds.
+// CredsInitCredentials is the list of credentials that the Docker and Git credentials
+// helpers (aka "creds-init") can write to /tekton/creds.
+//
+// Deprecated: use Paths, which also covers every other registered credential type.
 var CredsInitCredentials = []string{".docker", ".gitconfig", ".git-credentials", ".ssh"}
 
 // VolumePath is the path where build secrets are written.
@@ -59,6 +63,82 @@ type Builder interface {
 	Write(string) error
 }
 
+// Registration describes a credential type that creds-init can initialize.
+type Registration struct {
+	// Name identifies the credential type, e.g. "docker".
+	Name string
+
+	// AddFlags adds the flags populated from the arguments returned by
+	// the Builder's MatchingAnnotations to the given flag.FlagSet.
+	AddFlags func(*flag.FlagSet)
+
+	// NewBuilder returns a Builder for the credential type.
+	NewBuilder func() Builder
+
+	// Paths lists the files and directories, relative to the directory
+	// passed to Write, that the Builder can write.
+	Paths []string
+}
+
+var (
+	registryMu    sync.Mutex
+	registrations = map[string]Registration{}
+)
+
+// Register adds a credential type to the registry. It is intended to be
+// called from the init function of the package implementing the Builder
+// and panics if the credential type is registered twice.
+func Register(r Registration) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registrations[r.Name]; ok {
+		panic(fmt.Sprintf("credential type %q registered twice", r.Name))
+	}
+	registrations[r.Name] = r
+}
+
+// Registrations returns every registered credential type, sorted by name
+// so that the arguments passed to the entrypoint are stable.
+func Registrations() []Registration {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	var rs []Registration
+	for _, r := range registrations {
+		rs = append(rs, r)
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].Name < rs[j].Name })
+	return rs
+}
+
+// Builders returns a Builder for every registered credential type.
+func Builders() []Builder {
+	var builders []Builder
+	for _, r := range Registrations() {
+		builders = append(builders, r.NewBuilder())
+	}
+	return builders
+}
+
+// AddFlags adds the flags of every registered credential type to the
+// given flag.FlagSet.
+func AddFlags(flagSet *flag.FlagSet) {
+	for _, r := range Registrations() {
+		if r.AddFlags != nil {
+			r.AddFlags(flagSet)
+		}
+	}
+}
+
+// Paths returns the files and directories that the registered credential
+// types can write to /tekton/creds.
+func Paths() []string {
+	var paths []string
+	for _, r := range Registrations() {
+		paths = append(paths, r.Paths...)
+	}
+	return paths
+}
+
 // VolumeName returns the full path to the secret, inside the VolumePath.
 func VolumeName(secretName string) string {
 	return fmt.Sprintf("%s/%s", VolumePath, secretName)
@@ -1,10 +1,15 @@
 package credentials
 
 import (
+	"flag"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const credContents string = "hello, world!"
@@ -74,6 +79,50 @@ func TestTryCopyCredFileMissing(t *testing.T) {
 	}
 }
 
+type fakeBuilder struct{ name string }
+
+func (*fakeBuilder) MatchingAnnotations(*corev1.Secret) []string { return nil }
+func (*fakeBuilder) Write(string) error                          { return nil }
+
+func TestRegister(t *testing.T) {
+	saved := registrations
+	t.Cleanup(func() { registrations = saved })
+	registrations = map[string]Registration{}
+
+	var flagged []string
+	for _, name := range []string{"zeta", "alpha"} {
+		name := name
+		Register(Registration{
+			Name:       name,
+			AddFlags:   func(fs *flag.FlagSet) { flagged = append(flagged, name) },
+			NewBuilder: func() Builder { return &fakeBuilder{name: name} },
+			Paths:      []string{"." + name},
+		})
+	}
+
+	if d := cmp.Diff([]string{".alpha", ".zeta"}, Paths()); d != "" {
+		t.Errorf("Paths() %s", diff.PrintWantGot(d))
+	}
+	var built []string
+	for _, b := range Builders() {
+		built = append(built, b.(*fakeBuilder).name)
+	}
+	if d := cmp.Diff([]string{"alpha", "zeta"}, built); d != "" {
+		t.Errorf("Builders() %s", diff.PrintWantGot(d))
+	}
+	AddFlags(flag.NewFlagSet("test", flag.ContinueOnError))
+	if d := cmp.Diff([]string{"alpha", "zeta"}, flagged); d != "" {
+		t.Errorf("AddFlags() %s", diff.PrintWantGot(d))
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected registering a credential type twice to panic")
+		}
+	}()
+	Register(Registration{Name: "alpha"})
+}
+
 func writeFakeCred(t *testing.T, dir, name, contents string) string {
 	flags := os.O_RDWR | os.O_CREATE | os.O_TRUNC
 	path := filepath.Join(dir, name)
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mavencreds
+
+import (
+	"encoding/xml"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/tektoncd/pipeline/pkg/credentials"
+)
+
+const (
+	annotationPrefix = "tekton.dev/maven-"
+	basicAuthFlag    = "basic-maven"
+)
+
+var config *credentials.BasicAuthConfig
+
+func init() {
+	credentials.Register(credentials.Registration{
+		Name:       "maven",
+		AddFlags:   AddFlags,
+		NewBuilder: NewBuilder,
+		Paths:      []string{".m2"},
+	})
+}
+
+// AddFlags adds CLI flags that mavencreds supports to a given flag.FlagSet.
+func AddFlags(flagSet *flag.FlagSet) {
+	flags(flagSet)
+}
+
+func flags(fs *flag.FlagSet) {
+	config = credentials.NewBasicAuthConfig()
+	fs.Var(config, basicAuthFlag, "List of secret=server-id pairs.")
+}
+
+type settings struct {
+	XMLName xml.Name `xml:"settings"`
+	Servers []server `xml:"servers>server"`
+}
+
+type server struct {
+	ID       string `xml:"id"`
+	Username string `xml:"username"`
+	Password string `xml:"password"`
+}
+
+type mavenBuilder struct{}
+
+// NewBuilder returns a new builder for Maven credentials.
+func NewBuilder() credentials.Builder { return &mavenBuilder{} }
+
+// MatchingAnnotations extracts flags for the credential helper
+// from the supplied secret and returns a slice (of length 0 or
+// greater) of applicable server ids.
+func (*mavenBuilder) MatchingAnnotations(secret *corev1.Secret) []string {
+	return credentials.BasicAuthMatchingAnnotations(secret, annotationPrefix, basicAuthFlag)
+}
+
+// Write builds a .m2/settings.xml file with a server entry for
+// every server id and writes it to the given directory. If no
+// entries exist then nothing will be written to disk.
+func (*mavenBuilder) Write(directory string) error {
+	if config == nil || len(config.Order) == 0 {
+		return nil
+	}
+	s := settings{}
+	for _, k := range config.Order {
+		e := config.Entries[k]
+		s.Servers = append(s.Servers, server{ID: k, Username: e.Username, Password: e.Password})
+	}
+	content, err := xml.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	m2Dir := filepath.Join(directory, ".m2")
+	if err := os.MkdirAll(m2Dir, os.ModePerm); err != nil {
+		return err
+	}
+	content = append([]byte(xml.Header), append(content, '\n')...)
+	return ioutil.WriteFile(filepath.Join(m2Dir, "settings.xml"), content, 0600)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mavencreds
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/credentials"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFlagHandling(t *testing.T) {
+	credentials.VolumePath = t.TempDir()
+	writeBasicAuthSecret(t, "foo", "bar", "baz")
+	writeBasicAuthSecret(t, "qux", "quux", "corge")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs)
+	err := fs.Parse([]string{
+		"-basic-maven=foo=central",
+		"-basic-maven=qux=releases",
+	})
+	if err != nil {
+		t.Fatalf("flag.CommandLine.Parse() = %v", err)
+	}
+
+	if err := NewBuilder().Write(credentials.VolumePath); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(credentials.VolumePath, ".m2", "settings.xml"))
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile() = %v", err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<settings>
+  <servers>
+    <server>
+      <id>central</id>
+      <username>bar</username>
+      <password>baz</password>
+    </server>
+    <server>
+      <id>releases</id>
+      <username>quux</username>
+      <password>corge</password>
+    </server>
+  </servers>
+</settings>
+`
+	if d := cmp.Diff(expected, string(b)); d != "" {
+		t.Errorf("Write() wrote unexpected content %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestNoEntries(t *testing.T) {
+	credentials.VolumePath = t.TempDir()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatalf("flag.CommandLine.Parse() = %v", err)
+	}
+
+	if err := NewBuilder().Write(credentials.VolumePath); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(credentials.VolumePath, ".m2", "settings.xml")); !os.IsNotExist(err) {
+		t.Errorf("expected no file to be written, got %v", err)
+	}
+}
+
+func TestMatchingAnnotations(t *testing.T) {
+	tests := []struct {
+		secret   *corev1.Secret
+		wantFlag []string
+	}{{
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeBasicAuth,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "maven",
+				Annotations: map[string]string{
+					fmt.Sprintf("%s.testkeys1", annotationPrefix): "central",
+					fmt.Sprintf("%s.testkeys2", annotationPrefix): "releases",
+					"tekton.dev/git-0": "https://github.com",
+				},
+			},
+		},
+		wantFlag: []string{fmt.Sprintf("-%s=maven=central", basicAuthFlag), fmt.Sprintf("-%s=maven=releases", basicAuthFlag)},
+	}, {
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeOpaque,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "opaque",
+				Annotations: map[string]string{
+					fmt.Sprintf("%s.testkeys", annotationPrefix): "central",
+				},
+			},
+		},
+		wantFlag: nil,
+	}}
+
+	nb := NewBuilder()
+	for _, ts := range tests {
+		gotFlag := nb.MatchingAnnotations(ts.secret)
+		if d := cmp.Diff(ts.wantFlag, gotFlag); d != "" {
+			t.Errorf("MatchingAnnotations() Mismatch of flags %s", diff.PrintWantGot(d))
+		}
+	}
+}
+
+func writeBasicAuthSecret(t *testing.T, name, username, password string) {
+	t.Helper()
+	dir := credentials.VolumeName(name)
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("os.MkdirAll(%s) = %v", dir, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, corev1.BasicAuthUsernameKey), []byte(username), 0777); err != nil {
+		t.Fatalf("ioutil.WriteFile(username) = %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, corev1.BasicAuthPasswordKey), []byte(password), 0777); err != nil {
+		t.Fatalf("ioutil.WriteFile(password) = %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package netrccreds
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/tektoncd/pipeline/pkg/credentials"
+)
+
+const (
+	annotationPrefix = "tekton.dev/netrc-"
+	basicAuthFlag    = "basic-netrc"
+)
+
+var config *credentials.BasicAuthConfig
+
+func init() {
+	credentials.Register(credentials.Registration{
+		Name:       "netrc",
+		AddFlags:   AddFlags,
+		NewBuilder: NewBuilder,
+		Paths:      []string{".netrc"},
+	})
+}
+
+// AddFlags adds CLI flags that netrccreds supports to a given flag.FlagSet.
+func AddFlags(flagSet *flag.FlagSet) {
+	flags(flagSet)
+}
+
+func flags(fs *flag.FlagSet) {
+	config = credentials.NewBasicAuthConfig()
+	fs.Var(config, basicAuthFlag, "List of secret=host pairs.")
+}
+
+type netrcBuilder struct{}
+
+// NewBuilder returns a new builder for .netrc credentials.
+func NewBuilder() credentials.Builder { return &netrcBuilder{} }
+
+// MatchingAnnotations extracts flags for the credential helper
+// from the supplied secret and returns a slice (of length 0 or
+// greater) of applicable hosts.
+func (*netrcBuilder) MatchingAnnotations(secret *corev1.Secret) []string {
+	return credentials.BasicAuthMatchingAnnotations(secret, annotationPrefix, basicAuthFlag)
+}
+
+// Write builds a .netrc file with a machine entry for every host
+// and writes it to the given directory. If no entries exist then
+// nothing will be written to disk.
+func (*netrcBuilder) Write(directory string) error {
+	if config == nil || len(config.Order) == 0 {
+		return nil
+	}
+	var lines []string
+	for _, k := range config.Order {
+		e := config.Entries[k]
+		lines = append(lines, fmt.Sprintf("machine %s\nlogin %s\npassword %s\n", machine(k), e.Username, e.Password))
+	}
+	return ioutil.WriteFile(filepath.Join(directory, ".netrc"), []byte(strings.Join(lines, "")), 0600)
+}
+
+// machine returns the host of the given target, which may either be
+// a bare host name or a URL.
+func machine(target string) string {
+	if u, err := url.Parse(target); err == nil && u.Host != "" {
+		return u.Hostname()
+	}
+	return target
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package npmcreds
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/tektoncd/pipeline/pkg/credentials"
+)
+
+const (
+	annotationPrefix = "tekton.dev/npm-"
+	basicAuthFlag    = "basic-npm"
+)
+
+var config *credentials.BasicAuthConfig
+
+func init() {
+	credentials.Register(credentials.Registration{
+		Name:       "npm",
+		AddFlags:   AddFlags,
+		NewBuilder: NewBuilder,
+		Paths:      []string{".npmrc"},
+	})
+}
+
+// AddFlags adds CLI flags that npmcreds supports to a given flag.FlagSet.
+func AddFlags(flagSet *flag.FlagSet) {
+	flags(flagSet)
+}
+
+func flags(fs *flag.FlagSet) {
+	config = credentials.NewBasicAuthConfig()
+	fs.Var(config, basicAuthFlag, "List of secret=registry pairs.")
+}
+
+type npmrcBuilder struct{}
+
+// NewBuilder returns a new builder for npm credentials.
+func NewBuilder() credentials.Builder { return &npmrcBuilder{} }
+
+// MatchingAnnotations extracts flags for the credential helper
+// from the supplied secret and returns a slice (of length 0 or
+// greater) of applicable registries.
+func (*npmrcBuilder) MatchingAnnotations(secret *corev1.Secret) []string {
+	return credentials.BasicAuthMatchingAnnotations(secret, annotationPrefix, basicAuthFlag)
+}
+
+// Write builds a .npmrc file with an auth entry for every registry
+// and writes it to the given directory. If no entries exist then
+// nothing will be written to disk.
+func (*npmrcBuilder) Write(directory string) error {
+	if config == nil || len(config.Order) == 0 {
+		return nil
+	}
+	var lines []string
+	for _, k := range config.Order {
+		e := config.Entries[k]
+		auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", e.Username, e.Password)))
+		lines = append(lines, fmt.Sprintf("%s:_auth=%s\n", registryKey(k), auth))
+	}
+	return ioutil.WriteFile(filepath.Join(directory, ".npmrc"), []byte(strings.Join(lines, "")), 0600)
+}
+
+// registryKey turns a registry URL such as https://registry.npmjs.org
+// into the scheme-less form npm expects in front of per-registry
+// settings, e.g. //registry.npmjs.org/.
+func registryKey(registry string) string {
+	if i := strings.Index(registry, "://"); i >= 0 {
+		registry = registry[i+1:]
+	}
+	if !strings.HasPrefix(registry, "//") {
+		registry = "//" + registry
+	}
+	if !strings.HasSuffix(registry, "/") {
+		registry += "/"
+	}
+	return registry
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipcreds
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/tektoncd/pipeline/pkg/credentials"
+)
+
+const (
+	annotationPrefix = "tekton.dev/pip-"
+	basicAuthFlag    = "basic-pip"
+)
+
+var config *credentials.BasicAuthConfig
+
+func init() {
+	credentials.Register(credentials.Registration{
+		Name:       "pip",
+		AddFlags:   AddFlags,
+		NewBuilder: NewBuilder,
+		Paths:      []string{".config/pip"},
+	})
+}
+
+// AddFlags adds CLI flags that pipcreds supports to a given flag.FlagSet.
+func AddFlags(flagSet *flag.FlagSet) {
+	flags(flagSet)
+}
+
+func flags(fs *flag.FlagSet) {
+	config = credentials.NewBasicAuthConfig()
+	fs.Var(config, basicAuthFlag, "List of secret=index-url pairs.")
+}
+
+type pipBuilder struct{}
+
+// NewBuilder returns a new builder for pip credentials.
+func NewBuilder() credentials.Builder { return &pipBuilder{} }
+
+// MatchingAnnotations extracts flags for the credential helper
+// from the supplied secret and returns a slice (of length 0 or
+// greater) of applicable index URLs.
+func (*pipBuilder) MatchingAnnotations(secret *corev1.Secret) []string {
+	return credentials.BasicAuthMatchingAnnotations(secret, annotationPrefix, basicAuthFlag)
+}
+
+// Write builds a .config/pip/pip.conf file and writes it to the
+// given directory. The first index URL becomes pip's index-url and
+// any further ones are added as extra-index-url, each with the
+// credentials of its secret embedded. If no entries exist then
+// nothing will be written to disk.
+func (*pipBuilder) Write(directory string) error {
+	if config == nil || len(config.Order) == 0 {
+		return nil
+	}
+	var indexURLs []string
+	for _, k := range config.Order {
+		e := config.Entries[k]
+		u, err := url.Parse(k)
+		if err != nil {
+			return fmt.Errorf("invalid index URL %q for secret %q: %w", k, e.Secret, err)
+		}
+		u.User = url.UserPassword(e.Username, e.Password)
+		indexURLs = append(indexURLs, u.String())
+	}
+
+	content := fmt.Sprintf("[global]\nindex-url = %s\n", indexURLs[0])
+	if len(indexURLs) > 1 {
+		content += fmt.Sprintf("extra-index-url =\n    %s\n", strings.Join(indexURLs[1:], "\n    "))
+	}
+
+	pipDir := filepath.Join(directory, ".config", "pip")
+	if err := os.MkdirAll(pipDir, os.ModePerm); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(pipDir, "pip.conf"), []byte(content), 0600)
+}
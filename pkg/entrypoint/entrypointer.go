@@ -18,6 +18,7 @@ package entrypoint
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -55,6 +56,10 @@ type Entrypointer struct {
 	// WaitFileContent indicates the WaitFile should have non-zero size
 	// before continuing with execution.
 	WaitFileContent bool
+	// WaitReadyFiles is the set of files to wait for until they have
+	// content, after the WaitFiles. It is used to wait for Sidecars to be
+	// ready.
+	WaitReadyFiles []string
 	// PostFile is the file to write when complete. If not specified, no
 	// file is written.
 	PostFile string
@@ -85,6 +90,16 @@ type Entrypointer struct {
 	SpireWorkloadAPI spire.EntrypointerAPIClient
 	// ResultsDirectory is the directory to find results, defaults to pipeline.DefaultResultPath
 	ResultsDirectory string
+	// CgroupDir is the directory of the cgroup v2 of the step's container, which is sampled
+	// to report the resource usage of the step. No usage is reported if it is empty.
+	CgroupDir string
+	// SecretMasker redacts the values of the Secrets of the TaskRun from the
+	// results of the step. The runner redacts them from its logs. Nothing is
+	// redacted if it is nil.
+	SecretMasker *SecretMasker
+	// Hermetic indicates that the command is executed in hermetic execution mode, in which
+	// case its failure is recorded with the HermeticStepFailed reason.
+	Hermetic bool
 }
 
 // Waiter encapsulates waiting for files to exist.
@@ -118,8 +133,9 @@ func (e Entrypointer) Go() error {
 		_ = logger.Sync()
 	}()
 
-	for _, f := range e.WaitFiles {
-		if err := e.Waiter.Wait(f, e.WaitFileContent, e.BreakpointOnFailure); err != nil {
+	waitFiles := append(append([]string{}, e.WaitFiles...), e.WaitReadyFiles...)
+	for i, f := range waitFiles {
+		if err := e.Waiter.Wait(f, e.WaitFileContent || i >= len(e.WaitFiles), e.BreakpointOnFailure); err != nil {
 			// An error happened while waiting, so we bail
 			// *but* we write postfile to make next steps bail too.
 			// In case of breakpoint on failure do not write post file.
@@ -154,13 +170,24 @@ func (e Entrypointer) Go() error {
 			ctx, cancel = context.WithTimeout(ctx, *e.Timeout)
 			defer cancel()
 		}
+		sampler := startCgroupSampler(e.CgroupDir)
 		err = e.Runner.Run(ctx, e.Command...)
-		if err == context.DeadlineExceeded {
+		if sampler != nil {
+			output = append(output, e.resourceUsageResults(logger, sampler)...)
+		}
+		switch {
+		case err == context.DeadlineExceeded:
 			output = append(output, v1beta1.PipelineResourceResult{
 				Key:        "Reason",
 				Value:      "TimeoutExceeded",
 				ResultType: v1beta1.InternalTektonResultType,
 			})
+		case err != nil && e.Hermetic:
+			output = append(output, v1beta1.PipelineResourceResult{
+				Key:        "Reason",
+				Value:      v1beta1.TaskRunReasonHermeticStepFailed.String(),
+				ResultType: v1beta1.InternalTektonResultType,
+			})
 		}
 	}
 
@@ -199,6 +226,10 @@ func (e Entrypointer) Go() error {
 		}
 	}
 
+	if e.SecretMasker != nil {
+		output = append(output, e.redactionsResult())
+	}
+
 	return err
 }
 
@@ -214,6 +245,9 @@ func (e Entrypointer) readResultsFromDisk(ctx context.Context, resultDir string)
 		} else if err != nil {
 			return err
 		}
+		if e.SecretMasker != nil {
+			fileContents = e.SecretMasker.Mask(fileContents)
+		}
 		// if the file doesn't exist, ignore it
 		output = append(output, v1beta1.PipelineResourceResult{
 			Key:        resultFile,
@@ -239,6 +273,44 @@ func (e Entrypointer) readResultsFromDisk(ctx context.Context, resultDir string)
 	return nil
 }
 
+// resourceUsageResults stops the sampler, writes the resource usage of the step to its
+// metadata directory and returns it as internal results.
+func (e Entrypointer) resourceUsageResults(logger *zap.SugaredLogger, sampler *cgroupSampler) []v1beta1.PipelineResourceResult {
+	usage, err := sampler.Stop()
+	if err != nil {
+		logger.Warnf("Error reading the resource usage of the step: %v", err)
+		return nil
+	}
+	if e.StepMetadataDir != "" {
+		if b, err := json.Marshal(usage); err == nil {
+			e.PostWriter.Write(filepath.Join(e.StepMetadataDir, resourceUsageFile), string(b))
+		}
+	}
+	return []v1beta1.PipelineResourceResult{{
+		Key:        PeakMemoryBytesResultKey,
+		Value:      strconv.FormatInt(usage.PeakMemoryBytes, 10),
+		ResultType: v1beta1.InternalTektonResultType,
+	}, {
+		Key:        CPUTimeMicrosecondsResultKey,
+		Value:      strconv.FormatInt(usage.CPUTimeMicroseconds, 10),
+		ResultType: v1beta1.InternalTektonResultType,
+	}}
+}
+
+// redactionsResult writes the number of secret values redacted from the logs and
+// results of the step to its metadata directory and returns it as an internal result.
+func (e Entrypointer) redactionsResult() v1beta1.PipelineResourceResult {
+	redactions := strconv.FormatInt(e.SecretMasker.Redactions(), 10)
+	if e.StepMetadataDir != "" {
+		e.PostWriter.Write(filepath.Join(e.StepMetadataDir, redactionsFile), redactions)
+	}
+	return v1beta1.PipelineResourceResult{
+		Key:        RedactionsResultKey,
+		Value:      redactions,
+		ResultType: v1beta1.InternalTektonResultType,
+	}
+}
+
 // BreakpointExitCode reads the post file and returns the exit code it contains
 func (e Entrypointer) BreakpointExitCode(breakpointExitPostFile string) (int, error) {
 	exitCode, err := ioutil.ReadFile(breakpointExitPostFile)
@@ -133,7 +133,7 @@ func TestEntrypointerFailures(t *testing.T) {
 func TestEntrypointer(t *testing.T) {
 	for _, c := range []struct {
 		desc, entrypoint, postFile, stepDir, stepDirLink string
-		waitFiles, args                                  []string
+		waitFiles, waitReadyFiles, args                  []string
 		breakpointOnFailure                              bool
 	}{{
 		desc: "do nothing",
@@ -162,6 +162,10 @@ func TestEntrypointer(t *testing.T) {
 	}, {
 		desc:      "multiple wait files",
 		waitFiles: []string{"waitforme", "metoo", "methree"},
+	}, {
+		desc:           "wait ready files",
+		waitFiles:      []string{"waitforme"},
+		waitReadyFiles: []string{"sidecar-db", "sidecar-cache"},
 	}, {
 		desc:                "breakpointOnFailure to wait or not to wait ",
 		breakpointOnFailure: true,
@@ -179,6 +183,7 @@ func TestEntrypointer(t *testing.T) {
 			err := Entrypointer{
 				Command:             append([]string{c.entrypoint}, c.args...),
 				WaitFiles:           c.waitFiles,
+				WaitReadyFiles:      c.waitReadyFiles,
 				PostFile:            c.postFile,
 				Waiter:              fw,
 				Runner:              fr,
@@ -192,14 +197,15 @@ func TestEntrypointer(t *testing.T) {
 				t.Fatalf("Entrypointer failed: %v", err)
 			}
 
-			if len(c.waitFiles) > 0 {
+			wantWaited := append(append([]string{}, c.waitFiles...), c.waitReadyFiles...)
+			if len(wantWaited) > 0 {
 				if fw.waited == nil {
 					t.Error("Wanted waited file, got nil")
-				} else if !reflect.DeepEqual(fw.waited, c.waitFiles) {
-					t.Errorf("Waited for %v, want %v", fw.waited, c.waitFiles)
+				} else if !reflect.DeepEqual(fw.waited, wantWaited) {
+					t.Errorf("Waited for %v, want %v", fw.waited, wantWaited)
 				}
 			}
-			if len(c.waitFiles) == 0 && fw.waited != nil {
+			if len(wantWaited) == 0 && fw.waited != nil {
 				t.Errorf("Waited for file when not required")
 			}
 
@@ -599,6 +605,114 @@ func TestEntrypointerResults(t *testing.T) {
 	}
 }
 
+func TestEntrypointerMasksSecrets(t *testing.T) {
+	resultsDir := createTmpDir(t, "results")
+	defer os.RemoveAll(resultsDir)
+	terminationPath := filepath.Join(resultsDir, "termination")
+	fpw := &fakePostWriter{}
+
+	err := Entrypointer{
+		Command:    []string{"echo"},
+		Waiter:     &fakeWaiter{},
+		PostWriter: fpw,
+		Runner: &fakeResultsWriter{
+			resultsToWrite: map[string]string{
+				filepath.Join(resultsDir, "token"): "token: hunter2",
+				filepath.Join(resultsDir, "url"):   "https://example.com",
+			},
+		},
+		Results:          []string{"token", "url"},
+		ResultsDirectory: resultsDir,
+		TerminationPath:  terminationPath,
+		StepMetadataDir:  "/tekton/run/0/status",
+		SecretMasker:     NewSecretMasker("hunter2"),
+	}.Go()
+	if err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	fileContents, err := ioutil.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatalf("failed to read the termination message: %v", err)
+	}
+	var entries []v1beta1.PipelineResourceResult
+	if err := json.Unmarshal(fileContents, &entries); err != nil {
+		t.Fatalf("failed to unmarshal results: %v", err)
+	}
+	got := map[string]string{}
+	for _, e := range entries {
+		if e.Key != "StartedAt" {
+			got[e.Key] = e.Value
+		}
+	}
+	want := map[string]string{
+		"token":             "token: ***",
+		"url":               "https://example.com",
+		RedactionsResultKey: "1",
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+	if fpw.exitCodeFile == nil || *fpw.exitCodeFile != "/tekton/run/0/status/redactions" || *fpw.exitCode != "1" {
+		t.Errorf("expected the number of redactions to be written to the step metadata directory")
+	}
+}
+
+func TestEntrypointerHermeticFailure(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		hermetic bool
+		runner   Runner
+		want     map[string]string
+	}{{
+		desc:     "hermetic, command failed",
+		hermetic: true,
+		runner:   &fakeErrorRunner{},
+		want:     map[string]string{"Reason": v1beta1.TaskRunReasonHermeticStepFailed.String()},
+	}, {
+		desc:     "hermetic, command succeeded",
+		hermetic: true,
+		runner:   &fakeRunner{},
+		want:     map[string]string{},
+	}, {
+		desc:   "not hermetic, command failed",
+		runner: &fakeErrorRunner{},
+		want:   map[string]string{},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			terminationDir := createTmpDir(t, "termination")
+			defer os.RemoveAll(terminationDir)
+			terminationPath := filepath.Join(terminationDir, "termination")
+			_ = Entrypointer{
+				Command:         []string{"echo"},
+				Waiter:          &fakeWaiter{},
+				Runner:          tc.runner,
+				PostWriter:      &fakePostWriter{},
+				TerminationPath: terminationPath,
+				Hermetic:        tc.hermetic,
+			}.Go()
+
+			fileContents, err := ioutil.ReadFile(terminationPath)
+			if err != nil {
+				t.Fatalf("failed to read the termination message: %v", err)
+			}
+			var entries []v1beta1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("failed to unmarshal results: %v", err)
+			}
+			got := map[string]string{}
+			for _, e := range entries {
+				if e.Key != "StartedAt" {
+					got[e.Key] = e.Value
+				}
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 type fakeWaiter struct{ waited []string }
 
 func (f *fakeWaiter) Wait(file string, _ bool, _ bool) error {
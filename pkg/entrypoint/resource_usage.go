@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// PeakMemoryBytesResultKey is the key of the internal result with the peak
+	// memory usage of a step, in bytes.
+	PeakMemoryBytesResultKey = "PeakMemoryBytes"
+	// CPUTimeMicrosecondsResultKey is the key of the internal result with the
+	// CPU time used by a step, in microseconds.
+	CPUTimeMicrosecondsResultKey = "CPUTimeMicroseconds"
+
+	// resourceUsageFile is the file of the step metadata directory the
+	// resource usage of the step is written to.
+	resourceUsageFile = "resourceUsage"
+
+	// memorySamplingInterval is the interval at which memory.current is
+	// sampled on kernels which do not provide memory.peak.
+	memorySamplingInterval = 500 * time.Millisecond
+)
+
+// ResourceUsage is the resource usage of a step, read from the cgroup v2 of
+// its container. In Kubernetes, each container has its own cgroup, which only
+// holds the entrypoint and the process tree of the step.
+type ResourceUsage struct {
+	PeakMemoryBytes     int64 `json:"peakMemoryBytes"`
+	CPUTimeMicroseconds int64 `json:"cpuTimeMicroseconds"`
+}
+
+// cgroupSampler samples the resource usage of a cgroup v2 while a step runs.
+type cgroupSampler struct {
+	dir      string
+	startCPU int64
+
+	mu   sync.Mutex
+	peak int64
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// startCgroupSampler starts sampling the cgroup v2 mounted at dir. It returns
+// nil if dir is empty or is not a cgroup v2, e.g. on nodes using cgroup v1.
+func startCgroupSampler(dir string) *cgroupSampler {
+	if dir == "" {
+		return nil
+	}
+	startCPU, err := readCPUUsage(dir)
+	if err != nil {
+		return nil
+	}
+	s := &cgroupSampler{
+		dir:      dir,
+		startCPU: startCPU,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if _, err := readInt(filepath.Join(dir, "memory.peak")); err == nil {
+		// The kernel keeps track of the peak memory usage itself.
+		close(s.done)
+		return s
+	}
+	go s.sampleMemory()
+	return s
+}
+
+func (s *cgroupSampler) sampleMemory() {
+	defer close(s.done)
+	ticker := time.NewTicker(memorySamplingInterval)
+	defer ticker.Stop()
+	for {
+		s.recordMemory()
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *cgroupSampler) recordMemory() {
+	current, err := readInt(filepath.Join(s.dir, "memory.current"))
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current > s.peak {
+		s.peak = current
+	}
+}
+
+// Stop stops sampling and returns the resource usage since the sampler was
+// started.
+func (s *cgroupSampler) Stop() (*ResourceUsage, error) {
+	close(s.stop)
+	<-s.done
+	cpu, err := readCPUUsage(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	peak, err := readInt(filepath.Join(s.dir, "memory.peak"))
+	if err != nil {
+		s.recordMemory()
+		s.mu.Lock()
+		peak = s.peak
+		s.mu.Unlock()
+	}
+	return &ResourceUsage{
+		PeakMemoryBytes:     peak,
+		CPUTimeMicroseconds: cpu - s.startCPU,
+	}, nil
+}
+
+// readCPUUsage reads the usage_usec field of the cpu.stat file of a cgroup v2.
+func readCPUUsage(dir string) (int64, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return 0, err
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "usage_usec" {
+			return strconv.ParseInt(fields[1], 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("no usage_usec in %s", filepath.Join(dir, "cpu.stat"))
+}
+
+func readInt(path string) (int64, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+}
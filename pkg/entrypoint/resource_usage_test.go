@@ -0,0 +1,149 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func writeCgroupFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCgroupSampler(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		start map[string]string
+		end   map[string]string
+		want  *ResourceUsage
+	}{{
+		name: "memory.peak",
+		start: map[string]string{
+			"cpu.stat":       "usage_usec 1000\nuser_usec 800\nsystem_usec 200\n",
+			"memory.current": "1024\n",
+			"memory.peak":    "4096\n",
+		},
+		end: map[string]string{
+			"cpu.stat":       "usage_usec 251000\nuser_usec 200800\nsystem_usec 50200\n",
+			"memory.current": "2048\n",
+			"memory.peak":    "1048576\n",
+		},
+		want: &ResourceUsage{PeakMemoryBytes: 1048576, CPUTimeMicroseconds: 250000},
+	}, {
+		name: "sampled memory.current",
+		start: map[string]string{
+			"cpu.stat":       "usage_usec 0\n",
+			"memory.current": "8192\n",
+		},
+		end: map[string]string{
+			"cpu.stat":       "usage_usec 100\n",
+			"memory.current": "4096\n",
+		},
+		want: &ResourceUsage{PeakMemoryBytes: 8192, CPUTimeMicroseconds: 100},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeCgroupFiles(t, dir, tc.start)
+			s := startCgroupSampler(dir)
+			if s == nil {
+				t.Fatal("expected a sampler")
+			}
+			// Sample the start usage, the sampling goroutine may not have run yet.
+			s.recordMemory()
+			writeCgroupFiles(t, dir, tc.end)
+			got, err := s.Stop()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected resource usage %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestCgroupSamplerNoCgroupV2(t *testing.T) {
+	if s := startCgroupSampler(""); s != nil {
+		t.Error("expected no sampler without a cgroup directory")
+	}
+	if s := startCgroupSampler(t.TempDir()); s != nil {
+		t.Error("expected no sampler without cpu.stat")
+	}
+}
+
+type recordingPostWriter map[string]string
+
+func (w recordingPostWriter) Write(file, content string) {
+	w[file] = content
+}
+
+func TestEntrypointerResourceUsage(t *testing.T) {
+	cgroupDir := t.TempDir()
+	writeCgroupFiles(t, cgroupDir, map[string]string{
+		"cpu.stat":    "usage_usec 1000\n",
+		"memory.peak": "4096\n",
+	})
+	terminationPath := filepath.Join(t.TempDir(), "termination")
+	stepDir := t.TempDir()
+	pw := recordingPostWriter{}
+	err := Entrypointer{
+		Command:         []string{"echo"},
+		Waiter:          &fakeWaiter{},
+		Runner:          &fakeRunner{},
+		PostWriter:      pw,
+		TerminationPath: terminationPath,
+		StepMetadataDir: stepDir,
+		CgroupDir:       cgroupDir,
+	}.Go()
+	if err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	if d := cmp.Diff(`{"peakMemoryBytes":4096,"cpuTimeMicroseconds":0}`, pw[filepath.Join(stepDir, "resourceUsage")]); d != "" {
+		t.Errorf("unexpected resource usage file %s", diff.PrintWantGot(d))
+	}
+	b, err := ioutil.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []v1beta1.PipelineResourceResult
+	if err := json.Unmarshal(b, &entries); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, e := range entries {
+		if e.Key == PeakMemoryBytesResultKey || e.Key == CPUTimeMicrosecondsResultKey {
+			got[e.Key] = e.Value
+		}
+	}
+	want := map[string]string{PeakMemoryBytesResultKey: "4096", CPUTimeMicrosecondsResultKey: "0"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("unexpected termination message %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	// RedactionsResultKey is the key of the internal result with the number
+	// of secret values redacted from the logs and results of a step.
+	RedactionsResultKey = "Redactions"
+
+	// SecretMask is what the secret values are replaced with.
+	SecretMask = "***"
+
+	// redactionsFile is the file of the step metadata directory the number of
+	// redactions of the step is written to.
+	redactionsFile = "redactions"
+
+	// minSecretLength is the minimum length of the secret values that are
+	// masked, so that short values such as "1" or "true" are not masked
+	// everywhere they appear.
+	minSecretLength = 4
+
+	// maxMaskBufferSize is the size above which the output of a step is
+	// masked and written even when it has no line break.
+	maxMaskBufferSize = 64 * 1024
+)
+
+// SecretMasker redacts the values of the Secrets of a TaskRun from the logs
+// and results of a step, and counts the redactions.
+type SecretMasker struct {
+	// secrets are the values to redact, the longest first so that values
+	// containing other values are redacted entirely.
+	secrets    [][]byte
+	redactions int64
+}
+
+// NewSecretMaskerFromDir returns a SecretMasker redacting the contents of the
+// files of the directory, such as the projected volume of the Secrets of a
+// step. A missing directory redacts nothing: steps reading no Secrets have
+// nothing mounted there.
+func NewSecretMaskerFromDir(dir string) (*SecretMasker, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return NewSecretMasker(), nil
+	} else if err != nil {
+		return nil, err
+	}
+	var values []string
+	for _, entry := range entries {
+		// Skip the ..data link and the timestamped directory Kubernetes
+		// atomically updates the files of projected volumes through.
+		if strings.HasPrefix(entry.Name(), "..") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, string(b))
+	}
+	return NewSecretMasker(values...), nil
+}
+
+// NewSecretMasker returns a SecretMasker redacting the values, as well as
+// each of their lines. Leading and trailing spaces are ignored, so that
+// values written with a trailing line break are masked too.
+func NewSecretMasker(values ...string) *SecretMasker {
+	set := map[string]bool{}
+	add := func(v string) {
+		if v = strings.TrimSpace(v); len(v) >= minSecretLength {
+			set[v] = true
+		}
+	}
+	for _, v := range values {
+		add(v)
+		for _, line := range strings.Split(v, "\n") {
+			add(line)
+		}
+	}
+	secrets := make([]string, 0, len(set))
+	for v := range set {
+		secrets = append(secrets, v)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		if len(secrets[i]) != len(secrets[j]) {
+			return len(secrets[i]) > len(secrets[j])
+		}
+		return secrets[i] < secrets[j]
+	})
+	m := &SecretMasker{}
+	for _, s := range secrets {
+		m.secrets = append(m.secrets, []byte(s))
+	}
+	return m
+}
+
+// Mask returns b with the secret values replaced by SecretMask.
+func (m *SecretMasker) Mask(b []byte) []byte {
+	for _, s := range m.secrets {
+		if n := bytes.Count(b, s); n > 0 {
+			b = bytes.ReplaceAll(b, s, []byte(SecretMask))
+			atomic.AddInt64(&m.redactions, int64(n))
+		}
+	}
+	return b
+}
+
+// Redactions returns the number of secret values redacted so far.
+func (m *SecretMasker) Redactions() int64 {
+	return atomic.LoadInt64(&m.redactions)
+}
+
+// NewWriter returns a MaskingWriter masking the secret values of what is
+// written to it before writing it to w.
+func (m *SecretMasker) NewWriter(w io.Writer) *MaskingWriter {
+	return &MaskingWriter{masker: m, w: w}
+}
+
+// MaskingWriter masks the output of a step line by line, so that the secret
+// values split across writes are masked too. Flush must be called once the
+// output is complete to write its last line.
+type MaskingWriter struct {
+	sync.Mutex
+	masker *SecretMasker
+	w      io.Writer
+	buf    []byte
+}
+
+// Write buffers p and writes the complete lines buffered so far, masked.
+func (mw *MaskingWriter) Write(p []byte) (int, error) {
+	mw.Lock()
+	defer mw.Unlock()
+	mw.buf = append(mw.buf, p...)
+	n := bytes.LastIndexByte(mw.buf, '\n') + 1
+	if n == 0 {
+		if len(mw.buf) < maxMaskBufferSize {
+			return len(p), nil
+		}
+		// Secret values split around this point are not masked, which only
+		// happens with lines longer than maxMaskBufferSize.
+		n = len(mw.buf)
+	}
+	if _, err := mw.w.Write(mw.masker.Mask(mw.buf[:n])); err != nil {
+		return 0, err
+	}
+	mw.buf = append(mw.buf[:0], mw.buf[n:]...)
+	return len(p), nil
+}
+
+// Flush writes the buffered output that does not end with a line break, masked.
+func (mw *MaskingWriter) Flush() error {
+	mw.Lock()
+	defer mw.Unlock()
+	if len(mw.buf) == 0 {
+		return nil
+	}
+	_, err := mw.w.Write(mw.masker.Mask(mw.buf))
+	mw.buf = mw.buf[:0]
+	return err
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSecretMaskerMask(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		secrets        []string
+		in             string
+		want           string
+		wantRedactions int64
+	}{{
+		name:    "no secrets",
+		secrets: nil,
+		in:      "password: hunter2",
+		want:    "password: hunter2",
+	}, {
+		name:           "secret",
+		secrets:        []string{"hunter2"},
+		in:             "password: hunter2, again hunter2",
+		want:           "password: ***, again ***",
+		wantRedactions: 2,
+	}, {
+		name:           "trailing line break",
+		secrets:        []string{"hunter2\n"},
+		in:             "password: hunter2",
+		want:           "password: ***",
+		wantRedactions: 1,
+	}, {
+		name:           "longest secret first",
+		secrets:        []string{"hunter", "hunter2"},
+		in:             "hunter2 hunter",
+		want:           "*** ***",
+		wantRedactions: 2,
+	}, {
+		name:           "lines of multi-line secrets",
+		secrets:        []string{"-----BEGIN KEY-----\nMIIEowIBAAKCAQEA\n-----END KEY-----\n"},
+		in:             "key line: MIIEowIBAAKCAQEA",
+		want:           "key line: ***",
+		wantRedactions: 1,
+	}, {
+		name:    "short secrets are not masked",
+		secrets: []string{"1", "yes"},
+		in:      "1 yes",
+		want:    "1 yes",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := NewSecretMasker(tc.secrets...)
+			if got := string(m.Mask([]byte(tc.in))); got != tc.want {
+				t.Errorf("Mask() = %q, want %q", got, tc.want)
+			}
+			if got := m.Redactions(); got != tc.wantRedactions {
+				t.Errorf("Redactions() = %d, want %d", got, tc.wantRedactions)
+			}
+		})
+	}
+}
+
+func TestMaskingWriter(t *testing.T) {
+	m := NewSecretMasker("hunter2")
+	var out bytes.Buffer
+	w := m.NewWriter(&out)
+	// The secret is split across writes.
+	for _, s := range []string{"password: hun", "ter2\nno line ", "break: hunt", "er2"} {
+		if _, err := w.Write([]byte(s)); err != nil {
+			t.Fatalf("Write() = %v", err)
+		}
+	}
+	if got, want := out.String(), "password: ***\n"; got != want {
+		t.Errorf("got %q before flushing, want %q", got, want)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() = %v", err)
+	}
+	if got, want := out.String(), "password: ***\nno line break: ***"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := m.Redactions(); got != 2 {
+		t.Errorf("Redactions() = %d, want 2", got)
+	}
+}
+
+func TestNewSecretMaskerFromDir(t *testing.T) {
+	dir := t.TempDir()
+	// Lay out the directory like a projected volume, with the files linked
+	// through the ..data directory.
+	data := filepath.Join(dir, "..2022_10_01_10_00_00.000000000")
+	if err := os.Mkdir(data, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, value := range map[string]string{"0": "hunter2", "1": "s3cr3t-token\n"} {
+		if err := os.WriteFile(filepath.Join(data, name), []byte(value), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(filepath.Join("..data", name), filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Base(data), filepath.Join(dir, "..data")); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := NewSecretMaskerFromDir(dir)
+	if err != nil {
+		t.Fatalf("NewSecretMaskerFromDir() = %v", err)
+	}
+	if got, want := string(m.Mask([]byte("hunter2 s3cr3t-token"))), "*** ***"; got != want {
+		t.Errorf("Mask() = %q, want %q", got, want)
+	}
+}
+
+func TestNewSecretMaskerFromMissingDir(t *testing.T) {
+	m, err := NewSecretMaskerFromDir(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("NewSecretMaskerFromDir() = %v", err)
+	}
+	if got, want := string(m.Mask([]byte("hunter2"))), "hunter2"; got != want {
+		t.Errorf("Mask() = %q, want %q", got, want)
+	}
+}
@@ -18,6 +18,7 @@ package git
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"os/exec"
@@ -40,7 +41,15 @@ const (
 var sshURLRegexFormat = regexp.MustCompile(`(ssh://[\w\d\.]+|.+@?.+\..+:)(:[\d]+){0,1}/*(.*)`)
 
 func run(logger *zap.SugaredLogger, dir string, args ...string) (string, error) {
+	return runWithEnv(logger, dir, nil, args...)
+}
+
+// runWithEnv runs git like run, with env added to the environment of the process.
+func runWithEnv(logger *zap.SugaredLogger, dir string, env []string, args ...string) (string, error) {
 	c := exec.Command("git", args...)
+	if len(env) > 0 {
+		c.Env = append(os.Environ(), env...)
+	}
 	var output bytes.Buffer
 	c.Stderr = &output
 	c.Stdout = &output
@@ -69,6 +78,22 @@ type FetchSpec struct {
 	HTTPSProxy                string
 	NOProxy                   string
 	SparseCheckoutDirectories string
+	// Filter is the partial clone filter to fetch with, e.g. "blob:none" for a blobless
+	// clone or "tree:0" for a treeless clone. Missing objects are fetched on demand.
+	Filter string
+	// LFS fetches the Git LFS objects of the checked out revision.
+	LFS bool
+	// SubmodulePaths is a comma separated list of submodule paths. When set, only these
+	// submodules are initialized and updated instead of all of them.
+	SubmodulePaths string
+	// ReferenceCache is the path of a directory, typically on a workspace, in which a
+	// reference repository is kept per URL and reused across fetches so that only the
+	// objects missing from the cache are fetched from the remote.
+	ReferenceCache string
+	// DissociateReferenceCache copies the objects borrowed from the reference cache into
+	// the repository once it is fetched, for the repository to be usable where the
+	// reference cache is not available. This defeats most of the cache's savings.
+	DissociateReferenceCache bool
 }
 
 // Fetch fetches the specified git repository at the revision into path, using the refspec to fetch if provided.
@@ -83,6 +108,13 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 	}
 	ensureHomeEnv(logger, homepath)
 	validateGitAuth(logger, pipeline.CredsDir, spec.URL)
+	if spec.ReferenceCache != "" {
+		// The reference cache is written to the alternates of the repository, which must be
+		// absolute, before changing to the directory of the repository.
+		if spec.ReferenceCache, err = filepath.Abs(spec.ReferenceCache); err != nil {
+			return fmt.Errorf("failed to resolve the reference cache path: %w", err)
+		}
+	}
 
 	if spec.Path != "" {
 		if _, err := run(logger, "", "init", spec.Path); err != nil {
@@ -125,22 +157,34 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 		logger.Warnf("Failed to set http.sslVerify in git config: %s", err)
 		return err
 	}
+	if spec.Filter != "" {
+		if err := configPartialClone(logger, "", spec.Filter); err != nil {
+			return err
+		}
+	}
+	if spec.ReferenceCache != "" {
+		if err := useReferenceCache(logger, spec); err != nil {
+			return err
+		}
+	}
 
 	fetchArgs := []string{"fetch"}
-	if spec.Submodules {
+	if spec.Submodules && spec.SubmodulePaths == "" {
 		fetchArgs = append(fetchArgs, "--recurse-submodules=yes")
 	}
 	if spec.Depth > 0 {
 		fetchArgs = append(fetchArgs, fmt.Sprintf("--depth=%d", spec.Depth))
 	}
+	if spec.Filter != "" {
+		fetchArgs = append(fetchArgs, "--filter="+spec.Filter)
+	}
 
 	// Fetch the revision and verify with FETCH_HEAD
-	fetchParam := []string{spec.Revision}
+	fetchParam := fetchParams(spec)
 	checkoutParam := "FETCH_HEAD"
 
 	if spec.Refspec != "" {
 		// if refspec is specified, fetch the refspec and verify with provided revision
-		fetchParam = strings.Split(spec.Refspec, " ")
 		checkoutParam = spec.Revision
 	}
 
@@ -161,9 +205,25 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 		return fmt.Errorf("error parsing %s after fetching refspec %s", checkoutParam, spec.Refspec)
 	}
 
-	if _, err := run(logger, "", "checkout", "-f", checkoutParam); err != nil {
+	if spec.LFS {
+		// Skip the smudge filter on checkout so that LFS objects are fetched in a
+		// single batch by lfsFetch rather than one by one.
+		if _, err := runWithEnv(logger, "", []string{"GIT_LFS_SKIP_SMUDGE=1"}, "checkout", "-f", checkoutParam); err != nil {
+			return err
+		}
+	} else if _, err := run(logger, "", "checkout", "-f", checkoutParam); err != nil {
 		return err
 	}
+	if spec.LFS {
+		if err := lfsFetch(logger, spec.Path); err != nil {
+			return err
+		}
+	}
+	if spec.ReferenceCache != "" && spec.DissociateReferenceCache {
+		if err := dissociateReferenceCache(logger, spec.Path); err != nil {
+			return err
+		}
+	}
 
 	commit, err := ShowCommit(logger, "HEAD", spec.Path)
 	if err != nil {
@@ -174,7 +234,7 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 		return err
 	}
 	logger.Infof("Successfully cloned %s @ %s (%s) in path %s", trimmedURL, commit, ref, spec.Path)
-	if spec.Submodules {
+	if spec.Submodules || spec.SubmodulePaths != "" {
 		if err := submoduleFetch(logger, spec); err != nil {
 			return err
 		}
@@ -182,6 +242,14 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 	return nil
 }
 
+// fetchParams returns the revision to fetch, or the refspecs to fetch if provided.
+func fetchParams(spec FetchSpec) []string {
+	if spec.Refspec != "" {
+		return strings.Split(spec.Refspec, " ")
+	}
+	return []string{spec.Revision}
+}
+
 // ShowCommit calls "git show ..." to get the commit SHA for the given revision
 func ShowCommit(logger *zap.SugaredLogger, revision, path string) (string, error) {
 	output, err := run(logger, path, "show", "-q", "--pretty=format:%H", revision)
@@ -209,13 +277,129 @@ func submoduleFetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 	if spec.Depth > 0 {
 		updateArgs = append(updateArgs, fmt.Sprintf("--depth=%d", spec.Depth))
 	}
+	if spec.Filter != "" {
+		updateArgs = append(updateArgs, "--filter="+spec.Filter)
+	}
+	if spec.SubmodulePaths != "" {
+		updateArgs = append(updateArgs, "--")
+		for _, p := range strings.Split(spec.SubmodulePaths, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				updateArgs = append(updateArgs, p)
+			}
+		}
+	}
 	if _, err := run(logger, "", updateArgs...); err != nil {
 		return err
 	}
+	if spec.LFS {
+		if _, err := run(logger, "", "submodule", "foreach", "--recursive", "git lfs install --local && git lfs pull"); err != nil {
+			return err
+		}
+	}
 	logger.Infof("Successfully initialized and updated submodules in path %s", spec.Path)
 	return nil
 }
 
+// configPartialClone marks origin as a promisor remote of the repository in dir so that
+// the objects left out by filter are lazily fetched from it when they are needed.
+func configPartialClone(logger *zap.SugaredLogger, dir, filter string) error {
+	if _, err := run(logger, dir, "config", "remote.origin.promisor", "true"); err != nil {
+		return err
+	}
+	if _, err := run(logger, dir, "config", "remote.origin.partialclonefilter", filter); err != nil {
+		return err
+	}
+	return nil
+}
+
+// lfsFetch fetches and checks out the Git LFS objects of the current revision. git-lfs uses the
+// same credential helper and SSH configuration as git, so the credentials initialized for the
+// step are reused.
+func lfsFetch(logger *zap.SugaredLogger, path string) error {
+	if _, err := run(logger, path, "lfs", "install", "--local"); err != nil {
+		return fmt.Errorf("failed to install git-lfs hooks: %w", err)
+	}
+	if _, err := run(logger, path, "lfs", "pull", "origin"); err != nil {
+		return fmt.Errorf("failed to fetch git-lfs objects: %w", err)
+	}
+	logger.Infof("Successfully fetched git-lfs objects in path %s", path)
+	return nil
+}
+
+// referenceCacheDir returns the directory of the reference repository for url in cacheDir.
+func referenceCacheDir(cacheDir, url string) string {
+	hashBytes := sha256.Sum256([]byte(url))
+	return filepath.Join(cacheDir, fmt.Sprintf("%x.git", hashBytes[:10]))
+}
+
+// referenceCacheRef returns the ref under which the reference cache keeps what is fetched for
+// param, a revision or a refspec without a destination, so that its objects stay reachable for
+// the repositories borrowing them.
+func referenceCacheRef(param string) string {
+	hashBytes := sha256.Sum256([]byte(param))
+	return fmt.Sprintf("refs/cache/%x", hashBytes[:10])
+}
+
+// useReferenceCache updates the reference repository for the URL of spec in its cache directory,
+// creating it if needed, and borrows its objects through the alternates of the repository being
+// fetched, so that only the objects missing from the cache are fetched from origin. Only the
+// revision or the refspecs of spec are fetched into the cache, with the partial clone filter of
+// spec if any.
+func useReferenceCache(logger *zap.SugaredLogger, spec FetchSpec) error {
+	url := strings.TrimSpace(spec.URL)
+	cache := referenceCacheDir(spec.ReferenceCache, url)
+	if _, err := os.Stat(filepath.Join(cache, "objects")); os.IsNotExist(err) {
+		if _, err := run(logger, "", "init", "--bare", cache); err != nil {
+			return fmt.Errorf("failed to create reference cache %s: %w", cache, err)
+		}
+		if _, err := run(logger, cache, "remote", "add", "origin", url); err != nil {
+			return err
+		}
+		if _, err := run(logger, "", "config", "--add", "--global", "safe.directory", cache); err != nil {
+			return err
+		}
+	}
+	fetchArgs := []string{"fetch", "--force", "--no-tags"}
+	if spec.Filter != "" {
+		if err := configPartialClone(logger, cache, spec.Filter); err != nil {
+			return err
+		}
+		fetchArgs = append(fetchArgs, "--filter="+spec.Filter)
+	}
+	fetchArgs = append(fetchArgs, "origin")
+	for _, param := range fetchParams(spec) {
+		if strings.Contains(param, ":") {
+			fetchArgs = append(fetchArgs, param)
+			continue
+		}
+		param = strings.TrimPrefix(param, "+")
+		fetchArgs = append(fetchArgs, fmt.Sprintf("+%s:%s", param, referenceCacheRef(param)))
+	}
+	if _, err := run(logger, cache, fetchArgs...); err != nil {
+		return fmt.Errorf("failed to update reference cache %s: %w", cache, err)
+	}
+	alternates := filepath.Join(".git", "objects", "info", "alternates")
+	if err := os.WriteFile(alternates, []byte(filepath.Join(cache, "objects")+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", alternates, err)
+	}
+	logger.Infof("Using reference cache %s for %s", cache, url)
+	return nil
+}
+
+// dissociateReferenceCache copies the objects borrowed from the reference cache into the
+// repository in path and stops borrowing them, so that the repository stays usable where
+// the cache is not available.
+func dissociateReferenceCache(logger *zap.SugaredLogger, path string) error {
+	if _, err := run(logger, path, "repack", "-a", "-d", "-q"); err != nil {
+		return fmt.Errorf("failed to copy objects from the reference cache: %w", err)
+	}
+	alternates := filepath.Join(path, ".git", "objects", "info", "alternates")
+	if err := os.Remove(alternates); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", alternates, err)
+	}
+	return nil
+}
+
 // ensureHomeEnv works around an issue where ssh doesn't respect the HOME env variable. If HOME is set and
 // different from the user's detected home directory then symlink .ssh from the home directory to the HOME env
 // var. This way ssh will see the .ssh directory in the user's home directory even though it ignores
@@ -24,6 +24,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
 	"go.uber.org/zap/zaptest/observer"
 )
 
@@ -429,3 +430,185 @@ func checkLogMessage(logMessage string, log *observer.ObservedLogs, logLine int,
 		}
 	}
 }
+
+func TestFetchPartialClone(t *testing.T) {
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+	url := createTempBareGit(t, logger, "v1", "v2")
+	targetPath := t.TempDir()
+
+	if err := Fetch(logger, FetchSpec{
+		URL:      url,
+		Revision: "main",
+		Path:     targetPath,
+		Filter:   "blob:none",
+	}); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	filter, err := run(logger, targetPath, "config", "remote.origin.partialclonefilter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff("blob:none", strings.TrimSpace(filter)); d != "" {
+		t.Errorf("unexpected partial clone filter %s", d)
+	}
+	// The blob of the first revision of the file is not needed for the checkout, so it
+	// must not have been fetched.
+	objects, err := run(logger, targetPath, "rev-list", "--objects", "--all", "--missing=print")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(objects, "\n?") && !strings.HasPrefix(objects, "?") {
+		t.Errorf("expected missing objects in a blobless clone, got:\n%s", objects)
+	}
+	if content, err := os.ReadFile(filepath.Join(targetPath, "file")); err != nil || string(content) != "v2" {
+		t.Errorf("expected file with content v2 to be checked out, got %q, %v", content, err)
+	}
+}
+
+func TestFetchWithReferenceCache(t *testing.T) {
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+	url := createTempBareGit(t, logger, "v1", "v2")
+	// The reference cache is given as a relative path, which must be resolved before it is
+	// written to the alternates of the repositories.
+	parentDir := t.TempDir()
+	if err := os.Chdir(parentDir); err != nil {
+		t.Fatal(err)
+	}
+	cacheDir := filepath.Join(parentDir, "cache")
+
+	// Fetching twice exercises both the creation and the update of the reference cache.
+	for i := 0; i < 2; i++ {
+		targetPath := t.TempDir()
+		if err := os.Chdir(parentDir); err != nil {
+			t.Fatal(err)
+		}
+		if err := Fetch(logger, FetchSpec{
+			URL:            url,
+			Revision:       "main",
+			Path:           targetPath,
+			Depth:          1,
+			ReferenceCache: "cache",
+			Filter:         "blob:none",
+		}); err != nil {
+			t.Fatalf("Fetch() error = %v", err)
+		}
+
+		head, err := ShowCommit(logger, "HEAD", targetPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cached, err := run(logger, referenceCacheDir(cacheDir, url), "rev-parse", referenceCacheRef("main"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := cmp.Diff(head, strings.TrimSpace(cached)); d != "" {
+			t.Errorf("reference cache is not up to date %s", d)
+		}
+		alternates, err := os.ReadFile(filepath.Join(targetPath, ".git", "objects", "info", "alternates"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := cmp.Diff(filepath.Join(referenceCacheDir(cacheDir, url), "objects")+"\n", string(alternates)); d != "" {
+			t.Errorf("unexpected alternates %s", d)
+		}
+	}
+}
+
+func TestFetchWithReferenceCacheDissociated(t *testing.T) {
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+	url := createTempBareGit(t, logger, "v1", "v2")
+	targetPath := t.TempDir()
+
+	if err := Fetch(logger, FetchSpec{
+		URL:                      url,
+		Revision:                 "main",
+		Path:                     targetPath,
+		ReferenceCache:           t.TempDir(),
+		DissociateReferenceCache: true,
+	}); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(targetPath, ".git", "objects", "info", "alternates")); !os.IsNotExist(err) {
+		t.Errorf("expected the repository to be dissociated from the reference cache, got %v", err)
+	}
+	if _, err := run(logger, targetPath, "fsck", "--connectivity-only"); err != nil {
+		t.Errorf("repository is not complete without the reference cache: %v", err)
+	}
+}
+
+func TestFetchSubmodulePaths(t *testing.T) {
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+	// Submodules are added from local paths in this test.
+	if _, err := run(logger, t.TempDir(), "config", "--global", "protocol.file.allow", "always"); err != nil {
+		t.Fatal(err)
+	}
+
+	firstSubmodule := t.TempDir()
+	createTempGit(t, logger, firstSubmodule, "")
+	secondSubmodule := t.TempDir()
+	createTempGit(t, logger, secondSubmodule, "")
+	gitDir := t.TempDir()
+	createTempGit(t, logger, gitDir, "")
+	for name, path := range map[string]string{"first": firstSubmodule, "second": secondSubmodule} {
+		if _, err := run(logger, gitDir, "submodule", "add", path, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := run(logger, gitDir, "commit", "-m", "Add submodules"); err != nil {
+		t.Fatal(err)
+	}
+
+	targetPath := t.TempDir()
+	if err := Fetch(logger, FetchSpec{
+		URL:            gitDir,
+		Revision:       "main",
+		Path:           targetPath,
+		SubmodulePaths: "first",
+	}); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(targetPath, "first", ".git")); err != nil {
+		t.Errorf("expected submodule first to be initialized: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(targetPath, "second", ".git")); !os.IsNotExist(err) {
+		t.Errorf("expected submodule second not to be initialized, got %v", err)
+	}
+}
+
+// createTempBareGit creates a bare repository which allows partial clones, with one commit
+// per given content of a single file, and returns its URL.
+func createTempBareGit(t *testing.T, logger *zap.SugaredLogger, contents ...string) string {
+	t.Helper()
+	workDir := t.TempDir()
+	createTempGit(t, logger, workDir, "")
+	for _, content := range contents {
+		if err := os.WriteFile(filepath.Join(workDir, "file"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := run(logger, workDir, "add", "file"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := run(logger, workDir, "commit", "-m", content); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bareDir := t.TempDir()
+	if _, err := run(logger, "", "init", "--bare", bareDir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(logger, bareDir, "config", "uploadpack.allowFilter", "true"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(logger, workDir, "push", bareDir, "main"); err != nil {
+		t.Fatal(err)
+	}
+	return "file://" + bareDir
+}
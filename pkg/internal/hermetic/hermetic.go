@@ -0,0 +1,127 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hermetic
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/pod"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// tmpVolumeName is the name of the emptyDir volume mounted on /tmp in the steps,
+	// since their root filesystem is read-only
+	tmpVolumeName = "tekton-internal-hermetic-tmp"
+	tmpMountPath  = "/tmp"
+)
+
+// Enabled returns true if the TaskRun is executed in hermetic mode through its
+// executionMode field, which requires the alpha API fields to be enabled. The
+// experimental annotation only makes the entrypoint refuse network access.
+func Enabled(ctx context.Context, tr *v1beta1.TaskRun) bool {
+	return tr.Spec.ExecutionMode == v1beta1.ExecutionModeHermetic && config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields
+}
+
+// NewTransformer returns a pod.Transformer that drops all the capabilities of the
+// steps of hermetic TaskRuns and makes their root filesystem read-only, with a
+// writable /tmp
+func NewTransformer(ctx context.Context, tr *v1beta1.TaskRun) pod.Transformer {
+	return func(p *corev1.Pod) (*corev1.Pod, error) {
+		if !Enabled(ctx, tr) {
+			return p, nil
+		}
+		tmpVolume := false
+		for i, c := range p.Spec.Containers {
+			if !pod.IsContainerStep(c.Name) {
+				continue
+			}
+			if c.SecurityContext == nil {
+				p.Spec.Containers[i].SecurityContext = &corev1.SecurityContext{}
+			}
+			sc := p.Spec.Containers[i].SecurityContext
+			if sc.Capabilities == nil {
+				sc.Capabilities = &corev1.Capabilities{}
+			}
+			// Capabilities added by the step are removed too.
+			sc.Capabilities.Add = nil
+			sc.Capabilities.Drop = []corev1.Capability{"ALL"}
+			readOnly := true
+			sc.ReadOnlyRootFilesystem = &readOnly
+			if !mountsPath(c, tmpMountPath) {
+				p.Spec.Containers[i].VolumeMounts = append(p.Spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+					Name:      tmpVolumeName,
+					MountPath: tmpMountPath,
+				})
+				tmpVolume = true
+			}
+		}
+		if tmpVolume {
+			p.Spec.Volumes = append(p.Spec.Volumes, corev1.Volume{
+				Name:         tmpVolumeName,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			})
+		}
+		return p, nil
+	}
+}
+
+// mountsPath returns true if a volume is already mounted on the path in the container
+func mountsPath(c corev1.Container, path string) bool {
+	for _, vm := range c.VolumeMounts {
+		if filepath.Clean(vm.MountPath) == path {
+			return true
+		}
+	}
+	return false
+}
+
+// NetworkPolicy returns a NetworkPolicy denying all the ingress and egress
+// traffic of the pods of the TaskRun
+func NetworkPolicy(tr *v1beta1.TaskRun) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            NetworkPolicyName(tr),
+			Namespace:       tr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(tr)},
+			Labels: map[string]string{
+				pipeline.TaskRunLabelKey: tr.Name,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					pipeline.TaskRunLabelKey: tr.Name,
+				},
+			},
+			// No ingress or egress rules deny all the traffic
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		},
+	}
+}
+
+// NetworkPolicyName returns the name of the NetworkPolicy of the TaskRun
+func NetworkPolicyName(tr *v1beta1.TaskRun) string {
+	return kmeta.ChildName(tr.Name, "-hermetic")
+}
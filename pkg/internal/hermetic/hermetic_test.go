@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hermetic_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/internal/hermetic"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func alphaContext() context.Context {
+	featureFlags, _ := config.NewFeatureFlagsFromMap(map[string]string{
+		"enable-api-fields": "alpha",
+	})
+	cfg := &config.Config{
+		Defaults: &config.Defaults{
+			DefaultTimeoutMinutes: 60,
+		},
+		FeatureFlags: featureFlags,
+	}
+	return config.ToContext(context.Background(), cfg)
+}
+
+func testPod() *corev1.Pod {
+	return &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "prepare"}},
+			Containers: []corev1.Container{{
+				Name: "step-build",
+			}, {
+				Name: "step-push",
+				SecurityContext: &corev1.SecurityContext{
+					Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"NET_ADMIN"}},
+				},
+			}, {
+				Name: "sidecar-registry",
+			}},
+		},
+	}
+}
+
+func TestNewTransformer(t *testing.T) {
+	readOnly := true
+	hardened := &corev1.SecurityContext{
+		Capabilities:           &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		ReadOnlyRootFilesystem: &readOnly,
+	}
+	tmpMount := []corev1.VolumeMount{{Name: "tekton-internal-hermetic-tmp", MountPath: "/tmp"}}
+	hermeticPod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "prepare"}},
+			Containers: []corev1.Container{{
+				Name:            "step-build",
+				SecurityContext: hardened,
+				VolumeMounts:    tmpMount,
+			}, {
+				Name:            "step-push",
+				SecurityContext: hardened,
+				VolumeMounts:    tmpMount,
+			}, {
+				Name: "sidecar-registry",
+			}},
+			Volumes: []corev1.Volume{{
+				Name:         "tekton-internal-hermetic-tmp",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}},
+		},
+	}
+
+	for _, tc := range []struct {
+		description string
+		ctx         context.Context
+		tr          *v1beta1.TaskRun
+		expected    *corev1.Pod
+	}{{
+		description: "not hermetic",
+		ctx:         alphaContext(),
+		tr:          &v1beta1.TaskRun{},
+		expected:    testPod(),
+	}, {
+		description: "hermetic without alpha",
+		ctx:         context.Background(),
+		tr:          &v1beta1.TaskRun{Spec: v1beta1.TaskRunSpec{ExecutionMode: v1beta1.ExecutionModeHermetic}},
+		expected:    testPod(),
+	}, {
+		description: "hermetic execution mode",
+		ctx:         alphaContext(),
+		tr:          &v1beta1.TaskRun{Spec: v1beta1.TaskRunSpec{ExecutionMode: v1beta1.ExecutionModeHermetic}},
+		expected:    hermeticPod,
+	}, {
+		description: "hermetic annotation",
+		ctx:         alphaContext(),
+		tr: &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			"experimental.tekton.dev/execution-mode": "hermetic",
+		}}},
+		expected: testPod(),
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := hermetic.NewTransformer(tc.ctx, tc.tr)(testPod())
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.expected, got); d != "" {
+				t.Errorf("Pod diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewTransformerTmpMounted(t *testing.T) {
+	tr := &v1beta1.TaskRun{Spec: v1beta1.TaskRunSpec{ExecutionMode: v1beta1.ExecutionModeHermetic}}
+	p := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:         "step-build",
+				VolumeMounts: []corev1.VolumeMount{{Name: "scratch", MountPath: "/tmp/"}},
+			}},
+			Volumes: []corev1.Volume{{Name: "scratch"}},
+		},
+	}
+	got, err := hermetic.NewTransformer(alphaContext(), tr)(p)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The volume the step mounts on /tmp is kept, and no other volume is added.
+	if d := cmp.Diff([]corev1.VolumeMount{{Name: "scratch", MountPath: "/tmp/"}}, got.Spec.Containers[0].VolumeMounts); d != "" {
+		t.Errorf("VolumeMounts diff %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]corev1.Volume{{Name: "scratch"}}, got.Spec.Volumes); d != "" {
+		t.Errorf("Volumes diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestNetworkPolicy(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo"},
+	}
+	got := hermetic.NetworkPolicy(tr)
+	if got.Name != "build-hermetic" || got.Namespace != "foo" {
+		t.Errorf("expected the NetworkPolicy foo/build-hermetic, got %s/%s", got.Namespace, got.Name)
+	}
+	if len(got.OwnerReferences) != 1 || got.OwnerReferences[0].Name != "build" {
+		t.Errorf("expected the NetworkPolicy to be owned by the TaskRun, got %v", got.OwnerReferences)
+	}
+	want := networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{
+			MatchLabels: map[string]string{"tekton.dev/taskRun": "build"},
+		},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+	}
+	if d := cmp.Diff(want, got.Spec); d != "" {
+		t.Errorf("NetworkPolicy spec diff %s", diff.PrintWantGot(d))
+	}
+}
@@ -18,6 +18,7 @@ package resolution
 
 import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -26,4 +27,7 @@ type ResolvedObjectMeta struct {
 	*metav1.ObjectMeta `json:",omitempty"`
 	// ConfigSource identifies where the spec came from.
 	ConfigSource *v1beta1.ConfigSource `json:",omitempty"`
+	// VerificationResult is the result of the trusted resources verification
+	// of the spec, nil if it wasn't verified.
+	VerificationResult *trustedresources.VerificationResult `json:",omitempty"`
 }
@@ -24,8 +24,13 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/credentials"
-	"github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
-	"github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
+	// Register the credential types creds-init can initialize.
+	_ "github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/mavencreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/netrccreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/npmcreds"
+	_ "github.com/tektoncd/pipeline/pkg/credentials/pipcreds"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -45,7 +50,8 @@ var dnsLabel1123Forbidden = regexp.MustCompile("[^a-zA-Z0-9-]+")
 // and VolumeMounts to be given to each Step. Additionally, a list of
 // entrypointer arguments are returned, each with a meaning specific to
 // the credential type it describes: git credentials expect one set of
-// args while docker credentials expect another.
+// args while docker credentials expect another. The credential types
+// considered are those registered with the credentials package.
 //
 // Any errors encountered during this process are returned to the
 // caller. If no matching annotated secrets are found, nil lists with a
@@ -67,7 +73,7 @@ func credsInit(ctx context.Context, serviceAccountName, namespace string, kubecl
 		return nil, nil, nil, err
 	}
 
-	builders := []credentials.Builder{dockercreds.NewBuilder(), gitcreds.NewBuilder()}
+	builders := credentials.Builders()
 
 	var volumeMounts []corev1.VolumeMount
 	var volumes []corev1.Volume
@@ -119,6 +119,45 @@ func TestCredsInit(t *testing.T) {
 			MountPath: "/tekton/creds-secrets/my-creds",
 		}},
 		ctx: context.Background(),
+	}, {
+		desc: "service account has secret annotated for netrc, npm, maven and pip; initialize creds in /tekton/creds",
+		objs: []runtime.Object{
+			&corev1.ServiceAccount{
+				ObjectMeta: metav1.ObjectMeta{Name: serviceAccountName, Namespace: namespace},
+				Secrets: []corev1.ObjectReference{{
+					Name: "my-artifact-creds",
+				}},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-artifact-creds",
+					Namespace: namespace,
+					Annotations: map[string]string{
+						"tekton.dev/netrc-0": "artifacts.example.com",
+						"tekton.dev/npm-0":   "https://npm.example.com",
+						"tekton.dev/maven-0": "releases",
+						"tekton.dev/pip-0":   "https://pypi.example.com/simple",
+					},
+				},
+				Type: "kubernetes.io/basic-auth",
+				Data: map[string][]byte{
+					"username": []byte("foo"),
+					"password": []byte("BestEver"),
+				},
+			},
+		},
+		envVars: []corev1.EnvVar{},
+		wantArgs: []string{
+			"-basic-maven=my-artifact-creds=releases",
+			"-basic-netrc=my-artifact-creds=artifacts.example.com",
+			"-basic-npm=my-artifact-creds=https://npm.example.com",
+			"-basic-pip=my-artifact-creds=https://pypi.example.com/simple",
+		},
+		wantVolumeMounts: []corev1.VolumeMount{{
+			Name:      "tekton-internal-secret-volume-my-artifact-creds-9l9zj",
+			MountPath: "/tekton/creds-secrets/my-artifact-creds",
+		}},
+		ctx: context.Background(),
 	}, {
 		desc: "service account has duplicate dockerconfigjson secret and no HOME env var passed in; initialize creds in /tekton/creds",
 		objs: []runtime.Object{
@@ -143,6 +143,17 @@ func orderContainers(commonExtraEntrypointArgs []string, steps []corev1.Containe
 					}
 					argsForEntrypoint = append(argsForEntrypoint, "-on_error", string(taskSpec.Steps[i].OnError))
 				}
+				if len(taskSpec.Steps[i].WaitForSidecars) > 0 {
+					var readyFiles []string
+					for _, name := range taskSpec.Steps[i].WaitForSidecars {
+						readyFiles = append(readyFiles, sidecarReadyFile(name))
+					}
+					argsForEntrypoint = append(argsForEntrypoint, "-wait_ready_file", strings.Join(readyFiles, ","))
+					if i != 0 || !waitForReadyAnnotation {
+						// Mount the Downward volume to read the readiness of the Sidecars.
+						steps[i].VolumeMounts = append(steps[i].VolumeMounts, downwardMount)
+					}
+				}
 				if taskSpec.Steps[i].Timeout != nil {
 					argsForEntrypoint = append(argsForEntrypoint, "-timeout", taskSpec.Steps[i].Timeout.Duration.String())
 				}
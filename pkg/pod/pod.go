@@ -30,10 +30,12 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/internal/computeresources/tasklevel"
 	"github.com/tektoncd/pipeline/pkg/names"
+	"github.com/tektoncd/pipeline/pkg/spire"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"knative.dev/pkg/changeset"
 	"knative.dev/pkg/kmeta"
 )
@@ -48,6 +50,10 @@ const (
 	// ExecutionModeHermetic indicates hermetic execution mode
 	ExecutionModeHermetic = "hermetic"
 
+	// spireCSIDriver is the name of the SPIFFE CSI driver, which mounts the SPIFFE workload API of the
+	// SPIRE agent of the node in the pods
+	spireCSIDriver = "csi.spiffe.io"
+
 	// deadlineFactor is the factor we multiply the taskrun timeout with to determine the activeDeadlineSeconds of the Pod.
 	// It has to be higher than the timeout (to not be killed before)
 	deadlineFactor = 1.5
@@ -91,6 +97,14 @@ var (
 		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
 	}}
 
+	// spireWorkloadAPIMount lets the entrypoint of the steps get the SVID of the TaskRun from the
+	// SPIRE agent to sign the results, when spire is enabled.
+	spireWorkloadAPIMount = corev1.VolumeMount{
+		Name:      spire.WorkloadAPI,
+		MountPath: spire.VolumeMountPath,
+		ReadOnly:  true,
+	}
+
 	// MaxActiveDeadlineSeconds is a maximum permitted value to be used for a task with no timeout
 	MaxActiveDeadlineSeconds = int64(math.MaxInt32)
 )
@@ -100,6 +114,9 @@ type Builder struct {
 	Images          pipeline.Images
 	KubeClient      kubernetes.Interface
 	EntrypointCache EntrypointCache
+	// NamespaceLister reads the Pod Security Standard enforced in the
+	// namespaces of the TaskRuns to mask their Secrets by default.
+	NamespaceLister corev1listers.NamespaceLister
 }
 
 // Transformer is a function that will transform a Pod. This can be used to mutate
@@ -127,7 +144,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	// Create Volumes and VolumeMounts for any credentials found in annotated
 	// Secrets, along with any arguments needed by Step entrypoints to process
 	// those secrets.
-	credEntrypointArgs, credVolumes, credVolumeMounts, err := credsInit(ctx, taskRun.Spec.ServiceAccountName, taskRun.Namespace, b.KubeClient)
+	commonEntrypointArgs, credVolumes, credVolumeMounts, err := credsInit(ctx, taskRun.Spec.ServiceAccountName, taskRun.Namespace, b.KubeClient)
 	if err != nil {
 		return nil, err
 	}
@@ -183,24 +200,79 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		podTemplate = *taskRun.Spec.PodTemplate
 	}
 
+	// Mount the values of the Secrets each step can read for the entrypoint
+	// to mask them from the logs and results of the step. Steps reading no
+	// Secrets have nothing mounted in the directory and mask nothing.
+	maskSecrets, err := secretMaskingEnabled(ctx, b.NamespaceLister, taskRun)
+	if err != nil {
+		return nil, err
+	}
+	if maskSecrets {
+		podVolumes := append(append(append([]corev1.Volume{}, volumes...), taskSpec.Volumes...), podTemplate.Volumes...)
+		secretMasks, masked, err := secretMasksVolume(ctx, b.KubeClient, taskRun.Namespace, stepContainers, volumeMounts, podVolumes)
+		if err != nil {
+			return nil, err
+		}
+		if secretMasks != nil {
+			volumes = append(volumes, *secretMasks)
+			for i := range stepContainers {
+				if !masked[i] {
+					continue
+				}
+				stepContainers[i].VolumeMounts = append(stepContainers[i].VolumeMounts, corev1.VolumeMount{
+					Name:      secretMasksVolumeName,
+					MountPath: secretMasksDir,
+					SubPath:   secretMasksStepDir(i),
+					ReadOnly:  true,
+				})
+			}
+			commonEntrypointArgs = append(commonEntrypointArgs, "-secret_mask_dir", secretMasksDir)
+		}
+	}
+
+	// Let the entrypoint sign the results of the steps with the SVID of the TaskRun.
+	if featureFlags.EnableSpire {
+		volumes = append(volumes, spireWorkloadAPIVolume())
+		for i := range stepContainers {
+			stepContainers[i].VolumeMounts = append(stepContainers[i].VolumeMounts, spireWorkloadAPIMount)
+		}
+		commonEntrypointArgs = append(commonEntrypointArgs, "-enable_spire")
+	}
+
 	// Resolve entrypoint for any steps that don't specify command.
 	stepContainers, err = resolveEntrypoints(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, podTemplate.ImagePullSecrets, stepContainers)
 	if err != nil {
 		return nil, err
 	}
 
-	readyImmediately := isPodReadyImmediately(*featureFlags, taskSpec.Sidecars)
+	// Steps waiting for specific Sidecars replace the first Step waiting for
+	// all the Sidecars.
+	waitForSidecars := alphaAPIEnabled && stepsWaitForSidecars(taskSpec.Steps)
+	startSidecarsInOrder := alphaAPIEnabled && sidecarsStartInOrder(sidecars)
+	readyImmediately := waitForSidecars || isPodReadyImmediately(*featureFlags, taskSpec.Sidecars)
 
 	if alphaAPIEnabled {
-		stepContainers, err = orderContainers(credEntrypointArgs, stepContainers, &taskSpec, taskRun.Spec.Debug, !readyImmediately)
+		stepContainers, err = orderContainers(commonEntrypointArgs, stepContainers, &taskSpec, taskRun.Spec.Debug, !readyImmediately)
 	} else {
-		stepContainers, err = orderContainers(credEntrypointArgs, stepContainers, &taskSpec, nil, !readyImmediately)
+		stepContainers, err = orderContainers(commonEntrypointArgs, stepContainers, &taskSpec, nil, !readyImmediately)
 	}
 	if err != nil {
 		return nil, err
 	}
+	if startSidecarsInOrder {
+		// Resolve entrypoint for any sidecars that don't specify command,
+		// so that they can be started in order.
+		sidecarContainers, err = resolveEntrypoints(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, podTemplate.ImagePullSecrets, sidecarContainers)
+		if err != nil {
+			return nil, err
+		}
+		sidecarContainers = orderSidecars(sidecarContainers, sidecars)
+		volumes = append(volumes, sidecarRunVolume)
+	}
 	volumes = append(volumes, binVolume)
-	if !readyImmediately {
+	if waitForSidecars || startSidecarsInOrder {
+		volumes = append(volumes, sidecarDownwardVolume(sidecars))
+	} else if !readyImmediately {
 		volumes = append(volumes, downwardVolume)
 	}
 
@@ -215,7 +287,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	}
 
 	// Add env var if hermetic execution was requested & if the alpha API is enabled
-	if taskRun.Annotations[ExecutionModeAnnotation] == ExecutionModeHermetic && alphaAPIEnabled {
+	if IsHermetic(taskRun) && alphaAPIEnabled {
 		for i, s := range stepContainers {
 			// Add it at the end so it overrides
 			env := append(s.Env, corev1.EnvVar{Name: TektonHermeticEnvVar, Value: "1"}) //nolint
@@ -299,6 +371,11 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	if readyImmediately {
 		podAnnotations[readyAnnotation] = readyAnnotationValue
 	}
+	if waitForSidecars || startSidecarsInOrder {
+		for k, v := range sidecarReadyAnnotations(sidecars) {
+			podAnnotations[k] = v
+		}
+	}
 
 	// calculate the activeDeadlineSeconds based on the specified timeout (uses default timeout if it's not specified)
 	activeDeadlineSeconds := int64(taskRun.GetTimeout(ctx).Seconds() * deadlineFactor)
@@ -352,6 +429,8 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		},
 	}
 
+	applySecurityProfile(config.FromContextOrDefaults(ctx).Defaults.DefaultSecurityProfile, newPod)
+
 	for _, f := range transformers {
 		newPod, err = f(newPod)
 		if err != nil {
@@ -362,6 +441,12 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	return newPod, nil
 }
 
+// IsHermetic returns true if the TaskRun requests the hermetic execution mode,
+// either through its spec or through the experimental annotation.
+func IsHermetic(tr *v1beta1.TaskRun) bool {
+	return tr.Spec.ExecutionMode == v1beta1.ExecutionModeHermetic || tr.Annotations[ExecutionModeAnnotation] == ExecutionModeHermetic
+}
+
 // makeLabels constructs the labels we will propagate from TaskRuns to Pods.
 func makeLabels(s *v1beta1.TaskRun) map[string]string {
 	labels := make(map[string]string, len(s.ObjectMeta.Labels)+1)
@@ -435,3 +520,15 @@ func entrypointInitContainer(image string, steps []v1beta1.Step) corev1.Containe
 	}
 	return prepareInitContainer
 }
+
+// spireWorkloadAPIVolume returns the volume of the SPIFFE workload API of the SPIRE agent of the node.
+func spireWorkloadAPIVolume() corev1.Volume {
+	readOnly := true
+	return corev1.Volume{
+		Name: spire.WorkloadAPI,
+		VolumeSource: corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{
+			Driver:   spireCSIDriver,
+			ReadOnly: &readOnly,
+		}},
+	}
+}
@@ -1556,6 +1556,103 @@ _EOF_
 				}),
 				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 			},
+		}, {
+			desc:         "spire enabled",
+			featureFlags: map[string]string{"enable-spire": "true"},
+			ts: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-enable_spire",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}, {
+						Name:      "spiffe-workload-api",
+						MountPath: "/spiffe-workload-api",
+						ReadOnly:  true,
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+				}},
+				Volumes: append(implicitVolumes, spireWorkloadAPIVolume(), binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
+		}, {
+			desc:         "hermetic execution mode",
+			featureFlags: map[string]string{"enable-api-fields": "alpha"},
+			ts: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Name:    "name",
+					Image:   "image",
+					Command: []string{"cmd"}, // avoid entrypoint lookup.
+				}},
+			},
+			trs: v1beta1.TaskRunSpec{
+				ExecutionMode: v1beta1.ExecutionModeHermetic,
+			},
+			want: &corev1.PodSpec{
+				RestartPolicy:  corev1.RestartPolicyNever,
+				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+				Containers: []corev1.Container{{
+					Name:    "step-name",
+					Image:   "image",
+					Command: []string{"/tekton/bin/entrypoint"},
+					Args: []string{
+						"-wait_file",
+						"/tekton/downward/ready",
+						"-wait_file_content",
+						"-post_file",
+						"/tekton/run/0/out",
+						"-termination_path",
+						"/tekton/termination",
+						"-step_metadata_dir",
+						"/tekton/run/0/status",
+						"-entrypoint",
+						"cmd",
+						"--",
+					},
+					VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+						Name:      "tekton-creds-init-home-0",
+						MountPath: "/tekton/creds",
+					}}, implicitVolumeMounts...),
+					TerminationMessagePath: "/tekton/termination",
+					Env: []corev1.EnvVar{
+						{Name: "TEKTON_HERMETIC", Value: "1"},
+					},
+				}},
+				Volumes: append(implicitVolumes, binVolume, runVolume(0), downwardVolume, corev1.Volume{
+					Name:         "tekton-creds-init-home-0",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+				}),
+				ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+			},
 		}, {
 			desc:         "override hermetic env var",
 			featureFlags: map[string]string{"enable-api-fields": "alpha"},
@@ -1992,6 +2089,109 @@ debug-fail-continue-heredoc-randomly-generated-mz4c7
 			}),
 			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 		},
+	}, {
+		desc: "sidecars started in order and waited for by steps",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:            "name",
+				Image:           "image",
+				Command:         []string{"cmd"}, // avoid entrypoint lookup.
+				WaitForSidecars: []string{"db"},
+			}},
+			Sidecars: []v1beta1.Sidecar{{
+				Name:    "db",
+				Image:   "db-image",
+				Command: []string{"db"},
+			}, {
+				Name:       "app",
+				Image:      "app-image",
+				Command:    []string{"serve", "--port"},
+				Args:       []string{"8080"},
+				StartOrder: 1,
+			}},
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-post_file",
+					"/tekton/run/0/out",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/run/0/status",
+					"-wait_ready_file",
+					"/tekton/downward/sidecar-db",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				VolumeMounts: append([]corev1.VolumeMount{binROMount, runMount(0, false), downwardMount, {
+					Name:      "tekton-creds-init-home-0",
+					MountPath: "/tekton/creds",
+				}}, implicitVolumeMounts...),
+				TerminationMessagePath: "/tekton/termination",
+			}, {
+				Name:    "sidecar-db",
+				Image:   "db-image",
+				Command: []string{"db"},
+			}, {
+				Name:    "sidecar-app",
+				Image:   "app-image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-wait_ready_file",
+					"/tekton/downward/sidecar-db",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/sidecar-run/app",
+					"-entrypoint",
+					"serve",
+					"--",
+					"--port",
+					"8080",
+				},
+				VolumeMounts:           []corev1.VolumeMount{binROMount, downwardMount, sidecarRunMount},
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, sidecarRunVolume, binVolume, runVolume(0), corev1.Volume{
+				Name: downwardVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					DownwardAPI: &corev1.DownwardAPIVolumeSource{
+						Items: []corev1.DownwardAPIVolumeFile{{
+							Path: "ready",
+							FieldRef: &corev1.ObjectFieldSelector{
+								FieldPath: "metadata.annotations['tekton.dev/ready']",
+							},
+						}, {
+							Path: "sidecar-db",
+							FieldRef: &corev1.ObjectFieldSelector{
+								FieldPath: "metadata.annotations['sidecar-ready.tekton.dev/db']",
+							},
+						}, {
+							Path: "sidecar-app",
+							FieldRef: &corev1.ObjectFieldSelector{
+								FieldPath: "metadata.annotations['sidecar-ready.tekton.dev/app']",
+							},
+						}},
+					},
+				},
+			}, corev1.Volume{
+				Name:         "tekton-creds-init-home-0",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}),
+			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+		},
+		wantAnnotations: map[string]string{
+			"tekton.dev/ready":             "READY",
+			"sidecar-ready.tekton.dev/db":  "",
+			"sidecar-ready.tekton.dev/app": "",
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			featureFlags := map[string]string{
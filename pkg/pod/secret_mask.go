@@ -0,0 +1,215 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+const (
+	// PodSecurityEnforceLabel is the label of the namespaces setting the Pod Security
+	// Standard enforced in them. The Secrets of the TaskRuns are masked by default in
+	// the namespaces enforcing the "restricted" Pod Security Standard.
+	PodSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+
+	secretMasksVolumeName = "tekton-internal-secret-masks"
+	secretMasksDir        = "/tekton/secret-masks"
+)
+
+// secretMaskingEnabled returns true if the values of the Secrets used by the steps of the
+// TaskRun are to be masked from their logs and results. Unless the TaskRun sets maskSecrets,
+// they are masked in the namespaces enforcing the "restricted" Pod Security Standard, which
+// are read from the namespace lister.
+func secretMaskingEnabled(ctx context.Context, namespaceLister corev1listers.NamespaceLister, tr *v1beta1.TaskRun) (bool, error) {
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields != config.AlphaAPIFields {
+		return false, nil
+	}
+	if tr.Spec.MaskSecrets != nil {
+		return *tr.Spec.MaskSecrets, nil
+	}
+	if namespaceLister == nil {
+		return false, nil
+	}
+	ns, err := namespaceLister.Get(tr.Namespace)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return ns.Labels[PodSecurityEnforceLabel] == pod.SecurityProfileRestricted, nil
+}
+
+// secretMasksStepDir is the directory of the secret masks volume holding the values of
+// the Secrets of the step at index i.
+func secretMasksStepDir(i int) string {
+	return fmt.Sprintf("step-%d", i)
+}
+
+// secretMasksVolume returns a projected volume of the values of the Secrets each step can
+// read: the Secrets referenced by its env and the Secrets of the volumes it mounts, which
+// include the Secrets bound to workspaces and the creds-init Secrets. The implicit volume
+// mounts are the mounts added to every step which does not mount its own volume at their
+// path. The values of the Secrets of the step at index i are projected to their own files
+// under secretMasksStepDir(i), so that a step mounting its directory only sees the values
+// of the Secrets it can already read. It returns nil if no Secrets are referenced, along
+// with whether each step references Secrets.
+func secretMasksVolume(ctx context.Context, kubeclient kubernetes.Interface, namespace string, steps []corev1.Container, implicitMounts []corev1.VolumeMount, volumes []corev1.Volume) (*corev1.Volume, []bool, error) {
+	volumesByName := map[string]corev1.Volume{}
+	for _, v := range volumes {
+		volumesByName[v.Name] = v
+	}
+
+	// The Secrets may be optional, so the projections are optional too: the pod
+	// starts even when a Secret or one of its keys is missing.
+	optional := true
+	secrets := map[string]*corev1.Secret{}
+	var sources []corev1.VolumeProjection
+	masked := make([]bool, len(steps))
+	for i, s := range steps {
+		refs := secretReferences{keys: map[string]map[string]bool{}, allKeys: map[string]bool{}}
+		for _, e := range s.Env {
+			if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil {
+				refs.add(e.ValueFrom.SecretKeyRef.Name, e.ValueFrom.SecretKeyRef.Key)
+			}
+		}
+		for _, e := range s.EnvFrom {
+			if e.SecretRef != nil {
+				refs.add(e.SecretRef.Name)
+			}
+		}
+		requestedMountPaths := map[string]bool{}
+		mounts := append([]corev1.VolumeMount{}, s.VolumeMounts...)
+		for _, vm := range s.VolumeMounts {
+			requestedMountPaths[filepath.Clean(vm.MountPath)] = true
+		}
+		for _, vm := range implicitMounts {
+			if !requestedMountPaths[filepath.Clean(vm.MountPath)] {
+				mounts = append(mounts, vm)
+			}
+		}
+		for _, vm := range mounts {
+			v, ok := volumesByName[vm.Name]
+			if !ok {
+				continue
+			}
+			if v.Secret != nil {
+				refs.add(v.Secret.SecretName, secretKeys(v.Secret.Items)...)
+			}
+			if v.Projected != nil {
+				for _, ps := range v.Projected.Sources {
+					if ps.Secret != nil {
+						refs.add(ps.Secret.Name, secretKeys(ps.Secret.Items)...)
+					}
+				}
+			}
+		}
+
+		path := 0
+		for _, name := range refs.names {
+			keys := refs.keys[name]
+			if refs.allKeys[name] {
+				secret, ok := secrets[name]
+				if !ok {
+					var err error
+					secret, err = kubeclient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+					if errors.IsNotFound(err) {
+						secret = nil
+					} else if err != nil {
+						return nil, nil, err
+					}
+					secrets[name] = secret
+				}
+				if secret == nil {
+					continue
+				}
+				for k := range secret.Data {
+					keys[k] = true
+				}
+			}
+			sorted := make([]string, 0, len(keys))
+			for k := range keys {
+				sorted = append(sorted, k)
+			}
+			sort.Strings(sorted)
+			projection := &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: name},
+				Optional:             &optional,
+			}
+			for _, k := range sorted {
+				projection.Items = append(projection.Items, corev1.KeyToPath{Key: k, Path: filepath.Join(secretMasksStepDir(i), strconv.Itoa(path))})
+				path++
+			}
+			if len(projection.Items) > 0 {
+				sources = append(sources, corev1.VolumeProjection{Secret: projection})
+				masked[i] = true
+			}
+		}
+	}
+	if len(sources) == 0 {
+		return nil, masked, nil
+	}
+	return &corev1.Volume{
+		Name:         secretMasksVolumeName,
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: sources}},
+	}, masked, nil
+}
+
+// secretReferences are the keys of the Secrets referenced by a step, in the order the
+// Secrets are first referenced.
+type secretReferences struct {
+	names   []string
+	keys    map[string]map[string]bool
+	allKeys map[string]bool
+}
+
+// add references the keys of the Secret, or all its keys if none are given.
+func (r *secretReferences) add(name string, keys ...string) {
+	if name == "" {
+		return
+	}
+	if _, ok := r.keys[name]; !ok {
+		r.names = append(r.names, name)
+		r.keys[name] = map[string]bool{}
+	}
+	if len(keys) == 0 {
+		r.allKeys[name] = true
+	}
+	for _, k := range keys {
+		r.keys[name][k] = true
+	}
+}
+
+func secretKeys(items []corev1.KeyToPath) []string {
+	keys := make([]string, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.Key)
+	}
+	return keys
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestSecretMaskingEnabled(t *testing.T) {
+	falseVal, trueVal := false, true
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "restricted", Labels: map[string]string{PodSecurityEnforceLabel: "restricted"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "baseline", Labels: map[string]string{PodSecurityEnforceLabel: "baseline"}}},
+	} {
+		if err := indexer.Add(ns); err != nil {
+			t.Fatal(err)
+		}
+	}
+	namespaceLister := corev1listers.NewNamespaceLister(indexer)
+	for _, c := range []struct {
+		desc        string
+		alpha       bool
+		namespace   string
+		maskSecrets *bool
+		want        bool
+	}{{
+		desc:        "alpha API fields disabled",
+		namespace:   "restricted",
+		maskSecrets: &trueVal,
+		want:        false,
+	}, {
+		desc:        "opted in",
+		alpha:       true,
+		namespace:   "baseline",
+		maskSecrets: &trueVal,
+		want:        true,
+	}, {
+		desc:        "opted out in a restricted namespace",
+		alpha:       true,
+		namespace:   "restricted",
+		maskSecrets: &falseVal,
+		want:        false,
+	}, {
+		desc:      "restricted namespace",
+		alpha:     true,
+		namespace: "restricted",
+		want:      true,
+	}, {
+		desc:      "baseline namespace",
+		alpha:     true,
+		namespace: "baseline",
+		want:      false,
+	}, {
+		desc:      "namespace not found",
+		alpha:     true,
+		namespace: "missing",
+		want:      false,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			ctx := context.Background()
+			if c.alpha {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			}
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: c.namespace},
+				Spec:       v1beta1.TaskRunSpec{MaskSecrets: c.maskSecrets},
+			}
+			got, err := secretMaskingEnabled(ctx, namespaceLister, tr)
+			if err != nil {
+				t.Fatalf("secretMaskingEnabled() = %v", err)
+			}
+			if got != c.want {
+				t.Errorf("secretMaskingEnabled() = %t, want %t", got, c.want)
+			}
+		})
+	}
+}
+
+func TestSecretMasksVolume(t *testing.T) {
+	optional := true
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "default"},
+			Data:       map[string][]byte{"username": []byte("foo"), "password": []byte("bar")},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-ssh", Namespace: "default"},
+			Data:       map[string][]byte{"ssh-privatekey": []byte("key"), "known_hosts": []byte("hosts")},
+		},
+	)
+	steps := []corev1.Container{{
+		Name: "step-build",
+		Env: []corev1.EnvVar{{
+			Name:  "PLAIN",
+			Value: "value",
+		}, {
+			Name: "TOKEN",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			}},
+		}},
+		VolumeMounts: []corev1.VolumeMount{{Name: "ws-ssh", MountPath: "/workspace/ssh"}},
+	}, {
+		Name: "step-push",
+		EnvFrom: []corev1.EnvFromSource{{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "registry"}},
+		}},
+		VolumeMounts: []corev1.VolumeMount{{Name: "tekton-internal-workspace", MountPath: "/tekton/creds-secrets/api"}},
+	}, {
+		Name:         "step-test",
+		VolumeMounts: []corev1.VolumeMount{{Name: "tekton-internal-workspace", MountPath: "/tekton/creds-secrets/api"}},
+	}}
+	implicitMounts := []corev1.VolumeMount{{
+		Name:      "ws-api",
+		MountPath: "/tekton/creds-secrets/api",
+	}, {
+		Name:      "ws-missing",
+		MountPath: "/tekton/creds-secrets/missing",
+	}}
+	volumes := []corev1.Volume{{
+		Name:         "tekton-internal-workspace",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}, {
+		Name: "ws-ssh",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			SecretName: "git-ssh",
+		}},
+	}, {
+		Name: "ws-api",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			SecretName: "api",
+			Items:      []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}, {Key: "token", Path: "token"}},
+		}},
+	}, {
+		Name: "ws-missing",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			SecretName: "missing",
+		}},
+	}, {
+		Name: "ws-unmounted",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+			SecretName: "unmounted",
+			Items:      []corev1.KeyToPath{{Key: "password", Path: "password"}},
+		}},
+	}}
+
+	// The first step reads the api Secret through its env and the implicit
+	// mount, and the git-ssh Secret through its own mount. The second step
+	// mounts its own volume at the path of the api Secret, so it only reads
+	// the registry Secret. The last step only mounts the missing Secret, so it
+	// reads no Secrets.
+	want := &corev1.Volume{
+		Name: secretMasksVolumeName,
+		VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Items:                []corev1.KeyToPath{{Key: "ca.crt", Path: "step-0/0"}, {Key: "token", Path: "step-0/1"}},
+				Optional:             &optional,
+			},
+		}, {
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "git-ssh"},
+				Items:                []corev1.KeyToPath{{Key: "known_hosts", Path: "step-0/2"}, {Key: "ssh-privatekey", Path: "step-0/3"}},
+				Optional:             &optional,
+			},
+		}, {
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "registry"},
+				Items:                []corev1.KeyToPath{{Key: "password", Path: "step-1/0"}, {Key: "username", Path: "step-1/1"}},
+				Optional:             &optional,
+			},
+		}}}},
+	}
+
+	got, masked, err := secretMasksVolume(context.Background(), kubeclient, "default", steps, implicitMounts, volumes)
+	if err != nil {
+		t.Fatalf("secretMasksVolume() = %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]bool{true, true, false}, masked); d != "" {
+		t.Errorf("Diff masked steps %s", diff.PrintWantGot(d))
+	}
+
+	if got, _, err := secretMasksVolume(context.Background(), kubeclient, "default", steps[2:], nil, volumes); err != nil || got != nil {
+		t.Errorf("expected no volume without Secrets, got %v, %v", got, err)
+	}
+}
+
+func TestBuildMasksSecrets(t *testing.T) {
+	maskSecrets := true
+	kubeclient := fakek8s.NewSimpleClientset(
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	)
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "default"},
+		Spec:       v1beta1.TaskRunSpec{MaskSecrets: &maskSecrets},
+	}
+	ts := v1beta1.TaskSpec{Steps: []v1beta1.Step{{
+		Name:    "name",
+		Image:   "image",
+		Command: []string{"cmd"},
+		Env: []corev1.EnvVar{{
+			Name: "TOKEN",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "api"},
+				Key:                  "token",
+			}},
+		}},
+	}, {
+		Name:    "unmasked",
+		Image:   "image",
+		Command: []string{"cmd"},
+	}}}
+
+	builder := Builder{Images: images, KubeClient: kubeclient, EntrypointCache: fakeCache{}}
+	got, err := builder.Build(config.EnableAlphaAPIFields(context.Background()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+
+	var volume *corev1.Volume
+	for i, v := range got.Spec.Volumes {
+		if v.Name == secretMasksVolumeName {
+			volume = &got.Spec.Volumes[i]
+		}
+	}
+	if volume == nil || volume.Projected == nil || len(volume.Projected.Sources) != 1 {
+		t.Fatalf("expected a projected volume of the Secrets to mask, got %v", volume)
+	}
+	step := got.Spec.Containers[0]
+	if args := strings.Join(step.Args, " "); !strings.Contains(args, "-secret_mask_dir "+secretMasksDir) {
+		t.Errorf("expected the entrypoint to mask the Secrets, got args %q", args)
+	}
+	mounted := false
+	for _, vm := range step.VolumeMounts {
+		mounted = mounted || (vm.Name == secretMasksVolumeName && vm.MountPath == secretMasksDir && vm.SubPath == secretMasksStepDir(0) && vm.ReadOnly)
+	}
+	if !mounted {
+		t.Errorf("expected the Secrets of the step to be mounted read-only at %s, got %v", secretMasksDir, step.VolumeMounts)
+	}
+	for _, vm := range got.Spec.Containers[1].VolumeMounts {
+		if vm.Name == secretMasksVolumeName {
+			t.Errorf("expected no Secrets to be mounted in a step reading no Secrets, got %v", vm)
+		}
+	}
+}
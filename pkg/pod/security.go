@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nonRootUser is the ID of the nonroot user the containers injected by Tekton
+// run as under the "restricted" security profile, unless the pod template sets
+// the user of the pod.
+const nonRootUser = int64(65532)
+
+// applySecurityProfile makes the containers of the pod comply with the security profile.
+// The containers injected by Tekton, i.e. the init containers, get the security context
+// the profile requires, and the fields the profile requires are defaulted onto the
+// security context of Steps and Sidecars.
+func applySecurityProfile(profile string, p *corev1.Pod) {
+	if profile != pod.SecurityProfileRestricted {
+		return
+	}
+	podRunsAsUser := p.Spec.SecurityContext != nil && p.Spec.SecurityContext.RunAsUser != nil
+	for i, c := range p.Spec.InitContainers {
+		sc := pod.ApplySecurityProfile(profile, c.SecurityContext)
+		if sc.RunAsUser == nil && !podRunsAsUser {
+			runAsUser := nonRootUser
+			sc.RunAsUser = &runAsUser
+		}
+		p.Spec.InitContainers[i].SecurityContext = sc
+	}
+	for i, c := range p.Spec.Containers {
+		p.Spec.Containers[i].SecurityContext = pod.ApplySecurityProfile(profile, c.SecurityContext)
+	}
+}
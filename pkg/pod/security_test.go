@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestApplySecurityProfile(t *testing.T) {
+	falseVal, trueVal := false, true
+	user, podUser := int64(65532), int64(1000)
+	stepUser := int64(2000)
+	restricted := func(runAsUser *int64) *corev1.SecurityContext {
+		return &corev1.SecurityContext{
+			AllowPrivilegeEscalation: &falseVal,
+			RunAsNonRoot:             &trueVal,
+			RunAsUser:                runAsUser,
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}
+	}
+	testPod := func(podSecurityContext *corev1.PodSecurityContext) *corev1.Pod {
+		return &corev1.Pod{Spec: corev1.PodSpec{
+			SecurityContext: podSecurityContext,
+			InitContainers:  []corev1.Container{{Name: "prepare"}},
+			Containers: []corev1.Container{{
+				Name: "step-build",
+			}, {
+				Name:            "step-push",
+				SecurityContext: &corev1.SecurityContext{RunAsUser: &stepUser},
+			}, {
+				Name: "sidecar-registry",
+			}},
+		}}
+	}
+
+	for _, c := range []struct {
+		desc    string
+		profile string
+		pod     *corev1.Pod
+		want    *corev1.Pod
+	}{{
+		desc:    "no profile",
+		profile: "",
+		pod:     testPod(nil),
+		want:    testPod(nil),
+	}, {
+		desc:    "baseline profile",
+		profile: "baseline",
+		pod:     testPod(nil),
+		want:    testPod(nil),
+	}, {
+		desc:    "restricted profile",
+		profile: "restricted",
+		pod:     testPod(nil),
+		want: &corev1.Pod{Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "prepare", SecurityContext: restricted(&user)}},
+			Containers: []corev1.Container{{
+				Name:            "step-build",
+				SecurityContext: restricted(nil),
+			}, {
+				Name:            "step-push",
+				SecurityContext: restricted(&stepUser),
+			}, {
+				Name:            "sidecar-registry",
+				SecurityContext: restricted(nil),
+			}},
+		}},
+	}, {
+		desc:    "restricted profile with a pod user",
+		profile: "restricted",
+		pod:     testPod(&corev1.PodSecurityContext{RunAsUser: &podUser}),
+		want: &corev1.Pod{Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{RunAsUser: &podUser},
+			InitContainers:  []corev1.Container{{Name: "prepare", SecurityContext: restricted(nil)}},
+			Containers: []corev1.Container{{
+				Name:            "step-build",
+				SecurityContext: restricted(nil),
+			}, {
+				Name:            "step-push",
+				SecurityContext: restricted(&stepUser),
+			}, {
+				Name:            "sidecar-registry",
+				SecurityContext: restricted(nil),
+			}},
+		}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			applySecurityProfile(c.profile, c.pod)
+			if d := cmp.Diff(c.want, c.pod); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/names"
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// sidecarReadyAnnotationPrefix prefixes the annotations signaling the
+	// readiness of each Sidecar, projected via the Downward API to the
+	// Steps and Sidecars waiting for it.
+	sidecarReadyAnnotationPrefix = "sidecar-ready.tekton.dev/"
+
+	sidecarRunVolumeName = "tekton-internal-sidecar-run"
+	sidecarRunDir        = "/tekton/sidecar-run"
+)
+
+var (
+	sidecarRunVolume = corev1.Volume{
+		Name:         sidecarRunVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	sidecarRunMount = corev1.VolumeMount{
+		Name:      sidecarRunVolumeName,
+		MountPath: sidecarRunDir,
+	}
+)
+
+// stepsWaitForSidecars returns true if any of the Steps waits for specific
+// Sidecars, instead of the first Step waiting for all the Sidecars.
+func stepsWaitForSidecars(steps []v1beta1.Step) bool {
+	for _, s := range steps {
+		if len(s.WaitForSidecars) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sidecarsStartInOrder returns true if any of the Sidecars has a StartOrder.
+func sidecarsStartInOrder(sidecars []v1beta1.Sidecar) bool {
+	for _, s := range sidecars {
+		if s.StartOrder != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// sidecarReadyFile returns the path of the file projecting the readiness of
+// the Sidecar with the given name.
+func sidecarReadyFile(name string) string {
+	return filepath.Join(downwardMountPoint, sidecarPrefix+name)
+}
+
+// sidecarReadyAnnotations returns the annotations signaling the readiness of
+// each Sidecar, initially empty until UpdateSidecarsReady marks them ready.
+func sidecarReadyAnnotations(sidecars []v1beta1.Sidecar) map[string]string {
+	annotations := map[string]string{}
+	for _, s := range sidecars {
+		if s.Name != "" {
+			annotations[sidecarReadyAnnotationPrefix+s.Name] = ""
+		}
+	}
+	return annotations
+}
+
+// sidecarDownwardVolume returns the Downward volume projecting the ready
+// annotation as well as the readiness annotation of each Sidecar.
+func sidecarDownwardVolume(sidecars []v1beta1.Sidecar) corev1.Volume {
+	v := *downwardVolume.DeepCopy()
+	for _, s := range sidecars {
+		if s.Name == "" {
+			continue
+		}
+		v.DownwardAPI.Items = append(v.DownwardAPI.Items, corev1.DownwardAPIVolumeFile{
+			Path: sidecarPrefix + s.Name,
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fmt.Sprintf("metadata.annotations['%s']", sidecarReadyAnnotationPrefix+s.Name),
+			},
+		})
+	}
+	return v
+}
+
+// orderSidecars returns the specified Sidecar containers, modified so that
+// each Sidecar with a StartOrder starts once the Sidecars with a lower
+// StartOrder are ready, by overriding the entrypoint binary. Sidecars with
+// the lowest StartOrder start immediately and are left untouched.
+//
+// The Sidecar containers match sidecars by index. As for Steps, containers
+// must have Command specified or their image's commands resolved.
+func orderSidecars(sidecarContainers []corev1.Container, sidecars []v1beta1.Sidecar) []corev1.Container {
+	if len(sidecars) == 0 {
+		return sidecarContainers
+	}
+	minOrder := sidecars[0].StartOrder
+	for _, s := range sidecars {
+		if s.StartOrder < minOrder {
+			minOrder = s.StartOrder
+		}
+	}
+
+	for i, s := range sidecars {
+		if s.StartOrder == minOrder {
+			continue
+		}
+		var readyFiles []string
+		for _, o := range sidecars {
+			if o.Name != "" && o.StartOrder < s.StartOrder {
+				readyFiles = append(readyFiles, sidecarReadyFile(o.Name))
+			}
+		}
+		argsForEntrypoint := []string{
+			"-wait_ready_file", strings.Join(readyFiles, ","),
+			"-termination_path", terminationPath,
+			"-step_metadata_dir", filepath.Join(sidecarRunDir, s.Name),
+		}
+
+		cmd, args := sidecarContainers[i].Command, sidecarContainers[i].Args
+		if len(cmd) > 0 {
+			argsForEntrypoint = append(argsForEntrypoint, "-entrypoint", cmd[0])
+		}
+		if len(cmd) > 1 {
+			args = append(cmd[1:], args...)
+		}
+		argsForEntrypoint = append(argsForEntrypoint, "--")
+		argsForEntrypoint = append(argsForEntrypoint, args...)
+
+		sidecarContainers[i].Command = []string{entrypointBinary}
+		sidecarContainers[i].Args = argsForEntrypoint
+		sidecarContainers[i].TerminationMessagePath = terminationPath
+		sidecarContainers[i].VolumeMounts = append(sidecarContainers[i].VolumeMounts, binROMount, downwardMount, sidecarRunMount)
+	}
+	return sidecarContainers
+}
+
+// UpdateSidecarsReady updates the Pod's annotations to signal the Steps and
+// Sidecars waiting for specific Sidecars that those are ready, by projecting
+// the readiness annotation of each Sidecar via the Downward API.
+func UpdateSidecarsReady(ctx context.Context, kubeclient kubernetes.Interface, pod corev1.Pod) error {
+	var patches []jsonpatch.JsonPatchOperation
+	for key, value := range pod.Annotations {
+		// Don't PATCH the annotations already Ready.
+		if !strings.HasPrefix(key, sidecarReadyAnnotationPrefix) || value != "" {
+			continue
+		}
+		containerName := names.SimpleNameGenerator.RestrictLength(sidecarPrefix + strings.TrimPrefix(key, sidecarReadyAnnotationPrefix))
+		for _, s := range pod.Status.ContainerStatuses {
+			if s.Name != containerName {
+				continue
+			}
+			if (s.State.Running != nil && s.Ready) || s.State.Terminated != nil {
+				patches = append(patches, jsonpatch.JsonPatchOperation{
+					Operation: "replace",
+					Path:      "/metadata/annotations/" + strings.Replace(key, "/", "~1", 1),
+					Value:     readyAnnotationValue,
+				})
+			}
+		}
+	}
+	if len(patches) == 0 {
+		return nil
+	}
+	sort.Slice(patches, func(i, j int) bool { return patches[i].Path < patches[j].Path })
+
+	patchBytes, err := json.Marshal(patches)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sidecars ready patch bytes: %w", err)
+	}
+	_, err = kubeclient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+	return err
+}
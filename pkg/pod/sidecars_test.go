@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestOrderSidecars(t *testing.T) {
+	sidecars := []v1beta1.Sidecar{{
+		Name:       "db",
+		StartOrder: 1,
+	}, {
+		Name:       "cache",
+		StartOrder: 1,
+	}, {
+		Name:       "app",
+		StartOrder: 2,
+	}}
+	sidecarContainers := []corev1.Container{{
+		Image:   "db",
+		Command: []string{"db"},
+	}, {
+		Image: "cache",
+		Args:  []string{"--memory"},
+	}, {
+		Image:        "app",
+		Command:      []string{"serve", "--port"},
+		Args:         []string{"8080"},
+		VolumeMounts: []corev1.VolumeMount{volumeMount},
+	}}
+	want := []corev1.Container{{
+		Image:   "db",
+		Command: []string{"db"},
+	}, {
+		Image: "cache",
+		Args:  []string{"--memory"},
+	}, {
+		Image:   "app",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_ready_file", "/tekton/downward/sidecar-db,/tekton/downward/sidecar-cache",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/sidecar-run/app",
+			"-entrypoint", "serve",
+			"--",
+			"--port", "8080",
+		},
+		VolumeMounts:           []corev1.VolumeMount{volumeMount, binROMount, downwardMount, sidecarRunMount},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	got := orderSidecars(sidecarContainers, sidecars)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestUpdateSidecarsReady(t *testing.T) {
+	for _, c := range []struct {
+		desc            string
+		pod             corev1.Pod
+		wantAnnotations map[string]string
+		wantPatch       bool // Whether we expect PATCH to be called on the pod.
+	}{{
+		desc: "Pod without sidecar ready annotations isn't patched",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod",
+				Annotations: map[string]string{
+					"something": "else",
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "sidecar-db",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					Ready: true,
+				}},
+			},
+		},
+		wantAnnotations: map[string]string{
+			"something": "else",
+		},
+		wantPatch: false,
+	}, {
+		desc: "Ready and terminated sidecars have their annotation replaced",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod",
+				Annotations: map[string]string{
+					"sidecar-ready.tekton.dev/db":    "",
+					"sidecar-ready.tekton.dev/init":  "",
+					"sidecar-ready.tekton.dev/cache": "",
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "sidecar-db",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					Ready: true,
+				}, {
+					Name:  "sidecar-init",
+					State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+				}, {
+					Name:  "sidecar-cache",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					Ready: false,
+				}},
+			},
+		},
+		wantAnnotations: map[string]string{
+			"sidecar-ready.tekton.dev/db":    readyAnnotationValue,
+			"sidecar-ready.tekton.dev/init":  readyAnnotationValue,
+			"sidecar-ready.tekton.dev/cache": "",
+		},
+		wantPatch: true,
+	}, {
+		desc: "Sidecars already marked ready aren't patched",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pod",
+				Annotations: map[string]string{
+					"sidecar-ready.tekton.dev/db": readyAnnotationValue,
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  "sidecar-db",
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+					Ready: true,
+				}},
+			},
+		},
+		wantAnnotations: map[string]string{
+			"sidecar-ready.tekton.dev/db": readyAnnotationValue,
+		},
+		wantPatch: false,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			ctx := context.Background()
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			kubeclient := fakek8s.NewSimpleClientset(&c.pod)
+			patchCalled := false
+			kubeclient.PrependReactor("patch", "pods", func(a k8stesting.Action) (bool, runtime.Object, error) {
+				if !c.wantPatch {
+					t.Fatal("Pod was patched unexpectedly")
+				}
+				patchCalled = true
+				return false, nil, nil
+			})
+			if err := UpdateSidecarsReady(ctx, kubeclient, c.pod); err != nil {
+				t.Errorf("UpdateSidecarsReady: %v", err)
+			}
+
+			if c.wantPatch && !patchCalled {
+				t.Fatal("Pod was not patched")
+			}
+
+			got, err := kubeclient.CoreV1().Pods(c.pod.Namespace).Get(ctx, c.pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Errorf("Getting pod %q after update: %v", c.pod.Name, err)
+			} else if d := cmp.Diff(c.wantAnnotations, got.Annotations); d != "" {
+				t.Errorf("Annotations Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
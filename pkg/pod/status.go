@@ -17,6 +17,7 @@ limitations under the License.
 package pod
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -24,10 +25,14 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/pkg/termination"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -49,6 +54,10 @@ const (
 	// a ResourceQuota in the namespace
 	ReasonExceededResourceQuota = "ExceededResourceQuota"
 
+	// ReasonQueued indicates that the TaskRun is waiting in the queue its pod
+	// is admitted from, see config-scheduling
+	ReasonQueued = "Queued"
+
 	// ReasonExceededNodeResources indicates that the TaskRun's pod has failed to start due
 	// to resource constraints on the node
 	ReasonExceededNodeResources = "ExceededNodeResources"
@@ -68,10 +77,17 @@ const (
 	// ReasonExceededNodeResources or isPodHitConfigError
 	ReasonPending = "Pending"
 
+	// ReasonEvicted indicates that the TaskRun failed because its Pod was evicted
+	ReasonEvicted = "Evicted"
+
 	// ReasonResourceVerificationFailed indicates that the task fails the trusted resource verification,
 	// it could be the content has changed, signature is invalid or public key is invalid
 	ReasonResourceVerificationFailed = "ResourceVerificationFailed"
 
+	// ReasonPolicyViolation indicates that the Task spec of the TaskRun violates the
+	// policy of the config-policy ConfigMap
+	ReasonPolicyViolation = "PolicyViolation"
+
 	// timeFormat is RFC3339 with millisecond
 	timeFormat = "2006-01-02T15:04:05.000Z07:00"
 )
@@ -103,8 +119,10 @@ func SidecarsReady(podStatus corev1.PodStatus) bool {
 	return true
 }
 
-// MakeTaskRunStatus returns a TaskRunStatus based on the Pod's status.
-func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev1.Pod) (v1beta1.TaskRunStatus, error) {
+// MakeTaskRunStatus returns a TaskRunStatus based on the Pod's status. When spireAPI is not nil, the
+// results of each step are verified against the signatures of its entrypoint, and the outcome is
+// recorded in the SignedResultsVerified condition.
+func MakeTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev1.Pod, spireAPI spire.ControllerAPIClient) (v1beta1.TaskRunStatus, error) {
 	trs := &tr.Status
 	if trs.GetCondition(apis.ConditionSucceeded) == nil || trs.GetCondition(apis.ConditionSucceeded).Status == corev1.ConditionUnknown {
 		// If the taskRunStatus doesn't exist yet, it's because we just started running
@@ -116,7 +134,7 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 	complete := areStepsComplete(pod) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
 
 	if complete {
-		updateCompletedTaskRunStatus(logger, trs, pod)
+		updateCompletedTaskRunStatus(ctx, logger, trs, pod)
 	} else {
 		updateIncompleteTaskRunStatus(trs, pod)
 	}
@@ -136,7 +154,7 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 	}
 
 	var merr *multierror.Error
-	if err := setTaskRunStatusBasedOnStepStatus(logger, stepStatuses, &tr); err != nil {
+	if err := setTaskRunStatusBasedOnStepStatus(ctx, logger, stepStatuses, &tr, spireAPI); err != nil {
 		merr = multierror.Append(merr, err)
 	}
 
@@ -147,11 +165,13 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 	return *trs, merr.ErrorOrNil()
 }
 
-func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses []corev1.ContainerStatus, tr *v1beta1.TaskRun) *multierror.Error {
+func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredLogger, stepStatuses []corev1.ContainerStatus, tr *v1beta1.TaskRun, spireAPI spire.ControllerAPIClient) *multierror.Error {
 	trs := &tr.Status
 	var merr *multierror.Error
 
 	for _, s := range stepStatuses {
+		var stepResourceUsage *v1beta1.StepResourceUsage
+		var redactions int64
 		if s.State.Terminated != nil && len(s.State.Terminated.Message) != 0 {
 			msg := s.State.Terminated.Message
 
@@ -170,10 +190,24 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 					logger.Errorf("error extracting the exit code of step %q in taskrun %q: %v", s.Name, tr.Name, err)
 					merr = multierror.Append(merr, err)
 				}
+				resourceUsage, err := extractResourceUsageFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the resource usage of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
+				stepResourceUsage = resourceUsage
+				redactions, err = extractRedactionsFromResults(results)
+				if err != nil {
+					logger.Errorf("error extracting the redactions of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
 				taskResults, pipelineResourceResults, filteredResults := filterResultsAndResources(results)
 				if tr.IsSuccessful() {
 					trs.TaskRunResults = append(trs.TaskRunResults, taskResults...)
 					trs.ResourcesResult = append(trs.ResourcesResult, pipelineResourceResults...)
+					if spireAPI != nil && len(taskResults) > 0 {
+						verifyStepResults(ctx, logger, spireAPI, s.Name, results, tr)
+					}
 				}
 				msg, err = createMessageFromResults(filteredResults)
 				if err != nil {
@@ -195,6 +229,8 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 			Name:           trimStepPrefix(s.Name),
 			ContainerName:  s.Name,
 			ImageID:        s.ImageID,
+			ResourceUsage:  stepResourceUsage,
+			Redactions:     redactions,
 		})
 	}
 
@@ -202,10 +238,43 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 
 }
 
+// verifyStepResults verifies the results of a step against the signatures of its entrypoint, made with
+// the SVID of the TaskRun. The SignedResultsVerified condition of the TaskRun stays false once the
+// results of one of its steps failed the verification.
+func verifyStepResults(ctx context.Context, logger *zap.SugaredLogger, spireAPI spire.ControllerAPIClient, stepName string, results []v1beta1.PipelineResourceResult, tr *v1beta1.TaskRun) {
+	conditionType := apis.ConditionType(v1beta1.TaskRunConditionResultsVerified.String())
+	if err := spireAPI.VerifyTaskRunResults(ctx, results, tr); err != nil {
+		logger.Errorf("failed to verify the results of step %q of taskrun %q with spire: %v", stepName, tr.Name, err)
+		tr.Status.SetCondition(&apis.Condition{
+			Type:    conditionType,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.TaskRunReasonsResultsVerificationFailed.String(),
+			Message: fmt.Sprintf("Failed to verify the results of step %q with spire: %v", trimStepPrefix(stepName), err),
+		})
+		return
+	}
+	if tr.Status.GetCondition(conditionType).IsFalse() {
+		return
+	}
+	tr.Status.SetCondition(&apis.Condition{
+		Type:    conditionType,
+		Status:  corev1.ConditionTrue,
+		Reason:  v1beta1.TaskRunReasonResultsVerified.String(),
+		Message: "Successfully verified all spire signed taskrun results",
+	})
+}
+
 func setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses []corev1.ContainerStatus, trs *v1beta1.TaskRunStatus) {
 	for _, s := range sidecarStatuses {
+		state := *s.State.DeepCopy()
+		// Pods never restart their containers, so a Sidecar last terminated
+		// when it was stopped by swapping its image for the nop image. Report
+		// how the Sidecar itself terminated instead of the nop container.
+		if s.LastTerminationState.Terminated != nil {
+			state = corev1.ContainerState{Terminated: s.LastTerminationState.Terminated.DeepCopy()}
+		}
 		trs.Sidecars = append(trs.Sidecars, v1beta1.SidecarState{
-			ContainerState: *s.State.DeepCopy(),
+			ContainerState: state,
 			Name:           TrimSidecarPrefix(s.Name),
 			ContainerName:  s.Name,
 			ImageID:        s.ImageID,
@@ -290,6 +359,53 @@ func extractStartedAtTimeFromResults(results []v1beta1.PipelineResourceResult) (
 	return nil, nil
 }
 
+// extractResourceUsageFromResults returns the resource usage of a step reported by the
+// entrypoint, or nil if it was not reported.
+func extractResourceUsageFromResults(results []v1beta1.PipelineResourceResult) (*v1beta1.StepResourceUsage, error) {
+	var usage *v1beta1.StepResourceUsage
+	for _, result := range results {
+		if result.ResultType != v1beta1.InternalTektonResultType {
+			continue
+		}
+		switch result.Key {
+		case entrypoint.PeakMemoryBytesResultKey:
+			b, err := strconv.ParseInt(result.Value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse int value %q in %s field: %w", result.Value, result.Key, err)
+			}
+			if usage == nil {
+				usage = &v1beta1.StepResourceUsage{}
+			}
+			usage.PeakMemory = resource.NewQuantity(b, resource.BinarySI)
+		case entrypoint.CPUTimeMicrosecondsResultKey:
+			us, err := strconv.ParseInt(result.Value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse int value %q in %s field: %w", result.Value, result.Key, err)
+			}
+			if usage == nil {
+				usage = &v1beta1.StepResourceUsage{}
+			}
+			usage.CPUTime = &metav1.Duration{Duration: time.Duration(us) * time.Microsecond}
+		}
+	}
+	return usage, nil
+}
+
+// extractRedactionsFromResults returns the number of secret values the entrypoint
+// redacted from the logs and results of a step, or 0 if it was not reported.
+func extractRedactionsFromResults(results []v1beta1.PipelineResourceResult) (int64, error) {
+	for _, result := range results {
+		if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "Redactions" {
+			redactions, err := strconv.ParseInt(result.Value, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("could not parse int value %q in Redactions field: %w", result.Value, err)
+			}
+			return redactions, nil
+		}
+	}
+	return 0, nil
+}
+
 func extractExitCodeFromResults(results []v1beta1.PipelineResourceResult) (*int32, error) {
 	for _, result := range results {
 		if result.Key == "ExitCode" {
@@ -305,10 +421,23 @@ func extractExitCodeFromResults(results []v1beta1.PipelineResourceResult) (*int3
 	return nil, nil
 }
 
-func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
+func updateCompletedTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	if DidTaskRunFail(pod) {
 		msg := getFailureMessage(logger, pod)
-		markStatusFailure(trs, v1beta1.TaskRunReasonFailed.String(), msg)
+		switch {
+		// The Evicted reason is only reported with alpha, where evicted TaskRuns can be retried with more memory.
+		case isPodEvicted(pod) && config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields:
+			markStatusFailure(trs, ReasonEvicted, msg)
+		case isPodHermetic(pod):
+			if step, violation, ok := hermeticViolation(logger, pod); ok {
+				markStatusFailure(trs, v1beta1.TaskRunReasonHermeticStepFailed.String(),
+					fmt.Sprintf("%q failed in hermetic execution mode, without network access, capabilities or a writable root filesystem: %s", step, violation))
+				break
+			}
+			markStatusFailure(trs, v1beta1.TaskRunReasonFailed.String(), msg)
+		default:
+			markStatusFailure(trs, v1beta1.TaskRunReasonFailed.String(), msg)
+		}
 	} else {
 		markStatusSuccess(trs)
 	}
@@ -317,6 +446,58 @@ func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRu
 	trs.CompletionTime = &metav1.Time{Time: time.Now()}
 }
 
+// hermeticViolations are the errors raised by the restrictions of the hermetic execution mode
+var hermeticViolations = []string{
+	"read-only file system",
+	"operation not permitted",
+	"network is unreachable",
+	"no such host",
+	"temporary failure in name resolution",
+}
+
+// hermeticViolation returns the name and the error of a failed step of a hermetic pod. The
+// entrypoint records the failure of the command of the step in its termination message; when the
+// container runtime fails to start the step instead, its termination message reports an error
+// raised by the restrictions of the hermetic execution mode.
+func hermeticViolation(logger *zap.SugaredLogger, pod *corev1.Pod) (string, string, bool) {
+	for _, s := range pod.Status.ContainerStatuses {
+		term := s.State.Terminated
+		if !IsContainerStep(s.Name) || term == nil || term.ExitCode == 0 || term.Message == "" {
+			continue
+		}
+		if results, err := termination.ParseMessage(logger, term.Message); err == nil {
+			for _, result := range results {
+				if result.ResultType == v1beta1.InternalTektonResultType && result.Key == "Reason" && result.Value == v1beta1.TaskRunReasonHermeticStepFailed.String() {
+					return s.Name, fmt.Sprintf("exited with code %d", term.ExitCode), true
+				}
+			}
+			continue
+		}
+		msg := strings.ToLower(term.Message)
+		for _, v := range hermeticViolations {
+			if strings.Contains(msg, v) {
+				return s.Name, term.Message, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// isPodHermetic returns true if the steps of the pod are executed in hermetic mode
+func isPodHermetic(pod *corev1.Pod) bool {
+	for _, c := range pod.Spec.Containers {
+		if !IsContainerStep(c.Name) {
+			continue
+		}
+		for _, e := range c.Env {
+			if e.Name == TektonHermeticEnvVar && e.Value == "1" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func updateIncompleteTaskRunStatus(trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	switch pod.Status.Phase {
 	case corev1.PodRunning:
@@ -526,3 +707,25 @@ func sortPodContainerStatuses(podContainerStatuses []corev1.ContainerStatus, pod
 func isOOMKilled(s corev1.ContainerStatus) bool {
 	return s.State.Terminated.Reason == oomKilled
 }
+
+func isPodEvicted(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodFailed && pod.Status.Reason == ReasonEvicted
+}
+
+// IsOOMKilledOrEvicted returns true if the TaskRun failed because one of its
+// Steps was OOM killed or because its Pod was evicted.
+func IsOOMKilledOrEvicted(trs *v1beta1.TaskRunStatus) bool {
+	c := trs.GetCondition(apis.ConditionSucceeded)
+	if !c.IsFalse() {
+		return false
+	}
+	if c.Reason == ReasonEvicted {
+		return true
+	}
+	for _, s := range trs.Steps {
+		if s.Terminated != nil && s.Terminated.Reason == oomKilled {
+			return true
+		}
+	}
+	return false
+}
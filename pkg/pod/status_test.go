@@ -17,15 +17,20 @@ limitations under the License.
 package pod
 
 import (
+	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -80,7 +85,7 @@ func TestSetTaskRunStatusBasedOnStepStatus(t *testing.T) {
 			}
 
 			logger, _ := logging.NewLogger("", "status")
-			merr := setTaskRunStatusBasedOnStepStatus(logger, c.ContainerStatuses, &tr)
+			merr := setTaskRunStatusBasedOnStepStatus(context.Background(), logger, c.ContainerStatuses, &tr, nil)
 			if merr != nil {
 				t.Errorf("setTaskRunStatusBasedOnStepStatus: %s", merr)
 			}
@@ -311,6 +316,67 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "failure-evicted",
+		podStatus: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+		want: v1beta1.TaskRunStatus{
+			// The Evicted reason is only reported with alpha.
+			Status: statusFailure(v1beta1.TaskRunReasonFailed.String(), "The node was low on resource: memory."),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps:    []v1beta1.StepState{},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "failure-hermetic",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod",
+				Namespace: "foo",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "step-install",
+					Env:  []corev1.EnvVar{{Name: TektonHermeticEnvVar, Value: "1"}},
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodFailed,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "step-install",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 100,
+						},
+					},
+					ImageID: "image-id",
+				}},
+			},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusFailure(v1beta1.TaskRunReasonFailed.String(),
+				"\"step-install\" exited with code 100 (image: \"image-id\"); for logs run: kubectl -n foo logs pod -c step-install\n"),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 100,
+						}},
+					Name:          "install",
+					ContainerName: "step-install",
+					ImageID:       "image-id",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc:      "failure-unspecified",
 		podStatus: corev1.PodStatus{Phase: corev1.PodFailed},
@@ -517,6 +583,55 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				}},
 			},
 		},
+	}, {
+		desc: "with-sidecar-stopped",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-running-step",
+				State: corev1.ContainerState{
+					Running: &corev1.ContainerStateRunning{},
+				},
+			}, {
+				Name:    "sidecar-stopped",
+				ImageID: "nop-image-id",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 0,
+						Reason:   "Completed",
+					},
+				},
+				LastTerminationState: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 143,
+						Reason:   "Error",
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusRunning(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Running: &corev1.ContainerStateRunning{},
+					},
+					Name:          "running-step",
+					ContainerName: "step-running-step",
+				}},
+				Sidecars: []v1beta1.SidecarState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 143,
+							Reason:   "Error",
+						},
+					},
+					Name:          "stopped",
+					ImageID:       "nop-image-id",
+					ContainerName: "sidecar-stopped",
+				}},
+			},
+		},
 	}, {
 		desc: "with-sidecar-terminated",
 		podStatus: corev1.PodStatus{
@@ -817,6 +932,63 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "step resource usage",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-pear",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"PeakMemoryBytes","value":"268435456","type":3}, {"key":"CPUTimeMicroseconds","value":"1500000","type":3}]`},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{}},
+					Name:          "pear",
+					ContainerName: "step-pear",
+					ResourceUsage: &v1beta1.StepResourceUsage{
+						PeakMemory: resource.NewQuantity(268435456, resource.BinarySI),
+						CPUTime:    &metav1.Duration{Duration: 1500 * time.Millisecond},
+					},
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "step redactions",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-pear",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"Redactions","value":"3","type":3}]`},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{}},
+					Name:          "pear",
+					ContainerName: "step-pear",
+					Redactions:    3,
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc: "filter internaltektonresult with `type` as string",
 		podStatus: corev1.PodStatus{
@@ -1061,7 +1233,7 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				},
 			}
 			logger, _ := logging.NewLogger("", "status")
-			got, err := MakeTaskRunStatus(logger, tr, &c.pod)
+			got, err := MakeTaskRunStatus(context.Background(), logger, tr, &c.pod, nil)
 			if err != nil {
 				t.Errorf("MakeTaskRunResult: %s", err)
 			}
@@ -1275,7 +1447,7 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 				},
 			}
 			logger, _ := logging.NewLogger("", "status")
-			got, err := MakeTaskRunStatus(logger, tr, &c.pod)
+			got, err := MakeTaskRunStatus(context.Background(), logger, tr, &c.pod, nil)
 			if err != nil {
 				t.Errorf("MakeTaskRunResult: %s", err)
 			}
@@ -1396,7 +1568,7 @@ func TestMakeRunStatusJSONError(t *testing.T) {
 	}
 
 	logger, _ := logging.NewLogger("", "status")
-	gotTr, err := MakeTaskRunStatus(logger, tr, pod)
+	gotTr, err := MakeTaskRunStatus(context.Background(), logger, tr, pod, nil)
 	if err == nil {
 		t.Error("Expected error, got nil")
 	}
@@ -1413,6 +1585,208 @@ func TestMakeRunStatusJSONError(t *testing.T) {
 
 }
 
+func TestMakeTaskRunStatusHermeticViolation(t *testing.T) {
+	runtimeError := &corev1.ContainerStateTerminated{
+		ExitCode: 128,
+		Reason:   "StartError",
+		Message:  "failed to create containerd task: mkdir /var/cache/apt: read-only file system",
+	}
+	entrypointMessage := func(t *testing.T, results ...v1beta1.PipelineResourceResult) *corev1.ContainerStateTerminated {
+		t.Helper()
+		results = append([]v1beta1.PipelineResourceResult{{
+			Key:        "StartedAt",
+			Value:      "2022-01-01T00:00:00.000Z",
+			ResultType: v1beta1.InternalTektonResultType,
+		}}, results...)
+		message, err := json.Marshal(results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &corev1.ContainerStateTerminated{ExitCode: 1, Message: string(message)}
+	}
+	hermeticEnv := []corev1.EnvVar{{Name: TektonHermeticEnvVar, Value: "1"}}
+	for _, tc := range []struct {
+		desc       string
+		env        []corev1.EnvVar
+		terminated func(t *testing.T) *corev1.ContainerStateTerminated
+		wantReason string
+	}{{
+		desc:       "hermetic, runtime error",
+		env:        hermeticEnv,
+		terminated: func(*testing.T) *corev1.ContainerStateTerminated { return runtimeError },
+		wantReason: v1beta1.TaskRunReasonHermeticStepFailed.String(),
+	}, {
+		desc:       "not hermetic, runtime error",
+		terminated: func(*testing.T) *corev1.ContainerStateTerminated { return runtimeError },
+		wantReason: v1beta1.TaskRunReasonFailed.String(),
+	}, {
+		desc: "hermetic, failure recorded by the entrypoint",
+		env:  hermeticEnv,
+		terminated: func(t *testing.T) *corev1.ContainerStateTerminated {
+			return entrypointMessage(t, v1beta1.PipelineResourceResult{
+				Key:        "Reason",
+				Value:      v1beta1.TaskRunReasonHermeticStepFailed.String(),
+				ResultType: v1beta1.InternalTektonResultType,
+			})
+		},
+		wantReason: v1beta1.TaskRunReasonHermeticStepFailed.String(),
+	}, {
+		desc: "hermetic, no failure recorded by the entrypoint",
+		env:  hermeticEnv,
+		terminated: func(t *testing.T) *corev1.ContainerStateTerminated {
+			return entrypointMessage(t)
+		},
+		wantReason: v1beta1.TaskRunReasonFailed.String(),
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "step-install", Env: tc.env}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodFailed,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name:  "step-install",
+						State: corev1.ContainerState{Terminated: tc.terminated(t)},
+					}},
+				},
+			}
+			tr := v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+			logger, _ := logging.NewLogger("", "status")
+			// The message of the container runtime is not a termination message of the entrypoint,
+			// which is reported as an error.
+			got, _ := MakeTaskRunStatus(context.Background(), logger, tr, pod, nil)
+			if reason := got.GetCondition(apis.ConditionSucceeded).GetReason(); reason != tc.wantReason {
+				t.Errorf("expected the reason %s, got %s", tc.wantReason, reason)
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatusEvicted(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Status: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+	}
+	for _, tc := range []struct {
+		name       string
+		ctx        context.Context
+		wantReason string
+	}{{
+		name:       "alpha",
+		ctx:        config.EnableAlphaAPIFields(context.Background()),
+		wantReason: ReasonEvicted,
+	}, {
+		name:       "stable",
+		ctx:        context.Background(),
+		wantReason: v1beta1.TaskRunReasonFailed.String(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(tc.ctx, logger, tr, pod, nil)
+			if err != nil {
+				t.Fatalf("MakeTaskRunStatus() = %v", err)
+			}
+			if reason := got.GetCondition(apis.ConditionSucceeded).GetReason(); reason != tc.wantReason {
+				t.Errorf("expected the reason %s, got %s", tc.wantReason, reason)
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatusSpire(t *testing.T) {
+	tr := v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+	}
+	signedMessage := func(t *testing.T, tamper bool) string {
+		t.Helper()
+		spireClient := &spire.MockClient{}
+		identity := spireClient.GetIdentity(&tr)
+		if err := spireClient.CreateEntries(context.Background(), &tr, nil, 10000); err != nil {
+			t.Fatal(err)
+		}
+		spireClient.SignIdentities = []string{identity}
+		results := []v1beta1.PipelineResourceResult{{
+			Key:        "digest",
+			Value:      "sha256:1234",
+			ResultType: v1beta1.TaskRunResultType,
+		}}
+		signed, err := spireClient.Sign(context.Background(), results)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tamper {
+			results[0].Value = "sha256:5678"
+		}
+		msg, err := json.Marshal(append(results, signed...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(msg)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		spire      bool
+		tamper     bool
+		wantStatus corev1.ConditionStatus
+	}{{
+		name:       "signed results",
+		spire:      true,
+		wantStatus: corev1.ConditionTrue,
+	}, {
+		name:       "tampered results",
+		spire:      true,
+		tamper:     true,
+		wantStatus: corev1.ConditionFalse,
+	}, {
+		name: "spire disabled",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "step-foo"}},
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodSucceeded,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "step-foo",
+						State: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{Message: signedMessage(t, tc.tamper)},
+						},
+					}},
+				},
+			}
+			var spireAPI spire.ControllerAPIClient
+			if tc.spire {
+				spireAPI = &spire.MockClient{}
+			}
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(context.Background(), logger, tr, pod, spireAPI)
+			if err != nil {
+				t.Fatalf("MakeTaskRunStatus() = %v", err)
+			}
+			cond := got.GetCondition(apis.ConditionType(v1beta1.TaskRunConditionResultsVerified.String()))
+			if tc.wantStatus == "" {
+				if cond != nil {
+					t.Errorf("expected no results verification, got %v", cond)
+				}
+				return
+			}
+			if cond == nil || cond.Status != tc.wantStatus {
+				t.Errorf("expected the results verification to be %s, got %v", tc.wantStatus, cond)
+			}
+		})
+	}
+}
+
 func TestSidecarsReady(t *testing.T) {
 	for _, c := range []struct {
 		desc     string
@@ -1586,6 +1960,53 @@ func statusPending(reason, message string) duckv1beta1.Status {
 // status was inserted before user step statuses, which is not a valid ordering.
 // See github issue https://github.com/tektoncd/pipeline/issues/3677 for the full
 // details of the bug.
+func TestIsOOMKilledOrEvicted(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		trs  v1beta1.TaskRunStatus
+		want bool
+	}{{
+		desc: "running",
+		trs: v1beta1.TaskRunStatus{
+			Status: statusRunning(),
+		},
+		want: false,
+	}, {
+		desc: "failed",
+		trs:  v1beta1.TaskRunStatus{Status: statusFailure(v1beta1.TaskRunReasonFailed.String(), "boom")},
+		want: false,
+	}, {
+		desc: "evicted",
+		trs:  v1beta1.TaskRunStatus{Status: statusFailure(ReasonEvicted, "The node was low on resource: memory.")},
+		want: true,
+	}, {
+		desc: "step OOM killed",
+		trs: v1beta1.TaskRunStatus{
+			Status: statusFailure(v1beta1.TaskRunReasonFailed.String(), "OOMKilled"),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					Name: "first",
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+					},
+				}, {
+					Name: "second",
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137},
+					},
+				}},
+			},
+		},
+		want: true,
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			if got := IsOOMKilledOrEvicted(&c.trs); got != c.want {
+				t.Errorf("IsOOMKilledOrEvicted() = %t, want %t", got, c.want)
+			}
+		})
+	}
+}
+
 func TestSortPodContainerStatuses(t *testing.T) {
 	containerNames := []string{
 		"step-create-dir-notification-g2fjb",
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ValidateTaskRun validates the Task spec embedded in a v1beta1 or v1 TaskRun being
+// admitted against the policy. It is a callback of the validation webhook.
+func ValidateTaskRun(ctx context.Context, u *unstructured.Unstructured) error {
+	tr := &v1beta1.TaskRun{}
+	if u.GetAPIVersion() == v1.SchemeGroupVersion.String() {
+		v1tr := &v1.TaskRun{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, v1tr); err != nil {
+			return fmt.Errorf("failed to convert the TaskRun: %w", err)
+		}
+		if err := tr.ConvertFrom(ctx, v1tr); err != nil {
+			return fmt.Errorf("failed to convert the TaskRun: %w", err)
+		}
+	} else if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, tr); err != nil {
+		return fmt.Errorf("failed to convert the TaskRun: %w", err)
+	}
+	if tr.Spec.TaskSpec == nil {
+		return nil
+	}
+	return ValidateUnresolvedTaskSpec(ctx, tr.Namespace, tr.Spec.TaskSpec)
+}
+
+// ValidatePipelineRun validates the Task specs embedded in the Pipeline spec of a v1beta1
+// or v1 PipelineRun being admitted against the policy. It is a callback of the validation
+// webhook.
+func ValidatePipelineRun(ctx context.Context, u *unstructured.Unstructured) error {
+	pr := &v1beta1.PipelineRun{}
+	if u.GetAPIVersion() == v1.SchemeGroupVersion.String() {
+		v1pr := &v1.PipelineRun{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, v1pr); err != nil {
+			return fmt.Errorf("failed to convert the PipelineRun: %w", err)
+		}
+		if err := pr.ConvertFrom(ctx, v1pr); err != nil {
+			return fmt.Errorf("failed to convert the PipelineRun: %w", err)
+		}
+	} else if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, pr); err != nil {
+		return fmt.Errorf("failed to convert the PipelineRun: %w", err)
+	}
+	if pr.Spec.PipelineSpec == nil {
+		return nil
+	}
+	return ValidatePipelineSpec(ctx, pr.Namespace, pr.Spec.PipelineSpec)
+}
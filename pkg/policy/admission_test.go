@@ -0,0 +1,185 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/policy"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func toUnstructured(t *testing.T, s string) *unstructured.Unstructured {
+	t.Helper()
+	u := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal([]byte(s), &u.Object); err != nil {
+		t.Fatalf("Error unmarshalling %s: %v", s, err)
+	}
+	return u
+}
+
+func TestValidateTaskRun(t *testing.T) {
+	ctx := toContext(&config.Policy{AllowedImagePrefixes: []string{"registry.corp/"}})
+	for _, tc := range []struct {
+		name    string
+		taskRun string
+		wantErr string
+	}{{
+		name: "v1beta1",
+		taskRun: `
+apiVersion: tekton.dev/v1beta1
+kind: TaskRun
+metadata:
+  name: build
+  namespace: foo
+spec:
+  taskSpec:
+    steps:
+    - name: build
+      image: docker.io/builder
+`,
+		wantErr: `policy violation: step "build": image "docker.io/builder" does not start with any of the allowed prefixes registry.corp/`,
+	}, {
+		name: "v1",
+		taskRun: `
+apiVersion: tekton.dev/v1
+kind: TaskRun
+metadata:
+  name: build
+  namespace: foo
+spec:
+  taskSpec:
+    steps:
+    - name: build
+      image: docker.io/builder
+`,
+		wantErr: `policy violation: step "build": image "docker.io/builder" does not start with any of the allowed prefixes registry.corp/`,
+	}, {
+		name: "image from a parameter",
+		taskRun: `
+apiVersion: tekton.dev/v1beta1
+kind: TaskRun
+metadata:
+  name: build
+  namespace: foo
+spec:
+  params:
+  - name: image
+    value: docker.io/builder
+  taskSpec:
+    params:
+    - name: image
+    steps:
+    - name: build
+      image: $(params.image)
+`,
+	}, {
+		name: "referenced task",
+		taskRun: `
+apiVersion: tekton.dev/v1beta1
+kind: TaskRun
+metadata:
+  name: build
+  namespace: foo
+spec:
+  taskRef:
+    name: build
+`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policy.ValidateTaskRun(ctx, toUnstructured(t, tc.taskRun))
+			checkError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestValidatePipelineRun(t *testing.T) {
+	ctx := toContext(&config.Policy{DisallowPrivileged: true})
+	for _, tc := range []struct {
+		name        string
+		pipelineRun string
+		wantErr     string
+	}{{
+		name: "v1beta1",
+		pipelineRun: `
+apiVersion: tekton.dev/v1beta1
+kind: PipelineRun
+metadata:
+  name: build
+  namespace: foo
+spec:
+  pipelineSpec:
+    tasks:
+    - name: build
+      taskSpec:
+        steps:
+        - name: build
+          image: builder
+          securityContext:
+            privileged: true
+`,
+		wantErr: `policy violation: pipeline task "build": step "build": privileged containers are not allowed`,
+	}, {
+		name: "v1",
+		pipelineRun: `
+apiVersion: tekton.dev/v1
+kind: PipelineRun
+metadata:
+  name: build
+  namespace: foo
+spec:
+  pipelineSpec:
+    tasks:
+    - name: build
+      taskSpec:
+        steps:
+        - name: build
+          image: builder
+    finally:
+    - name: cleanup
+      taskSpec:
+        sidecars:
+        - name: docker
+          image: docker:dind
+          securityContext:
+            privileged: true
+        steps:
+        - name: cleanup
+          image: docker
+`,
+		wantErr: `policy violation: pipeline task "cleanup": sidecar "docker": privileged containers are not allowed`,
+	}, {
+		name: "referenced pipeline",
+		pipelineRun: `
+apiVersion: tekton.dev/v1beta1
+kind: PipelineRun
+metadata:
+  name: build
+  namespace: foo
+spec:
+  pipelineRef:
+    name: build
+`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policy.ValidatePipelineRun(ctx, toUnstructured(t, tc.pipelineRun))
+			checkError(t, err, tc.wantErr)
+		})
+	}
+}
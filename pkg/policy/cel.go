@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+var (
+	celEnvOnce sync.Once
+	celEnv     *cel.Env
+	celEnvErr  error
+
+	// celPrograms caches the programs compiled from the expressions of the rules,
+	// which are compiled once for all the runs.
+	celPrograms sync.Map
+)
+
+// evaluateCELRules returns the violations of the rules by the Task spec. A rule that
+// can't be compiled or evaluated is a violation too, so that broken rules don't let
+// any Task spec through.
+func evaluateCELRules(rules []config.CELRule, namespace string, ts *v1beta1.TaskSpec) []string {
+	if len(rules) == 0 {
+		return nil
+	}
+	taskSpec, err := toMap(ts)
+	if err != nil {
+		return []string{fmt.Sprintf("the task spec could not be converted for the CEL rules: %v", err)}
+	}
+	var violations []string
+	for _, rule := range rules {
+		prg, err := compile(rule.Expression)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("rule %q is invalid: %v", rule.Name, err))
+			continue
+		}
+		out, _, err := prg.Eval(map[string]interface{}{
+			"taskSpec":     taskSpec,
+			"runNamespace": namespace,
+		})
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("rule %q could not be evaluated: %v", rule.Name, err))
+			continue
+		}
+		satisfied, ok := out.Value().(bool)
+		switch {
+		case !ok:
+			violations = append(violations, fmt.Sprintf("rule %q evaluated to %v instead of a bool", rule.Name, out.Value()))
+		case !satisfied && rule.Message != "":
+			violations = append(violations, fmt.Sprintf("rule %q: %s", rule.Name, rule.Message))
+		case !satisfied:
+			violations = append(violations, fmt.Sprintf("rule %q is not satisfied", rule.Name))
+		}
+	}
+	return violations
+}
+
+// compile returns the program of the CEL expression, with the "taskSpec" and
+// "runNamespace" variables declared.
+func compile(expression string) (cel.Program, error) {
+	if prg, ok := celPrograms.Load(expression); ok {
+		return prg.(cel.Program), nil
+	}
+	celEnvOnce.Do(func() {
+		celEnv, celEnvErr = cel.NewEnv(
+			cel.Variable("taskSpec", cel.MapType(cel.StringType, cel.DynType)),
+			cel.Variable("runNamespace", cel.StringType),
+		)
+	})
+	if celEnvErr != nil {
+		return nil, celEnvErr
+	}
+	ast, issues := celEnv.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	prg, err := celEnv.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	celPrograms.Store(expression, prg)
+	return prg, nil
+}
+
+// toMap returns the Task spec as it is serialized, for the CEL rules to use the
+// field names of the Task spec in YAML.
+func toMap(ts *v1beta1.TaskSpec) (map[string]interface{}, error) {
+	b, err := json.Marshal(ts)
+	if err != nil {
+		return nil, err
+	}
+	m := map[string]interface{}{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
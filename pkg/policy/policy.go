@@ -0,0 +1,166 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy evaluates the rules of the config-policy ConfigMap against
+// the Task specs run by TaskRuns and PipelineRuns, when the runs are admitted
+// and once their Tasks and Pipelines are resolved.
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ErrorPolicyViolation is returned when a Task spec violates the policy.
+var ErrorPolicyViolation = errors.New("policy violation")
+
+// ValidateTaskSpec returns an error wrapping ErrorPolicyViolation if the Task spec,
+// with its variables substituted, violates the policy of the context.
+func ValidateTaskSpec(ctx context.Context, namespace string, ts *v1beta1.TaskSpec) error {
+	return validate(ctx, namespace, map[string]*v1beta1.TaskSpec{"": ts}, false)
+}
+
+// ValidateUnresolvedTaskSpec is like ValidateTaskSpec for a Task spec whose variables
+// are not substituted yet. The images referencing variables are not validated, they
+// are validated once the TaskRun substitutes them.
+func ValidateUnresolvedTaskSpec(ctx context.Context, namespace string, ts *v1beta1.TaskSpec) error {
+	return validate(ctx, namespace, map[string]*v1beta1.TaskSpec{"": ts}, true)
+}
+
+// ValidatePipelineSpec is like ValidateUnresolvedTaskSpec for the Task specs embedded
+// in the tasks and finally tasks of the Pipeline spec. The Tasks referenced by the
+// Pipeline are validated once resolved, see ValidateUnresolvedTaskSpecs.
+func ValidatePipelineSpec(ctx context.Context, namespace string, ps *v1beta1.PipelineSpec) error {
+	specs := map[string]*v1beta1.TaskSpec{}
+	for _, pt := range append(append([]v1beta1.PipelineTask{}, ps.Tasks...), ps.Finally...) {
+		// Custom tasks embed their spec with their kind, the policy only applies to Tasks.
+		if pt.TaskSpec != nil && pt.TaskSpec.Kind == "" {
+			specs[pt.Name] = &pt.TaskSpec.TaskSpec
+		}
+	}
+	return validate(ctx, namespace, specs, true)
+}
+
+// ValidateUnresolvedTaskSpecs is like ValidateUnresolvedTaskSpec for the Task specs of
+// the PipelineTasks of a Pipeline, by PipelineTask name.
+func ValidateUnresolvedTaskSpecs(ctx context.Context, namespace string, specs map[string]*v1beta1.TaskSpec) error {
+	return validate(ctx, namespace, specs, true)
+}
+
+func validate(ctx context.Context, namespace string, specs map[string]*v1beta1.TaskSpec, skipVariables bool) error {
+	p := config.FromContextOrDefaults(ctx).Policy
+	if p == nil || p.IsEmpty() {
+		return nil
+	}
+	var violations []string
+	for _, pipelineTask := range sortedKeys(specs) {
+		prefix := ""
+		if pipelineTask != "" {
+			prefix = fmt.Sprintf("pipeline task %q: ", pipelineTask)
+		}
+		for _, v := range validateTaskSpec(p, namespace, specs[pipelineTask], skipVariables) {
+			violations = append(violations, prefix+v)
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%w: %s", ErrorPolicyViolation, strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// validateTaskSpec returns the violations of the policy by the containers of the Steps
+// and Sidecars of the Task spec, and by the Task spec itself.
+func validateTaskSpec(p *config.Policy, namespace string, ts *v1beta1.TaskSpec, skipVariables bool) []string {
+	var templateImage string
+	var templateSecurityContext *corev1.SecurityContext
+	if ts.StepTemplate != nil {
+		templateImage = ts.StepTemplate.Image
+		templateSecurityContext = ts.StepTemplate.SecurityContext
+	}
+
+	var violations []string
+	for i, s := range ts.Steps {
+		image, securityContext := s.Image, s.SecurityContext
+		// Steps inherit the fields they don't set from the step template.
+		if image == "" {
+			image = templateImage
+		}
+		if securityContext == nil || securityContext.Privileged == nil {
+			securityContext = templateSecurityContext
+		}
+		for _, v := range validateContainer(p, image, securityContext, skipVariables) {
+			violations = append(violations, fmt.Sprintf("step %s: %s", containerName(s.Name, i), v))
+		}
+	}
+	for i, s := range ts.Sidecars {
+		for _, v := range validateContainer(p, s.Image, s.SecurityContext, skipVariables) {
+			violations = append(violations, fmt.Sprintf("sidecar %s: %s", containerName(s.Name, i), v))
+		}
+	}
+	return append(violations, evaluateCELRules(p.CELRules, namespace, ts)...)
+}
+
+func validateContainer(p *config.Policy, image string, securityContext *corev1.SecurityContext, skipVariables bool) []string {
+	var violations []string
+	if p.DisallowPrivileged && securityContext != nil && securityContext.Privileged != nil && *securityContext.Privileged {
+		violations = append(violations, "privileged containers are not allowed")
+	}
+	if image == "" || (skipVariables && strings.Contains(image, "$(")) {
+		return violations
+	}
+	if len(p.AllowedImagePrefixes) > 0 && !hasAllowedPrefix(image, p.AllowedImagePrefixes) {
+		violations = append(violations, fmt.Sprintf("image %q does not start with any of the allowed prefixes %s", image, strings.Join(p.AllowedImagePrefixes, ", ")))
+	}
+	if p.RequireImageDigest {
+		if _, err := name.NewDigest(image); err != nil {
+			violations = append(violations, fmt.Sprintf("image %q is not pinned by digest", image))
+		}
+	}
+	return violations
+}
+
+func hasAllowedPrefix(image string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(image, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func containerName(name string, i int) string {
+	if name != "" {
+		return fmt.Sprintf("%q", name)
+	}
+	return fmt.Sprintf("%d", i)
+}
+
+func sortedKeys(specs map[string]*v1beta1.TaskSpec) []string {
+	keys := make([]string, 0, len(specs))
+	for k := range specs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/policy"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const digest = "sha256:0d4e5b1f0a6c3c2d5b4e8f7a9c1b2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7a8b9c"
+
+func toContext(p *config.Policy) context.Context {
+	cfg := config.FromContextOrDefaults(context.Background())
+	cfg.Policy = p
+	return config.ToContext(context.Background(), cfg)
+}
+
+func TestValidateTaskSpec(t *testing.T) {
+	privileged := true
+	for _, tc := range []struct {
+		name    string
+		policy  *config.Policy
+		spec    *v1beta1.TaskSpec
+		wantErr string
+	}{{
+		name:   "empty policy",
+		policy: &config.Policy{},
+		spec: &v1beta1.TaskSpec{Steps: []v1beta1.Step{{
+			Image:           "docker.io/builder",
+			SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+		}}},
+	}, {
+		name:   "allowed images",
+		policy: &config.Policy{AllowedImagePrefixes: []string{"registry.corp/"}, RequireImageDigest: true},
+		spec: &v1beta1.TaskSpec{
+			Steps:    []v1beta1.Step{{Name: "build", Image: "registry.corp/builder@" + digest}},
+			Sidecars: []v1beta1.Sidecar{{Name: "cache", Image: "registry.corp/cache:v1@" + digest}},
+		},
+	}, {
+		name:   "image not allowed",
+		policy: &config.Policy{AllowedImagePrefixes: []string{"registry.corp/", "gcr.io/tekton-releases/"}},
+		spec: &v1beta1.TaskSpec{
+			Steps:    []v1beta1.Step{{Name: "build", Image: "registry.corp/builder"}},
+			Sidecars: []v1beta1.Sidecar{{Image: "docker.io/cache"}},
+		},
+		wantErr: `policy violation: sidecar 0: image "docker.io/cache" does not start with any of the allowed prefixes registry.corp/, gcr.io/tekton-releases/`,
+	}, {
+		name:    "image not pinned by digest",
+		policy:  &config.Policy{RequireImageDigest: true},
+		spec:    &v1beta1.TaskSpec{Steps: []v1beta1.Step{{Name: "build", Image: "registry.corp/builder:v1"}}},
+		wantErr: `policy violation: step "build": image "registry.corp/builder:v1" is not pinned by digest`,
+	}, {
+		name:   "image of the step template",
+		policy: &config.Policy{RequireImageDigest: true},
+		spec: &v1beta1.TaskSpec{
+			StepTemplate: &v1beta1.StepTemplate{Image: "registry.corp/builder:v1"},
+			Steps:        []v1beta1.Step{{Name: "build"}, {Name: "test", Image: "registry.corp/tester@" + digest}},
+		},
+		wantErr: `policy violation: step "build": image "registry.corp/builder:v1" is not pinned by digest`,
+	}, {
+		name:    "image with a variable",
+		policy:  &config.Policy{AllowedImagePrefixes: []string{"registry.corp/"}},
+		spec:    &v1beta1.TaskSpec{Steps: []v1beta1.Step{{Name: "build", Image: "$(params.image)"}}},
+		wantErr: `policy violation: step "build": image "$(params.image)" does not start with any of the allowed prefixes registry.corp/`,
+	}, {
+		name:   "privileged step",
+		policy: &config.Policy{DisallowPrivileged: true},
+		spec: &v1beta1.TaskSpec{Steps: []v1beta1.Step{{
+			Name:            "build",
+			Image:           "builder",
+			SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+		}}},
+		wantErr: `policy violation: step "build": privileged containers are not allowed`,
+	}, {
+		name:   "privileged step template",
+		policy: &config.Policy{DisallowPrivileged: true},
+		spec: &v1beta1.TaskSpec{
+			StepTemplate: &v1beta1.StepTemplate{SecurityContext: &corev1.SecurityContext{Privileged: &privileged}},
+			Steps:        []v1beta1.Step{{Name: "build", Image: "builder"}},
+		},
+		wantErr: `policy violation: step "build": privileged containers are not allowed`,
+	}, {
+		name: "CEL rules",
+		policy: &config.Policy{CELRules: []config.CELRule{{
+			Name:       "namespace",
+			Expression: `runNamespace == "foo"`,
+		}, {
+			Name:       "timeout",
+			Expression: `taskSpec.steps.all(s, has(s.timeout))`,
+			Message:    "the steps must have a timeout",
+		}}},
+		spec:    &v1beta1.TaskSpec{Steps: []v1beta1.Step{{Name: "build", Image: "builder"}}},
+		wantErr: `policy violation: rule "timeout": the steps must have a timeout`,
+	}, {
+		name: "invalid CEL rules",
+		policy: &config.Policy{CELRules: []config.CELRule{{
+			Name:       "not-a-bool",
+			Expression: `runNamespace`,
+		}, {
+			Name:       "no-such-field",
+			Expression: `taskSpec.stepTemplate.image == "builder"`,
+		}}},
+		spec:    &v1beta1.TaskSpec{Steps: []v1beta1.Step{{Name: "build", Image: "builder"}}},
+		wantErr: `policy violation: rule "not-a-bool" evaluated to foo instead of a bool; rule "no-such-field" could not be evaluated: no such key: stepTemplate`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policy.ValidateTaskSpec(toContext(tc.policy), "foo", tc.spec)
+			checkError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestValidateUnresolvedTaskSpec(t *testing.T) {
+	ctx := toContext(&config.Policy{AllowedImagePrefixes: []string{"registry.corp/"}, RequireImageDigest: true})
+	spec := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{Name: "build", Image: "$(params.image)"}, {Name: "test", Image: "tester"}},
+	}
+	err := policy.ValidateUnresolvedTaskSpec(ctx, "foo", spec)
+	checkError(t, err, `policy violation: step "test": image "tester" does not start with any of the allowed prefixes registry.corp/; step "test": image "tester" is not pinned by digest`)
+}
+
+func TestValidatePipelineSpec(t *testing.T) {
+	ctx := toContext(&config.Policy{AllowedImagePrefixes: []string{"registry.corp/"}})
+	spec := &v1beta1.PipelineSpec{
+		Tasks: []v1beta1.PipelineTask{{
+			Name:    "referenced",
+			TaskRef: &v1beta1.TaskRef{Name: "build"},
+		}, {
+			Name: "custom",
+			TaskSpec: &v1beta1.EmbeddedTask{
+				TypeMeta: runtime.TypeMeta{APIVersion: "example.dev/v0", Kind: "Example"},
+				Spec:     runtime.RawExtension{Raw: []byte(`{"image":"docker.io/example"}`)},
+			},
+		}, {
+			Name: "build",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Name: "build", Image: "docker.io/builder"}},
+			}},
+		}},
+		Finally: []v1beta1.PipelineTask{{
+			Name: "notify",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Name: "notify", Image: "registry.corp/notify"}},
+			}},
+		}},
+	}
+	err := policy.ValidatePipelineSpec(ctx, "foo", spec)
+	checkError(t, err, `policy violation: pipeline task "build": step "build": image "docker.io/builder" does not start with any of the allowed prefixes registry.corp/`)
+}
+
+func checkError(t *testing.T, err error, wantErr string) {
+	t.Helper()
+	if wantErr == "" {
+		if err != nil {
+			t.Errorf("Expected no error but got %v", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("Expected error %q but got none", wantErr)
+	}
+	if !errors.Is(err, policy.ErrorPolicyViolation) {
+		t.Errorf("Expected error %v to wrap ErrorPolicyViolation", err)
+	}
+	if err.Error() != wantErr {
+		t.Errorf("Expected error %q but got %q", wantErr, err.Error())
+	}
+}
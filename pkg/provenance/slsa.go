@@ -0,0 +1,320 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provenance generates the in-toto SLSA provenance statements of
+// completed TaskRuns and PipelineRuns.
+package provenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// StatementType is the in-toto statement type of the provenance statements
+	StatementType = "https://in-toto.io/Statement/v0.1"
+	// PredicateType is the SLSA provenance predicate type of the provenance statements
+	PredicateType = "https://slsa.dev/provenance/v0.2"
+	// BuilderID identifies the Tekton Pipelines controller as the builder in the provenance statements
+	BuilderID = "https://tekton.dev/pipelines"
+	// TaskRunBuildType is the build type of the provenance statements of TaskRuns
+	TaskRunBuildType = "tekton.dev/v1beta1/TaskRun"
+	// PipelineRunBuildType is the build type of the provenance statements of PipelineRuns
+	PipelineRunBuildType = "tekton.dev/v1beta1/PipelineRun"
+
+	// AnnotationKey is the annotation the provenance statement of a TaskRun or PipelineRun is stored in
+	AnnotationKey = "tekton.dev/slsa-provenance"
+	// MaxAnnotationSize is the maximum size of the provenance statement stored in AnnotationKey,
+	// which keeps the object well within the size limit of the annotations of Kubernetes objects
+	MaxAnnotationSize = 64 * 1024
+
+	// ImageURLResultSuffix is the suffix of the results naming an image built by the run. The image is
+	// a subject of the provenance when a result of the same prefix with ImageDigestResultSuffix holds its digest.
+	ImageURLResultSuffix = "IMAGE_URL"
+	// ImageDigestResultSuffix is the suffix of the results holding the digest of an image built by the run
+	ImageDigestResultSuffix = "IMAGE_DIGEST"
+	// GitURLResultSuffix is the suffix of the results naming a git repository used by the run. The repository
+	// is a material of the provenance when a result of the same prefix with GitCommitResultSuffix holds its commit.
+	GitURLResultSuffix = "GIT_URL"
+	// GitCommitResultSuffix is the suffix of the results holding the commit of a git repository used by the run
+	GitCommitResultSuffix = "GIT_COMMIT"
+
+	// ExecutionModeEnvironmentKey is the key of the invocation environment holding the execution mode of the run
+	ExecutionModeEnvironmentKey = "executionMode"
+)
+
+// Statement is an in-toto statement of the SLSA provenance of a TaskRun or PipelineRun
+type Statement struct {
+	Type          string    `json:"_type"`
+	PredicateType string    `json:"predicateType"`
+	Subject       []Subject `json:"subject"`
+	Predicate     Predicate `json:"predicate"`
+}
+
+// Subject is an artifact produced by the run
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Predicate is the SLSA provenance of the run
+type Predicate struct {
+	Builder    Builder    `json:"builder"`
+	BuildType  string     `json:"buildType"`
+	Invocation Invocation `json:"invocation"`
+	Metadata   Metadata   `json:"metadata"`
+	Materials  []Material `json:"materials,omitempty"`
+}
+
+// Builder identifies the entity that executed the run
+type Builder struct {
+	ID string `json:"id"`
+}
+
+// Invocation identifies the configuration, the parameters and the environment of the run
+type Invocation struct {
+	ConfigSource *v1beta1.ConfigSource `json:"configSource,omitempty"`
+	Parameters   []v1beta1.Param       `json:"parameters,omitempty"`
+	Environment  map[string]string     `json:"environment,omitempty"`
+}
+
+// Metadata identifies the run and holds the times it started and finished
+type Metadata struct {
+	BuildInvocationID string       `json:"buildInvocationId,omitempty"`
+	BuildStartedOn    *metav1.Time `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn   *metav1.Time `json:"buildFinishedOn,omitempty"`
+}
+
+// Material is an artifact the run was built from
+type Material struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// ForTaskRun returns the provenance statement of the TaskRun. Its materials are the source of
+// the Task, the images of the steps and the git repositories named in the results, and its
+// subjects are the images named in the results. The environment records whether the TaskRun
+// ran hermetically.
+func ForTaskRun(tr *v1beta1.TaskRun) *Statement {
+	s := newStatement(TaskRunBuildType, tr.ObjectMeta, tr.Spec.Params, tr.Status.Provenance)
+	if tr.Spec.ExecutionMode == v1beta1.ExecutionModeHermetic {
+		s.Predicate.Invocation.Environment = hermeticEnvironment()
+	}
+	s.Predicate.Metadata.BuildStartedOn = tr.Status.StartTime
+	s.Predicate.Metadata.BuildFinishedOn = tr.Status.CompletionTime
+	s.Predicate.Materials = appendMaterials(s.Predicate.Materials, taskRunMaterials(tr)...)
+	s.Subject = subjects(taskRunResults(tr.Status.TaskRunResults))
+	return s
+}
+
+// ForPipelineRun returns the provenance statement of the PipelineRun. Its materials are the source
+// of the Pipeline and the materials of its TaskRuns, and its subjects are the images named in the
+// results of the PipelineRun. The environment records whether all its TaskRuns ran hermetically.
+func ForPipelineRun(pr *v1beta1.PipelineRun, taskRuns []*v1beta1.TaskRun) *Statement {
+	s := newStatement(PipelineRunBuildType, pr.ObjectMeta, pr.Spec.Params, pr.Status.Provenance)
+	s.Predicate.Metadata.BuildStartedOn = pr.Status.StartTime
+	s.Predicate.Metadata.BuildFinishedOn = pr.Status.CompletionTime
+	hermetic := len(taskRuns) > 0
+	for _, tr := range taskRuns {
+		hermetic = hermetic && tr.Spec.ExecutionMode == v1beta1.ExecutionModeHermetic
+		if tr.Status.Provenance != nil && tr.Status.Provenance.ConfigSource != nil {
+			s.Predicate.Materials = appendMaterials(s.Predicate.Materials, configSourceMaterial(tr.Status.Provenance.ConfigSource)...)
+		}
+		s.Predicate.Materials = appendMaterials(s.Predicate.Materials, taskRunMaterials(tr)...)
+	}
+	s.Subject = subjects(pipelineRunResults(pr.Status.PipelineResults))
+	if hermetic {
+		s.Predicate.Invocation.Environment = hermeticEnvironment()
+	}
+	return s
+}
+
+// StripAnnotations removes the provenance statement and its signature from the annotations. The statement
+// is only trusted in the status annotations of the runs, which users can't write, and is removed from their
+// metadata annotations.
+func StripAnnotations(annotations map[string]string) {
+	for k := range annotations {
+		if strings.HasPrefix(k, AnnotationKey) {
+			delete(annotations, k)
+		}
+	}
+}
+
+// Annotation returns the statement serialized for AnnotationKey, or an error if it exceeds MaxAnnotationSize.
+func (s *Statement) Annotation() (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	if len(b) > MaxAnnotationSize {
+		return "", fmt.Errorf("provenance statement of %d bytes exceeds the maximum size of %d bytes", len(b), MaxAnnotationSize)
+	}
+	return string(b), nil
+}
+
+func newStatement(buildType string, meta metav1.ObjectMeta, params []v1beta1.Param, provenance *v1beta1.Provenance) *Statement {
+	s := &Statement{
+		Type:          StatementType,
+		PredicateType: PredicateType,
+		Subject:       []Subject{},
+		Predicate: Predicate{
+			Builder:    Builder{ID: BuilderID},
+			BuildType:  buildType,
+			Invocation: Invocation{Parameters: params},
+			Metadata:   Metadata{BuildInvocationID: string(meta.UID)},
+		},
+	}
+	if provenance != nil && provenance.ConfigSource != nil {
+		s.Predicate.Invocation.ConfigSource = provenance.ConfigSource
+		s.Predicate.Materials = configSourceMaterial(provenance.ConfigSource)
+	}
+	return s
+}
+
+func hermeticEnvironment() map[string]string {
+	return map[string]string{ExecutionModeEnvironmentKey: string(v1beta1.ExecutionModeHermetic)}
+}
+
+func configSourceMaterial(source *v1beta1.ConfigSource) []Material {
+	if source.URI == "" {
+		return nil
+	}
+	return []Material{{URI: source.URI, Digest: source.Digest}}
+}
+
+// taskRunMaterials returns the images of the steps and the git repositories named in the results of the TaskRun
+func taskRunMaterials(tr *v1beta1.TaskRun) []Material {
+	materials := []Material{}
+	for _, step := range tr.Status.Steps {
+		if m, ok := imageMaterial(step.ImageID); ok {
+			materials = append(materials, m)
+		}
+	}
+	results := taskRunResults(tr.Status.TaskRunResults)
+	for _, url := range pairedResults(results, GitURLResultSuffix, GitCommitResultSuffix) {
+		materials = append(materials, Material{
+			URI:    "git+" + url.value,
+			Digest: map[string]string{"sha1": url.paired},
+		})
+	}
+	return materials
+}
+
+// imageMaterial returns the image of a step from its image ID, e.g. docker-pullable://gcr.io/foo/bar@sha256:abc
+func imageMaterial(imageID string) (Material, bool) {
+	if i := strings.Index(imageID, "://"); i >= 0 {
+		imageID = imageID[i+len("://"):]
+	}
+	repository, digest, ok := strings.Cut(imageID, "@")
+	if !ok || repository == "" {
+		return Material{}, false
+	}
+	d, ok := parseDigest(digest)
+	if !ok {
+		return Material{}, false
+	}
+	return Material{URI: "oci://" + repository, Digest: d}, true
+}
+
+// appendMaterials appends the materials that are not listed already
+func appendMaterials(materials []Material, added ...Material) []Material {
+	for _, m := range added {
+		found := false
+		for _, existing := range materials {
+			if existing.URI == m.URI && reflect.DeepEqual(existing.Digest, m.Digest) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			materials = append(materials, m)
+		}
+	}
+	return materials
+}
+
+// subjects returns the images named by the results and their digests
+func subjects(results []result) []Subject {
+	subjects := []Subject{}
+	for _, url := range pairedResults(results, ImageURLResultSuffix, ImageDigestResultSuffix) {
+		digest, ok := parseDigest(url.paired)
+		if !ok {
+			continue
+		}
+		subjects = append(subjects, Subject{Name: url.value, Digest: digest})
+	}
+	return subjects
+}
+
+// parseDigest parses a digest of the form algorithm:hex
+func parseDigest(digest string) (map[string]string, bool) {
+	algorithm, hex, ok := strings.Cut(strings.TrimSpace(digest), ":")
+	if !ok || algorithm == "" || hex == "" {
+		return nil, false
+	}
+	return map[string]string{algorithm: hex}, true
+}
+
+type result struct {
+	name   string
+	value  string
+	paired string
+}
+
+func taskRunResults(results []v1beta1.TaskRunResult) []result {
+	r := []result{}
+	for _, tr := range results {
+		if tr.Value.Type == v1beta1.ParamTypeString {
+			r = append(r, result{name: tr.Name, value: strings.TrimSpace(tr.Value.StringVal)})
+		}
+	}
+	return r
+}
+
+func pipelineRunResults(results []v1beta1.PipelineRunResult) []result {
+	r := []result{}
+	for _, pr := range results {
+		if pr.Value.Type == v1beta1.ParamTypeString {
+			r = append(r, result{name: pr.Name, value: strings.TrimSpace(pr.Value.StringVal)})
+		}
+	}
+	return r
+}
+
+// pairedResults returns the results named with the suffix, paired with the value of the result of
+// the same prefix named with the paired suffix. Results without a paired result are ignored.
+func pairedResults(results []result, suffix, pairedSuffix string) []result {
+	values := map[string]string{}
+	for _, r := range results {
+		values[r.name] = r.value
+	}
+	paired := []result{}
+	for _, r := range results {
+		if !strings.HasSuffix(r.name, suffix) || r.value == "" {
+			continue
+		}
+		prefix := strings.TrimSuffix(r.name, suffix)
+		if value, ok := values[prefix+pairedSuffix]; ok && value != "" {
+			paired = append(paired, result{name: r.name, value: r.value, paired: value})
+		}
+	}
+	return paired
+}
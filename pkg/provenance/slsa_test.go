@@ -0,0 +1,229 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provenance_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/provenance"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	startTime      = metav1.NewTime(time.Date(2022, time.October, 1, 10, 0, 0, 0, time.UTC))
+	completionTime = metav1.NewTime(time.Date(2022, time.October, 1, 10, 5, 0, 0, time.UTC))
+	configSource   = &v1beta1.ConfigSource{
+		URI:        "git+https://github.com/tektoncd/catalog.git",
+		Digest:     map[string]string{"sha1": "a123"},
+		EntryPoint: "task/kaniko/0.6/kaniko.yaml",
+	}
+)
+
+func taskRun() *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo", UID: "tr-uid"},
+		Spec: v1beta1.TaskRunSpec{
+			Params: []v1beta1.Param{{Name: "IMAGE", Value: *v1beta1.NewStructuredValues("gcr.io/foo/bar")}},
+		},
+		Status: v1beta1.TaskRunStatus{
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				StartTime:      &startTime,
+				CompletionTime: &completionTime,
+				Provenance:     &v1beta1.Provenance{ConfigSource: configSource},
+				Steps: []v1beta1.StepState{{
+					Name:    "clone",
+					ImageID: "docker-pullable://gcr.io/tekton-releases/git-init@sha256:111",
+				}, {
+					Name:    "build",
+					ImageID: "gcr.io/kaniko-project/executor@sha256:222",
+				}, {
+					Name:    "no-digest",
+					ImageID: "ubuntu",
+				}},
+				TaskRunResults: []v1beta1.TaskRunResult{{
+					Name:  "IMAGE_URL",
+					Value: *v1beta1.NewStructuredValues("gcr.io/foo/bar\n"),
+				}, {
+					Name:  "IMAGE_DIGEST",
+					Value: *v1beta1.NewStructuredValues("sha256:333"),
+				}, {
+					Name:  "base-IMAGE_URL",
+					Value: *v1beta1.NewStructuredValues("gcr.io/foo/base"),
+				}, {
+					Name:  "source-GIT_URL",
+					Value: *v1beta1.NewStructuredValues("https://github.com/foo/bar"),
+				}, {
+					Name:  "source-GIT_COMMIT",
+					Value: *v1beta1.NewStructuredValues("abc123"),
+				}},
+			},
+		},
+	}
+}
+
+func TestForTaskRun(t *testing.T) {
+	want := &provenance.Statement{
+		Type:          provenance.StatementType,
+		PredicateType: provenance.PredicateType,
+		Subject: []provenance.Subject{{
+			Name:   "gcr.io/foo/bar",
+			Digest: map[string]string{"sha256": "333"},
+		}},
+		Predicate: provenance.Predicate{
+			Builder:   provenance.Builder{ID: provenance.BuilderID},
+			BuildType: provenance.TaskRunBuildType,
+			Invocation: provenance.Invocation{
+				ConfigSource: configSource,
+				Parameters:   []v1beta1.Param{{Name: "IMAGE", Value: *v1beta1.NewStructuredValues("gcr.io/foo/bar")}},
+			},
+			Metadata: provenance.Metadata{
+				BuildInvocationID: "tr-uid",
+				BuildStartedOn:    &startTime,
+				BuildFinishedOn:   &completionTime,
+			},
+			Materials: []provenance.Material{{
+				URI:    "git+https://github.com/tektoncd/catalog.git",
+				Digest: map[string]string{"sha1": "a123"},
+			}, {
+				URI:    "oci://gcr.io/tekton-releases/git-init",
+				Digest: map[string]string{"sha256": "111"},
+			}, {
+				URI:    "oci://gcr.io/kaniko-project/executor",
+				Digest: map[string]string{"sha256": "222"},
+			}, {
+				URI:    "git+https://github.com/foo/bar",
+				Digest: map[string]string{"sha1": "abc123"},
+			}},
+		},
+	}
+
+	got := provenance.ForTaskRun(taskRun())
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
+func TestForPipelineRun(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "foo", UID: "pr-uid"},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				StartTime:      &startTime,
+				CompletionTime: &completionTime,
+				PipelineResults: []v1beta1.PipelineRunResult{{
+					Name:  "app-IMAGE_URL",
+					Value: *v1beta1.NewStructuredValues("gcr.io/foo/app"),
+				}, {
+					Name:  "app-IMAGE_DIGEST",
+					Value: *v1beta1.NewStructuredValues("sha256:444"),
+				}, {
+					Name:  "invalid-IMAGE_URL",
+					Value: *v1beta1.NewStructuredValues("gcr.io/foo/invalid"),
+				}, {
+					Name:  "invalid-IMAGE_DIGEST",
+					Value: *v1beta1.NewStructuredValues("444"),
+				}},
+			},
+		},
+	}
+	// The same step image is used by both TaskRuns
+	tr1 := taskRun()
+	tr2 := taskRun()
+	tr2.Status.Provenance = nil
+	tr2.Status.TaskRunResults = nil
+
+	want := &provenance.Statement{
+		Type:          provenance.StatementType,
+		PredicateType: provenance.PredicateType,
+		Subject: []provenance.Subject{{
+			Name:   "gcr.io/foo/app",
+			Digest: map[string]string{"sha256": "444"},
+		}},
+		Predicate: provenance.Predicate{
+			Builder:   provenance.Builder{ID: provenance.BuilderID},
+			BuildType: provenance.PipelineRunBuildType,
+			Metadata: provenance.Metadata{
+				BuildInvocationID: "pr-uid",
+				BuildStartedOn:    &startTime,
+				BuildFinishedOn:   &completionTime,
+			},
+			Materials: []provenance.Material{{
+				URI:    "git+https://github.com/tektoncd/catalog.git",
+				Digest: map[string]string{"sha1": "a123"},
+			}, {
+				URI:    "oci://gcr.io/tekton-releases/git-init",
+				Digest: map[string]string{"sha256": "111"},
+			}, {
+				URI:    "oci://gcr.io/kaniko-project/executor",
+				Digest: map[string]string{"sha256": "222"},
+			}, {
+				URI:    "git+https://github.com/foo/bar",
+				Digest: map[string]string{"sha1": "abc123"},
+			}},
+		},
+	}
+
+	got := provenance.ForPipelineRun(pr, []*v1beta1.TaskRun{tr1, tr2})
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
+func TestForTaskRunHermetic(t *testing.T) {
+	tr := taskRun()
+	tr.Spec.ExecutionMode = v1beta1.ExecutionModeHermetic
+	want := map[string]string{provenance.ExecutionModeEnvironmentKey: "hermetic"}
+	if d := cmp.Diff(want, provenance.ForTaskRun(tr).Predicate.Invocation.Environment); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+
+	// A PipelineRun is hermetic only if all its TaskRuns are.
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "foo", UID: "pr-uid"}}
+	if d := cmp.Diff(want, provenance.ForPipelineRun(pr, []*v1beta1.TaskRun{tr}).Predicate.Invocation.Environment); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+	if env := provenance.ForPipelineRun(pr, []*v1beta1.TaskRun{tr, taskRun()}).Predicate.Invocation.Environment; env != nil {
+		t.Errorf("expected no environment for a PipelineRun with a non hermetic TaskRun, got %v", env)
+	}
+}
+
+func TestStatementAnnotation(t *testing.T) {
+	s := provenance.ForTaskRun(taskRun())
+	annotation, err := s.Annotation()
+	if err != nil {
+		t.Fatalf("Annotation() = %v", err)
+	}
+	got := &provenance.Statement{}
+	if err := json.Unmarshal([]byte(annotation), got); err != nil {
+		t.Fatalf("failed to unmarshal the annotation: %v", err)
+	}
+	if d := cmp.Diff(s.Subject, got.Subject); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+
+	tr := taskRun()
+	tr.Spec.Params = []v1beta1.Param{{Name: "large", Value: *v1beta1.NewStructuredValues(strings.Repeat("a", provenance.MaxAnnotationSize))}}
+	if _, err := provenance.ForTaskRun(tr).Annotation(); err == nil {
+		t.Error("expected an error for a statement exceeding the maximum size")
+	}
+}
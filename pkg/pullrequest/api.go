@@ -18,6 +18,7 @@ package pullrequest
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"strconv"
@@ -27,6 +28,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// reviewBody is the body of the reviews created to hold new review comments.
+const reviewBody = "Review comments uploaded by Tekton."
+
 // Handler handles interactions with the GitHub API.
 type Handler struct {
 	client *scm.Client
@@ -82,15 +86,49 @@ func (h *Handler) Download(ctx context.Context) (*Resource, error) {
 	}
 	pr.Labels = labels
 
+	reviewComments := h.listReviewComments(ctx)
+
 	r := &Resource{
-		PR:       pr,
-		Statuses: status,
-		Comments: comments,
+		PR:             pr,
+		Statuses:       status,
+		Comments:       comments,
+		ReviewComments: reviewComments,
 	}
 	populateManifest(r)
 	return r, nil
 }
 
+// listReviewComments returns the file and line comments of all reviews of
+// the PR. Fetching them is best-effort: providers that don't support reviews,
+// and reviews whose comments can't be listed, yield no review comments.
+func (h *Handler) listReviewComments(ctx context.Context) []*scm.ReviewComment {
+	h.logger.Info("finding reviews")
+	reviews, _, err := h.client.Reviews.List(ctx, h.repo, h.prNum, &scm.ListOptions{})
+	if errors.Is(err, scm.ErrNotSupported) {
+		h.logger.Infof("Reviews are not supported by %s, skipping review comments", h.client.Driver)
+		return nil
+	}
+	if err != nil {
+		h.logger.Warnf("Error finding reviews for pr %d, skipping review comments: %v", h.prNum, err)
+		return nil
+	}
+
+	var comments []*scm.ReviewComment
+	for _, r := range reviews {
+		rc, _, err := h.client.Reviews.ListComments(ctx, h.repo, h.prNum, r.ID, &scm.ListOptions{})
+		if errors.Is(err, scm.ErrNotSupported) {
+			h.logger.Infof("Review comments are not supported by %s, skipping review comments", h.client.Driver)
+			return nil
+		}
+		if err != nil {
+			h.logger.Warnf("Error finding comments of review %d for pr %d, skipping them: %v", r.ID, h.prNum, err)
+			continue
+		}
+		comments = append(comments, rc...)
+	}
+	return comments
+}
+
 func populateManifest(r *Resource) {
 	labels := make(Manifest)
 	for _, l := range r.PR.Labels {
@@ -127,6 +165,10 @@ func (h *Handler) Upload(ctx context.Context, r *Resource) error {
 		merr = multierror.Append(merr, err)
 	}
 
+	if err := h.uploadReviewComments(ctx, r.ReviewComments, r.PR.Sha); err != nil {
+		merr = multierror.Append(merr, err)
+	}
+
 	return merr
 }
 
@@ -259,6 +301,60 @@ func (h *Handler) createNewComments(ctx context.Context, comments []*scm.Comment
 	return merr
 }
 
+// uploadReviewComments creates a single review holding every review comment
+// that has not been created yet (has no ID). Existing review comments are
+// left untouched.
+func (h *Handler) uploadReviewComments(ctx context.Context, comments []*scm.ReviewComment, sha string) error {
+	var newComments []*scm.ReviewCommentInput
+	for _, c := range comments {
+		if c.ID != 0 {
+			continue
+		}
+		newComments = append(newComments, &scm.ReviewCommentInput{
+			Body: c.Body,
+			Path: c.Path,
+			Line: c.Line,
+		})
+	}
+	if len(newComments) == 0 {
+		h.logger.Info("Skipping review comments, nothing to create.")
+		return nil
+	}
+
+	if err := validateReviewComments(newComments); err != nil {
+		return err
+	}
+
+	h.logger.Infof("Creating review with %d comments for PR %d", len(newComments), h.prNum)
+	_, _, err := h.client.Reviews.Create(ctx, h.repo, h.prNum, &scm.ReviewInput{
+		// Some providers, e.g. Gitea, reject reviews without a body.
+		Body:     reviewBody,
+		Sha:      sha,
+		Event:    "COMMENT",
+		Comments: newComments,
+	})
+	if errors.Is(err, scm.ErrNotSupported) {
+		return fmt.Errorf("review comments are not supported by %s", h.client.Driver)
+	}
+	if err != nil {
+		return fmt.Errorf("creating review for pr %d: %w", h.prNum, err)
+	}
+	return nil
+}
+
+func validateReviewComments(comments []*scm.ReviewCommentInput) error {
+	var merr error
+	for _, c := range comments {
+		if c.Path == "" {
+			merr = multierror.Append(merr, fmt.Errorf("invalid review comment: \"Path\" should not be empty: %v", *c))
+		}
+		if c.Line <= 0 {
+			merr = multierror.Append(merr, fmt.Errorf("invalid review comment: \"Line\" should be a positive number: %v", *c))
+		}
+	}
+	return merr
+}
+
 func validateStatuses(statuses []*scm.Status) error {
 	var merr error
 	for _, s := range statuses {
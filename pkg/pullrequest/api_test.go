@@ -18,6 +18,10 @@ package pullrequest
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/go-multierror"
@@ -367,3 +371,143 @@ func TestUpload_ManifestLabel(t *testing.T) {
 		t.Errorf(diff.PrintWantGot(d))
 	}
 }
+
+func TestUpload_NewReviewComment(t *testing.T) {
+	ctx := context.Background()
+	h, data := newHandler(t)
+
+	r := defaultResource()
+	r.ReviewComments = []*scm.ReviewComment{{
+		ID:   7,
+		Body: "already reviewed",
+		Path: "main.go",
+		Line: 1,
+	}, {
+		Body: "unused variable",
+		Path: "main.go",
+		Line: 12,
+	}}
+
+	if err := h.Upload(ctx, r); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the new review comment is uploaded, in a single review.
+	if len(data.Reviews[prNum]) != 1 {
+		t.Errorf("Upload() created %d reviews, want 1", len(data.Reviews[prNum]))
+	}
+}
+
+func TestUpload_InvalidReviewComment(t *testing.T) {
+	ctx := context.Background()
+	h, data := newHandler(t)
+
+	r := defaultResource()
+	r.ReviewComments = []*scm.ReviewComment{{
+		Body: "no file or line",
+	}}
+
+	err := h.Upload(ctx, r)
+	if err == nil {
+		t.Fatal("expected an error uploading an invalid review comment")
+	}
+	merr, ok := err.(*multierror.Error)
+	if !ok {
+		t.Fatalf("Upload() returned %T, want *multierror.Error", err)
+	}
+	// Both the missing path and the missing line are reported.
+	if len(merr.WrappedErrors()) != 2 {
+		t.Errorf("Upload() returned %d errors, want 2: %v", len(merr.WrappedErrors()), merr)
+	}
+	if len(data.Reviews[prNum]) != 0 {
+		t.Errorf("Upload() created %d reviews, want 0", len(data.Reviews[prNum]))
+	}
+}
+
+func TestUploadReviewComments_Gitea(t *testing.T) {
+	var got map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/version":
+			fmt.Fprint(w, `{"version":"1.17.0"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/repos/foo/bar/pulls/1/reviews":
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("decoding review: %v", err)
+			}
+			fmt.Fprint(w, `{"id":1}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	logger := zaptest.NewLogger(t).Sugar()
+	h, err := NewSCMHandler(logger, srv.URL+"/foo/bar/pulls/1", "gitea", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	comments := []*scm.ReviewComment{{
+		Body: "unused variable",
+		Path: "main.go",
+		Line: 12,
+	}}
+	if err := h.uploadReviewComments(context.Background(), comments, "sha1"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]interface{}{
+		"event":     "COMMENT",
+		"body":      reviewBody,
+		"commit_id": "sha1",
+		"comments": []interface{}{map[string]interface{}{
+			"path":         "main.go",
+			"body":         "unused variable",
+			"old_position": float64(0),
+			"new_position": float64(12),
+		}},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Create review %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestUploadReviewComments_NotSupported(t *testing.T) {
+	logger := zaptest.NewLogger(t).Sugar()
+	h, err := NewSCMHandler(logger, "https://bitbucket.example.com/projects/FOO/repos/bar/pull-requests/1", "bitbucket-server", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	comments := []*scm.ReviewComment{{
+		Body: "unused variable",
+		Path: "main.go",
+		Line: 12,
+	}}
+	err = h.uploadReviewComments(context.Background(), comments, "sha1")
+	if err == nil || err.Error() != "review comments are not supported by stash" {
+		t.Errorf("uploadReviewComments() = %v, want review comments to be unsupported", err)
+	}
+}
+
+func TestListReviewComments_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/version":
+			fmt.Fprint(w, `{"version":"1.17.0"}`)
+		default:
+			http.Error(w, "internal error", http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	logger := zaptest.NewLogger(t).Sugar()
+	h, err := NewSCMHandler(logger, srv.URL+"/foo/bar/pulls/1", "gitea", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := h.listReviewComments(context.Background()); got != nil {
+		t.Errorf("listReviewComments() = %v, want no review comments", got)
+	}
+}
@@ -41,6 +41,8 @@ import (
 // /workspace/<resource>/status/<status>.json
 // /workspace/<resource>/comments/
 // /workspace/<resource>/comments/<comment>.json
+// /workspace/<resource>/review_comments/
+// /workspace/<resource>/review_comments/<review_comment>.json
 // /workspace/<resource>/head.json
 // /workspace/<resource>/base.json
 
@@ -55,6 +57,8 @@ type Resource struct {
 	PR       *scm.PullRequest
 	Statuses []*scm.Status
 	Comments []*scm.Comment
+	// ReviewComments are comments on a line of a file changed by the PR.
+	ReviewComments []*scm.ReviewComment
 
 	// Manifests contain data about the resource when it was written to disk.
 	Manifests map[string]Manifest
@@ -65,10 +69,11 @@ type Resource struct {
 func ToDisk(r *Resource, path string) error {
 	labelsPath := filepath.Join(path, "labels")
 	commentsPath := filepath.Join(path, "comments")
+	reviewCommentsPath := filepath.Join(path, "review_comments")
 	statusesPath := filepath.Join(path, "status")
 
 	// Setup subdirs
-	for _, p := range []string{labelsPath, commentsPath, statusesPath} {
+	for _, p := range []string{labelsPath, commentsPath, reviewCommentsPath, statusesPath} {
 		if err := os.MkdirAll(p, 0755); err != nil {
 			return err
 		}
@@ -84,6 +89,10 @@ func ToDisk(r *Resource, path string) error {
 		return err
 	}
 
+	if err := reviewCommentsToDisk(reviewCommentsPath, r.ReviewComments); err != nil {
+		return err
+	}
+
 	if err := labelsToDisk(labelsPath, r.PR.Labels); err != nil {
 		return err
 	}
@@ -117,6 +126,16 @@ func commentsToDisk(path string, comments []*scm.Comment) error {
 	return manifestToDisk(manifest, filepath.Join(path, manifestPath))
 }
 
+func reviewCommentsToDisk(path string, comments []*scm.ReviewComment) error {
+	for _, c := range comments {
+		commentPath := filepath.Join(path, strconv.Itoa(c.ID)+".json")
+		if err := toDisk(commentPath, c, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func labelsToDisk(path string, labels []*scm.Label) error {
 	manifest := Manifest{}
 	for _, l := range labels {
@@ -186,6 +205,12 @@ func FromDisk(path string, disableJSONComments bool) (*Resource, error) {
 	}
 	r.Manifests["comments"] = manifest
 
+	reviewCommentsPath := filepath.Join(path, "review_comments")
+	r.ReviewComments, err = reviewCommentsFromDisk(reviewCommentsPath)
+	if err != nil {
+		return nil, err
+	}
+
 	labelsPath := filepath.Join(path, "labels")
 	r.PR.Labels, manifest, err = labelsFromDisk(labelsPath)
 	if err != nil {
@@ -283,6 +308,29 @@ func commentsFromDisk(path string, disableStrictJSON bool) ([]*scm.Comment, Mani
 	return comments, manifest, nil
 }
 
+func reviewCommentsFromDisk(path string) ([]*scm.ReviewComment, error) {
+	fis, err := ioutil.ReadDir(path)
+	if isNotExistError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	comments := []*scm.ReviewComment{}
+	for _, fi := range fis {
+		b, err := ioutil.ReadFile(filepath.Join(path, fi.Name()))
+		if err != nil {
+			return nil, err
+		}
+		comment := scm.ReviewComment{}
+		if err := json.Unmarshal(b, &comment); err != nil {
+			return nil, fmt.Errorf("error parsing review comment file %q: %w", fi.Name(), err)
+		}
+		comments = append(comments, &comment)
+	}
+	return comments, nil
+}
+
 func labelsFromDisk(path string) ([]*scm.Label, Manifest, error) {
 	fis, err := ioutil.ReadDir(path)
 	if isNotExistError(err) {
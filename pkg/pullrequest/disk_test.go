@@ -654,6 +654,47 @@ func TestStatusToDisk(t *testing.T) {
 	}
 }
 
+func TestReviewCommentsToFromDisk(t *testing.T) {
+	d := t.TempDir()
+	existing := []*scm.ReviewComment{{
+		ID:   1,
+		Body: "unused variable",
+		Path: "main.go",
+		Line: 12,
+	}}
+	if err := reviewCommentsToDisk(d, existing); err != nil {
+		t.Fatalf("reviewCommentsToDisk() = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(d, "1.json")); err != nil {
+		t.Errorf("expected file 1.json to exist")
+	}
+
+	// Users add review comments by writing a json file with a path and a line.
+	if err := ioutil.WriteFile(filepath.Join(d, "lint.json"), []byte(`{"Body":"missing doc comment","Path":"api.go","Line":3}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := reviewCommentsFromDisk(d)
+	if err != nil {
+		t.Fatalf("reviewCommentsFromDisk() = %v", err)
+	}
+	want := append(existing, &scm.ReviewComment{
+		Body: "missing doc comment",
+		Path: "api.go",
+		Line: 3,
+	})
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("reviewCommentsFromDisk() %s", diff.PrintWantGot(d))
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(d, "bad.json"), []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reviewCommentsFromDisk(d); err == nil {
+		t.Error("expected an error reading a malformed review comment")
+	}
+}
+
 func writeManifest(t *testing.T, items []string, path string) {
 	t.Helper()
 	m := Manifest{}
@@ -27,8 +27,10 @@ import (
 
 	"crypto/tls"
 
+	"github.com/jenkins-x/go-scm/scm/driver/gitea"
 	"github.com/jenkins-x/go-scm/scm/driver/github"
 	"github.com/jenkins-x/go-scm/scm/driver/gitlab"
+	"github.com/jenkins-x/go-scm/scm/driver/stash"
 	"go.uber.org/zap"
 )
 
@@ -54,6 +56,10 @@ func NewSCMHandler(logger *zap.SugaredLogger, raw, provider, token string, skipT
 		handler, err = githubHandlerFromURL(u, token, skipTLSVerify, logger)
 	case "gitlab":
 		handler, err = gitlabHandlerFromURL(u, token, skipTLSVerify, logger)
+	case "bitbucket-server":
+		handler, err = bitbucketServerHandlerFromURL(u, token, skipTLSVerify, logger)
+	case "gitea":
+		handler, err = giteaHandlerFromURL(u, token, skipTLSVerify, logger)
 	default:
 		return nil, fmt.Errorf("unsupported pr url: %s", raw)
 	}
@@ -95,7 +101,15 @@ func githubHandlerFromURL(u *url.URL, token string, skipTLSVerify bool, logger *
 		return nil, fmt.Errorf("error creating client: %w", err)
 	}
 	ownerRepo := fmt.Sprintf("%s/%s", owner, repo)
+	client.Client = bearerTokenClient(token, skipTLSVerify)
 
+	h := NewHandler(logger, client, ownerRepo, prNumber)
+	return h, nil
+}
+
+// bearerTokenClient returns an http.Client that authenticates with the given
+// token as an OAuth2 bearer token, if one is set.
+func bearerTokenClient(token string, skipTLSVerify bool) *http.Client {
 	// Make sure to keep the default transport. This has builtin features like
 	// recognizing proxy settings that are useful to us.
 	t := http.DefaultTransport.(*http.Transport).Clone()
@@ -105,24 +119,20 @@ func githubHandlerFromURL(u *url.URL, token string, skipTLSVerify bool, logger *
 	// #nosec G402
 	t.TLSClientConfig = &tls.Config{InsecureSkipVerify: skipTLSVerify}
 
-	if token != "" {
-		ts := oauth2.StaticTokenSource(
-			&oauth2.Token{AccessToken: token},
-		)
-		client.Client = &http.Client{
-			Transport: &oauth2.Transport{
-				Source: ts,
-				Base:   t,
-			},
-		}
-	} else {
-		client.Client = &http.Client{
+	if token == "" {
+		return &http.Client{
 			Transport: t,
 		}
 	}
-
-	h := NewHandler(logger, client, ownerRepo, prNumber)
-	return h, nil
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: token},
+	)
+	return &http.Client{
+		Transport: &oauth2.Transport{
+			Source: ts,
+			Base:   t,
+		},
+	}
 }
 
 func gitlabHandlerFromURL(u *url.URL, token string, skipTLSVerify bool, logger *zap.SugaredLogger) (*Handler, error) {
@@ -177,6 +187,70 @@ func gitlabHandlerFromURL(u *url.URL, token string, skipTLSVerify bool, logger *
 	return NewHandler(logger, client, project, prInt), nil
 }
 
+func bitbucketServerHandlerFromURL(u *url.URL, token string, skipTLSVerify bool, logger *zap.SugaredLogger) (*Handler, error) {
+	// Bitbucket Server pull request URLs have the form
+	// http(s)://[hostname][/context]/projects/<project>/repos/<repo>/pull-requests/<number>[/<tab>]
+	split := strings.Split(u.Path, "/")
+	i := 0
+	for i < len(split) && split[i] != "projects" {
+		i++
+	}
+	if len(split) < i+6 || split[i+2] != "repos" || split[i+4] != "pull-requests" {
+		return nil, fmt.Errorf("invalid bitbucket server url: %s", u)
+	}
+	project, repo, pr := split[i+1], split[i+3], split[i+5]
+	prNumber, err := strconv.Atoi(pr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing PR number: %s", pr)
+	}
+	logger = logger.With(
+		zap.String("project", project),
+		zap.String("repo", repo),
+		zap.String("pr", pr),
+	)
+
+	client, err := stash.New(fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, strings.Join(split[:i], "/")))
+	if err != nil {
+		return nil, fmt.Errorf("error creating client: %w", err)
+	}
+	// Bitbucket Server accepts HTTP access tokens as bearer tokens.
+	client.Client = bearerTokenClient(token, skipTLSVerify)
+
+	return NewHandler(logger, client, fmt.Sprintf("%s/%s", project, repo), prNumber), nil
+}
+
+func giteaHandlerFromURL(u *url.URL, token string, skipTLSVerify bool, logger *zap.SugaredLogger) (*Handler, error) {
+	// Gitea pull request URLs have the form
+	// http(s)://[hostname][/subpath]/<owner>/<repo>/pulls/<number>
+	split := strings.Split(u.Path, "/")
+	last := len(split) - 1
+	if last < 4 || split[last-1] != "pulls" {
+		return nil, fmt.Errorf("invalid gitea url: %s", u)
+	}
+	owner, repo, pr := split[last-3], split[last-2], split[last]
+	prNumber, err := strconv.Atoi(pr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing PR number: %s", pr)
+	}
+	logger = logger.With(
+		zap.String("owner", owner),
+		zap.String("repo", repo),
+		zap.String("pr", pr),
+	)
+
+	// The Gitea driver talks to the server through the Gitea SDK, which
+	// brings its own http.Client that can't be configured through go-scm.
+	if skipTLSVerify {
+		return nil, fmt.Errorf("skipping TLS verification is not supported for gitea: %s", u)
+	}
+	client, err := gitea.NewWithToken(fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, strings.Join(split[:last-3], "/")), token)
+	if err != nil {
+		return nil, fmt.Errorf("error creating client: %w", err)
+	}
+
+	return NewHandler(logger, client, fmt.Sprintf("%s/%s", owner, repo), prNumber), nil
+}
+
 // gitlab client wraps a normal http client, adding support for private-token auth.
 type gitlabClient struct {
 	token     string
@@ -195,6 +269,10 @@ func guessProvider(u *url.URL) (string, error) {
 		return "github", nil
 	case strings.Contains(u.Hostname(), "gitlab"):
 		return "gitlab", nil
+	case strings.Contains(u.Hostname(), "gitea"):
+		return "gitea", nil
+	case strings.Contains(u.Path, "/projects/") && strings.Contains(u.Path, "/pull-requests/"):
+		return "bitbucket-server", nil
 	}
 	return "", fmt.Errorf("unable to guess scm provider from url: %s", u)
 }
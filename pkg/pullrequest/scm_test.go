@@ -17,7 +17,9 @@ limitations under the License.
 package pullrequest
 
 import (
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strconv"
 	"testing"
@@ -72,6 +74,30 @@ func TestNewSCMHandler(t *testing.T) {
 			wantNum:       3,
 			wantErr:       false,
 		},
+		{
+			name:          "bitbucket server",
+			raw:           "https://bitbucket.example.com/projects/FOO/repos/bar/pull-requests/4/overview",
+			skipTLSVerify: false,
+			wantBaseURL:   "https://bitbucket.example.com/",
+			wantRepo:      "FOO/bar",
+			wantNum:       4,
+			wantErr:       false,
+		},
+		{
+			name:          "bitbucket server with context path",
+			raw:           "https://example.com/bitbucket/projects/FOO/repos/bar/pull-requests/5",
+			skipTLSVerify: true,
+			wantBaseURL:   "https://example.com/bitbucket/",
+			wantRepo:      "FOO/bar",
+			wantNum:       5,
+			wantErr:       false,
+		},
+		{
+			name:          "invalid bitbucket server",
+			raw:           "https://bitbucket.example.com/projects/FOO/pull-requests/4",
+			skipTLSVerify: false,
+			wantErr:       true,
+		},
 		{
 			name:          "unsupported",
 			raw:           "https://unsupported.com/foo/baz/merge_requests/3",
@@ -111,6 +137,40 @@ func TestNewSCMHandler(t *testing.T) {
 	}
 }
 
+func TestNewSCMHandlerGitea(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gitea/api/v1/version" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"version":"1.17.0"}`)
+	}))
+	defer srv.Close()
+
+	observer, _ := observer.New(zap.InfoLevel)
+	logger := zap.New(observer).Sugar()
+	got, err := NewSCMHandler(logger, srv.URL+"/gitea/foo/bar/pulls/6", "gitea", "", false)
+	if err != nil {
+		t.Fatalf("NewSCMHandler() = %v", err)
+	}
+	if got.prNum != 6 {
+		t.Errorf("NewSCMHandler() [pr num] = %v, want %v", got.prNum, 6)
+	}
+	if got.repo != "foo/bar" {
+		t.Errorf("NewSCMHandler() [repo] = %v, want %v", got.repo, "foo/bar")
+	}
+	if baseURL := got.client.BaseURL.String(); baseURL != srv.URL+"/gitea/" {
+		t.Errorf("NewSCMHandler() [base url] = %v, want %v", baseURL, srv.URL+"/gitea/")
+	}
+
+	if _, err := NewSCMHandler(logger, srv.URL+"/gitea/foo/bar/pulls/6", "gitea", "", true); err == nil {
+		t.Error("NewSCMHandler() expected an error when skipping TLS verification for gitea")
+	}
+	if _, err := NewSCMHandler(logger, srv.URL+"/foo/bar/merge_requests/6", "gitea", "", false); err == nil {
+		t.Error("NewSCMHandler() expected an error for an invalid gitea url")
+	}
+}
+
 func TestGuessProvider(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -138,6 +198,16 @@ func TestGuessProvider(t *testing.T) {
 			url:  "https://gitlab.foo.com/foo/bar",
 			want: "gitlab",
 		},
+		{
+			name: "gitea",
+			url:  "https://gitea.com/foo/bar/pulls/1",
+			want: "gitea",
+		},
+		{
+			name: "bitbucket server",
+			url:  "https://git.foo.com/projects/FOO/repos/bar/pull-requests/1",
+			want: "bitbucket-server",
+		},
 		{
 			name:    "err",
 			url:     "https://foo.com/foo/bar",
@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approval
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
+	runreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/run"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+)
+
+const (
+	// DecisionApproved is the value of the decision result of approved ApprovalTasks.
+	DecisionApproved = "approved"
+	// DecisionRejected is the value of the decision result of rejected ApprovalTasks.
+	DecisionRejected = "rejected"
+)
+
+// Reconciler implements controller.Reconciler for the Runs of ApprovalTasks.
+// An ApprovalTask waits for the decisions of its approvers, which they record
+// in the spec of its Run, and succeeds once it gets the required number of
+// approvals. It fails as soon as one of its approvers rejects it, when it times
+// out, or when it is cancelled.
+type Reconciler struct {
+	Clock clock.PassiveClock
+}
+
+// Check that our Reconciler implements runreconciler.Interface
+var (
+	_ runreconciler.Interface = (*Reconciler)(nil)
+)
+
+// ReconcileKind updates the status of the Run of an ApprovalTask from the
+// decisions recorded by its approvers.
+func (c *Reconciler) ReconcileKind(ctx context.Context, run *v1alpha1.Run) pkgreconciler.Event {
+	logger := logging.FromContext(ctx)
+	if !run.IsApprovalTask() || run.IsDone() {
+		return nil
+	}
+	logger.Infof("Reconciling approval Run %s", run.Name)
+
+	if !run.HasStarted() {
+		run.Status.InitializeConditions()
+		run.Status.StartTime.Time = c.Clock.Now()
+	}
+	// Decisions are only read from the spec of the Run, where the webhook
+	// validates them, and copied to its status for reference.
+	decisions := append([]v1alpha1.ApprovalDecision{}, run.Spec.ApprovalDecisions...)
+	if err := run.Status.EncodeExtraFields(v1alpha1.ApprovalTaskStatus{Decisions: decisions}); err != nil {
+		return err
+	}
+
+	if run.IsCancelled() {
+		run.Status.MarkRunFailed(v1alpha1.RunReasonCancelled.String(), "Run %q was cancelled. %s", run.Name, run.Spec.StatusMessage)
+		return nil
+	}
+	if run.HasTimedOut(c.Clock) {
+		run.Status.MarkRunFailed(v1alpha1.RunReasonTimedOut.String(), "Run %q timed out after %s waiting for approval", run.Name, run.GetTimeout())
+		return nil
+	}
+
+	approvers, required, err := approvalParams(run.Spec.Params)
+	if err != nil {
+		run.Status.MarkRunFailed(v1alpha1.RunReasonInvalidApprovalParams.String(), "Run %q has invalid params: %v", run.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
+	var approved []string
+	var comments []string
+	rejectedBy := ""
+	for _, d := range decisions {
+		// Decisions of users who are not approvers are ignored.
+		if !approvers.Has(d.Approver) {
+			continue
+		}
+		if d.Comment != "" {
+			comments = append(comments, fmt.Sprintf("%s: %s", d.Approver, d.Comment))
+		}
+		switch d.Decision {
+		case v1alpha1.ApprovalDecisionApprove:
+			approved = append(approved, d.Approver)
+		case v1alpha1.ApprovalDecisionReject:
+			if rejectedBy == "" {
+				rejectedBy = d.Approver
+			}
+		}
+	}
+
+	switch {
+	case rejectedBy != "":
+		run.Status.Results = approvalResults(DecisionRejected, approved, comments)
+		run.Status.MarkRunFailed(v1alpha1.RunReasonRejected.String(), "Run %q was rejected by %s", run.Name, rejectedBy)
+	case len(approved) >= required:
+		run.Status.Results = approvalResults(DecisionApproved, approved, comments)
+		run.Status.MarkRunSucceeded(v1alpha1.RunReasonApproved.String(), "Run %q was approved by %s", run.Name, strings.Join(approved, ", "))
+	default:
+		run.Status.MarkRunRunning(v1alpha1.RunReasonWaitingForApproval.String(), "Run %q is waiting for approval: %d of %d approvals", run.Name, len(approved), required)
+		if timeout := run.GetTimeout(); timeout > 0 {
+			return controller.NewRequeueAfter(timeout - c.Clock.Since(run.Status.StartTime.Time))
+		}
+	}
+	return nil
+}
+
+// approvalParams returns the approvers and the number of approvals required
+// from the params of an ApprovalTask.
+func approvalParams(params []v1beta1.Param) (sets.String, int, error) {
+	approvers := sets.NewString()
+	required := 1
+	for _, p := range params {
+		switch p.Name {
+		case v1alpha1.ApprovalTaskApproversParam:
+			if p.Value.Type == v1beta1.ParamTypeString {
+				for _, a := range strings.Split(p.Value.StringVal, ",") {
+					if a = strings.TrimSpace(a); a != "" {
+						approvers.Insert(a)
+					}
+				}
+			} else {
+				approvers.Insert(p.Value.ArrayVal...)
+			}
+		case v1alpha1.ApprovalTaskRequiredApprovalsParam:
+			n, err := strconv.Atoi(p.Value.StringVal)
+			if err != nil || n < 1 {
+				return nil, 0, fmt.Errorf("%q must be a positive integer, got %q", v1alpha1.ApprovalTaskRequiredApprovalsParam, p.Value.StringVal)
+			}
+			required = n
+		}
+	}
+	if approvers.Len() == 0 {
+		return nil, 0, fmt.Errorf("%q must list at least one approver", v1alpha1.ApprovalTaskApproversParam)
+	}
+	if required > approvers.Len() {
+		return nil, 0, fmt.Errorf("%d approvals are required but there are only %d approvers", required, approvers.Len())
+	}
+	return approvers, required, nil
+}
+
+func approvalResults(decision string, approved, comments []string) []runv1alpha1.RunResult {
+	return []runv1alpha1.RunResult{{
+		Name:  v1alpha1.ApprovalTaskDecisionResult,
+		Value: decision,
+	}, {
+		Name:  v1alpha1.ApprovalTaskApproversResult,
+		Value: strings.Join(approved, ","),
+	}, {
+		Name:  v1alpha1.ApprovalTaskCommentsResult,
+		Value: strings.Join(comments, "\n"),
+	}}
+}
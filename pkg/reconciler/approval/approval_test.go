@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approval
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+)
+
+var now = time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+
+func approvalRun(params []v1beta1.Param, decisions ...v1alpha1.ApprovalDecision) *v1alpha1.Run {
+	run := &v1alpha1.Run{
+		ObjectMeta: metav1.ObjectMeta{Name: "approve-release", Namespace: "foo"},
+		Spec: v1alpha1.RunSpec{
+			Ref: &v1beta1.TaskRef{
+				APIVersion: "tekton.dev/v1alpha1",
+				Kind:       v1alpha1.ApprovalTaskKind,
+			},
+			Params:            params,
+			Timeout:           &metav1.Duration{Duration: time.Hour},
+			ApprovalDecisions: decisions,
+		},
+	}
+	run.Status.StartTime = &metav1.Time{Time: now.Add(-time.Minute)}
+	return run
+}
+
+func approvers(names ...string) v1beta1.Param {
+	return v1beta1.Param{Name: "approvers", Value: *v1beta1.NewStructuredValues(names[0], names[1:]...)}
+}
+
+func required(n string) v1beta1.Param {
+	return v1beta1.Param{Name: "numberOfApprovalsRequired", Value: *v1beta1.NewStructuredValues(n)}
+}
+
+func TestReconcileKind(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		run         *v1alpha1.Run
+		wantStatus  corev1.ConditionStatus
+		wantReason  v1alpha1.RunReason
+		wantResults []runv1alpha1.RunResult
+		wantRequeue bool
+	}{{
+		name:        "waiting for approval",
+		run:         approvalRun([]v1beta1.Param{approvers("alice", "bob")}),
+		wantStatus:  corev1.ConditionUnknown,
+		wantReason:  v1alpha1.RunReasonWaitingForApproval,
+		wantRequeue: true,
+	}, {
+		name: "approved",
+		run: approvalRun([]v1beta1.Param{approvers("alice", "bob")},
+			v1alpha1.ApprovalDecision{Approver: "bob", Decision: v1alpha1.ApprovalDecisionApprove, Comment: "lgtm"}),
+		wantStatus: corev1.ConditionTrue,
+		wantReason: v1alpha1.RunReasonApproved,
+		wantResults: []runv1alpha1.RunResult{
+			{Name: "decision", Value: "approved"},
+			{Name: "approvers", Value: "bob"},
+			{Name: "comments", Value: "bob: lgtm"},
+		},
+	}, {
+		name: "not enough approvals",
+		run: approvalRun([]v1beta1.Param{approvers("alice", "bob"), required("2")},
+			v1alpha1.ApprovalDecision{Approver: "bob", Decision: v1alpha1.ApprovalDecisionApprove}),
+		wantStatus:  corev1.ConditionUnknown,
+		wantReason:  v1alpha1.RunReasonWaitingForApproval,
+		wantRequeue: true,
+	}, {
+		name: "decisions of other users are ignored",
+		run: approvalRun([]v1beta1.Param{approvers("alice")},
+			v1alpha1.ApprovalDecision{Approver: "mallory", Decision: v1alpha1.ApprovalDecisionApprove}),
+		wantStatus:  corev1.ConditionUnknown,
+		wantReason:  v1alpha1.RunReasonWaitingForApproval,
+		wantRequeue: true,
+	}, {
+		name: "rejected",
+		run: approvalRun([]v1beta1.Param{approvers("alice", "bob"), required("2")},
+			v1alpha1.ApprovalDecision{Approver: "alice", Decision: v1alpha1.ApprovalDecisionApprove},
+			v1alpha1.ApprovalDecision{Approver: "bob", Decision: v1alpha1.ApprovalDecisionReject, Comment: "not on a Friday"}),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.RunReasonRejected,
+		wantResults: []runv1alpha1.RunResult{
+			{Name: "decision", Value: "rejected"},
+			{Name: "approvers", Value: "alice"},
+			{Name: "comments", Value: "bob: not on a Friday"},
+		},
+	}, {
+		name: "decisions in the status are ignored",
+		run: func() *v1alpha1.Run {
+			r := approvalRun([]v1beta1.Param{approvers("alice")})
+			if err := r.Status.EncodeExtraFields(v1alpha1.ApprovalTaskStatus{Decisions: []v1alpha1.ApprovalDecision{
+				{Approver: "alice", Decision: v1alpha1.ApprovalDecisionApprove},
+			}}); err != nil {
+				t.Fatal(err)
+			}
+			return r
+		}(),
+		wantStatus:  corev1.ConditionUnknown,
+		wantReason:  v1alpha1.RunReasonWaitingForApproval,
+		wantRequeue: true,
+	}, {
+		name:       "no approvers",
+		run:        approvalRun(nil),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.RunReasonInvalidApprovalParams,
+	}, {
+		name:       "more approvals required than approvers",
+		run:        approvalRun([]v1beta1.Param{approvers("alice"), required("2")}),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.RunReasonInvalidApprovalParams,
+	}, {
+		name: "timed out",
+		run: func() *v1alpha1.Run {
+			r := approvalRun([]v1beta1.Param{approvers("alice")})
+			r.Status.StartTime = &metav1.Time{Time: now.Add(-2 * time.Hour)}
+			return r
+		}(),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.RunReasonTimedOut,
+	}, {
+		name: "cancelled",
+		run: func() *v1alpha1.Run {
+			r := approvalRun([]v1beta1.Param{approvers("alice")})
+			r.Spec.Status = v1alpha1.RunSpecStatusCancelled
+			return r
+		}(),
+		wantStatus: corev1.ConditionFalse,
+		wantReason: v1alpha1.RunReasonCancelled,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Reconciler{Clock: clock.NewFakePassiveClock(now)}
+			err := c.ReconcileKind(context.Background(), tc.run)
+			if ok, _ := controller.IsRequeueKey(err); ok != tc.wantRequeue {
+				t.Errorf("expected requeue %t, got error %v", tc.wantRequeue, err)
+			}
+			cond := tc.run.Status.GetCondition(apis.ConditionSucceeded)
+			if cond == nil {
+				t.Fatalf("expected a Succeeded condition")
+			}
+			if cond.Status != tc.wantStatus || cond.Reason != tc.wantReason.String() {
+				t.Errorf("expected condition %s with reason %s, got %v", tc.wantStatus, tc.wantReason, cond)
+			}
+			if d := cmp.Diff(tc.wantResults, tc.run.Status.Results); d != "" {
+				t.Errorf("unexpected results %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileKindStartsRun(t *testing.T) {
+	run := approvalRun([]v1beta1.Param{approvers("alice")})
+	run.Status.StartTime = nil
+	c := &Reconciler{Clock: clock.NewFakePassiveClock(now)}
+	if err := c.ReconcileKind(context.Background(), run); err == nil {
+		t.Fatalf("expected the Run to be requeued until it times out")
+	}
+	if !run.Status.StartTime.Time.Equal(now) {
+		t.Errorf("expected start time %s, got %s", now, run.Status.StartTime.Time)
+	}
+	if d := cmp.Diff(`{"decisions":[]}`, string(run.Status.ExtraFields.Raw)); d != "" {
+		t.Errorf("expected empty decisions %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileKindCopiesDecisionsToStatus(t *testing.T) {
+	decision := v1alpha1.ApprovalDecision{Approver: "bob", Decision: v1alpha1.ApprovalDecisionApprove, Comment: "lgtm"}
+	run := approvalRun([]v1beta1.Param{approvers("alice", "bob"), required("2")}, decision)
+	c := &Reconciler{Clock: clock.NewFakePassiveClock(now)}
+	if err := c.ReconcileKind(context.Background(), run); err == nil {
+		t.Fatalf("expected the Run to be requeued until it times out")
+	}
+	var status v1alpha1.ApprovalTaskStatus
+	if err := run.Status.DecodeExtraFields(&status); err != nil {
+		t.Fatal(err)
+	}
+	if d := cmp.Diff([]v1alpha1.ApprovalDecision{decision}, status.Decisions); d != "" {
+		t.Errorf("unexpected decisions in the status %s", diff.PrintWantGot(d))
+	}
+}
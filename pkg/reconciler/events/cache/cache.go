@@ -30,8 +30,17 @@ import (
 
 // Struct to unmarshal the event data
 type eventData struct {
-	Run       *v1alpha1.Run      `json:"run,omitempty"`
-	CustomRun *v1beta1.CustomRun `json:"customRun,omitempty"`
+	Run          *v1alpha1.Run        `json:"run,omitempty"`
+	CustomRun    *v1beta1.CustomRun   `json:"customRun,omitempty"`
+	PipelineRun  *v1beta1.PipelineRun `json:"pipelineRun,omitempty"`
+	PipelineTask *pipelineTask        `json:"pipelineTask,omitempty"`
+}
+
+// Struct to unmarshal the PipelineTask of PipelineTask events
+type pipelineTask struct {
+	Name       string `json:"name"`
+	ChildName  string `json:"childName,omitempty"`
+	RetryCount int    `json:"retryCount,omitempty"`
 }
 
 // ContainsOrAddCloudEvent checks if the event exists in the cache
@@ -60,6 +69,13 @@ func EventKey(event *cloudevents.Event) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	eventType := event.Type()
+	if data.PipelineRun != nil && data.PipelineTask != nil {
+		// PipelineTask events are about one PipelineTask, and possibly one retry
+		// of its TaskRun, rather than about the PipelineRun.
+		return fmt.Sprintf("%s/pipelinerun/%s/%s/%s/%s/%d", eventType, data.PipelineRun.Namespace, data.PipelineRun.Name,
+			data.PipelineTask.Name, data.PipelineTask.ChildName, data.PipelineTask.RetryCount), nil
+	}
 	if data.Run == nil && data.CustomRun == nil {
 		return "", fmt.Errorf("Invalid Run data in %v", event)
 	}
@@ -72,6 +88,5 @@ func EventKey(event *cloudevents.Event) (string, error) {
 		resourceNamespace = data.CustomRun.Namespace
 		resourceKind = "customrun"
 	}
-	eventType := event.Type()
 	return fmt.Sprintf("%s/%s/%s/%s", eventType, resourceKind, resourceNamespace, resourceName), nil
 }
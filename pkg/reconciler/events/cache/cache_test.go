@@ -37,6 +37,9 @@ import (
 func strptr(s string) *string { return &s }
 
 func getEventData(run interface{}) map[string]interface{} {
+	if v, ok := run.(map[string]interface{}); ok {
+		return v
+	}
 	cloudEventData := map[string]interface{}{}
 	if v, ok := run.(*v1alpha1.Run); ok {
 		cloudEventData["run"] = v
@@ -113,6 +116,15 @@ func TestEventsKey(t *testing.T) {
 		run:       getCustomRunByMeta("myrun", "mynamespace"),
 		wantKey:   "my.test.run.event/customrun/mynamespace/myrun",
 		wantErr:   false,
+	}, {
+		name:      "pipelinetask event",
+		eventtype: "my.test.pipelinetask.event",
+		run: map[string]interface{}{
+			"pipelineRun":  &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "mypipelinerun", Namespace: "mynamespace"}},
+			"pipelineTask": map[string]interface{}{"name": "mytask", "childName": "mypipelinerun-mytask", "retryCount": 2},
+		},
+		wantKey: "my.test.pipelinetask.event/pipelinerun/mynamespace/mypipelinerun/mytask/mypipelinerun-mytask/2",
+		wantErr: false,
 	}, {
 		name:      "run event missing data",
 		eventtype: "my.test.run.event",
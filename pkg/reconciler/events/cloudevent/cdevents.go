@@ -0,0 +1,190 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"knative.dev/pkg/apis"
+)
+
+// CDEventsSpecVersion is the version of the CDEvents specification
+// the CDEvents sent by Tekton follow, see https://cdevents.dev
+const CDEventsSpecVersion = "0.1.0"
+
+// CDEventType holds the types of the CDEvents sent by Tekton
+type CDEventType string
+
+const (
+	// CDEventTaskRunStarted is sent for TaskRuns along with TaskRunStartedEventV1
+	CDEventTaskRunStarted CDEventType = "dev.cdevents.taskrun.started." + CDEventsSpecVersion
+	// CDEventTaskRunFinished is sent for TaskRuns along with TaskRunSuccessfulEventV1
+	// and TaskRunFailedEventV1
+	CDEventTaskRunFinished CDEventType = "dev.cdevents.taskrun.finished." + CDEventsSpecVersion
+	// CDEventPipelineRunQueued is sent for PipelineRuns along with PipelineRunStartedEventV1
+	CDEventPipelineRunQueued CDEventType = "dev.cdevents.pipelinerun.queued." + CDEventsSpecVersion
+	// CDEventPipelineRunStarted is sent for PipelineRuns along with PipelineRunRunningEventV1
+	CDEventPipelineRunStarted CDEventType = "dev.cdevents.pipelinerun.started." + CDEventsSpecVersion
+	// CDEventPipelineRunFinished is sent for PipelineRuns along with PipelineRunSuccessfulEventV1
+	// and PipelineRunFailedEventV1
+	CDEventPipelineRunFinished CDEventType = "dev.cdevents.pipelinerun.finished." + CDEventsSpecVersion
+)
+
+const (
+	// CDEventOutcomeSuccess is the outcome of finished TaskRuns and PipelineRuns that succeeded
+	CDEventOutcomeSuccess = "success"
+	// CDEventOutcomeFailure is the outcome of finished TaskRuns and PipelineRuns that failed
+	CDEventOutcomeFailure = "failure"
+)
+
+func (t CDEventType) String() string {
+	return string(t)
+}
+
+// cdEventTypes maps the lifecycle transitions of TaskRuns and PipelineRuns, in the
+// form of Tekton event types, to CDEvents types. Transitions that have no
+// equivalent in CDEvents, and other resources, are not mapped.
+var cdEventTypes = map[TektonEventType]CDEventType{
+	TaskRunStartedEventV1:        CDEventTaskRunStarted,
+	TaskRunSuccessfulEventV1:     CDEventTaskRunFinished,
+	TaskRunFailedEventV1:         CDEventTaskRunFinished,
+	PipelineRunStartedEventV1:    CDEventPipelineRunQueued,
+	PipelineRunRunningEventV1:    CDEventPipelineRunStarted,
+	PipelineRunSuccessfulEventV1: CDEventPipelineRunFinished,
+	PipelineRunFailedEventV1:     CDEventPipelineRunFinished,
+}
+
+// CDEvent is the payload of a cloud event in the CDEvents format
+type CDEvent struct {
+	Context CDEventContext `json:"context"`
+	Subject CDEventSubject `json:"subject"`
+}
+
+// CDEventContext holds the context of a CDEvent
+type CDEventContext struct {
+	Version   string      `json:"version"`
+	ID        string      `json:"id"`
+	Source    string      `json:"source"`
+	Type      CDEventType `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// CDEventSubject holds the subject of a CDEvent, i.e. the TaskRun or
+// PipelineRun the event is about
+type CDEventSubject struct {
+	ID      string                `json:"id"`
+	Source  string                `json:"source"`
+	Type    string                `json:"type"`
+	Content CDEventSubjectContent `json:"content"`
+}
+
+// CDEventSubjectContent holds the content of the subject of a CDEvent
+type CDEventSubjectContent struct {
+	// TaskName is the name of the Task of a TaskRun
+	TaskName string `json:"taskName,omitempty"`
+	// PipelineName is the name of the Pipeline of a PipelineRun
+	PipelineName string `json:"pipelineName,omitempty"`
+	// PipelineRun is the PipelineRun of a TaskRun, if any
+	PipelineRun *CDEventSubjectReference `json:"pipelineRun,omitempty"`
+	// Outcome is the outcome of a finished TaskRun or PipelineRun
+	Outcome string `json:"outcome,omitempty"`
+	// Errors is the message of a failed TaskRun or PipelineRun
+	Errors string `json:"errors,omitempty"`
+}
+
+// CDEventSubjectReference is a reference to the subject of other CDEvents
+type CDEventSubjectReference struct {
+	ID string `json:"id"`
+}
+
+// cdEventForObjectWithCondition creates a new cloud event in the CDEvents format
+// for a TaskRun or a PipelineRun, given the type of its Tekton event. It returns
+// nil if the transition of runObject has no equivalent in CDEvents.
+func cdEventForObjectWithCondition(runObject objectWithCondition, eventType TektonEventType) (*cloudevents.Event, error) {
+	cdEvent := newCDEvent(runObject, eventType, uuid.New().String())
+	if cdEvent == nil {
+		return nil, nil
+	}
+	event := cloudevents.NewEvent()
+	event.SetID(cdEvent.Context.ID)
+	event.SetSource(cdEvent.Context.Source)
+	event.SetType(cdEvent.Context.Type.String())
+	event.SetSubject(cdEvent.Subject.ID)
+	event.SetTime(cdEvent.Context.Timestamp)
+	if err := event.SetData(cloudevents.ApplicationJSON, cdEvent); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// newCDEvent returns the CDEvent with the given id for a TaskRun or a PipelineRun,
+// given the type of its Tekton event, or nil if there is none.
+func newCDEvent(runObject objectWithCondition, eventType TektonEventType, id string) *CDEvent {
+	cdEventType, ok := cdEventTypes[eventType]
+	if !ok {
+		return nil
+	}
+	source := eventSource(runObject)
+	meta := runObject.GetObjectMeta()
+	cdEvent := &CDEvent{
+		Context: CDEventContext{
+			Version: CDEventsSpecVersion,
+			ID:      id,
+			Source:  source,
+			Type:    cdEventType,
+		},
+		Subject: CDEventSubject{
+			ID:     meta.GetName(),
+			Source: source,
+		},
+	}
+
+	c := runObject.GetStatusCondition().GetCondition(apis.ConditionSucceeded)
+	cdEvent.Context.Timestamp = time.Now().UTC()
+	if c != nil && !c.LastTransitionTime.Inner.IsZero() {
+		cdEvent.Context.Timestamp = c.LastTransitionTime.Inner.UTC()
+	}
+	if c.IsTrue() {
+		cdEvent.Subject.Content.Outcome = CDEventOutcomeSuccess
+	} else if c.IsFalse() {
+		cdEvent.Subject.Content.Outcome = CDEventOutcomeFailure
+		cdEvent.Subject.Content.Errors = c.Message
+	}
+
+	switch o := runObject.(type) {
+	case *v1beta1.TaskRun:
+		cdEvent.Subject.Type = "taskRun"
+		cdEvent.Subject.Content.TaskName = meta.GetLabels()[pipeline.TaskLabelKey]
+		if cdEvent.Subject.Content.TaskName == "" && o.Spec.TaskRef != nil {
+			cdEvent.Subject.Content.TaskName = o.Spec.TaskRef.Name
+		}
+		if pr := meta.GetLabels()[pipeline.PipelineRunLabelKey]; pr != "" {
+			cdEvent.Subject.Content.PipelineRun = &CDEventSubjectReference{ID: pr}
+		}
+	case *v1beta1.PipelineRun:
+		cdEvent.Subject.Type = "pipelineRun"
+		cdEvent.Subject.Content.PipelineName = meta.GetLabels()[pipeline.PipelineLabelKey]
+		if cdEvent.Subject.Content.PipelineName == "" && o.Spec.PipelineRef != nil {
+			cdEvent.Subject.Content.PipelineName = o.Spec.PipelineRef.Name
+		}
+	}
+	return cdEvent
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the CDEvents tests")
+
+var cdEventTransitionTime = apis.VolatileTime{Inner: metav1.NewTime(time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC))}
+
+func cdEventStatus(status corev1.ConditionStatus, reason, message string) duckv1beta1.Status {
+	return duckv1beta1.Status{
+		Conditions: []apis.Condition{{
+			Type:               apis.ConditionSucceeded,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: cdEventTransitionTime,
+		}},
+	}
+}
+
+func cdEventTaskRun(status duckv1beta1.Status) *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pipelinerun-task1",
+			Namespace: "foo",
+			SelfLink:  "/apis/tekton.dev/v1beta1/namespaces/foo/taskruns/test-pipelinerun-task1",
+			Labels: map[string]string{
+				pipeline.TaskLabelKey:        "build",
+				pipeline.PipelineRunLabelKey: "test-pipelinerun",
+			},
+		},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "build"},
+		},
+		Status: v1beta1.TaskRunStatus{Status: status},
+	}
+}
+
+func cdEventPipelineRun(status duckv1beta1.Status) *v1beta1.PipelineRun {
+	return &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pipelinerun",
+			Namespace: "foo",
+			SelfLink:  "/apis/tekton.dev/v1beta1/namespaces/foo/pipelineruns/test-pipelinerun",
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "release"},
+		},
+		Status: v1beta1.PipelineRunStatus{Status: status},
+	}
+}
+
+func TestNewCDEvent(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		object    objectWithCondition
+		eventType TektonEventType
+	}{{
+		name:      "taskrun-started",
+		object:    cdEventTaskRun(cdEventStatus(corev1.ConditionUnknown, "Started", "")),
+		eventType: TaskRunStartedEventV1,
+	}, {
+		name:      "taskrun-successful",
+		object:    cdEventTaskRun(cdEventStatus(corev1.ConditionTrue, "Succeeded", "All Steps have completed executing")),
+		eventType: TaskRunSuccessfulEventV1,
+	}, {
+		name:      "taskrun-failed",
+		object:    cdEventTaskRun(cdEventStatus(corev1.ConditionFalse, "Failed", `"step-build" exited with code 1`)),
+		eventType: TaskRunFailedEventV1,
+	}, {
+		name:      "pipelinerun-queued",
+		object:    cdEventPipelineRun(cdEventStatus(corev1.ConditionUnknown, "Started", "")),
+		eventType: PipelineRunStartedEventV1,
+	}, {
+		name:      "pipelinerun-started",
+		object:    cdEventPipelineRun(cdEventStatus(corev1.ConditionUnknown, "Running", "Tasks Completed: 0 (Failed: 0, Cancelled 0), Skipped: 0")),
+		eventType: PipelineRunRunningEventV1,
+	}, {
+		name:      "pipelinerun-successful",
+		object:    cdEventPipelineRun(cdEventStatus(corev1.ConditionTrue, "Succeeded", "Tasks Completed: 2 (Failed: 0, Cancelled 0), Skipped: 0")),
+		eventType: PipelineRunSuccessfulEventV1,
+	}, {
+		name:      "pipelinerun-failed",
+		object:    cdEventPipelineRun(cdEventStatus(corev1.ConditionFalse, "Failed", "Tasks Completed: 1 (Failed: 1, Cancelled 0), Skipped: 1")),
+		eventType: PipelineRunFailedEventV1,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdEvent := newCDEvent(tc.object, tc.eventType, "test-event-id")
+			if cdEvent == nil {
+				t.Fatalf("Expected a CDEvent for %s, got none", tc.eventType)
+			}
+			got, err := json.MarshalIndent(cdEvent, "", "  ")
+			if err != nil {
+				t.Fatalf("Unexpected error marshalling the CDEvent: %v", err)
+			}
+			got = append(got, '\n')
+			golden := filepath.Join("testdata", "cdevents", tc.name+".json")
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatalf("Unexpected error updating %s: %v", golden, err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("Unexpected error reading %s: %v", golden, err)
+			}
+			if d := cmp.Diff(string(want), string(got)); d != "" {
+				t.Errorf("CDEvent does not match %s %s", golden, diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewCDEventNotMapped(t *testing.T) {
+	for _, eventType := range []TektonEventType{
+		TaskRunUnknownEventV1,
+		TaskRunRunningEventV1,
+		PipelineRunUnknownEventV1,
+		PipelineTaskSkippedEventV1,
+	} {
+		t.Run(eventType.String(), func(t *testing.T) {
+			if cdEvent := newCDEvent(cdEventTaskRun(cdEventStatus(corev1.ConditionUnknown, "", "")), eventType, "test-event-id"); cdEvent != nil {
+				t.Errorf("Expected no CDEvent for %s, got %v", eventType, cdEvent)
+			}
+		})
+	}
+}
+
+func TestCDEventForObjectWithCondition(t *testing.T) {
+	tr := cdEventTaskRun(cdEventStatus(corev1.ConditionTrue, "Succeeded", ""))
+	event, err := cdEventForObjectWithCondition(tr, TaskRunSuccessfulEventV1)
+	if err != nil {
+		t.Fatalf("Unexpected error creating the CDEvent: %v", err)
+	}
+	if d := cmp.Diff(CDEventTaskRunFinished.String(), event.Type()); d != "" {
+		t.Errorf("Wrong event type %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(tr.SelfLink, event.Source()); d != "" {
+		t.Errorf("Wrong event source %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(tr.Name, event.Subject()); d != "" {
+		t.Errorf("Wrong event subject %s", diff.PrintWantGot(d))
+	}
+	if !event.Time().Equal(cdEventTransitionTime.Inner.Time) {
+		t.Errorf("Expected the event time to be %v, got %v", cdEventTransitionTime.Inner.Time, event.Time())
+	}
+	data := CDEvent{}
+	if err := json.Unmarshal(event.Data(), &data); err != nil {
+		t.Fatalf("Unexpected error unmarshalling the event data: %v", err)
+	}
+	if d := cmp.Diff(event.ID(), data.Context.ID); d != "" {
+		t.Errorf("Wrong CDEvent id %s", diff.PrintWantGot(d))
+	}
+}
@@ -19,10 +19,13 @@ package cloudevent
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/protocol"
 	"github.com/hashicorp/go-multierror"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
@@ -32,6 +35,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/utils/clock"
 	controller "knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -117,9 +121,19 @@ func SendCloudEvents(tr *v1beta1.TaskRun, ceclient CEClient, logger *zap.Sugared
 	return merr.ErrorOrNil()
 }
 
+// deliveryBackoff is the backoff between the attempts to deliver a cloud
+// event to a sink. Steps is the maximum number of attempts.
+var deliveryBackoff = wait.Backoff{
+	Duration: 10 * time.Millisecond,
+	Factor:   2.0,
+	Steps:    10,
+}
+
 // SendCloudEventWithRetries sends a cloud event for the specified resource.
-// It does not block and it perform retries with backoff using the cloudevents
-// sdk-go capabilities.
+// It does not block and it perform retries with backoff.
+// The event is sent to all the sinks configured that accept its type, in the
+// formats configured for each sink. Events that cannot be delivered to a sink
+// are recorded as a Kubernetes warning event on the resource.
 // It accepts a runtime.Object to avoid making objectWithCondition public since
 // it's only used within the events/cloudevents packages.
 func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error {
@@ -130,7 +144,6 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 	if o, ok = object.(objectWithCondition); !ok {
 		return errors.New("Input object does not satisfy objectWithCondition")
 	}
-	logger := logging.FromContext(ctx)
 	ceClient := Get(ctx)
 	if ceClient == nil {
 		return errors.New("No cloud events client found in the context")
@@ -139,11 +152,46 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 	if err != nil {
 		return err
 	}
+	events := map[string]*cloudevents.Event{config.CloudEventsFormatTekton: event}
+	cdEvent, err := cdEventForObjectWithCondition(o, TektonEventType(event.Type()))
+	if err != nil {
+		return err
+	}
+	if cdEvent != nil {
+		events[config.CloudEventsFormatCDEvents] = cdEvent
+	}
 	// Events for Runs require a cache of events that have been sent
-	cacheClient := cache.Get(ctx)
 	_, isRun := object.(*v1alpha1.Run)
 	_, isCustomRun := object.(*v1beta1.CustomRun)
 
+	return sendCloudEvent(ctx, ceClient, object, events, isRun || isCustomRun)
+}
+
+// SendPipelineTaskCloudEventWithRetries sends a cloud event of the given type
+// about one of the PipelineTasks of a PipelineRun, in the same way as
+// SendCloudEventWithRetries. PipelineTask events are sent only once, so they
+// may be sent on every reconcile of the PipelineRun.
+func SendPipelineTaskCloudEventWithRetries(ctx context.Context, pr *v1beta1.PipelineRun, eventType TektonEventType, pipelineTask PipelineTaskEventData) error {
+	ceClient := Get(ctx)
+	if ceClient == nil {
+		return errors.New("No cloud events client found in the context")
+	}
+	event, err := eventForPipelineTask(pr, eventType, pipelineTask)
+	if err != nil {
+		return err
+	}
+	return sendCloudEvent(ctx, ceClient, pr, map[string]*cloudevents.Event{config.CloudEventsFormatTekton: event}, true)
+}
+
+// sendCloudEvent sends the events, keyed by format, to all the sinks that
+// accept them, without blocking. The event in the Tekton format is required.
+// If useCache is true, the events are not sent again if they were sent already.
+func sendCloudEvent(ctx context.Context, ceClient CEClient, object runtime.Object, events map[string]*cloudevents.Event, useCache bool) error {
+	logger := logging.FromContext(ctx)
+	cacheClient := cache.Get(ctx)
+	event := events[config.CloudEventsFormatTekton]
+	deliveries := deliveriesForEvents(ctx, events)
+
 	wasIn := make(chan error)
 
 	ceClient.addCount()
@@ -152,7 +200,7 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 		wasIn <- nil
 		logger.Debugf("Sending cloudevent of type %q", event.Type())
 		// In case of Run event, check cache if cloudevent is already sent
-		if isRun || isCustomRun {
+		if useCache {
 			cloudEventSent, err := cache.ContainsOrAddCloudEvent(cacheClient, event)
 			if err != nil {
 				logger.Errorf("error while checking cache: %s", err)
@@ -162,16 +210,89 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 				return
 			}
 		}
-		if result := ceClient.Send(cloudevents.ContextWithRetriesExponentialBackoff(ctx, 10*time.Millisecond, 10), *event); !cloudevents.IsACK(result) {
-			logger.Warnf("Failed to send cloudevent: %s", result.Error())
-			recorder := controller.GetEventRecorder(ctx)
-			if recorder == nil {
-				logger.Warnf("No recorder in context, cannot emit error event")
-				return
-			}
-			recorder.Event(object, corev1.EventTypeWarning, "Cloud Event Failure", result.Error())
+		var wg sync.WaitGroup
+		for _, d := range deliveries {
+			wg.Add(1)
+			go func(d delivery) {
+				defer wg.Done()
+				deliverCloudEvent(ctx, ceClient, object, *d.event, d.target)
+			}(d)
 		}
+		wg.Wait()
 	}()
 
 	return <-wasIn
 }
+
+// delivery is an event to be sent to a sink
+type delivery struct {
+	event  *cloudevents.Event
+	target string
+}
+
+// deliveriesForEvents returns the deliveries of the events, keyed by format,
+// to the sinks that accept them. Each sink receives the events in the formats
+// configured for it, if they accept their type. If no sink is configured, the
+// event in the Tekton format is sent to the target set in the context, if any.
+func deliveriesForEvents(ctx context.Context, events map[string]*cloudevents.Event) []delivery {
+	sinks := config.FromContextOrDefaults(ctx).Defaults.CloudEventsSinks()
+	if len(sinks) == 0 {
+		return []delivery{{event: events[config.CloudEventsFormatTekton]}}
+	}
+	var deliveries []delivery
+	for _, sink := range sinks {
+		for _, format := range sink.Formats {
+			event, ok := events[format]
+			if !ok || !sink.Accepts(event.Type()) {
+				continue
+			}
+			deliveries = append(deliveries, delivery{event: event, target: sink.URI})
+		}
+	}
+	return deliveries
+}
+
+// deliverCloudEvent sends event to target, retrying with exponential backoff
+// until the event is acknowledged or all the attempts failed. In the latter
+// case the event is recorded as a warning event on object, so that it is not
+// lost silently.
+func deliverCloudEvent(ctx context.Context, ceClient CEClient, object runtime.Object, event cloudevents.Event, target string) {
+	logger := logging.FromContext(ctx)
+	sendCtx := ctx
+	if target != "" {
+		sendCtx = cloudevents.ContextWithTarget(ctx, target)
+	}
+
+	start := time.Now()
+	backoff := deliveryBackoff
+	attempts := 0
+	var result protocol.Result
+retry:
+	for {
+		attempts++
+		result = ceClient.Send(sendCtx, event)
+		if cloudevents.IsACK(result) || backoff.Steps <= 1 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			break retry
+		case <-time.After(backoff.Step()):
+		}
+	}
+	delivered := cloudevents.IsACK(result)
+	recordDelivery(ctx, event.Type(), target, attempts, time.Since(start), delivered)
+	if delivered {
+		return
+	}
+
+	logger.Warnf("Failed to send cloudevent %s to %q after %d attempts: %s", event.ID(), target, attempts, result.Error())
+	recorder := controller.GetEventRecorder(ctx)
+	if recorder == nil {
+		logger.Warnf("No recorder in context, cannot emit error event")
+		return
+	}
+	recorder.Eventf(object, corev1.EventTypeWarning, "Cloud Event Failure",
+		"Failed to deliver cloudevent %s of type %q to sink %q after %d attempts: %s",
+		event.ID(), event.Type(), target, attempts, result.Error())
+}
@@ -18,10 +18,12 @@ package cloudevent
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
@@ -29,12 +31,15 @@ import (
 	eventstest "github.com/tektoncd/pipeline/test/events"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	clock "k8s.io/utils/clock/testing"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/metrics/metricstest"
+	_ "knative.dev/pkg/metrics/testing"
 	rtesting "knative.dev/pkg/reconciler/testing"
 )
 
@@ -612,6 +617,7 @@ func TestSendCloudEventWithRetries(t *testing.T) {
 		wantCEvents: []string{"Context Attributes,"},
 		wantEvents:  []string{},
 	}}
+	withFastDeliveryBackoff(t)
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := setupFakeContext(t, tc.clientBehaviour, true, len(tc.wantCEvents))
@@ -677,6 +683,161 @@ func TestSendCloudEventWithRetriesNoClient(t *testing.T) {
 	}
 }
 
+func TestSendCloudEventWithRetriesSinks(t *testing.T) {
+	object := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			SelfLink: "/pipelineruns/test1",
+		},
+		Status: v1beta1.PipelineRunStatus{Status: duckv1beta1.Status{
+			Conditions: []apis.Condition{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionFalse,
+			}},
+		}},
+	}
+	tests := []struct {
+		name        string
+		data        map[string]string
+		wantCEvents []string
+	}{{
+		name: "default sink only",
+		data: map[string]string{
+			"default-cloud-events-sink": "http://sink",
+		},
+		wantCEvents: []string{`(?s)dev.tekton.event.pipelinerun.failed.v1.*test1`},
+	}, {
+		name: "sinks accepting the event type",
+		data: map[string]string{
+			"default-cloud-events-sink": "http://sink",
+			"default-cloud-events-sinks": `
+- uri: http://pipelineruns
+  types: ["dev.tekton.event.pipelinerun.*"]
+- uri: http://failures
+  types: ["dev.tekton.event.pipelinerun.failed.v1"]`,
+		},
+		wantCEvents: []string{
+			`(?s)dev.tekton.event.pipelinerun.failed.v1.*test1`,
+			`(?s)dev.tekton.event.pipelinerun.failed.v1.*test1`,
+			`(?s)dev.tekton.event.pipelinerun.failed.v1.*test1`,
+		},
+	}, {
+		name: "sinks filtering the event type out",
+		data: map[string]string{
+			"default-cloud-events-sinks": `
+- uri: http://taskruns
+  types: ["dev.tekton.event.taskrun.*"]
+- uri: http://failures
+  types: ["dev.tekton.event.pipelinerun.failed.v1"]`,
+		},
+		wantCEvents: []string{`(?s)dev.tekton.event.pipelinerun.failed.v1.*test1`},
+	}, {
+		name: "both formats side by side",
+		data: map[string]string{
+			"default-cloud-events-sink":    "http://sink",
+			"default-cloud-events-formats": "tekton,cdevents",
+		},
+		wantCEvents: []string{
+			`(?s)dev.tekton.event.pipelinerun.failed.v1.*test1`,
+			`(?s)dev.cdevents.pipelinerun.finished.0.1.0.*"outcome": "failure"`,
+		},
+	}, {
+		name: "formats per sink",
+		data: map[string]string{
+			"default-cloud-events-sinks": `
+- uri: http://tekton
+- uri: http://cdevents
+  formats: [cdevents]
+- uri: http://cdevents-started
+  formats: [cdevents]
+  types: ["dev.cdevents.pipelinerun.started.*"]`,
+		},
+		wantCEvents: []string{
+			`(?s)dev.tekton.event.pipelinerun.failed.v1.*test1`,
+			`(?s)dev.cdevents.pipelinerun.finished.0.1.0.*"outcome": "failure"`,
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := setupFakeContext(t, FakeClientBehaviour{SendSuccessfully: true}, true, len(tc.wantCEvents))
+			defaults, err := config.NewDefaultsFromMap(tc.data)
+			if err != nil {
+				t.Fatalf("Unexpected error parsing the defaults: %v", err)
+			}
+			ctx = config.ToContext(ctx, &config.Config{Defaults: defaults})
+			if err := SendCloudEventWithRetries(ctx, object); err != nil {
+				t.Fatalf("Unexpected error sending cloud events: %v", err)
+			}
+			ceClient := Get(ctx).(FakeClient)
+			ceClient.CheckCloudEventsUnordered(t, tc.name, tc.wantCEvents)
+		})
+	}
+}
+
+func TestSendCloudEventWithRetriesDeadLetter(t *testing.T) {
+	withFastDeliveryBackoff(t)
+	metricstest.Unregister("cloudevent_delivery_count", "cloudevent_delivery_attempts", "cloudevent_delivery_latency")
+	registerViewsOnce = sync.Once{}
+
+	object := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			SelfLink: "/taskruns/test1",
+		},
+		Status: v1beta1.TaskRunStatus{Status: duckv1beta1.Status{
+			Conditions: []apis.Condition{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}},
+		}},
+	}
+	ctx := setupFakeContext(t, FakeClientBehaviour{SendSuccessfully: false}, true, 0)
+	defaults, err := config.NewDefaultsFromMap(map[string]string{"default-cloud-events-sink": "http://sink"})
+	if err != nil {
+		t.Fatalf("Unexpected error parsing the defaults: %v", err)
+	}
+	ctx = config.ToContext(ctx, &config.Config{Defaults: defaults})
+	if err := SendCloudEventWithRetries(ctx, object); err != nil {
+		t.Fatalf("Unexpected error sending cloud events: %v", err)
+	}
+	ceClient := Get(ctx).(FakeClient)
+	ceClient.CheckCloudEventsUnordered(t, "dead letter", []string{})
+	recorder := controller.GetEventRecorder(ctx).(*record.FakeRecorder)
+	wantEvents := []string{`Warning Cloud Event Failure Failed to deliver cloudevent .* of type "dev.tekton.event.taskrun.successful.v1" to sink "http://sink" after 3 attempts: Had to fail.*`}
+	if err := eventstest.CheckEventsOrdered(t, recorder.Events, "dead letter", wantEvents); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	tags := map[string]string{
+		"event_type": TaskRunSuccessfulEventV1.String(),
+		"sink":       "http://sink",
+		"status":     deliveryStatusFailed,
+	}
+	metricstest.CheckCountData(t, "cloudevent_delivery_count", tags, 1)
+	metricstest.CheckDistributionCount(t, "cloudevent_delivery_attempts", tags, 1)
+}
+
+func TestSendPipelineTaskCloudEventWithRetries(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			SelfLink: "/pipelineruns/test1",
+		},
+	}
+	ctx := setupFakeContext(t, FakeClientBehaviour{SendSuccessfully: true}, true, 1)
+	if err := SendPipelineTaskCloudEventWithRetries(ctx, pr, PipelineTaskRetriedEventV1, PipelineTaskEventData{Name: "task1", ChildName: "test1-task1", RetryCount: 1}); err != nil {
+		t.Fatalf("Unexpected error sending cloud events: %v", err)
+	}
+	ceClient := Get(ctx).(FakeClient)
+	ceClient.CheckCloudEventsUnordered(t, "pipelinetask event", []string{`(?s)dev.tekton.event.pipelinerun.task.retried.v1.*"pipelineTask": {.*"name": "task1",.*"childName": "test1-task1",.*"retryCount": 1`})
+}
+
+// withFastDeliveryBackoff shortens the backoff between delivery attempts for
+// the duration of the test
+func withFastDeliveryBackoff(t *testing.T) {
+	t.Helper()
+	backoff := deliveryBackoff
+	deliveryBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 2.0, Steps: 3}
+	t.Cleanup(func() { deliveryBackoff = backoff })
+}
+
 func setupFakeContext(t *testing.T, behaviour FakeClientBehaviour, withClient bool, expectedEventCount int) context.Context {
 	var ctx context.Context
 	ctx, _ = rtesting.SetupFakeContext(t)
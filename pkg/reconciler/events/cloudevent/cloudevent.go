@@ -62,6 +62,15 @@ const (
 	PipelineRunSuccessfulEventV1 TektonEventType = "dev.tekton.event.pipelinerun.successful.v1"
 	// PipelineRunFailedEventV1 is sent for PipelineRuns with "ConditionSucceeded" "False"
 	PipelineRunFailedEventV1 TektonEventType = "dev.tekton.event.pipelinerun.failed.v1"
+	// PipelineTaskSkippedEventV1 is sent for PipelineRuns when one of their PipelineTasks
+	// is added to the "SkippedTasks" in the PipelineRun status
+	PipelineTaskSkippedEventV1 TektonEventType = "dev.tekton.event.pipelinerun.task.skipped.v1"
+	// PipelineTaskRetriedEventV1 is sent for PipelineRuns when the TaskRun of one of their
+	// PipelineTasks failed and is retried, i.e. when an entry is added to its "RetriesStatus"
+	PipelineTaskRetriedEventV1 TektonEventType = "dev.tekton.event.pipelinerun.task.retried.v1"
+	// PipelineTaskCancelledEventV1 is sent for PipelineRuns when the TaskRun or Run of one
+	// of their PipelineTasks is cancelled by the PipelineRun
+	PipelineTaskCancelledEventV1 TektonEventType = "dev.tekton.event.pipelinerun.task.cancelled.v1"
 	// RunStartedEventV1 is sent for Runs with "ConditionSucceeded" "Unknown"
 	// the first time they are picked up by the reconciler
 	RunStartedEventV1 TektonEventType = "dev.tekton.event.run.started.v1"
@@ -101,10 +110,24 @@ type CEClient interface {
 // TektonCloudEventData type is used to marshal and unmarshal the payload of
 // a Tekton cloud event. It can include a TaskRun or a PipelineRun
 type TektonCloudEventData struct {
-	TaskRun     *v1beta1.TaskRun     `json:"taskRun,omitempty"`
-	PipelineRun *v1beta1.PipelineRun `json:"pipelineRun,omitempty"`
-	Run         *v1alpha1.Run        `json:"run,omitempty"`
-	CustomRun   *v1beta1.CustomRun   `json:"customRun,omitempty"`
+	TaskRun      *v1beta1.TaskRun       `json:"taskRun,omitempty"`
+	PipelineRun  *v1beta1.PipelineRun   `json:"pipelineRun,omitempty"`
+	Run          *v1alpha1.Run          `json:"run,omitempty"`
+	CustomRun    *v1beta1.CustomRun     `json:"customRun,omitempty"`
+	PipelineTask *PipelineTaskEventData `json:"pipelineTask,omitempty"`
+}
+
+// PipelineTaskEventData is the part of the payload of PipelineTask events
+// that describes the PipelineTask of the PipelineRun the event is about
+type PipelineTaskEventData struct {
+	// Name is the name of the PipelineTask
+	Name string `json:"name"`
+	// ChildName is the name of the TaskRun or Run of the PipelineTask, if any
+	ChildName string `json:"childName,omitempty"`
+	// Reason is the reason why the PipelineTask was skipped
+	Reason string `json:"reason,omitempty"`
+	// RetryCount is the number of times the TaskRun has been retried so far
+	RetryCount int `json:"retryCount,omitempty"`
 }
 
 // newTektonCloudEventData returns a new instance of TektonCloudEventData
@@ -126,9 +149,49 @@ func newTektonCloudEventData(runObject objectWithCondition) TektonCloudEventData
 // eventForObjectWithCondition creates a new event based for a objectWithCondition,
 // or return an error if not possible.
 func eventForObjectWithCondition(runObject objectWithCondition) (*cloudevents.Event, error) {
+	event := newEvent(runObject)
+	eventType, err := getEventType(runObject)
+	if err != nil {
+		return nil, err
+	}
+	if eventType == nil {
+		return nil, errors.New("No matching event type found")
+	}
+	event.SetType(eventType.String())
+
+	if err := event.SetData(cloudevents.ApplicationJSON, newTektonCloudEventData(runObject)); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// eventForPipelineTask creates a new event of the given type about one of the
+// PipelineTasks of a PipelineRun, or return an error if not possible.
+func eventForPipelineTask(pipelineRun *v1beta1.PipelineRun, eventType TektonEventType, pipelineTask PipelineTaskEventData) (*cloudevents.Event, error) {
+	if pipelineRun == nil {
+		return nil, errors.New("Cannot send an event for an empty PipelineRun")
+	}
+	event := newEvent(pipelineRun)
+	event.SetType(eventType.String())
+	data := newTektonCloudEventData(pipelineRun)
+	data.PipelineTask = &pipelineTask
+	if err := event.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// newEvent creates a new event with the ID, subject and source set for runObject
+func newEvent(runObject objectWithCondition) cloudevents.Event {
 	event := cloudevents.NewEvent()
 	event.SetID(uuid.New().String())
 	event.SetSubject(runObject.GetObjectMeta().GetName())
+	event.SetSource(eventSource(runObject))
+	return event
+}
+
+// eventSource returns the source of the events for runObject
+func eventSource(runObject objectWithCondition) string {
 	// TODO: SelfLink is deprecated https://github.com/tektoncd/pipeline/issues/2676
 	source := runObject.GetObjectMeta().GetSelfLink()
 	if source == "" {
@@ -140,20 +203,7 @@ func eventForObjectWithCondition(runObject objectWithCondition) (*cloudevents.Ev
 			gvk.Kind,
 			runObject.GetObjectMeta().GetName())
 	}
-	event.SetSource(source)
-	eventType, err := getEventType(runObject)
-	if err != nil {
-		return nil, err
-	}
-	if eventType == nil {
-		return nil, errors.New("No matching event type found")
-	}
-	event.SetType(eventType.String())
-
-	if err := event.SetData(cloudevents.ApplicationJSON, newTektonCloudEventData(runObject)); err != nil {
-		return nil, err
-	}
-	return &event, nil
+	return source
 }
 
 // eventForTaskRun will create a new event based on a TaskRun,
@@ -266,6 +266,57 @@ func TestEventForPipelineRun(t *testing.T) {
 	}
 }
 
+func TestEventForPipelineTask(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		eventType    TektonEventType
+		pipelineTask PipelineTaskEventData
+	}{{
+		desc:         "send a cloud event for a skipped pipelinetask",
+		eventType:    PipelineTaskSkippedEventV1,
+		pipelineTask: PipelineTaskEventData{Name: "task1", Reason: string(v1beta1.WhenExpressionsSkip)},
+	}, {
+		desc:         "send a cloud event for a retried pipelinetask",
+		eventType:    PipelineTaskRetriedEventV1,
+		pipelineTask: PipelineTaskEventData{Name: "task1", ChildName: "pipelinerun-task1", RetryCount: 2},
+	}, {
+		desc:         "send a cloud event for a cancelled pipelinetask",
+		eventType:    PipelineTaskCancelledEventV1,
+		pipelineTask: PipelineTaskEventData{Name: "task1", ChildName: "pipelinerun-task1"},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pipelineRun := getPipelineRunByCondition(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+			got, err := eventForPipelineTask(pipelineRun, c.eventType, c.pipelineTask)
+			if err != nil {
+				t.Fatalf("I did not expect an error but I got %s", err)
+			}
+			if d := cmp.Diff(pipelineRunName, got.Subject()); d != "" {
+				t.Errorf("Wrong Event ID %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(string(c.eventType), got.Type()); d != "" {
+				t.Errorf("Wrong Event Type %s", diff.PrintWantGot(d))
+			}
+			wantData := TektonCloudEventData{PipelineRun: pipelineRun, PipelineTask: &c.pipelineTask}
+			gotData := TektonCloudEventData{}
+			if err := got.DataAs(&gotData); err != nil {
+				t.Errorf("Unexpected error from DataAsl; %s", err)
+			}
+			if d := cmp.Diff(wantData, gotData); d != "" {
+				t.Errorf("Wrong Event data %s", diff.PrintWantGot(d))
+			}
+			if err := got.Validate(); err != nil {
+				t.Errorf("Expected event to be valid; %s", err)
+			}
+		})
+	}
+}
+
+func TestEventForPipelineTaskNoPipelineRun(t *testing.T) {
+	if _, err := eventForPipelineTask(nil, PipelineTaskSkippedEventV1, PipelineTaskEventData{Name: "task1"}); err == nil {
+		t.Fatalf("Expected an error creating an event for an empty PipelineRun, got none")
+	}
+}
+
 func TestEventForRun(t *testing.T) {
 	runTests := []struct {
 		desc          string
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	// deliveryStatusDelivered is the status of cloud events acknowledged by a sink
+	deliveryStatusDelivered = "delivered"
+	// deliveryStatusFailed is the status of cloud events that could not be delivered
+	// to a sink after all the attempts
+	deliveryStatusFailed = "failed"
+)
+
+var (
+	eventTypeTag = tag.MustNewKey("event_type")
+	sinkTag      = tag.MustNewKey("sink")
+	statusTag    = tag.MustNewKey("status")
+
+	deliveryCount = stats.Int64("cloudevent_delivery_count",
+		"number of cloud events delivered to, or failed to be delivered to, a sink",
+		stats.UnitDimensionless)
+
+	deliveryAttempts = stats.Int64("cloudevent_delivery_attempts",
+		"number of attempts made to deliver a cloud event to a sink",
+		stats.UnitDimensionless)
+
+	deliveryLatency = stats.Float64("cloudevent_delivery_latency",
+		"time taken to deliver a cloud event to a sink, including retries",
+		stats.UnitMilliseconds)
+
+	registerViewsOnce sync.Once
+)
+
+// registerViews registers the views of the delivery metrics. Views cannot be
+// registered more than once, so this is done only the first time it's called.
+func registerViews(ctx context.Context) {
+	registerViewsOnce.Do(func() {
+		tagKeys := []tag.Key{eventTypeTag, sinkTag, statusTag}
+		if err := view.Register(&view.View{
+			Description: deliveryCount.Description(),
+			Measure:     deliveryCount,
+			Aggregation: view.Count(),
+			TagKeys:     tagKeys,
+		}, &view.View{
+			Description: deliveryAttempts.Description(),
+			Measure:     deliveryAttempts,
+			Aggregation: view.Distribution(1, 2, 3, 5, 10),
+			TagKeys:     tagKeys,
+		}, &view.View{
+			Description: deliveryLatency.Description(),
+			Measure:     deliveryLatency,
+			Aggregation: view.Distribution(10, 50, 100, 500, 1000, 5000, 10000, 30000),
+			TagKeys:     tagKeys,
+		}); err != nil {
+			logging.FromContext(ctx).Warnf("Failed to register the cloud events delivery metrics: %v", err)
+		}
+	})
+}
+
+// recordDelivery records the outcome of the delivery of a cloud event of type
+// eventType to sink, which took the given attempts and time.
+func recordDelivery(ctx context.Context, eventType, sink string, attempts int, latency time.Duration, delivered bool) {
+	registerViews(ctx)
+	status := deliveryStatusDelivered
+	if !delivered {
+		status = deliveryStatusFailed
+	}
+	ctx, err := tag.New(ctx,
+		tag.Insert(eventTypeTag, eventType),
+		tag.Insert(sinkTag, sink),
+		tag.Insert(statusTag, status))
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Failed to record the cloud events delivery metrics: %v", err)
+		return
+	}
+	metrics.Record(ctx, deliveryCount.M(1))
+	metrics.Record(ctx, deliveryAttempts.M(int64(attempts)))
+	metrics.Record(ctx, deliveryLatency.M(float64(latency/time.Millisecond)))
+}
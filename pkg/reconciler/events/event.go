@@ -19,8 +19,8 @@ package events
 import (
 	"context"
 
-	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -46,15 +46,11 @@ const (
 // Two types of events are supported, k8s and cloud events.
 //
 // k8s events are always sent if afterCondition is different from beforeCondition
-// Cloud events are always sent if enabled, i.e. if a sink is available
+// Cloud events are always sent if enabled, i.e. if at least one sink is configured
 func Emit(ctx context.Context, beforeCondition *apis.Condition, afterCondition *apis.Condition, object runtime.Object) {
 	recorder := controller.GetEventRecorder(ctx)
 	logger := logging.FromContext(ctx)
-	configs := config.FromContextOrDefaults(ctx)
-	sendCloudEvents := (configs.Defaults.DefaultCloudEventsSink != "")
-	if sendCloudEvents {
-		ctx = cloudevents.ContextWithTarget(ctx, configs.Defaults.DefaultCloudEventsSink)
-	}
+	sendCloudEvents := cloudEventsSinksConfigured(ctx)
 
 	sendKubernetesEvents(recorder, beforeCondition, afterCondition, object)
 
@@ -72,13 +68,7 @@ func Emit(ctx context.Context, beforeCondition *apis.Condition, afterCondition *
 // EmitCloudEvents emits CloudEvents (only) for object
 func EmitCloudEvents(ctx context.Context, object runtime.Object) {
 	logger := logging.FromContext(ctx)
-	configs := config.FromContextOrDefaults(ctx)
-	sendCloudEvents := (configs.Defaults.DefaultCloudEventsSink != "")
-	if sendCloudEvents {
-		ctx = cloudevents.ContextWithTarget(ctx, configs.Defaults.DefaultCloudEventsSink)
-	}
-
-	if sendCloudEvents {
+	if cloudEventsSinksConfigured(ctx) {
 		err := cloudevent.SendCloudEventWithRetries(ctx, object)
 		if err != nil {
 			logger.Warnf("Failed to emit cloud events %v", err.Error())
@@ -86,6 +76,26 @@ func EmitCloudEvents(ctx context.Context, object runtime.Object) {
 	}
 }
 
+// EmitPipelineTaskCloudEvent emits a CloudEvent (only) of the given type about
+// one of the PipelineTasks of pr. It returns true if the event was emitted.
+func EmitPipelineTaskCloudEvent(ctx context.Context, pr *v1beta1.PipelineRun, eventType cloudevent.TektonEventType, pipelineTask cloudevent.PipelineTaskEventData) bool {
+	logger := logging.FromContext(ctx)
+	if !cloudEventsSinksConfigured(ctx) {
+		return false
+	}
+	if err := cloudevent.SendPipelineTaskCloudEventWithRetries(ctx, pr, eventType, pipelineTask); err != nil {
+		logger.Warnf("Failed to emit cloud events %v", err.Error())
+		return false
+	}
+	return true
+}
+
+// cloudEventsSinksConfigured returns true if cloud events are enabled, i.e. if
+// at least one sink is configured
+func cloudEventsSinksConfigured(ctx context.Context) bool {
+	return len(config.FromContextOrDefaults(ctx).Defaults.CloudEventsSinks()) > 0
+}
+
 func sendKubernetesEvents(c record.EventRecorder, beforeCondition *apis.Condition, afterCondition *apis.Condition, object runtime.Object) {
 	// Events that are going to be sent
 	//
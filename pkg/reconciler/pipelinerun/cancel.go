@@ -28,6 +28,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"go.uber.org/zap"
 	jsonpatch "gomodules.xyz/jsonpatch/v2"
 	corev1 "k8s.io/api/core/v1"
@@ -38,7 +40,11 @@ import (
 	"knative.dev/pkg/apis"
 )
 
-var cancelTaskRunPatchBytes, cancelRunPatchBytes []byte
+// cancellationRequestedAtAnnotation records when the cancellation of a Run or CustomRun with a
+// cancellation grace period was first observed by the PipelineRun controller.
+const cancellationRequestedAtAnnotation = "tekton.dev/cancellationRequestedAt"
+
+var cancelTaskRunPatchBytes, cancelRunPatchBytes, cancelCustomRunPatchBytes []byte
 
 func init() {
 	var err error
@@ -70,26 +76,65 @@ func init() {
 	if err != nil {
 		log.Fatalf("failed to marshal Run cancel patch bytes: %v", err)
 	}
+	cancelCustomRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{
+		{
+			Operation: "add",
+			Path:      "/spec/status",
+			Value:     v1beta1.CustomRunSpecStatusCancelled,
+		},
+		{
+			Operation: "add",
+			Path:      "/spec/statusMessage",
+			Value:     v1beta1.CustomRunCancelledByPipelineMsg,
+		}})
+	if err != nil {
+		log.Fatalf("failed to marshal CustomRun cancel patch bytes: %v", err)
+	}
 }
 
-func cancelRun(ctx context.Context, runName string, namespace string, clientSet clientset.Interface) error {
-	_, err := clientSet.TektonV1alpha1().Runs(namespace).Patch(ctx, runName, types.JSONPatchType, cancelRunPatchBytes, metav1.PatchOptions{}, "")
+// cancelRun patches the Run with cancelled status. It returns true if the Run
+// was still running, i.e. if it is actually cancelled.
+func cancelRun(ctx context.Context, runName string, namespace string, clientSet clientset.Interface) (bool, error) {
+	run, err := clientSet.TektonV1alpha1().Runs(namespace).Patch(ctx, runName, types.JSONPatchType, cancelRunPatchBytes, metav1.PatchOptions{}, "")
 	if errors.IsNotFound(err) {
 		// The resource may have been deleted in the meanwhile, but we should
 		// still be able to cancel the PipelineRun
-		return nil
+		return false, nil
 	}
-	return err
+	if err != nil {
+		return false, err
+	}
+	return !run.IsDone(), nil
 }
 
-func cancelTaskRun(ctx context.Context, taskRunName string, namespace string, clientSet clientset.Interface) error {
-	_, err := clientSet.TektonV1beta1().TaskRuns(namespace).Patch(ctx, taskRunName, types.JSONPatchType, cancelTaskRunPatchBytes, metav1.PatchOptions{}, "")
+// cancelCustomRun patches the CustomRun with cancelled status. It returns true if the
+// CustomRun was still running, i.e. if it is actually cancelled.
+func cancelCustomRun(ctx context.Context, customRunName string, namespace string, clientSet clientset.Interface) (bool, error) {
+	customRun, err := clientSet.TektonV1beta1().CustomRuns(namespace).Patch(ctx, customRunName, types.JSONPatchType, cancelCustomRunPatchBytes, metav1.PatchOptions{}, "")
 	if errors.IsNotFound(err) {
 		// The resource may have been deleted in the meanwhile, but we should
 		// still be able to cancel the PipelineRun
-		return nil
+		return false, nil
 	}
-	return err
+	if err != nil {
+		return false, err
+	}
+	return !customRun.IsDone(), nil
+}
+
+// cancelTaskRun patches the TaskRun with cancelled status. It returns true if
+// the TaskRun was still running, i.e. if it is actually cancelled.
+func cancelTaskRun(ctx context.Context, taskRunName string, namespace string, clientSet clientset.Interface) (bool, error) {
+	tr, err := clientSet.TektonV1beta1().TaskRuns(namespace).Patch(ctx, taskRunName, types.JSONPatchType, cancelTaskRunPatchBytes, metav1.PatchOptions{}, "")
+	if errors.IsNotFound(err) {
+		// The resource may have been deleted in the meanwhile, but we should
+		// still be able to cancel the PipelineRun
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !tr.IsDone(), nil
 }
 
 // cancelPipelineRun marks the PipelineRun as cancelled and any resolved TaskRun(s) too.
@@ -122,16 +167,16 @@ func cancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1bet
 	return nil
 }
 
-// cancelPipelineTaskRuns patches `TaskRun` and `Run` with canceled status
+// cancelPipelineTaskRuns patches `TaskRun`, `Run` and `CustomRun` with canceled status
 func cancelPipelineTaskRuns(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface) []string {
 	return cancelPipelineTaskRunsForTaskNames(ctx, logger, pr, clientSet, sets.NewString())
 }
 
-// cancelPipelineTaskRunsForTaskNames patches `TaskRun`s and `Run`s for the given task names, or all if no task names are given, with canceled status
+// cancelPipelineTaskRunsForTaskNames patches `TaskRun`s, `Run`s and `CustomRun`s for the given task names, or all if no task names are given, with canceled status
 func cancelPipelineTaskRunsForTaskNames(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface, taskNames sets.String) []string {
 	errs := []string{}
 
-	trNames, runNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, pr.Status, taskNames)
+	trNames, runNames, customRunNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, pr.Status, taskNames)
 	if err != nil {
 		errs = append(errs, err.Error())
 	}
@@ -139,31 +184,54 @@ func cancelPipelineTaskRunsForTaskNames(ctx context.Context, logger *zap.Sugared
 	for _, taskRunName := range trNames {
 		logger.Infof("cancelling TaskRun %s", taskRunName)
 
-		if err := cancelTaskRun(ctx, taskRunName, pr.Namespace, clientSet); err != nil {
+		cancelled, err := cancelTaskRun(ctx, taskRunName, pr.Namespace, clientSet)
+		if err != nil {
 			errs = append(errs, fmt.Errorf("Failed to patch TaskRun `%s` with cancellation: %s", taskRunName, err).Error())
 			continue
 		}
+		if cancelled {
+			emitPipelineTaskCancelledEvent(ctx, pr, taskRunName)
+		}
 	}
 
 	for _, runName := range runNames {
 		logger.Infof("cancelling Run %s", runName)
 
-		if err := cancelRun(ctx, runName, pr.Namespace, clientSet); err != nil {
+		cancelled, err := cancelRun(ctx, runName, pr.Namespace, clientSet)
+		if err != nil {
 			errs = append(errs, fmt.Errorf("Failed to patch Run `%s` with cancellation: %s", runName, err).Error())
 			continue
 		}
+		if cancelled {
+			emitPipelineTaskCancelledEvent(ctx, pr, runName)
+		}
+	}
+
+	for _, customRunName := range customRunNames {
+		logger.Infof("cancelling CustomRun %s", customRunName)
+
+		cancelled, err := cancelCustomRun(ctx, customRunName, pr.Namespace, clientSet)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed to patch CustomRun `%s` with cancellation: %s", customRunName, err).Error())
+			continue
+		}
+		if cancelled {
+			emitPipelineTaskCancelledEvent(ctx, pr, customRunName)
+		}
 	}
 
 	return errs
 }
 
-// getChildObjectsFromPRStatusForTaskNames returns taskruns and runs in the PipelineRunStatus's ChildReferences or TaskRuns/Runs,
-// based on the value of the embedded status flag and the given set of PipelineTask names. If that set is empty, all are returned.
-func getChildObjectsFromPRStatusForTaskNames(ctx context.Context, prs v1beta1.PipelineRunStatus, taskNames sets.String) ([]string, []string, error) {
+// getChildObjectsFromPRStatusForTaskNames returns taskruns, runs and customruns in the PipelineRunStatus's ChildReferences or
+// TaskRuns/Runs, based on the value of the embedded status flag and the given set of PipelineTask names. If that set is empty,
+// all are returned. CustomRuns are only ever recorded in the ChildReferences.
+func getChildObjectsFromPRStatusForTaskNames(ctx context.Context, prs v1beta1.PipelineRunStatus, taskNames sets.String) ([]string, []string, []string, error) {
 	cfg := config.FromContextOrDefaults(ctx)
 
 	var trNames []string
 	var runNames []string
+	var customRunNames []string
 	unknownChildKinds := make(map[string]string)
 
 	if cfg.FeatureFlags.EmbeddedStatus != config.FullEmbeddedStatus {
@@ -174,6 +242,8 @@ func getChildObjectsFromPRStatusForTaskNames(ctx context.Context, prs v1beta1.Pi
 					trNames = append(trNames, cr.Name)
 				case "Run":
 					runNames = append(runNames, cr.Name)
+				case "CustomRun":
+					customRunNames = append(customRunNames, cr.Name)
 				default:
 					unknownChildKinds[cr.Name] = cr.Kind
 				}
@@ -197,12 +267,36 @@ func getChildObjectsFromPRStatusForTaskNames(ctx context.Context, prs v1beta1.Pi
 		err = fmt.Errorf("found child objects of unknown kinds: %v", unknownChildKinds)
 	}
 
-	return trNames, runNames, err
+	return trNames, runNames, customRunNames, err
+}
+
+// emitPipelineTaskCancelledEvent emits a cloud event for the cancellation of
+// the TaskRun or Run with the given name, a child of pr.
+func emitPipelineTaskCancelledEvent(ctx context.Context, pr *v1beta1.PipelineRun, childName string) {
+	var pipelineTaskName string
+	for _, cr := range pr.Status.ChildReferences {
+		if cr.Name == childName {
+			pipelineTaskName = cr.PipelineTaskName
+		}
+	}
+	if trs, ok := pr.Status.TaskRuns[childName]; ok {
+		pipelineTaskName = trs.PipelineTaskName
+	}
+	if rs, ok := pr.Status.Runs[childName]; ok {
+		pipelineTaskName = rs.PipelineTaskName
+	}
+	events.EmitPipelineTaskCloudEvent(ctx, pr, cloudevent.PipelineTaskCancelledEventV1, cloudevent.PipelineTaskEventData{
+		Name:      pipelineTaskName,
+		ChildName: childName,
+	})
 }
 
 // gracefullyCancelPipelineRun marks any non-final resolved TaskRun(s) as cancelled and runs finally.
-func gracefullyCancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface) error {
-	errs := cancelPipelineTaskRuns(ctx, logger, pr, clientSet)
+// Runs and CustomRuns which have not acknowledged their cancellation within their cancellation
+// grace period are marked as forcibly cancelled.
+func (c *Reconciler) gracefullyCancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun) error {
+	errs := cancelPipelineTaskRuns(ctx, logger, pr, c.PipelineClientSet)
+	errs = append(errs, c.forceCancelCustomTasks(ctx, logger, pr)...)
 
 	// If we successfully cancelled all the TaskRuns and Runs, we can proceed with the PipelineRun reconciliation to trigger finally.
 	if len(errs) > 0 {
@@ -218,3 +312,171 @@ func gracefullyCancelPipelineRun(ctx context.Context, logger *zap.SugaredLogger,
 	}
 	return nil
 }
+
+// forceCancelCustomTasks enforces the cancellation grace period of the cancelled Runs and
+// CustomRuns of pr, as seen by the listers. The first time a cancelled Run or CustomRun with a
+// grace period is seen, the time of its cancellation is recorded in the
+// cancellationRequestedAtAnnotation; once the grace period has elapsed since then, it is
+// marked as failed with a forcibly cancelled reason, so that the PipelineRun can proceed.
+// Runs and CustomRuns are only written to when one of these two changes is due.
+func (c *Reconciler) forceCancelCustomTasks(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun) []string {
+	errs := []string{}
+	_, runNames, customRunNames, _ := getChildObjectsFromPRStatusForTaskNames(ctx, pr.Status, sets.NewString())
+	for _, runName := range runNames {
+		run, err := c.runLister.Runs(pr.Namespace).Get(runName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed to get Run `%s`: %s", runName, err).Error())
+			continue
+		}
+		if run.IsDone() || !run.IsCancelled() || run.Spec.CancellationGracePeriod == nil {
+			continue
+		}
+		gracePeriod := run.Spec.CancellationGracePeriod.Duration
+		requestedAt, ok := cancellationRequestedAt(run)
+		switch {
+		case !ok:
+			if err := c.markRunCancellationRequested(ctx, run); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to patch Run `%s` with cancellation time: %s", runName, err).Error())
+			}
+		case c.Clock.Since(requestedAt) >= gracePeriod:
+			logger.Infof("forcibly cancelling Run %s after a grace period of %s", runName, gracePeriod)
+			run = run.DeepCopy()
+			run.Status.SetCondition(&apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1alpha1.RunReasonForciblyCancelled.String(),
+				Message: fmt.Sprintf("Run %q was not cancelled by its controller within %s", runName, gracePeriod),
+			})
+			run.Status.CompletionTime = &metav1.Time{Time: c.Clock.Now()}
+			if _, err := c.PipelineClientSet.TektonV1alpha1().Runs(pr.Namespace).UpdateStatus(ctx, run, metav1.UpdateOptions{}); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to update the status of Run `%s` with forced cancellation: %s", runName, err).Error())
+			}
+		}
+	}
+	for _, customRunName := range customRunNames {
+		customRun, err := c.customRunLister.CustomRuns(pr.Namespace).Get(customRunName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Failed to get CustomRun `%s`: %s", customRunName, err).Error())
+			continue
+		}
+		if customRun.IsDone() || !customRun.IsCancelled() || customRun.Spec.CancellationGracePeriod == nil {
+			continue
+		}
+		gracePeriod := customRun.Spec.CancellationGracePeriod.Duration
+		requestedAt, ok := cancellationRequestedAt(customRun)
+		switch {
+		case !ok:
+			if err := c.markCustomRunCancellationRequested(ctx, customRun); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to patch CustomRun `%s` with cancellation time: %s", customRunName, err).Error())
+			}
+		case c.Clock.Since(requestedAt) >= gracePeriod:
+			logger.Infof("forcibly cancelling CustomRun %s after a grace period of %s", customRunName, gracePeriod)
+			customRun = customRun.DeepCopy()
+			customRun.Status.SetCondition(&apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1beta1.CustomRunReasonForciblyCancelled.String(),
+				Message: fmt.Sprintf("CustomRun %q was not cancelled by its controller within %s", customRunName, gracePeriod),
+			})
+			customRun.Status.CompletionTime = &metav1.Time{Time: c.Clock.Now()}
+			if _, err := c.PipelineClientSet.TektonV1beta1().CustomRuns(pr.Namespace).UpdateStatus(ctx, customRun, metav1.UpdateOptions{}); err != nil {
+				errs = append(errs, fmt.Errorf("Failed to update the status of CustomRun `%s` with forced cancellation: %s", customRunName, err).Error())
+			}
+		}
+	}
+	return errs
+}
+
+// cancellationRequestedAt returns the time recorded in the cancellationRequestedAtAnnotation of obj.
+func cancellationRequestedAt(obj metav1.Object) (time.Time, bool) {
+	v, ok := obj.GetAnnotations()[cancellationRequestedAtAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// cancellationRequestedPatch returns a merge patch recording now in the cancellationRequestedAtAnnotation.
+func cancellationRequestedPatch(now time.Time) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				cancellationRequestedAtAnnotation: now.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+}
+
+// markRunCancellationRequested records the current time in the cancellationRequestedAtAnnotation of run.
+func (c *Reconciler) markRunCancellationRequested(ctx context.Context, run *v1alpha1.Run) error {
+	patch, err := cancellationRequestedPatch(c.Clock.Now())
+	if err != nil {
+		return err
+	}
+	_, err = c.PipelineClientSet.TektonV1alpha1().Runs(run.Namespace).Patch(ctx, run.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// markCustomRunCancellationRequested records the current time in the cancellationRequestedAtAnnotation of customRun.
+func (c *Reconciler) markCustomRunCancellationRequested(ctx context.Context, customRun *v1beta1.CustomRun) error {
+	patch, err := cancellationRequestedPatch(c.Clock.Now())
+	if err != nil {
+		return err
+	}
+	_, err = c.PipelineClientSet.TektonV1beta1().CustomRuns(customRun.Namespace).Patch(ctx, customRun.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// cancellationGracePeriodWaitTime returns the time left until the earliest end of the cancellation
+// grace period of the Runs and CustomRuns of a gracefully cancelled PipelineRun, or zero if there is none.
+func (c *Reconciler) cancellationGracePeriodWaitTime(ctx context.Context, pr *v1beta1.PipelineRun) time.Duration {
+	if !pr.IsGracefullyCancelled() || pr.IsDone() {
+		return 0
+	}
+	var waitTime time.Duration
+	earliest := func(obj metav1.Object, gracePeriod time.Duration) {
+		requestedAt, ok := cancellationRequestedAt(obj)
+		if !ok {
+			return
+		}
+		left := gracePeriod - c.Clock.Since(requestedAt)
+		if left <= 0 {
+			left = time.Nanosecond
+		}
+		if waitTime == 0 || left < waitTime {
+			waitTime = left
+		}
+	}
+	_, runNames, customRunNames, _ := getChildObjectsFromPRStatusForTaskNames(ctx, pr.Status, sets.NewString())
+	for _, runName := range runNames {
+		run, err := c.runLister.Runs(pr.Namespace).Get(runName)
+		if err != nil || run.IsDone() || run.Spec.CancellationGracePeriod == nil {
+			continue
+		}
+		earliest(run, run.Spec.CancellationGracePeriod.Duration)
+	}
+	for _, customRunName := range customRunNames {
+		customRun, err := c.customRunLister.CustomRuns(pr.Namespace).Get(customRunName)
+		if err != nil || customRun.IsDone() || customRun.Spec.CancellationGracePeriod == nil {
+			continue
+		}
+		earliest(customRun, customRun.Spec.CancellationGracePeriod.Duration)
+	}
+	return waitTime
+}
@@ -19,6 +19,7 @@ package pipelinerun
 import (
 	"context"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -33,8 +34,11 @@ import (
 	_ "github.com/tektoncd/pipeline/pkg/pipelinerunmetrics/fake" // Make sure the pipelinerunmetrics are setup
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/test"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clock "k8s.io/utils/clock/testing"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	logtesting "knative.dev/pkg/logging/testing"
 )
 
@@ -45,6 +49,7 @@ func TestCancelPipelineRun(t *testing.T) {
 		pipelineRun    *v1beta1.PipelineRun
 		taskRuns       []*v1beta1.TaskRun
 		runs           []*v1alpha1.Run
+		customRuns     []*v1beta1.CustomRun
 		wantErr        bool
 	}{{
 		name:           "no-resolved-taskrun",
@@ -306,6 +311,33 @@ func TestCancelPipelineRun(t *testing.T) {
 		runs: []*v1alpha1.Run{
 			{ObjectMeta: metav1.ObjectMeta{Name: "r1"}},
 		},
+	}, {
+		name:           "child-references-with-customruns",
+		embeddedStatus: config.MinimalEmbeddedStatus,
+		pipelineRun: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-cancelled"},
+			Spec: v1beta1.PipelineRunSpec{
+				Status: v1beta1.PipelineRunSpecStatusCancelled,
+			},
+			Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				ChildReferences: []v1beta1.ChildStatusReference{
+					{
+						TypeMeta:         runtime.TypeMeta{Kind: "CustomRun"},
+						Name:             "cr1",
+						PipelineTaskName: "custom-run-1",
+					},
+					{
+						TypeMeta:         runtime.TypeMeta{Kind: "CustomRun"},
+						Name:             "cr2",
+						PipelineTaskName: "custom-run-2",
+					},
+				},
+			}},
+		},
+		customRuns: []*v1beta1.CustomRun{
+			{ObjectMeta: metav1.ObjectMeta{Name: "cr1"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "cr2"}},
+		},
 	}, {
 		name:           "unknown-kind-on-child-references",
 		embeddedStatus: config.MinimalEmbeddedStatus,
@@ -332,6 +364,7 @@ func TestCancelPipelineRun(t *testing.T) {
 				PipelineRuns: []*v1beta1.PipelineRun{tc.pipelineRun},
 				TaskRuns:     tc.taskRuns,
 				Runs:         tc.runs,
+				CustomRuns:   tc.customRuns,
 			}
 			ctx, _ := ttesting.SetupFakeContext(t)
 			cfg := config.NewStore(logtesting.TestLogger(t))
@@ -385,20 +418,167 @@ func TestCancelPipelineRun(t *testing.T) {
 						}
 					}
 				}
+				for _, expectedCustomRun := range tc.customRuns {
+					cr, err := c.Pipeline.TektonV1beta1().CustomRuns("").Get(ctx, expectedCustomRun.Name, metav1.GetOptions{})
+					if err != nil {
+						t.Fatalf("couldn't get expected CustomRun %s, got error %s", expectedCustomRun.Name, err)
+					}
+					if cr.Spec.Status != v1beta1.CustomRunSpecStatusCancelled {
+						t.Errorf("expected task %q to be marked as cancelled, was %q", cr.Name, cr.Spec.Status)
+					}
+					expectedStatusMessage := v1beta1.CustomRunCancelledByPipelineMsg
+					if cr.Spec.StatusMessage != expectedStatusMessage {
+						t.Errorf("expected task %q to have status message %s but was %s", cr.Name, expectedStatusMessage, cr.Spec.StatusMessage)
+					}
+				}
 			}
 		})
 	}
 }
 
+func TestGracefullyCancelPipelineRunCancellationGracePeriod(t *testing.T) {
+	now := time.Date(2022, time.November, 1, 12, 0, 0, 0, time.UTC)
+	gracePeriod := &metav1.Duration{Duration: time.Minute}
+	cancelled := duckv1.Status{Conditions: []apis.Condition{{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionFalse,
+		Reason: v1alpha1.RunReasonCancelled.String(),
+	}}}
+	testCases := []struct {
+		name           string
+		annotations    map[string]string
+		specStatus     string
+		gracePeriod    *metav1.Duration
+		status         duckv1.Status
+		wantAnnotation string
+		wantForced     bool
+		wantWrites     int
+	}{{
+		name:       "no grace period",
+		specStatus: string(v1alpha1.RunSpecStatusCancelled),
+	}, {
+		name:        "cancellation not observed yet",
+		gracePeriod: gracePeriod,
+	}, {
+		name:           "cancellation time recorded",
+		specStatus:     string(v1alpha1.RunSpecStatusCancelled),
+		gracePeriod:    gracePeriod,
+		wantAnnotation: "2022-11-01T12:00:00Z",
+		wantWrites:     1,
+	}, {
+		name:           "grace period not elapsed",
+		annotations:    map[string]string{cancellationRequestedAtAnnotation: "2022-11-01T11:59:30Z"},
+		specStatus:     string(v1alpha1.RunSpecStatusCancelled),
+		gracePeriod:    gracePeriod,
+		wantAnnotation: "2022-11-01T11:59:30Z",
+	}, {
+		name:           "grace period elapsed",
+		annotations:    map[string]string{cancellationRequestedAtAnnotation: "2022-11-01T11:59:00Z"},
+		specStatus:     string(v1alpha1.RunSpecStatusCancelled),
+		gracePeriod:    gracePeriod,
+		wantAnnotation: "2022-11-01T11:59:00Z",
+		wantForced:     true,
+		wantWrites:     1,
+	}, {
+		name:           "already done",
+		annotations:    map[string]string{cancellationRequestedAtAnnotation: "2022-11-01T11:50:00Z"},
+		specStatus:     string(v1alpha1.RunSpecStatusCancelled),
+		gracePeriod:    gracePeriod,
+		status:         cancelled,
+		wantAnnotation: "2022-11-01T11:50:00Z",
+	}}
+	for _, tc := range testCases {
+		for _, kind := range []string{"Run", "CustomRun"} {
+			t.Run(kind+"/"+tc.name, func(t *testing.T) {
+				pr := &v1beta1.PipelineRun{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline-run-cancelled", Namespace: "foo"},
+					Spec: v1beta1.PipelineRunSpec{
+						Status: v1beta1.PipelineRunSpecStatusCancelled,
+					},
+					Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+						ChildReferences: []v1beta1.ChildStatusReference{{
+							TypeMeta:         runtime.TypeMeta{Kind: kind},
+							Name:             "r1",
+							PipelineTaskName: "task-1",
+						}},
+					}},
+				}
+				d := test.Data{PipelineRuns: []*v1beta1.PipelineRun{pr}}
+				objectMeta := metav1.ObjectMeta{Name: "r1", Namespace: "foo", Annotations: tc.annotations}
+				if kind == "Run" {
+					d.Runs = []*v1alpha1.Run{{
+						ObjectMeta: objectMeta,
+						Spec:       v1alpha1.RunSpec{Status: v1alpha1.RunSpecStatus(tc.specStatus), CancellationGracePeriod: tc.gracePeriod},
+						Status:     v1alpha1.RunStatus{Status: tc.status},
+					}}
+				} else {
+					d.CustomRuns = []*v1beta1.CustomRun{{
+						ObjectMeta: objectMeta,
+						Spec:       v1beta1.CustomRunSpec{Status: v1beta1.CustomRunSpecStatus(tc.specStatus), CancellationGracePeriod: tc.gracePeriod},
+						Status:     v1beta1.CustomRunStatus{Status: tc.status},
+					}}
+				}
+				ctx, _ := ttesting.SetupFakeContext(t)
+				cfg := config.NewStore(logtesting.TestLogger(t))
+				cfg.OnConfigChanged(withCustomTasks(withEmbeddedStatus(newFeatureFlagsConfigMap(), config.MinimalEmbeddedStatus)))
+				ctx = cfg.ToContext(ctx)
+				ctx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				c, informers := test.SeedTestData(t, ctx, d)
+				c.Pipeline.ClearActions()
+				r := &Reconciler{
+					PipelineClientSet: c.Pipeline,
+					Clock:             clock.NewFakePassiveClock(now),
+					runLister:         informers.Run.Lister(),
+					customRunLister:   informers.CustomRun.Lister(),
+				}
+
+				if errs := r.forceCancelCustomTasks(ctx, logtesting.TestLogger(t), pr); len(errs) > 0 {
+					t.Fatal(errs)
+				}
+				if writes := len(c.Pipeline.Actions()); writes != tc.wantWrites {
+					t.Errorf("expected %d writes, got %d: %v", tc.wantWrites, writes, c.Pipeline.Actions())
+				}
+				var annotations map[string]string
+				var cond *apis.Condition
+				if kind == "Run" {
+					run, err := c.Pipeline.TektonV1alpha1().Runs("foo").Get(ctx, "r1", metav1.GetOptions{})
+					if err != nil {
+						t.Fatalf("couldn't get Run r1: %v", err)
+					}
+					annotations, cond = run.Annotations, run.Status.GetCondition(apis.ConditionSucceeded)
+				} else {
+					customRun, err := c.Pipeline.TektonV1beta1().CustomRuns("foo").Get(ctx, "r1", metav1.GetOptions{})
+					if err != nil {
+						t.Fatalf("couldn't get CustomRun r1: %v", err)
+					}
+					annotations, cond = customRun.Annotations, customRun.Status.GetCondition(apis.ConditionSucceeded)
+				}
+				if d := cmp.Diff(tc.wantAnnotation, annotations[cancellationRequestedAtAnnotation]); d != "" {
+					t.Errorf("unexpected cancellation time %s", diff.PrintWantGot(d))
+				}
+				forced := cond.GetReason() == v1alpha1.RunReasonForciblyCancelled.String() || cond.GetReason() == v1beta1.CustomRunReasonForciblyCancelled.String()
+				if forced != tc.wantForced {
+					t.Errorf("expected %s to be forcibly cancelled: %t, got condition %v", kind, tc.wantForced, cond)
+				}
+				if forced && !cond.IsFalse() {
+					t.Errorf("expected forcibly cancelled %s to be done", kind)
+				}
+			})
+		}
+	}
+}
+
 func TestGetChildObjectsFromPRStatusForTaskNames(t *testing.T) {
 	testCases := []struct {
-		name             string
-		embeddedStatus   string
-		prStatus         v1beta1.PipelineRunStatus
-		taskNames        sets.String
-		expectedTRNames  []string
-		expectedRunNames []string
-		hasError         bool
+		name                   string
+		embeddedStatus         string
+		prStatus               v1beta1.PipelineRunStatus
+		taskNames              sets.String
+		expectedTRNames        []string
+		expectedRunNames       []string
+		expectedCustomRunNames []string
+		hasError               bool
 	}{
 		{
 			name:           "single taskrun, default embedded",
@@ -508,6 +688,30 @@ func TestGetChildObjectsFromPRStatusForTaskNames(t *testing.T) {
 			expectedTRNames:  nil,
 			expectedRunNames: []string{"r1"},
 			hasError:         false,
+		}, {
+			name:           "run and customrun, minimal embedded",
+			embeddedStatus: config.MinimalEmbeddedStatus,
+			prStatus: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				ChildReferences: []v1beta1.ChildStatusReference{{
+					TypeMeta: runtime.TypeMeta{
+						APIVersion: "v1alpha1",
+						Kind:       "Run",
+					},
+					Name:             "r1",
+					PipelineTaskName: "run-1",
+				}, {
+					TypeMeta: runtime.TypeMeta{
+						APIVersion: "v1beta1",
+						Kind:       "CustomRun",
+					},
+					Name:             "cr1",
+					PipelineTaskName: "custom-run-1",
+				}},
+			}},
+			expectedTRNames:        nil,
+			expectedRunNames:       []string{"r1"},
+			expectedCustomRunNames: []string{"cr1"},
+			hasError:               false,
 		}, {
 			name:           "unknown kind",
 			embeddedStatus: config.MinimalEmbeddedStatus,
@@ -534,7 +738,7 @@ func TestGetChildObjectsFromPRStatusForTaskNames(t *testing.T) {
 			cfg.OnConfigChanged(withCustomTasks(withEmbeddedStatus(newFeatureFlagsConfigMap(), tc.embeddedStatus)))
 			ctx = cfg.ToContext(ctx)
 
-			trNames, runNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, tc.prStatus, tc.taskNames)
+			trNames, runNames, customRunNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, tc.prStatus, tc.taskNames)
 
 			if tc.hasError {
 				if err == nil {
@@ -550,6 +754,9 @@ func TestGetChildObjectsFromPRStatusForTaskNames(t *testing.T) {
 			if d := cmp.Diff(tc.expectedRunNames, runNames); d != "" {
 				t.Errorf("expected to see Run names %v. Diff %s", tc.expectedRunNames, diff.PrintWantGot(d))
 			}
+			if d := cmp.Diff(tc.expectedCustomRunNames, customRunNames); d != "" {
+				t.Errorf("expected to see CustomRun names %v. Diff %s", tc.expectedCustomRunNames, diff.PrintWantGot(d))
+			}
 		})
 	}
 }
@@ -24,6 +24,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
 	runinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run"
+	verificationpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/verificationpolicy"
+	customruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/customrun"
 	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun"
 	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
 	pipelinerunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/pipelinerun"
@@ -34,6 +36,7 @@ import (
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/spire"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
@@ -50,25 +53,36 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		pipelineclientset := pipelineclient.Get(ctx)
 		taskRunInformer := taskruninformer.Get(ctx)
 		runInformer := runinformer.Get(ctx)
+		customRunInformer := customruninformer.Get(ctx)
 		pipelineRunInformer := pipelineruninformer.Get(ctx)
 		resourceInformer := resourceinformer.Get(ctx)
 		resolutionInformer := resolutioninformer.Get(ctx)
+		verificationpolicyInformer := verificationpolicyinformer.Get(ctx)
 		configStore := config.NewStore(logger.Named("config-store"), pipelinerunmetrics.MetricsOnStore(logger))
 		configStore.WatchConfigs(cmw)
 
+		spireClient := spire.GetControllerAPIClient(ctx)
+		if opts.SpireConfig.MockSpire {
+			spireClient = &spire.MockClient{}
+		}
+		spireClient.SetConfig(opts.SpireConfig)
+
 		c := &Reconciler{
-			KubeClientSet:       kubeclientset,
-			PipelineClientSet:   pipelineclientset,
-			Images:              opts.Images,
-			Clock:               clock,
-			pipelineRunLister:   pipelineRunInformer.Lister(),
-			taskRunLister:       taskRunInformer.Lister(),
-			runLister:           runInformer.Lister(),
-			resourceLister:      resourceInformer.Lister(),
-			cloudEventClient:    cloudeventclient.Get(ctx),
-			metrics:             pipelinerunmetrics.Get(ctx),
-			pvcHandler:          volumeclaim.NewPVCHandler(kubeclientset, logger),
-			resolutionRequester: resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
+			KubeClientSet:            kubeclientset,
+			PipelineClientSet:        pipelineclientset,
+			Images:                   opts.Images,
+			Clock:                    clock,
+			pipelineRunLister:        pipelineRunInformer.Lister(),
+			taskRunLister:            taskRunInformer.Lister(),
+			runLister:                runInformer.Lister(),
+			customRunLister:          customRunInformer.Lister(),
+			resourceLister:           resourceInformer.Lister(),
+			verificationPolicyLister: verificationpolicyInformer.Lister(),
+			cloudEventClient:         cloudeventclient.Get(ctx),
+			metrics:                  pipelinerunmetrics.Get(ctx),
+			pvcHandler:               volumeclaim.NewPVCHandler(kubeclientset, logger),
+			resolutionRequester:      resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
+			spireClient:              spireClient,
 		}
 		impl := pipelinerunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
@@ -77,6 +91,9 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			}
 		})
 
+		c.notifier = newNotifier(kubeclientset, impl.EnqueueKey)
+		go c.notifier.run(ctx, notificationWorkers)
+
 		pipelineRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
 		taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
@@ -87,6 +104,10 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 		})
+		customRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		})
 		resolutionInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
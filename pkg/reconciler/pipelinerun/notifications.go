@@ -0,0 +1,460 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/workqueue"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// defaultHTTPNotificationBody is the body of HTTP notifications which do not set one
+	defaultHTTPNotificationBody = `{"name": "$(context.pipelineRun.name)", "namespace": "$(context.pipelineRun.namespace)", "status": "$(notification.status)", "reason": "$(notification.reason)", "message": "$(notification.message)"}`
+	// defaultSlackNotificationText is the text of Slack notifications which do not set one
+	defaultSlackNotificationText = "PipelineRun $(context.pipelineRun.namespace)/$(context.pipelineRun.name) $(notification.status): $(notification.message)"
+	// maxCommitStatusDescription is the maximum length of the description of a commit status on GitHub
+	maxCommitStatusDescription = 140
+	// notificationWorkers is the number of notifications sent concurrently
+	notificationWorkers = 4
+)
+
+var (
+	// notificationHTTPClient is the client notifications are sent with. It does not follow
+	// redirects, which could lead to hosts that are not allowed.
+	notificationHTTPClient = &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	// newCommitStatusClient returns the client setting commit statuses. It can be replaced in tests.
+	newCommitStatusClient = func(provider, serverURL, token string) (*scm.Client, error) {
+		driver := provider
+		if provider == "bitbucket-server" {
+			driver = "stash"
+		}
+		return factory.NewClient(driver, serverURL, token)
+	}
+)
+
+// sendNotifications sends the notifications of a completed PipelineRun whose trigger matches its
+// outcome. Each notification is first recorded as Sending in the PipelineRun status, and only handed
+// to the notifier once that record is persisted, so that a failed update of the status does not send
+// it twice. The notifier sends it in the background and requeues the PipelineRun, which records the
+// outcome of the delivery. Notifications that fail are reported with a warning event but not retried.
+func (c *Reconciler) sendNotifications(ctx context.Context, pr *v1beta1.PipelineRun) {
+	if len(pr.Spec.Notifications) == 0 || c.notifier == nil {
+		return
+	}
+	recorder := controller.GetEventRecorder(ctx)
+	condition := pr.Status.GetCondition(apis.ConditionSucceeded)
+
+	recorded := map[string]int{}
+	for i, ns := range pr.Status.Notifications {
+		recorded[ns.Name] = i
+	}
+	var replacements map[string]string
+	for _, n := range pr.Spec.Notifications {
+		i, ok := recorded[n.Name]
+		if !ok {
+			if notificationTriggered(n.Trigger, condition) {
+				pr.Status.Notifications = append(pr.Status.Notifications, v1beta1.PipelineRunNotificationStatus{
+					Name:    n.Name,
+					Outcome: v1beta1.NotificationOutcomeSending,
+				})
+			}
+			continue
+		}
+
+		key := notificationKey(pr, n.Name)
+		status := &pr.Status.Notifications[i]
+		if status.Outcome != v1beta1.NotificationOutcomeSending {
+			c.notifier.forget(key)
+			continue
+		}
+		if outcome, ok := c.notifier.outcome(key); ok {
+			*status = outcome
+			if outcome.Outcome == v1beta1.NotificationOutcomeFailed && recorder != nil {
+				recorder.Eventf(pr, corev1.EventTypeWarning, "NotificationFailed", "Failed to send notification %q: %s", n.Name, outcome.Message)
+			}
+			continue
+		}
+		if replacements == nil {
+			replacements = notificationReplacements(pr, condition)
+		}
+		serviceAccountName := pr.Spec.ServiceAccountName
+		if serviceAccountName == "" {
+			serviceAccountName = config.FromContextOrDefaults(ctx).Defaults.DefaultServiceAccount
+		}
+		c.notifier.send(&notificationDelivery{
+			key:                key,
+			pipelineRun:        types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name},
+			serviceAccountName: serviceAccountName,
+			notification:       n,
+			replacements:       replacements,
+			allowedHosts:       config.FromContextOrDefaults(ctx).Defaults.DefaultNotificationAllowedHosts,
+		})
+	}
+}
+
+// notificationKey identifies a notification of a PipelineRun in the notifier.
+func notificationKey(pr *v1beta1.PipelineRun, name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", pr.Namespace, pr.Name, pr.UID, name)
+}
+
+// notificationDelivery is a notification of a PipelineRun handed to the notifier.
+type notificationDelivery struct {
+	key                string
+	pipelineRun        types.NamespacedName
+	serviceAccountName string
+	notification       v1beta1.PipelineRunNotification
+	replacements       map[string]string
+	allowedHosts       []string
+}
+
+// notifier sends the notifications of the PipelineRuns in the background, so that the
+// reconciler never waits for their targets.
+type notifier struct {
+	kubeclient kubernetes.Interface
+	// enqueue requeues the PipelineRun once its notification is sent.
+	enqueue func(types.NamespacedName)
+	queue   workqueue.Interface
+
+	mu sync.Mutex
+	// sending are the keys of the notifications handed to the notifier, until their
+	// outcome is recorded in the PipelineRun status.
+	sending  map[string]bool
+	outcomes map[string]v1beta1.PipelineRunNotificationStatus
+}
+
+func newNotifier(kubeclient kubernetes.Interface, enqueue func(types.NamespacedName)) *notifier {
+	return &notifier{
+		kubeclient: kubeclient,
+		enqueue:    enqueue,
+		queue:      workqueue.New(),
+		sending:    map[string]bool{},
+		outcomes:   map[string]v1beta1.PipelineRunNotificationStatus{},
+	}
+}
+
+// run sends the notifications with the given number of workers, until the context is done.
+func (n *notifier) run(ctx context.Context, workers int) {
+	for i := 0; i < workers; i++ {
+		go func() {
+			for n.processNextItem(ctx) {
+			}
+		}()
+	}
+	<-ctx.Done()
+	n.queue.ShutDown()
+}
+
+// send hands the notification to the workers, unless it is already being sent.
+func (n *notifier) send(d *notificationDelivery) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.sending[d.key] {
+		return
+	}
+	n.sending[d.key] = true
+	n.queue.Add(d)
+}
+
+// outcome returns the outcome of the notification, once it is sent.
+func (n *notifier) outcome(key string) (v1beta1.PipelineRunNotificationStatus, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	outcome, ok := n.outcomes[key]
+	return outcome, ok
+}
+
+// forget drops the notification once its outcome is recorded in the PipelineRun status.
+func (n *notifier) forget(key string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.sending, key)
+	delete(n.outcomes, key)
+}
+
+// processNextItem sends the next notification of the queue. It returns false once the
+// queue is shut down.
+func (n *notifier) processNextItem(ctx context.Context) bool {
+	item, shutdown := n.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer n.queue.Done(item)
+	d := item.(*notificationDelivery)
+
+	outcome := v1beta1.PipelineRunNotificationStatus{Name: d.notification.Name, Outcome: v1beta1.NotificationOutcomeDelivered}
+	if err := n.deliver(ctx, d); err != nil {
+		logging.FromContext(ctx).Warnf("Failed to send notification %q of PipelineRun %s: %v", d.notification.Name, d.pipelineRun, err)
+		outcome.Outcome = v1beta1.NotificationOutcomeFailed
+		outcome.Message = err.Error()
+	}
+	n.mu.Lock()
+	n.outcomes[d.key] = outcome
+	n.mu.Unlock()
+	n.enqueue(d.pipelineRun)
+	return true
+}
+
+// notificationTriggered returns whether a notification with the given trigger is sent for a
+// PipelineRun completed with the given condition.
+func notificationTriggered(trigger v1beta1.NotificationTrigger, condition *apis.Condition) bool {
+	switch trigger {
+	case v1beta1.NotificationTriggerSucceeded:
+		return condition.IsTrue()
+	case v1beta1.NotificationTriggerFailed:
+		return condition.IsFalse()
+	default:
+		return true
+	}
+}
+
+// notificationReplacements returns the variables that can be used in the templates of the
+// notifications of a PipelineRun.
+func notificationReplacements(pr *v1beta1.PipelineRun, condition *apis.Condition) map[string]string {
+	pipelineName := pr.Labels[pipeline.PipelineLabelKey]
+	if pipelineName == "" && pr.Spec.PipelineRef != nil {
+		pipelineName = pr.Spec.PipelineRef.Name
+	}
+	status := "Failed"
+	if condition.IsTrue() {
+		status = "Succeeded"
+	}
+	replacements := map[string]string{
+		"context.pipelineRun.name":      pr.Name,
+		"context.pipelineRun.namespace": pr.Namespace,
+		"context.pipelineRun.uid":       string(pr.UID),
+		"context.pipeline.name":         pipelineName,
+		"notification.status":           status,
+		"notification.reason":           "",
+		"notification.message":          "",
+	}
+	if condition != nil {
+		replacements["notification.reason"] = condition.Reason
+		replacements["notification.message"] = condition.Message
+	}
+	for _, p := range pr.Spec.Params {
+		if p.Value.Type == v1beta1.ParamTypeString {
+			replacements[fmt.Sprintf("params.%s", p.Name)] = p.Value.StringVal
+		}
+	}
+	for _, r := range pr.Status.PipelineResults {
+		if r.Value.Type == v1beta1.ParamTypeString {
+			replacements[fmt.Sprintf("results.%s", r.Name)] = r.Value.StringVal
+		}
+	}
+	return replacements
+}
+
+// jsonEscaped returns the replacements escaped to be used within JSON strings.
+func jsonEscaped(replacements map[string]string) map[string]string {
+	escaped := make(map[string]string, len(replacements))
+	for k, v := range replacements {
+		b, _ := json.Marshal(v)
+		escaped[k] = string(b[1 : len(b)-1])
+	}
+	return escaped
+}
+
+// deliver sends the notification to its target.
+func (n *notifier) deliver(ctx context.Context, d *notificationDelivery) error {
+	notification, replacements := d.notification, d.replacements
+	secret := ""
+	if notification.SecretRef != nil {
+		value, err := n.readSecret(ctx, d.pipelineRun.Namespace, d.serviceAccountName, notification.SecretRef)
+		if err != nil {
+			return err
+		}
+		secret = value
+	}
+
+	switch {
+	case notification.HTTP != nil:
+		body := notification.HTTP.Body
+		if body == "" {
+			body = defaultHTTPNotificationBody
+		}
+		body = substitution.ApplyReplacements(body, jsonEscaped(replacements))
+		if !json.Valid([]byte(body)) {
+			return fmt.Errorf("the body of the notification is not valid JSON: %s", body)
+		}
+		target := substitution.ApplyReplacements(notification.HTTP.URL, replacements)
+		if err := checkNotificationURL(target, d.allowedHosts); err != nil {
+			return err
+		}
+		return postNotification(ctx, target, secret, []byte(body))
+	case notification.Slack != nil:
+		text := notification.Slack.Text
+		if text == "" {
+			text = defaultSlackNotificationText
+		}
+		body, err := json.Marshal(map[string]string{"text": substitution.ApplyReplacements(text, replacements)})
+		if err != nil {
+			return err
+		}
+		if err := checkNotificationURL(secret, d.allowedHosts); err != nil {
+			return err
+		}
+		return postNotification(ctx, secret, "", body)
+	case notification.CommitStatus != nil:
+		// The default servers of the providers are their public APIs.
+		if notification.CommitStatus.ServerURL != "" {
+			if err := checkNotificationURL(notification.CommitStatus.ServerURL, d.allowedHosts); err != nil {
+				return err
+			}
+		}
+		return setCommitStatus(ctx, notification, secret, replacements)
+	}
+	return fmt.Errorf("notification %q has no target", notification.Name)
+}
+
+// readSecret returns the value of the key of the Secret, provided the service account of the
+// PipelineRun can read the Secret: the controller does not read the Secrets the PipelineRun
+// could not read itself.
+func (n *notifier) readSecret(ctx context.Context, namespace, serviceAccountName string, ref *corev1.SecretKeySelector) (string, error) {
+	review, err := n.kubeclient.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccountName),
+			Groups: []string{"system:serviceaccounts", "system:serviceaccounts:" + namespace},
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "get",
+				Resource:  "secrets",
+				Name:      ref.Name,
+			},
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to check the access of the service account %q to the secret %q: %w", serviceAccountName, ref.Name, err)
+	}
+	if !review.Status.Allowed {
+		return "", fmt.Errorf("the service account %q cannot get the secret %q", serviceAccountName, ref.Name)
+	}
+	s, err := n.kubeclient.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get the secret %q: %w", ref.Name, err)
+	}
+	value, ok := s.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %q has no key %q", ref.Name, ref.Key)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
+
+// checkNotificationURL returns an error unless the URL is an HTTP URL whose host is allowed,
+// either exactly or, for the hosts starting with "*.", by domain. The URL is not part of the
+// error, since it may be read from a Secret.
+func checkNotificationURL(target string, allowedHosts []string) error {
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("the URL of the notification is not a valid HTTP URL")
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed || (strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:])) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the host %q is not allowed for notifications", host)
+}
+
+// postNotification posts a JSON body to url, authenticated with token if it is set.
+func postNotification(ctx context.Context, url, token string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := notificationHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+		return fmt.Errorf("unexpected response %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// setCommitStatus sets the status of a commit to the outcome of the PipelineRun.
+func setCommitStatus(ctx context.Context, n v1beta1.PipelineRunNotification, token string, replacements map[string]string) error {
+	cs := n.CommitStatus
+	provider := cs.Provider
+	if provider == "" {
+		provider = "github"
+	}
+	client, err := newCommitStatusClient(provider, cs.ServerURL, token)
+	if err != nil {
+		return fmt.Errorf("failed to create a %s client: %w", provider, err)
+	}
+	label := cs.Context
+	if label == "" {
+		label = "tekton/" + n.Name
+	}
+	state := scm.StateFailure
+	if replacements["notification.status"] == "Succeeded" {
+		state = scm.StateSuccess
+	}
+	desc := replacements["notification.message"]
+	if len(desc) > maxCommitStatusDescription {
+		desc = desc[:maxCommitStatusDescription-3] + "..."
+	}
+	_, _, err = client.Repositories.CreateStatus(ctx,
+		substitution.ApplyReplacements(cs.Repository, replacements),
+		substitution.ApplyReplacements(cs.SHA, replacements),
+		&scm.StatusInput{
+			State:  state,
+			Label:  substitution.ApplyReplacements(label, replacements),
+			Desc:   desc,
+			Target: substitution.ApplyReplacements(cs.TargetURL, replacements),
+		})
+	return err
+}
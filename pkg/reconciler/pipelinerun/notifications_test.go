@@ -0,0 +1,445 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+// notificationRequest is a request received by the notificationServer
+type notificationRequest struct {
+	Path          string
+	Authorization string
+	Body          string
+}
+
+// notificationServer records the requests it receives, and fails the ones
+// sent to /fail
+type notificationServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []notificationRequest
+}
+
+func newNotificationServer(t *testing.T) *notificationServer {
+	t.Helper()
+	s := &notificationServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.requests = append(s.requests, notificationRequest{Path: r.URL.Path, Authorization: r.Header.Get("Authorization"), Body: string(body)})
+		s.mu.Unlock()
+		if r.URL.Path == "/fail" {
+			http.Error(w, "no such channel", http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func notificationPipelineRun(status corev1.ConditionStatus, notifications ...v1beta1.PipelineRunNotification) *v1beta1.PipelineRun {
+	return &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipelinerun", Namespace: "foo", UID: "uid"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "release"},
+			Params: []v1beta1.Param{{
+				Name:  "revision",
+				Value: *v1beta1.NewStructuredValues("abc123"),
+			}},
+			Notifications: notifications,
+		},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:    apis.ConditionSucceeded,
+				Status:  status,
+				Reason:  "Failed",
+				Message: `Task "build" failed`,
+			}}},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineResults: []v1beta1.PipelineRunResult{{
+					Name:  "image",
+					Value: *v1beta1.NewStructuredValues("registry/image@sha256:123"),
+				}},
+			},
+		},
+	}
+}
+
+// newTestNotifier returns a notifier whose service accounts can read all the Secrets but
+// the "forbidden" one, and the PipelineRuns it requeued.
+func newTestNotifier(objects ...runtime.Object) (*notifier, *[]types.NamespacedName) {
+	kubeclient := fakek8s.NewSimpleClientset(objects...)
+	kubeclient.PrependReactor("create", "subjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+		review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Name != "forbidden"
+		return true, review, nil
+	})
+	var enqueued []types.NamespacedName
+	return newNotifier(kubeclient, func(key types.NamespacedName) { enqueued = append(enqueued, key) }), &enqueued
+}
+
+func TestNotifierDeliver(t *testing.T) {
+	server := newNotificationServer(t)
+	allowedHosts := []string{"127.0.0.1"}
+	slackSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "foo"},
+		Data:       map[string][]byte{"url": []byte(server.URL + "/slack\n")},
+	}
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "foo"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}
+	forbiddenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "forbidden", Namespace: "foo"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}
+	for _, tc := range []struct {
+		name         string
+		status       corev1.ConditionStatus
+		notification v1beta1.PipelineRunNotification
+		allowedHosts []string
+		wantRequests []notificationRequest
+		wantStatus   v1beta1.PipelineRunNotificationStatus
+	}{{
+		name:   "http with the default body and a bearer token",
+		status: corev1.ConditionFalse,
+		notification: v1beta1.PipelineRunNotification{
+			Name:      "webhook",
+			SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}, Key: "token"},
+			HTTP:      &v1beta1.HTTPNotification{URL: server.URL + "/hook"},
+		},
+		allowedHosts: allowedHosts,
+		wantRequests: []notificationRequest{{
+			Path:          "/hook",
+			Authorization: "Bearer s3cr3t",
+			Body:          `{"name": "test-pipelinerun", "namespace": "foo", "status": "Failed", "reason": "Failed", "message": "Task \"build\" failed"}`,
+		}},
+		wantStatus: v1beta1.PipelineRunNotificationStatus{Name: "webhook", Outcome: v1beta1.NotificationOutcomeDelivered},
+	}, {
+		name:   "http with a templated body",
+		status: corev1.ConditionTrue,
+		notification: v1beta1.PipelineRunNotification{
+			Name:    "webhook",
+			Trigger: v1beta1.NotificationTriggerSucceeded,
+			HTTP: &v1beta1.HTTPNotification{
+				URL:  server.URL + "/hook/$(context.pipeline.name)",
+				Body: `{"revision": "$(params.revision)", "image": "$(results.image)", "status": "$(notification.status)"}`,
+			},
+		},
+		allowedHosts: allowedHosts,
+		wantRequests: []notificationRequest{{
+			Path: "/hook/release",
+			Body: `{"revision": "abc123", "image": "registry/image@sha256:123", "status": "Succeeded"}`,
+		}},
+		wantStatus: v1beta1.PipelineRunNotificationStatus{Name: "webhook", Outcome: v1beta1.NotificationOutcomeDelivered},
+	}, {
+		name:   "slack",
+		status: corev1.ConditionFalse,
+		notification: v1beta1.PipelineRunNotification{
+			Name:      "chat",
+			Trigger:   v1beta1.NotificationTriggerFailed,
+			SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "slack"}, Key: "url"},
+			Slack:     &v1beta1.SlackNotification{},
+		},
+		allowedHosts: []string{"*.example.com", "127.0.0.1"},
+		wantRequests: []notificationRequest{{
+			Path: "/slack",
+			Body: `{"text":"PipelineRun foo/test-pipelinerun Failed: Task \"build\" failed"}`,
+		}},
+		wantStatus: v1beta1.PipelineRunNotificationStatus{Name: "chat", Outcome: v1beta1.NotificationOutcomeDelivered},
+	}, {
+		name:   "target rejects the notification",
+		status: corev1.ConditionTrue,
+		notification: v1beta1.PipelineRunNotification{
+			Name: "webhook",
+			HTTP: &v1beta1.HTTPNotification{URL: server.URL + "/fail"},
+		},
+		allowedHosts: allowedHosts,
+		wantRequests: []notificationRequest{{
+			Path: "/fail",
+			Body: `{"name": "test-pipelinerun", "namespace": "foo", "status": "Succeeded", "reason": "Failed", "message": "Task \"build\" failed"}`,
+		}},
+		wantStatus: v1beta1.PipelineRunNotificationStatus{
+			Name:    "webhook",
+			Outcome: v1beta1.NotificationOutcomeFailed,
+			Message: "unexpected response 404 Not Found: no such channel",
+		},
+	}, {
+		name:   "host not allowed",
+		status: corev1.ConditionTrue,
+		notification: v1beta1.PipelineRunNotification{
+			Name:      "webhook",
+			SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}, Key: "token"},
+			HTTP:      &v1beta1.HTTPNotification{URL: server.URL + "/hook"},
+		},
+		allowedHosts: []string{"hooks.example.com"},
+		wantStatus: v1beta1.PipelineRunNotificationStatus{
+			Name:    "webhook",
+			Outcome: v1beta1.NotificationOutcomeFailed,
+			Message: `the host "127.0.0.1" is not allowed for notifications`,
+		},
+	}, {
+		name:   "slack webhook host not allowed",
+		status: corev1.ConditionTrue,
+		notification: v1beta1.PipelineRunNotification{
+			Name:      "chat",
+			SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "slack"}, Key: "url"},
+			Slack:     &v1beta1.SlackNotification{},
+		},
+		wantStatus: v1beta1.PipelineRunNotificationStatus{
+			Name:    "chat",
+			Outcome: v1beta1.NotificationOutcomeFailed,
+			Message: `the host "127.0.0.1" is not allowed for notifications`,
+		},
+	}, {
+		name:   "secret the service account cannot read",
+		status: corev1.ConditionTrue,
+		notification: v1beta1.PipelineRunNotification{
+			Name:      "webhook",
+			SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "forbidden"}, Key: "token"},
+			HTTP:      &v1beta1.HTTPNotification{URL: server.URL + "/hook"},
+		},
+		allowedHosts: allowedHosts,
+		wantStatus: v1beta1.PipelineRunNotificationStatus{
+			Name:    "webhook",
+			Outcome: v1beta1.NotificationOutcomeFailed,
+			Message: `the service account "default" cannot get the secret "forbidden"`,
+		},
+	}, {
+		name:   "missing secret",
+		status: corev1.ConditionTrue,
+		notification: v1beta1.PipelineRunNotification{
+			Name:      "chat",
+			SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "url"},
+			Slack:     &v1beta1.SlackNotification{},
+		},
+		allowedHosts: allowedHosts,
+		wantStatus: v1beta1.PipelineRunNotificationStatus{
+			Name:    "chat",
+			Outcome: v1beta1.NotificationOutcomeFailed,
+			Message: `failed to get the secret "missing": secrets "missing" not found`,
+		},
+	}, {
+		name:   "invalid JSON body",
+		status: corev1.ConditionTrue,
+		notification: v1beta1.PipelineRunNotification{
+			Name: "webhook",
+			HTTP: &v1beta1.HTTPNotification{URL: server.URL + "/hook", Body: `{"name": $(context.pipelineRun.name)}`},
+		},
+		allowedHosts: allowedHosts,
+		wantStatus: v1beta1.PipelineRunNotificationStatus{
+			Name:    "webhook",
+			Outcome: v1beta1.NotificationOutcomeFailed,
+			Message: `the body of the notification is not valid JSON: {"name": test-pipelinerun}`,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			server.requests = nil
+			pr := notificationPipelineRun(tc.status, tc.notification)
+			n, enqueued := newTestNotifier(slackSecret, tokenSecret, forbiddenSecret)
+			key := notificationKey(pr, tc.notification.Name)
+
+			n.send(&notificationDelivery{
+				key:                key,
+				pipelineRun:        types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name},
+				serviceAccountName: "default",
+				notification:       tc.notification,
+				replacements:       notificationReplacements(pr, pr.Status.GetCondition(apis.ConditionSucceeded)),
+				allowedHosts:       tc.allowedHosts,
+			})
+			n.processNextItem(context.Background())
+
+			if d := cmp.Diff(tc.wantRequests, server.requests); d != "" {
+				t.Errorf("unexpected notification requests %s", diff.PrintWantGot(d))
+			}
+			got, ok := n.outcome(key)
+			if !ok {
+				t.Fatalf("expected the outcome of the notification to be recorded")
+			}
+			if d := cmp.Diff(tc.wantStatus, got); d != "" {
+				t.Errorf("unexpected notification status %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff([]types.NamespacedName{{Namespace: "foo", Name: "test-pipelinerun"}}, *enqueued); d != "" {
+				t.Errorf("expected the PipelineRun to be requeued %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSendNotifications(t *testing.T) {
+	server := newNotificationServer(t)
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{DefaultServiceAccount: "default", DefaultNotificationAllowedHosts: []string{"127.0.0.1"}},
+	})
+	pr := notificationPipelineRun(corev1.ConditionTrue, v1beta1.PipelineRunNotification{
+		Name: "webhook",
+		HTTP: &v1beta1.HTTPNotification{URL: server.URL + "/hook"},
+	}, v1beta1.PipelineRunNotification{
+		Name:    "chat",
+		Trigger: v1beta1.NotificationTriggerFailed,
+		HTTP:    &v1beta1.HTTPNotification{URL: server.URL + "/chat"},
+	})
+	n, enqueued := newTestNotifier()
+	c := Reconciler{notifier: n}
+	key := notificationKey(pr, "webhook")
+
+	// The notifications matching the outcome of the PipelineRun are recorded before they are sent.
+	c.sendNotifications(ctx, pr)
+	wantStatus := []v1beta1.PipelineRunNotificationStatus{{Name: "webhook", Outcome: v1beta1.NotificationOutcomeSending}}
+	if d := cmp.Diff(wantStatus, pr.Status.Notifications); d != "" {
+		t.Fatalf("unexpected notifications status %s", diff.PrintWantGot(d))
+	}
+	if n.queue.Len() != 0 {
+		t.Fatalf("expected no notifications to be sent before they are recorded, got %d", n.queue.Len())
+	}
+
+	// Once recorded, they are handed to the notifier once, whatever the number of reconciles.
+	c.sendNotifications(ctx, pr)
+	c.sendNotifications(ctx, pr)
+	if n.queue.Len() != 1 {
+		t.Fatalf("expected the notification to be sent once, got %d", n.queue.Len())
+	}
+	n.processNextItem(ctx)
+	if len(server.requests) != 1 || len(*enqueued) != 1 {
+		t.Fatalf("expected the notification to be sent and the PipelineRun requeued, got %v and %v", server.requests, *enqueued)
+	}
+
+	// The next reconcile records the outcome, until it is persisted.
+	for i := 0; i < 2; i++ {
+		pr.Status.Notifications = []v1beta1.PipelineRunNotificationStatus{{Name: "webhook", Outcome: v1beta1.NotificationOutcomeSending}}
+		c.sendNotifications(ctx, pr)
+		wantStatus = []v1beta1.PipelineRunNotificationStatus{{Name: "webhook", Outcome: v1beta1.NotificationOutcomeDelivered}}
+		if d := cmp.Diff(wantStatus, pr.Status.Notifications); d != "" {
+			t.Fatalf("unexpected notifications status %s", diff.PrintWantGot(d))
+		}
+	}
+	c.sendNotifications(ctx, pr)
+	if _, ok := n.outcome(key); ok {
+		t.Errorf("expected the notifier to forget the notification once its outcome is recorded")
+	}
+	if n.queue.Len() != 0 || len(server.requests) != 1 {
+		t.Errorf("expected the notification not to be sent again, got %d queued and %v", n.queue.Len(), server.requests)
+	}
+}
+
+func TestNotifierDeliverCommitStatus(t *testing.T) {
+	client, data := fake.NewDefault()
+	var gotProvider, gotServerURL, gotToken string
+	newClient := newCommitStatusClient
+	newCommitStatusClient = func(provider, serverURL, token string) (*scm.Client, error) {
+		gotProvider, gotServerURL, gotToken = provider, serverURL, token
+		return client, nil
+	}
+	t.Cleanup(func() { newCommitStatusClient = newClient })
+
+	notification := v1beta1.PipelineRunNotification{
+		Name:      "github",
+		SecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "token"}, Key: "token"},
+		CommitStatus: &v1beta1.CommitStatusNotification{
+			Provider:   "gitlab",
+			ServerURL:  "https://gitlab.example.com",
+			Repository: "tektoncd/pipeline",
+			SHA:        "$(params.revision)",
+			TargetURL:  "https://dashboard.example.com/$(context.pipelineRun.namespace)/$(context.pipelineRun.name)",
+		},
+	}
+	pr := notificationPipelineRun(corev1.ConditionFalse, notification)
+	n, _ := newTestNotifier(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "token", Namespace: "foo"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	})
+
+	err := n.deliver(context.Background(), &notificationDelivery{
+		pipelineRun:        types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name},
+		serviceAccountName: "default",
+		notification:       notification,
+		replacements:       notificationReplacements(pr, pr.Status.GetCondition(apis.ConditionSucceeded)),
+		allowedHosts:       []string{"gitlab.example.com"},
+	})
+	if err != nil {
+		t.Fatalf("deliver() = %v", err)
+	}
+
+	if d := cmp.Diff([]string{"gitlab", "https://gitlab.example.com", "s3cr3t"}, []string{gotProvider, gotServerURL, gotToken}); d != "" {
+		t.Errorf("unexpected client %s", diff.PrintWantGot(d))
+	}
+	wantStatuses := []*scm.Status{{
+		State:  scm.StateFailure,
+		Label:  "tekton/github",
+		Desc:   `Task "build" failed`,
+		Target: "https://dashboard.example.com/foo/test-pipelinerun",
+	}}
+	if d := cmp.Diff(wantStatuses, data.Statuses["abc123"]); d != "" {
+		t.Errorf("unexpected commit statuses %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestCheckNotificationURL(t *testing.T) {
+	allowedHosts := []string{"hooks.slack.com", "*.corp.example.com"}
+	for _, tc := range []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://hooks.slack.com/services/T0/B0/X"},
+		{url: "https://HOOKS.slack.com:443/services"},
+		{url: "http://ci.corp.example.com/hook"},
+		{url: "https://corp.example.com/hook", wantErr: true},
+		{url: "https://evil.com/hooks.slack.com", wantErr: true},
+		{url: "https://hooks.slack.com.evil.com/", wantErr: true},
+		{url: "file:///etc/passwd", wantErr: true},
+		{url: "not a url", wantErr: true},
+	} {
+		t.Run(tc.url, func(t *testing.T) {
+			if err := checkNotificationURL(tc.url, allowedHosts); (err != nil) != tc.wantErr {
+				t.Errorf("checkNotificationURL() = %v, want error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotificationBodyEscaping(t *testing.T) {
+	escaped := jsonEscaped(map[string]string{"notification.message": "line 1\nline \"2\""})
+	var got map[string]string
+	if err := json.Unmarshal([]byte(`{"message": "`+escaped["notification.message"]+`"}`), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff("line 1\nline \"2\"", got["message"]); d != "" {
+		t.Errorf("unexpected message %s", diff.PrintWantGot(d))
+	}
+}
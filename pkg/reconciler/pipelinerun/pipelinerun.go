@@ -23,8 +23,10 @@ import (
 	"fmt"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -43,6 +45,9 @@ import (
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
 	"github.com/tektoncd/pipeline/pkg/matrix"
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/policy"
+	"github.com/tektoncd/pipeline/pkg/provenance"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
@@ -54,11 +59,13 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -128,6 +135,12 @@ const (
 	// ReasonResourceVerificationFailed indicates that the pipeline fails the trusted resource verification,
 	// it could be the content has changed, signature is invalid or public key is invalid
 	ReasonResourceVerificationFailed = "ResourceVerificationFailed"
+	// ReasonSpireVerificationFailed indicates that a TaskRun of the pipeline failed the spire verification
+	// of its results and status
+	ReasonSpireVerificationFailed = "SpireVerificationFailed"
+	// ReasonPolicyViolation indicates that the Task spec of a task of the pipeline
+	// violates the policy of the config-policy ConfigMap
+	ReasonPolicyViolation = "PolicyViolation"
 )
 
 // Reconciler implements controller.Reconciler for Configuration resources.
@@ -138,14 +151,18 @@ type Reconciler struct {
 	Clock             clock.PassiveClock
 
 	// listers index properties about resources
-	pipelineRunLister   listers.PipelineRunLister
-	taskRunLister       listers.TaskRunLister
-	runLister           listersv1alpha1.RunLister
-	resourceLister      resourcelisters.PipelineResourceLister
-	cloudEventClient    cloudevent.CEClient
-	metrics             *pipelinerunmetrics.Recorder
-	pvcHandler          volumeclaim.PvcHandler
-	resolutionRequester resolution.Requester
+	pipelineRunLister        listers.PipelineRunLister
+	taskRunLister            listers.TaskRunLister
+	runLister                listersv1alpha1.RunLister
+	customRunLister          listers.CustomRunLister
+	resourceLister           resourcelisters.PipelineResourceLister
+	verificationPolicyLister listersv1alpha1.VerificationPolicyLister
+	cloudEventClient         cloudevent.CEClient
+	metrics                  *pipelinerunmetrics.Recorder
+	pvcHandler               volumeclaim.PvcHandler
+	resolutionRequester      resolution.Requester
+	spireClient              spire.ControllerAPIClient
+	notifier                 *notifier
 }
 
 var (
@@ -183,7 +200,11 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 		before = pr.Status.GetCondition(apis.ConditionSucceeded)
 	}
 
-	getPipelineFunc, err := resources.GetPipelineFunc(ctx, c.KubeClientSet, c.PipelineClientSet, c.resolutionRequester, pr)
+	vp, err := c.verificationPolicyLister.VerificationPolicies(pr.Namespace).List(k8slabels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list VerificationPolicies from namespace %s with error %w", pr.Namespace, err)
+	}
+	getPipelineFunc, err := resources.GetPipelineFunc(ctx, c.KubeClientSet, c.PipelineClientSet, c.resolutionRequester, pr, vp)
 	if err != nil {
 		logger.Errorf("Failed to fetch pipeline func for pipeline %s: %w", pr.Spec.PipelineRef.Name, err)
 		pr.Status.MarkFailed(ReasonCouldntGetPipeline, "Error retrieving pipeline for pipelinerun %s/%s: %s",
@@ -202,6 +223,11 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 			logger.Errorf("Failed to delete StatefulSet for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 		}
+		if err := c.applyPVCRetentionPolicies(ctx, pr); err != nil {
+			logger.Errorf("Failed to apply PVC retention policies for PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+		c.sendNotifications(ctx, pr)
 		if err := c.updateTaskRunsStatusDirectly(pr); err != nil {
 			logger.Errorf("Failed to update TaskRun status for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
@@ -210,6 +236,10 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 			logger.Errorf("Failed to update Run status for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 		}
+		if err := c.recordProvenance(ctx, pr); err != nil {
+			logger.Errorf("Failed to record the SLSA provenance of PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
 		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
 	}
 
@@ -255,6 +285,20 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 				waitTime = finallyWaitTime
 			}
 		}
+		if gracePeriodWaitTime := c.cancellationGracePeriodWaitTime(ctx, pr); gracePeriodWaitTime > 0 && (waitTime <= 0 || gracePeriodWaitTime < waitTime) {
+			waitTime = gracePeriodWaitTime
+		}
+		if pr.Spec.Deadline != nil {
+			// Wake up earlier if the deadline is approaching or passes before then, or at
+			// all when there is no timeout to wait for.
+			untilDeadline := pr.Spec.Deadline.Sub(c.Clock.Now())
+			threshold := config.FromContextOrDefaults(ctx).Defaults.DefaultDeadlineWarningThreshold
+			for _, d := range []time.Duration{untilDeadline - threshold, untilDeadline} {
+				if d > 0 && (waitTime <= 0 || d < waitTime) {
+					waitTime = d
+				}
+			}
+		}
 		return controller.NewRequeueAfter(waitTime)
 	}
 	return nil
@@ -301,6 +345,17 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, pr *v1
 	return merr
 }
 
+// validatePolicy validates the Task specs of the PipelineTasks against the policy.
+func validatePolicy(ctx context.Context, namespace string, pipelineRunState resources.PipelineRunState) error {
+	specs := map[string]*v1beta1.TaskSpec{}
+	for _, rpt := range pipelineRunState {
+		if !rpt.CustomTask && rpt.ResolvedTaskResources != nil && rpt.ResolvedTaskResources.TaskSpec != nil {
+			specs[rpt.PipelineTask.Name] = rpt.ResolvedTaskResources.TaskSpec
+		}
+	}
+	return policy.ValidateUnresolvedTaskSpecs(ctx, namespace, specs)
+}
+
 // resolvePipelineState will attempt to resolve each referenced task in the pipeline's spec and all of the resources
 // specified by those tasks.
 func (c *Reconciler) resolvePipelineState(
@@ -310,12 +365,16 @@ func (c *Reconciler) resolvePipelineState(
 	pr *v1beta1.PipelineRun,
 	providedResources map[string]*resourcev1alpha1.PipelineResource) (resources.PipelineRunState, error) {
 	pst := resources.PipelineRunState{}
+	vp, err := c.verificationPolicyLister.VerificationPolicies(pr.Namespace).List(k8slabels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list VerificationPolicies from namespace %s with error %w", pr.Namespace, err)
+	}
 	// Resolve each task individually because they each could have a different reference context (remote or local).
 	for _, task := range tasks {
 		// We need the TaskRun name to ensure that we don't perform an additional remote resolution request for a PipelineTask
 		// in the TaskRun reconciler.
 		trName := resources.GetTaskRunName(pr.Status.TaskRuns, pr.Status.ChildReferences, task.Name, pr.Name)
-		fn, err := tresources.GetTaskFunc(ctx, c.KubeClientSet, c.PipelineClientSet, c.resolutionRequester, pr, task.TaskRef, trName, pr.Namespace, pr.Spec.ServiceAccountName)
+		fn, err := tresources.GetTaskFunc(ctx, c.KubeClientSet, c.PipelineClientSet, c.resolutionRequester, pr, task.TaskRef, trName, pr.Namespace, pr.Spec.ServiceAccountName, vp)
 		if err != nil {
 			// This Run has failed, so we need to mark it as failed and stop reconciling it
 			pr.Status.MarkFailed(ReasonCouldntGetTask, "Pipeline %s/%s can't be Run; task %s could not be fetched: %s",
@@ -375,6 +434,12 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return nil
 	}
 
+	// Warn that the deadline of the PipelineRun is approaching, before the
+	// $(context.pipelineRun.deadlineApproaching) variable is replaced
+	if pr.IsDeadlineApproaching(ctx, c.Clock) {
+		markDeadlineApproaching(pr)
+	}
+
 	pipelineMeta, pipelineSpec, err := rprp.GetPipelineData(ctx, pr, getPipelineFunc)
 	switch {
 	case errors.Is(err, remote.ErrorRequestInProgress):
@@ -396,6 +461,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		if err := storePipelineSpecAndMergeMeta(ctx, pr, pipelineSpec, pipelineMeta); err != nil {
 			logger.Errorf("Failed to store PipelineSpec on PipelineRun.Status for pipelinerun %s: %v", pr.Name, err)
 		}
+		markTrustedResourcesVerified(pr, pipelineMeta)
 	}
 
 	d, err := dag.Build(v1beta1.PipelineTaskList(pipelineSpec.Tasks), v1beta1.PipelineTaskList(pipelineSpec.Tasks).Deps())
@@ -534,6 +600,20 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	default:
 	}
 
+	// Validate the Task specs of the resolved Pipeline against the policy before the first
+	// TaskRun is created, so that the PipelineRuns already running are not failed by changes
+	// to the policy. The images set by variables are validated by the TaskRuns, once the
+	// variables are substituted.
+	if pipelineRunState.IsBeforeFirstTaskRun() {
+		if err := validatePolicy(ctx, pr.Namespace, pipelineRunState); err != nil {
+			logger.Errorf("PipelineRun %s/%s violates the policy: %v", pr.Namespace, pr.Name, err)
+			pr.Status.MarkFailed(ReasonPolicyViolation,
+				"PipelineRun %s/%s violates the policy: %s",
+				pr.Namespace, pr.Name, err)
+			return controller.NewPermanentError(err)
+		}
+	}
+
 	// Build PipelineRunFacts with a list of resolved pipeline tasks,
 	// dag tasks graph and final tasks graph
 	pipelineRunFacts := &resources.PipelineRunFacts{
@@ -572,10 +652,18 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		}
 	}
 
+	if cfg.FeatureFlags.EnableSpire {
+		if err := c.checkTaskRunsSpireVerified(pipelineRunFacts.State); err != nil {
+			logger.Errorf("PipelineRun %q failed the spire verification: %v", pr.Name, err)
+			pr.Status.MarkFailed(ReasonSpireVerificationFailed, err.Error())
+			return controller.NewPermanentError(err)
+		}
+	}
+
 	// check if pipeline run is not gracefully cancelled and there are active task runs, which require cancelling
 	if pr.IsGracefullyCancelled() && pipelineRunFacts.IsRunning() {
 		// If the pipelinerun is cancelled, cancel tasks, but run finally
-		err := gracefullyCancelPipelineRun(ctx, logger, pr, c.PipelineClientSet)
+		err := c.gracefullyCancelPipelineRun(ctx, logger, pr)
 		if err != nil {
 			// failed to cancel tasks, maybe retry would help (don't return permanent error)
 			return err
@@ -674,7 +762,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 
 	// If the pipelinerun has timed out, mark tasks as timed out and update status
 	if pr.HasTimedOut(ctx, c.Clock) {
-		if err := timeoutPipelineRun(ctx, logger, pr, c.PipelineClientSet); err != nil {
+		if err := timeoutPipelineRun(ctx, logger, pr, c.PipelineClientSet, c.Clock); err != nil {
 			return err
 		}
 	}
@@ -701,7 +789,22 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		pr.Status.ChildReferences = pipelineRunFacts.State.GetChildReferences()
 	}
 
+	// Events are sent only once for each skipped PipelineTask, which is recorded in the status
+	// so that they are not sent again after a restart of the controller.
+	eventsSent := sets.NewString()
+	for _, skippedTask := range pr.Status.SkippedTasks {
+		if skippedTask.CloudEventSent {
+			eventsSent.Insert(skippedTask.Name)
+		}
+	}
 	pr.Status.SkippedTasks = pipelineRunFacts.GetSkippedTasks()
+	for i, skippedTask := range pr.Status.SkippedTasks {
+		pr.Status.SkippedTasks[i].CloudEventSent = eventsSent.Has(skippedTask.Name) ||
+			events.EmitPipelineTaskCloudEvent(ctx, pr, cloudevent.PipelineTaskSkippedEventV1, cloudevent.PipelineTaskEventData{
+				Name:   skippedTask.Name,
+				Reason: string(skippedTask.Reason),
+			})
+	}
 	if after.Status == corev1.ConditionTrue || after.Status == corev1.ConditionFalse {
 		pr.Status.PipelineResults, err = resources.ApplyTaskResultsToPipelineResults(pipelineSpec.Results,
 			pipelineRunFacts.State.GetTaskRunsResults(), pipelineRunFacts.State.GetRunsResults())
@@ -709,12 +812,74 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 			pr.Status.MarkFailed(ReasonFailedValidation, err.Error())
 			return err
 		}
+		if cfg.FeatureFlags.EnableSpire && len(pr.Status.PipelineResults) > 0 && spire.CheckPipelineRunResultsAnnotation(pr) != nil {
+			if err := c.spireClient.AppendPipelineRunResultsAnnotation(ctx, pr); err != nil {
+				logger.Errorf("Failed to sign the results of PipelineRun %q: %v", pr.Name, err)
+				return err
+			}
+		}
 	}
 
 	logger.Infof("PipelineRun %s status is being set to %s", pr.Name, after)
 	return nil
 }
 
+// recordProvenance stores the SLSA provenance statement of the completed PipelineRun in its status
+// annotations when enabled, and signs it with the controller SVID when spire is enabled. The statement
+// is regenerated from the status, and any statement set in the metadata annotations, which users can
+// write, is removed. A statement exceeding the maximum annotation size is not recorded.
+func (c *Reconciler) recordProvenance(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	provenance.StripAnnotations(pr.Annotations)
+	cfg := config.FromContextOrDefaults(ctx)
+	if !cfg.FeatureFlags.EnableSLSAProvenance {
+		return nil
+	}
+	taskRuns, err := c.taskRunLister.TaskRuns(pr.Namespace).List(k8slabels.SelectorFromSet(k8slabels.Set{pipeline.PipelineRunLabelKey: pr.Name}))
+	if err != nil {
+		return err
+	}
+	sort.Slice(taskRuns, func(i, j int) bool { return taskRuns[i].Name < taskRuns[j].Name })
+	statement, err := provenance.ForPipelineRun(pr, taskRuns).Annotation()
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Not recording the SLSA provenance of PipelineRun %q: %v", pr.Name, err)
+		provenance.StripAnnotations(pr.Status.Annotations)
+		return nil
+	}
+	signatureKey := provenance.AnnotationKey + spire.AnnotationSignatureSuffix
+	if pr.Status.Annotations[provenance.AnnotationKey] == statement {
+		if _, ok := pr.Status.Annotations[signatureKey]; ok || !cfg.FeatureFlags.EnableSpire {
+			return nil
+		}
+	}
+	if pr.Status.Annotations == nil {
+		pr.Status.Annotations = map[string]string{}
+	}
+	pr.Status.Annotations[provenance.AnnotationKey] = statement
+	delete(pr.Status.Annotations, signatureKey)
+	if !cfg.FeatureFlags.EnableSpire {
+		return nil
+	}
+	return c.spireClient.AppendAnnotationSignature(ctx, pr.Status.Annotations, provenance.AnnotationKey)
+}
+
+// checkTaskRunsSpireVerified returns an error if any of the successful TaskRuns of the
+// pipeline, whose results can be used by the pipeline, was not marked as verified by the
+// TaskRun controller, once it verified its results and status with spire.
+func (c *Reconciler) checkTaskRunsSpireVerified(state resources.PipelineRunState) error {
+	for _, rpt := range state {
+		taskRuns := rpt.TaskRuns
+		if rpt.TaskRun != nil {
+			taskRuns = append(taskRuns, rpt.TaskRun)
+		}
+		for _, tr := range taskRuns {
+			if tr.IsSuccessful() && !c.spireClient.CheckSpireVerifiedFlag(tr) {
+				return fmt.Errorf("TaskRun %s of pipeline task %s failed the spire verification", tr.Name, rpt.PipelineTask.Name)
+			}
+		}
+	}
+	return nil
+}
+
 // runNextSchedulableTask gets the next schedulable Tasks from the dag based on the current
 // pipeline run state, and starts them
 // after all DAG tasks are done, it's responsible for scheduling final tasks and start executing them
@@ -872,10 +1037,30 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 		tr = tr.DeepCopy()
 		// is a retry
 		addRetryHistory(tr)
+		if computeResources := memoryRetryComputeResources(ctx, tr, rpt.PipelineTask.MemoryRetry); computeResources != nil {
+			logger.Infof("Retrying taskrun %s, which failed for OOM or eviction, with memory limit %s.", tr.GetName(), computeResources.Limits.Memory())
+			status := tr.Status
+			tr.Spec.ComputeResources = computeResources
+			updated, err := c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Update(ctx, tr, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, err
+			}
+			updated.Status = status
+			tr = updated
+		}
 		clearStatus(tr)
 		tr.Status.MarkResourceOngoing("", "")
 		logger.Infof("Updating taskrun %s with cleared status and retry history (length: %d).", tr.GetName(), len(tr.Status.RetriesStatus))
-		return c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).UpdateStatus(ctx, tr, metav1.UpdateOptions{})
+		tr, err := c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).UpdateStatus(ctx, tr, metav1.UpdateOptions{})
+		if err != nil {
+			return nil, err
+		}
+		events.EmitPipelineTaskCloudEvent(ctx, pr, cloudevent.PipelineTaskRetriedEventV1, cloudevent.PipelineTaskEventData{
+			Name:       rpt.PipelineTask.Name,
+			ChildName:  tr.Name,
+			RetryCount: len(tr.Status.RetriesStatus),
+		})
+		return tr, nil
 	}
 
 	rpt.PipelineTask = resources.ApplyPipelineTaskContexts(rpt.PipelineTask)
@@ -896,6 +1081,9 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 			StepOverrides:      taskRunSpec.StepOverrides,
 			SidecarOverrides:   taskRunSpec.SidecarOverrides,
 			ComputeResources:   taskRunSpec.ComputeResources,
+			Priority:           pr.Spec.Priority,
+			Deadline:           pr.Spec.Deadline,
+			ExecutionMode:      rpt.PipelineTask.ExecutionMode,
 		}}
 
 	if rpt.PipelineTask.Timeout != nil {
@@ -964,6 +1152,10 @@ func (c *Reconciler) createRun(ctx context.Context, runName string, params []v1b
 		r.Spec.Timeout = rpt.PipelineTask.Timeout
 	}
 
+	if gracePeriod := config.FromContextOrDefaults(ctx).Defaults.DefaultCustomTaskCancellationGracePeriod; gracePeriod > 0 {
+		r.Spec.CancellationGracePeriod = &metav1.Duration{Duration: gracePeriod}
+	}
+
 	if rpt.PipelineTask.TaskSpec != nil {
 		j, err := json.Marshal(rpt.PipelineTask.TaskSpec.Spec)
 		if err != nil {
@@ -1107,9 +1299,77 @@ func combinedSubPath(workspaceSubPath string, pipelineTaskSubPath string) string
 func addRetryHistory(tr *v1beta1.TaskRun) {
 	newStatus := *tr.Status.DeepCopy()
 	newStatus.RetriesStatus = nil
+	newStatus.ComputeResources = tr.Spec.ComputeResources.DeepCopy()
 	tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, newStatus)
 }
 
+// memoryRetryComputeResources returns the compute resources to retry a TaskRun that failed for
+// OOM or eviction with, which have its memory limit multiplied by the multiplier of the memory
+// retry policy of its PipelineTask up to its max limit, defaulting to default-memory-retry-multiplier
+// and default-memory-retry-max-limit. It returns nil when the memory limit is not to be changed.
+func memoryRetryComputeResources(ctx context.Context, tr *v1beta1.TaskRun, policy *v1beta1.MemoryRetryPolicy) *corev1.ResourceRequirements {
+	cfg := config.FromContextOrDefaults(ctx)
+	// Task-level compute resources are an alpha feature, and can't be combined with step overrides.
+	if cfg.FeatureFlags.EnableAPIFields != config.AlphaAPIFields || len(tr.Spec.StepOverrides) > 0 {
+		return nil
+	}
+	multiplier, maxLimit := cfg.Defaults.DefaultMemoryRetryMultiplier, cfg.Defaults.DefaultMemoryRetryMaxLimit
+	if policy != nil {
+		if policy.Multiplier != "" {
+			// The multiplier is validated by the webhook.
+			multiplier, _ = strconv.ParseFloat(policy.Multiplier, 64)
+		}
+		if policy.MaxLimit != nil {
+			maxLimit = policy.MaxLimit
+		}
+	}
+	if multiplier <= 1 || !podconvert.IsOOMKilledOrEvicted(&tr.Status) {
+		return nil
+	}
+	limit := taskRunMemoryLimit(tr)
+	if limit == nil {
+		return nil
+	}
+	bumped := resource.NewQuantity(int64(float64(limit.Value())*multiplier), resource.BinarySI)
+	if maxLimit != nil && bumped.Cmp(*maxLimit) > 0 {
+		bumped = maxLimit
+	}
+	if bumped.Cmp(*limit) <= 0 {
+		return nil
+	}
+	computeResources := &corev1.ResourceRequirements{}
+	if tr.Spec.ComputeResources != nil {
+		computeResources = tr.Spec.ComputeResources.DeepCopy()
+	}
+	if computeResources.Limits == nil {
+		computeResources.Limits = corev1.ResourceList{}
+	}
+	computeResources.Limits[corev1.ResourceMemory] = bumped.DeepCopy()
+	return computeResources
+}
+
+// taskRunMemoryLimit returns the task-level memory limit of the TaskRun or, if there is none,
+// the highest memory limit of its Steps. It returns nil if the memory is not limited.
+func taskRunMemoryLimit(tr *v1beta1.TaskRun) *resource.Quantity {
+	if tr.Spec.ComputeResources != nil {
+		if limit, ok := tr.Spec.ComputeResources.Limits[corev1.ResourceMemory]; ok {
+			return &limit
+		}
+	}
+	if tr.Status.TaskSpec == nil {
+		return nil
+	}
+	var maxLimit *resource.Quantity
+	for _, step := range tr.Status.TaskSpec.Steps {
+		if limit, ok := step.Resources.Limits[corev1.ResourceMemory]; ok {
+			if maxLimit == nil || limit.Cmp(*maxLimit) > 0 {
+				maxLimit = &limit
+			}
+		}
+	}
+	return maxLimit
+}
+
 func clearStatus(tr *v1beta1.TaskRun) {
 	tr.Status.StartTime = nil
 	tr.Status.CompletionTime = nil
@@ -1230,11 +1490,41 @@ func (c *Reconciler) updateLabelsAndAnnotations(ctx context.Context, pr *v1beta1
 		// Properly merge labels and annotations, as the labels *might* have changed during the reconciliation
 		newPr.Labels = kmap.Union(newPr.Labels, pr.Labels)
 		newPr.Annotations = kmap.Union(newPr.Annotations, pr.Annotations)
+		provenance.StripAnnotations(newPr.Annotations)
 		return c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Update(ctx, newPr, metav1.UpdateOptions{})
 	}
 	return newPr, nil
 }
 
+// markTrustedResourcesVerified sets the TrustedResourcesVerified condition of
+// the PipelineRun from the verification result of the resolved Pipeline, if it
+// was verified.
+func markTrustedResourcesVerified(pr *v1beta1.PipelineRun, pipelineMeta *resolutionutil.ResolvedObjectMeta) {
+	vr := pipelineMeta.VerificationResult
+	if vr == nil {
+		return
+	}
+	condition := &apis.Condition{
+		Type:   apis.ConditionType(v1beta1.PipelineRunConditionTrustedResourcesVerified.String()),
+		Reason: v1beta1.PipelineRunConditionTrustedResourcesVerified.String(),
+	}
+	switch vr.VerificationResultType {
+	case trustedresources.VerificationPass:
+		condition.Status = corev1.ConditionTrue
+		if len(vr.MatchedPolicies) > 0 {
+			condition.Message = fmt.Sprintf("Pipeline %q was verified with the VerificationPolicies %s", pipelineMeta.Name, strings.Join(vr.MatchedPolicies, ", "))
+		} else {
+			condition.Message = fmt.Sprintf("Pipeline %q was verified with the public keys of config-trusted-resources", pipelineMeta.Name)
+		}
+	case trustedresources.VerificationWarn:
+		condition.Status = corev1.ConditionFalse
+		condition.Message = fmt.Sprintf("Pipeline %q failed verification: %v", pipelineMeta.Name, vr.Err)
+	default:
+		return
+	}
+	pr.Status.SetCondition(condition)
+}
+
 func storePipelineSpecAndMergeMeta(ctx context.Context, pr *v1beta1.PipelineRun, ps *v1beta1.PipelineSpec, meta *resolutionutil.ResolvedObjectMeta) error {
 	// Only store the PipelineSpec once, if it has never been set before.
 	if pr.Status.PipelineSpec == nil {
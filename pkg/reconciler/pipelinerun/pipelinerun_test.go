@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"strconv"
 	"testing"
 	"time"
@@ -38,11 +39,14 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
+	"github.com/tektoncd/pipeline/pkg/provenance"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/spire"
+	spireconfig "github.com/tektoncd/pipeline/pkg/spire/config"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	eventstest "github.com/tektoncd/pipeline/test/events"
@@ -115,6 +119,7 @@ const (
 	ociBundlesFeatureFlag          = "enable-tekton-oci-bundles"
 	embeddedStatusFeatureFlag      = "embedded-status"
 	maxMatrixCombinationsCountFlag = "default-max-matrix-combinations-count"
+	spireFeatureFlag               = "enable-spire"
 )
 
 type PipelineRunTest struct {
@@ -127,7 +132,7 @@ type PipelineRunTest struct {
 // getPipelineRunController returns an instance of the PipelineRun controller/reconciler that has been seeded with
 // d, where d represents the state of the system (existing resources) needed for the test.
 func getPipelineRunController(t *testing.T, d test.Data) (test.Assets, func()) {
-	return initializePipelineRunControllerAssets(t, d, pipeline.Options{Images: images})
+	return initializePipelineRunControllerAssets(t, d, pipeline.Options{Images: images, SpireConfig: spireconfig.SpireConfig{MockSpire: true}})
 }
 
 // initiailizePipelinerunControllerAssets is a shared helper for
@@ -1465,6 +1470,12 @@ func withEmbeddedStatus(cm *corev1.ConfigMap, flagVal string) *corev1.ConfigMap
 	return newCM
 }
 
+func withSpire(cm *corev1.ConfigMap) *corev1.ConfigMap {
+	newCM := cm.DeepCopy()
+	newCM.Data[spireFeatureFlag] = "true"
+	return newCM
+}
+
 func withMaxMatrixCombinationsCount(cm *corev1.ConfigMap, count int) *corev1.ConfigMap {
 	newCM := cm.DeepCopy()
 	newCM.Data[maxMatrixCombinationsCountFlag] = strconv.Itoa(count)
@@ -2573,6 +2584,173 @@ spec:
 	}
 }
 
+func TestReconcile_PipelineTaskExecutionMode(t *testing.T) {
+	// TestReconcile_PipelineTaskExecutionMode runs "Reconcile" on a PipelineRun with a hermetic PipelineTask.
+	// It verifies that the TaskRun created for the PipelineTask is hermetic.
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-hermetic
+  namespace: foo
+spec:
+  pipelineSpec:
+    tasks:
+    - name: build
+      executionMode: hermetic
+      taskSpec:
+        steps:
+        - name: mystep
+          image: myimage
+`)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0",
+	}
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run-hermetic", wantEvents, false)
+
+	actual := getTaskRunCreations(t, clients.Pipeline.Actions(), 2)[0]
+	if actual.Spec.ExecutionMode != v1beta1.ExecutionModeHermetic {
+		t.Errorf("expected the TaskRun to be created in the %s execution mode but got %q", v1beta1.ExecutionModeHermetic, actual.Spec.ExecutionMode)
+	}
+}
+
+func TestReconcileWithDeadline(t *testing.T) {
+	// TestReconcileWithDeadline runs "Reconcile" on a PipelineRun whose deadline has passed before its timeout.
+	// It verifies that the PipelineRun and its TaskRun are timed out with a message about the deadline.
+	ps := []*v1beta1.Pipeline{simpleHelloWorldPipeline}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-with-deadline
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa
+  timeouts:
+    pipeline: 48h0m0s
+  deadline: "2021-12-31T18:00:00Z"
+status:
+  startTime: "2021-12-31T00:00:00Z"
+  taskRuns:
+    test-pipeline-run-with-deadline-hello-world-1:
+      pipelineTaskName: hello-world-1
+      status:
+        conditions:
+        - lastTransitionTime: null
+          status: "Unknown"
+          type: Succeeded
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t, taskRunObjectMeta("test-pipeline-run-with-deadline-hello-world-1", "foo", "test-pipeline-run-with-deadline",
+		"test-pipeline", "hello-world-1", false), `
+spec:
+  resources: {}
+  serviceAccountName: test-sa
+  taskRef:
+    name: hello-world
+    kind: Task
+`)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Warning Failed PipelineRun \"test-pipeline-run-with-deadline\" failed to finish by its deadline 2021-12-31T18:00:00Z",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-with-deadline", wantEvents, false)
+
+	if reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason != v1beta1.PipelineRunReasonTimedOut.String() {
+		t.Errorf("Expected PipelineRun to be timed out, but condition reason is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+
+	updatedTaskRun, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, trs[0].Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("error getting updated TaskRun: %#v", err)
+	}
+	if updatedTaskRun.Spec.Status != v1beta1.TaskRunSpecStatusCancelled {
+		t.Errorf("expected existing TaskRun Spec.Status to be set to %s, but was %s", v1beta1.TaskRunSpecStatusCancelled, updatedTaskRun.Spec.Status)
+	}
+}
+
+func TestReconcileWithDeadlineApproaching(t *testing.T) {
+	// TestReconcileWithDeadlineApproaching runs "Reconcile" on a PipelineRun whose deadline is closer than
+	// default-deadline-warning-threshold. It verifies that the DeadlineApproaching condition is set, that
+	// $(context.pipelineRun.deadlineApproaching) is replaced accordingly, and that the deadline is set on
+	// the TaskRun.
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+    when:
+    - input: $(context.pipelineRun.deadlineApproaching)
+      operator: in
+      values: ["true"]
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-with-deadline
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa
+  deadline: "2022-01-01T00:05:00Z"
+`)}
+	defaults := newDefaultsConfigMap()
+	defaults.Data["default-deadline-warning-threshold"] = "10m"
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		ConfigMaps:   []*corev1.ConfigMap{defaults},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-with-deadline", []string{}, false)
+
+	condition := reconciledRun.Status.GetCondition(apis.ConditionType(v1beta1.PipelineRunConditionDeadlineApproaching.String()))
+	wantCondition := &apis.Condition{
+		Type:     apis.ConditionType(v1beta1.PipelineRunConditionDeadlineApproaching.String()),
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   v1beta1.PipelineRunConditionDeadlineApproaching.String(),
+		Message:  `PipelineRun "test-pipeline-run-with-deadline" must finish by its deadline 2022-01-01T00:05:00Z`,
+	}
+	if d := cmp.Diff(wantCondition, condition, cmpopts.IgnoreFields(apis.Condition{}, "LastTransitionTime")); d != "" {
+		t.Errorf("Unexpected DeadlineApproaching condition %s", diff.PrintWantGot(d))
+	}
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Error listing TaskRuns: %v", err)
+	}
+	if len(taskRuns.Items) != 1 {
+		t.Fatalf("Expected the TaskRun to be created since the deadline is approaching, but got %d TaskRuns", len(taskRuns.Items))
+	}
+	if d := cmp.Diff(prs[0].Spec.Deadline, taskRuns.Items[0].Spec.Deadline); d != "" {
+		t.Errorf("Unexpected TaskRun deadline %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestReconcileWithTimeouts_Tasks(t *testing.T) {
 	// TestReconcileWithTimeouts_Tasks runs "Reconcile" on a PipelineRun with timeouts.tasks configured.
 	// It verifies that reconcile is successful, no TaskRun is created, the PipelineTask is marked as skipped, and the
@@ -3433,6 +3611,150 @@ status:
 	}
 }
 
+// TestReconcileRetryWithBumpedMemory runs "Reconcile" against a pipeline with
+// a TaskRun that failed for OOM, and verifies that the TaskRun is retried with
+// its memory limit multiplied by default-memory-retry-multiplier, up to
+// default-memory-retry-max-limit, unless the PipelineTask overrides them.
+func TestReconcileRetryWithBumpedMemory(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		apiFields     string
+		memoryRetry   string
+		stepReason    string
+		wantMemory    string
+		wantRetries   int
+		wantCondition corev1.ConditionStatus
+	}{{
+		name:          "OOM killed, memory multiplied",
+		apiFields:     config.AlphaAPIFields,
+		stepReason:    "OOMKilled",
+		wantMemory:    "1536Mi",
+		wantRetries:   1,
+		wantCondition: corev1.ConditionUnknown,
+	}, {
+		name:          "not OOM killed, memory unchanged",
+		apiFields:     config.AlphaAPIFields,
+		stepReason:    "Error",
+		wantMemory:    "1Gi",
+		wantRetries:   1,
+		wantCondition: corev1.ConditionUnknown,
+	}, {
+		name:      "OOM killed, memory multiplied by the PipelineTask policy",
+		apiFields: config.AlphaAPIFields,
+		memoryRetry: `
+    memoryRetry:
+      multiplier: "3"
+      maxLimit: 4Gi`,
+		stepReason:    "OOMKilled",
+		wantMemory:    "3Gi",
+		wantRetries:   1,
+		wantCondition: corev1.ConditionUnknown,
+	}, {
+		name:      "OOM killed, memory retry disabled by the PipelineTask policy",
+		apiFields: config.AlphaAPIFields,
+		memoryRetry: `
+    memoryRetry:
+      multiplier: "0"`,
+		stepReason:    "OOMKilled",
+		wantMemory:    "1Gi",
+		wantRetries:   1,
+		wantCondition: corev1.ConditionUnknown,
+	}, {
+		name:          "alpha API fields disabled, memory unchanged",
+		apiFields:     config.StableAPIFields,
+		stepReason:    "OOMKilled",
+		wantMemory:    "1Gi",
+		wantRetries:   1,
+		wantCondition: corev1.ConditionUnknown,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline-retry
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    retries: 2
+    taskRef:
+      name: hello-world%s
+`, tc.memoryRetry))}
+			prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-retry-run-with-bumped-memory
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline-retry
+  serviceAccountName: test-sa
+`)}
+			trs := []*v1beta1.TaskRun{parse.MustParseV1beta1TaskRun(t, fmt.Sprintf(`
+metadata:
+  name: hello-world-1
+  namespace: foo
+spec:
+  taskRef:
+    name: hello-world
+  computeResources:
+    limits:
+      memory: 1Gi
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+  podName: my-pod-name
+  steps:
+  - name: simple-step
+    terminated:
+      exitCode: 137
+      reason: %s
+`, tc.stepReason))}
+
+			prs[0].Status.TaskRuns = map[string]*v1beta1.PipelineRunTaskRunStatus{
+				"hello-world-1": {
+					PipelineTaskName: "hello-world-1",
+					Status:           &trs[0].Status,
+				},
+			}
+
+			featureFlags := newFeatureFlagsConfigMap()
+			featureFlags.Data[apiFieldsFeatureFlag] = tc.apiFields
+			defaults := newDefaultsConfigMap()
+			defaults.Data["default-memory-retry-multiplier"] = "2"
+			defaults.Data["default-memory-retry-max-limit"] = "1536Mi"
+
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				TaskRuns:     trs,
+				ConfigMaps:   []*corev1.ConfigMap{featureFlags, defaults},
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			_, clients := prt.reconcileRun("foo", "test-pipeline-retry-run-with-bumped-memory", []string{}, false)
+
+			tr, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, "hello-world-1", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting TaskRun: %v", err)
+			}
+			if got := tr.Spec.ComputeResources.Limits[corev1.ResourceMemory]; got.Cmp(resource.MustParse(tc.wantMemory)) != 0 {
+				t.Errorf("Expected memory limit %s but got %s", tc.wantMemory, got.String())
+			}
+			if len(tr.Status.RetriesStatus) != tc.wantRetries {
+				t.Fatalf("%d retries expected but got %d", tc.wantRetries, len(tr.Status.RetriesStatus))
+			}
+			if d := cmp.Diff(trs[0].Spec.ComputeResources, tr.Status.RetriesStatus[0].ComputeResources); d != "" {
+				t.Errorf("Unexpected compute resources recorded in the retries status %s", diff.PrintWantGot(d))
+			}
+			if status := tr.Status.GetCondition(apis.ConditionSucceeded).Status; status != tc.wantCondition {
+				t.Errorf("Succeeded expected to be %s but is %s", tc.wantCondition, status)
+			}
+		})
+	}
+}
+
 // TestReconcileAndPropagateCustomPipelineTaskRunSpec tests that custom PipelineTaskRunSpec declared
 // in PipelineRun is propagated to created TaskRuns
 func TestReconcileAndPropagateCustomPipelineTaskRunSpec(t *testing.T) {
@@ -5525,6 +5847,195 @@ status:
 	}
 }
 
+func TestReconcileWithPipelineResults_Spire(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  results:
+    - description: pipeline result
+      name: result
+      value: $(tasks.a-task.results.a-Result)
+  tasks:
+    - name: a-task
+      taskRef:
+        name: a-task
+`)}
+	ts := []*v1beta1.Task{parse.MustParseV1beta1Task(t, `
+metadata:
+  name: a-task
+  namespace: foo
+spec:
+  results:
+  - name: a-Result
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-spire
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  conditions:
+  - status: "Unknown"
+    type: Succeeded
+    reason: Running
+`)}
+
+	for _, tc := range []struct {
+		name                string
+		taskRunAnnotations  map[string]string
+		wantReason          string
+		wantPermanentError  bool
+		wantResultsVerified bool
+	}{{
+		name:                "results are signed",
+		taskRunAnnotations:  map[string]string{spire.VerifiedAnnotation: "true"},
+		wantReason:          v1beta1.PipelineRunReasonSuccessful.String(),
+		wantResultsVerified: true,
+	}, {
+		name:               "taskrun failed the spire verification",
+		taskRunAnnotations: map[string]string{spire.VerifiedAnnotation: "false"},
+		wantReason:         ReasonSpireVerificationFailed,
+		wantPermanentError: true,
+	}, {
+		name:               "taskrun not verified",
+		wantReason:         ReasonSpireVerificationFailed,
+		wantPermanentError: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := mustParseTaskRunWithObjectMeta(t,
+				taskRunObjectMeta("test-pipeline-run-spire-a-task", "foo",
+					"test-pipeline-run-spire", "test-pipeline", "a-task", true),
+				`
+spec:
+  taskRef:
+    name: a-task
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  taskResults:
+  - name: a-Result
+    value: aResultValue
+`)
+			tr.Status.Annotations = tc.taskRunAnnotations
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     []*v1beta1.TaskRun{tr},
+				ConfigMaps:   []*corev1.ConfigMap{withSpire(newFeatureFlagsConfigMap())},
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-spire", []string{}, tc.wantPermanentError)
+			if d := cmp.Diff(tc.wantReason, reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason); d != "" {
+				t.Errorf("unexpected PipelineRun reason %s", diff.PrintWantGot(d))
+			}
+
+			spireClient := &spire.MockClient{}
+			err := spireClient.VerifyPipelineRunResultsAnnotation(prt.TestAssets.Ctx, reconciledRun)
+			if tc.wantResultsVerified && err != nil {
+				t.Errorf("VerifyPipelineRunResultsAnnotation() = %v", err)
+			}
+			if !tc.wantResultsVerified && err == nil {
+				t.Error("expected the PipelineRun results not to be signed")
+			}
+		})
+	}
+}
+
+func TestReconcileOnCompletedPipelineRun_SLSAProvenance(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  results:
+    - name: IMAGE_URL
+      value: $(tasks.a-task.results.IMAGE_URL)
+    - name: IMAGE_DIGEST
+      value: $(tasks.a-task.results.IMAGE_DIGEST)
+  tasks:
+    - name: a-task
+      taskRef:
+        name: a-task
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-provenance
+  namespace: foo
+  uid: pr-uid
+  annotations:
+    tekton.dev/slsa-provenance: '{"subject":[{"name":"gcr.io/foo/evil"}]}'
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+    reason: Succeeded
+  pipelineResults:
+  - name: IMAGE_URL
+    value: gcr.io/foo/bar
+  - name: IMAGE_DIGEST
+    value: sha256:222
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-provenance-a-task", "foo",
+			"test-pipeline-run-provenance", "test-pipeline", "a-task", true),
+		`
+spec:
+  taskRef:
+    name: a-task
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  steps:
+  - name: build
+    imageID: docker-pullable://gcr.io/foo/builder@sha256:111
+`)}
+	cm := newFeatureFlagsConfigMap()
+	cm.Data["enable-slsa-provenance"] = "true"
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		TaskRuns:     trs,
+		ConfigMaps:   []*corev1.ConfigMap{cm},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-provenance", []string{}, false)
+	if _, ok := reconciledRun.Annotations[provenance.AnnotationKey]; ok {
+		t.Errorf("expected the %s annotation set by the user to be removed", provenance.AnnotationKey)
+	}
+	statement, ok := reconciledRun.Status.Annotations[provenance.AnnotationKey]
+	if !ok {
+		t.Fatalf("expected the PipelineRun to have the %s status annotation", provenance.AnnotationKey)
+	}
+	got := &provenance.Statement{}
+	if err := json.Unmarshal([]byte(statement), got); err != nil {
+		t.Fatalf("failed to unmarshal the provenance statement: %v", err)
+	}
+	want := provenance.ForPipelineRun(prs[0], trs)
+	if d := cmp.Diff(want.Subject, got.Subject); d != "" {
+		t.Errorf("unexpected provenance subjects %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(want.Predicate.Materials, got.Predicate.Materials); d != "" {
+		t.Errorf("unexpected provenance materials %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff("pr-uid", got.Predicate.Metadata.BuildInvocationID); d != "" {
+		t.Errorf("unexpected provenance invocation ID %s", diff.PrintWantGot(d))
+	}
+}
+
 func Test_storePipelineSpecAndConfigSource(t *testing.T) {
 	pr := parse.MustParseV1beta1PipelineRun(t, `
 metadata:
@@ -6558,56 +7069,311 @@ spec:
 	ts := []*v1beta1.Task{
 		parse.MustParseV1beta1Task(t, `
 metadata:
-  name: test-task
+  name: test-task
+  namespace: foo
+spec:
+  steps:
+    - name: simple-step
+      image: foo
+      command: ["/mycmd"]
+      env:
+       - name: foo
+         value: bar
+`),
+	}
+	cms := []*corev1.ConfigMap{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"default-cloud-events-sink": "http://synk:8080",
+			},
+		},
+	}
+	t.Logf("config maps: %s", cms)
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0",
+	}
+
+	d := test.Data{
+		PipelineRuns:            prs,
+		Pipelines:               ps,
+		Tasks:                   ts,
+		ConfigMaps:              cms,
+		ExpectedCloudEventCount: len(wantEvents),
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipelinerun", wantEvents, false)
+
+	// This PipelineRun is in progress now and the status should reflect that
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+
+	verifyTaskRunStatusesCount(t, cms[0].Data[embeddedStatusFeatureFlag], reconciledRun.Status, 1)
+
+	wantCloudEvents := []string{
+		`(?s)dev.tekton.event.pipelinerun.started.v1.*test-pipelinerun`,
+		`(?s)dev.tekton.event.pipelinerun.running.v1.*test-pipelinerun`,
+	}
+	ceClient := clients.CloudEvents.(cloudevent.FakeClient)
+	ceClient.CheckCloudEventsUnordered(t, "reconcile-cloud-events", wantCloudEvents)
+}
+
+// TestReconcile_CloudEventsPipelineTasks runs reconcile with a cloud event sink
+// configured to ensure that events are sent for skipped, retried and cancelled
+// PipelineTasks
+func TestReconcile_CloudEventsPipelineTasks(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		pipeline        string
+		pipelineRun     string
+		taskRuns        []string
+		wantEvents      []string
+		wantCloudEvents []string
+	}{{
+		name: "skipped",
+		pipeline: `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+  - name: hello-world-2
+    when:
+    - input: foo
+      operator: in
+      values: ["bar"]
+    taskRef:
+      name: hello-world
+`,
+		pipelineRun: `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+  selfLink: /pipeline/1234
+spec:
+  pipelineRef:
+    name: test-pipeline
+`,
+		wantEvents: []string{
+			"Normal Started",
+			"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 1",
+		},
+		wantCloudEvents: []string{
+			`(?s)dev.tekton.event.pipelinerun.started.v1.*test-pipeline-run`,
+			`(?s)dev.tekton.event.pipelinerun.running.v1.*test-pipeline-run`,
+			`(?s)dev.tekton.event.pipelinerun.task.skipped.v1.*test-pipeline-run.*"name": "hello-world-2",.*"reason": "When Expressions evaluated to false"`,
+		},
+	}, {
+		name: "retried",
+		pipeline: `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    retries: 1
+    taskRef:
+      name: hello-world
+`,
+		pipelineRun: `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+  selfLink: /pipeline/1234
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  startTime: "2021-12-31T00:00:00Z"
+  conditions:
+  - reason: Running
+    status: "Unknown"
+    type: Succeeded
+  taskRuns:
+    test-pipeline-run-hello-world-1:
+      pipelineTaskName: hello-world-1
+`,
+		taskRuns: []string{`
+metadata:
+  name: test-pipeline-run-hello-world-1
+  namespace: foo
+  labels:
+    tekton.dev/pipelineRun: test-pipeline-run
+    tekton.dev/pipelineTask: hello-world-1
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+`},
+		wantEvents: []string{},
+		wantCloudEvents: []string{
+			`(?s)dev.tekton.event.pipelinerun.task.retried.v1.*test-pipeline-run.*"name": "hello-world-1",.*"childName": "test-pipeline-run-hello-world-1",.*"retryCount": 1`,
+		},
+	}, {
+		name: "cancelled",
+		pipeline: `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+`,
+		pipelineRun: `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+  selfLink: /pipeline/1234
+spec:
+  pipelineRef:
+    name: test-pipeline
+  status: Cancelled
+status:
+  startTime: "2021-12-31T00:00:00Z"
+  conditions:
+  - reason: Running
+    status: "Unknown"
+    type: Succeeded
+  taskRuns:
+    test-pipeline-run-hello-world-1:
+      pipelineTaskName: hello-world-1
+`,
+		taskRuns: []string{`
+metadata:
+  name: test-pipeline-run-hello-world-1
+  namespace: foo
+  labels:
+    tekton.dev/pipelineRun: test-pipeline-run
+    tekton.dev/pipelineTask: hello-world-1
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "Unknown"
+    type: Succeeded
+`},
+		wantEvents: []string{
+			"Warning Failed PipelineRun \"test-pipeline-run\" was cancelled",
+		},
+		wantCloudEvents: []string{
+			`(?s)dev.tekton.event.pipelinerun.task.cancelled.v1.*test-pipeline-run.*"name": "hello-world-1",.*"childName": "test-pipeline-run-hello-world-1"`,
+			`(?s)dev.tekton.event.pipelinerun.failed.v1.*test-pipeline-run`,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			var trs []*v1beta1.TaskRun
+			for _, tr := range tc.taskRuns {
+				trs = append(trs, parse.MustParseV1beta1TaskRun(t, tr))
+			}
+			cms := []*corev1.ConfigMap{{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+				Data: map[string]string{
+					"default-cloud-events-sink": "http://synk:8080",
+				},
+			}}
+			d := test.Data{
+				PipelineRuns:            []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, tc.pipelineRun)},
+				Pipelines:               []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, tc.pipeline)},
+				Tasks:                   []*v1beta1.Task{simpleHelloWorldTask},
+				TaskRuns:                trs,
+				ConfigMaps:              cms,
+				ExpectedCloudEventCount: len(tc.wantCloudEvents),
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", tc.wantEvents, false)
+
+			ceClient := clients.CloudEvents.(cloudevent.FakeClient)
+			ceClient.CheckCloudEventsUnordered(t, tc.name, tc.wantCloudEvents)
+			for _, skippedTask := range reconciledRun.Status.SkippedTasks {
+				if !skippedTask.CloudEventSent {
+					t.Errorf("Expected the cloud event about the skipped PipelineTask %s to be recorded as sent", skippedTask.Name)
+				}
+			}
+		})
+	}
+}
+
+// TestReconcile_CloudEventsSkippedPipelineTaskSentOnce runs reconcile with a cloud
+// event sink configured to ensure that the event about a skipped PipelineTask is not
+// sent again when it is recorded as sent in the PipelineRun status
+func TestReconcile_CloudEventsSkippedPipelineTaskSentOnce(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+  - name: hello-world-2
+    when:
+    - input: foo
+      operator: in
+      values: ["bar"]
+    taskRef:
+      name: hello-world
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
   namespace: foo
+  selfLink: /pipeline/1234
 spec:
-  steps:
-    - name: simple-step
-      image: foo
-      command: ["/mycmd"]
-      env:
-       - name: foo
-         value: bar
-`),
-	}
-	cms := []*corev1.ConfigMap{
-		{
-			ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
-			Data: map[string]string{
-				"default-cloud-events-sink": "http://synk:8080",
-			},
+  pipelineRef:
+    name: test-pipeline
+status:
+  conditions:
+  - reason: Running
+    status: "Unknown"
+    type: Succeeded
+    message: "Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 1"
+  skippedTasks:
+  - name: hello-world-2
+    reason: When Expressions evaluated to false
+    cloudEventSent: true
+`)}
+	prs[0].Status.StartTime = &metav1.Time{Time: time.Now()}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"default-cloud-events-sink": "http://synk:8080",
 		},
-	}
-	t.Logf("config maps: %s", cms)
-
-	wantEvents := []string{
-		"Normal Started",
-		"Normal Running Tasks Completed: 0",
-	}
-
+	}}
 	d := test.Data{
-		PipelineRuns:            prs,
-		Pipelines:               ps,
-		Tasks:                   ts,
-		ConfigMaps:              cms,
-		ExpectedCloudEventCount: len(wantEvents),
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		ConfigMaps:   cms,
+		// Leave room for an event, so that it is detected if it is sent again
+		ExpectedCloudEventCount: 1,
 	}
 	prt := newPipelineRunTest(d, t)
 	defer prt.Cancel()
 
-	reconciledRun, clients := prt.reconcileRun("foo", "test-pipelinerun", wantEvents, false)
-
-	// This PipelineRun is in progress now and the status should reflect that
-	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
-
-	verifyTaskRunStatusesCount(t, cms[0].Data[embeddedStatusFeatureFlag], reconciledRun.Status, 1)
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
 
-	wantCloudEvents := []string{
-		`(?s)dev.tekton.event.pipelinerun.started.v1.*test-pipelinerun`,
-		`(?s)dev.tekton.event.pipelinerun.running.v1.*test-pipelinerun`,
-	}
 	ceClient := clients.CloudEvents.(cloudevent.FakeClient)
-	ceClient.CheckCloudEventsUnordered(t, "reconcile-cloud-events", wantCloudEvents)
+	ceClient.CheckCloudEventsUnordered(t, "skipped-sent-once", []string{})
+	if len(reconciledRun.Status.SkippedTasks) != 1 || !reconciledRun.Status.SkippedTasks[0].CloudEventSent {
+		t.Errorf("Expected the skipped PipelineTask to remain recorded as sent, got %v", reconciledRun.Status.SkippedTasks)
+	}
 }
 
 // this test validates taskSpec metadata is embedded into task run
@@ -10876,6 +11642,134 @@ spec:
 
 	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
 
+	wantCondition := apis.Condition{
+		Type:    apis.ConditionType(v1beta1.PipelineRunConditionTrustedResourcesVerified.String()),
+		Status:  corev1.ConditionTrue,
+		Reason:  v1beta1.PipelineRunConditionTrustedResourcesVerified.String(),
+		Message: `Pipeline "test-pipeline" was verified with the public keys of config-trusted-resources`,
+	}
+	if d := cmp.Diff(&wantCondition, reconciledRun.Status.GetCondition(wantCondition.Type), ignoreLastTransitionTime); d != "" {
+		t.Errorf("TrustedResourcesVerified condition %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_verifyResolvedPipeline_VerificationPolicy(t *testing.T) {
+	names.TestingSeed()
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	prs := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipelinerun
+  namespace: foo
+  selfLink: /pipeline/1234
+spec:
+  pipelineRef:
+    name: test-pipeline
+`)
+	ps := parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+    - name: test-1
+      taskRef:
+        name: test-task
+`)
+	ts := parse.MustParseV1beta1Task(t, `
+metadata:
+  name: test-task
+  namespace: foo
+spec:
+  steps:
+    - name: simple-step
+      image: foo
+      command: ["/mycmd"]
+`)
+
+	signer, keypath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyData, err := os.ReadFile(keypath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherKeypath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyData, err := os.ReadFile(otherKeypath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedTask, err := test.GetSignedTask(ts, signer, "test-task")
+	if err != nil {
+		t.Fatal("fail to sign task", err)
+	}
+	signedPipeline, err := test.GetSignedPipeline(ps, signer, "test-pipeline")
+	if err != nil {
+		t.Fatal("fail to sign pipeline", err)
+	}
+
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"resource-verification-mode": "enforce",
+		},
+	}}
+	policy := func(key []byte) *v1alpha1.VerificationPolicy {
+		return &v1alpha1.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "local-resources", Namespace: "foo"},
+			Spec: v1alpha1.VerificationPolicySpec{
+				Resources:   []v1alpha1.ResourcePattern{{Pattern: ".*"}},
+				Authorities: []v1alpha1.Authority{{Name: "key", Key: &v1alpha1.KeyRef{Data: string(key)}}},
+			},
+		}
+	}
+
+	t.Run("signed with the key of the matching policy", func(t *testing.T) {
+		d := test.Data{
+			PipelineRuns:         []*v1beta1.PipelineRun{prs},
+			Pipelines:            []*v1beta1.Pipeline{signedPipeline},
+			Tasks:                []*v1beta1.Task{signedTask},
+			ConfigMaps:           cms,
+			VerificationPolicies: []*v1alpha1.VerificationPolicy{policy(keyData)},
+		}
+		prt := newPipelineRunTest(d, t)
+		defer prt.Cancel()
+
+		reconciledRun, _ := prt.reconcileRun("foo", "test-pipelinerun", []string{}, false)
+
+		checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+		wantCondition := apis.Condition{
+			Type:    apis.ConditionType(v1beta1.PipelineRunConditionTrustedResourcesVerified.String()),
+			Status:  corev1.ConditionTrue,
+			Reason:  v1beta1.PipelineRunConditionTrustedResourcesVerified.String(),
+			Message: `Pipeline "test-pipeline" was verified with the VerificationPolicies local-resources`,
+		}
+		if d := cmp.Diff(&wantCondition, reconciledRun.Status.GetCondition(wantCondition.Type), ignoreLastTransitionTime); d != "" {
+			t.Errorf("TrustedResourcesVerified condition %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("signed with the key of another policy", func(t *testing.T) {
+		d := test.Data{
+			PipelineRuns:         []*v1beta1.PipelineRun{prs},
+			Pipelines:            []*v1beta1.Pipeline{signedPipeline},
+			Tasks:                []*v1beta1.Task{signedTask},
+			ConfigMaps:           cms,
+			VerificationPolicies: []*v1alpha1.VerificationPolicy{policy(otherKeyData)},
+		}
+		prt := newPipelineRunTest(d, t)
+		defer prt.Cancel()
+
+		reconciledRun, _ := prt.reconcileRun("foo", "test-pipelinerun", []string{}, true)
+
+		checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionFalse, ReasonResourceVerificationFailed)
+	})
 }
 
 func TestReconcile_verifyResolvedPipeline_Error(t *testing.T) {
@@ -11006,3 +11900,156 @@ spec:
 		})
 	}
 }
+
+func TestReconcilePipelineRunPolicyViolation(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipelinerun
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+`)}
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: build
+    taskRef:
+      name: build-task
+  finally:
+  - name: notify
+    taskSpec:
+      steps:
+      - name: notify
+        image: registry.corp/notify
+        securityContext:
+          privileged: true
+`)}
+	ts := []*v1beta1.Task{parse.MustParseV1beta1Task(t, `
+metadata:
+  name: build-task
+  namespace: foo
+spec:
+  steps:
+  - name: build
+    image: docker.io/builder
+`)}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetPolicyConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"allowed-image-prefixes": "- registry.corp/\n",
+			"disallow-privileged":    "true",
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		ConfigMaps:   cms,
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Warning Failed PipelineRun foo/test-pipelinerun violates the policy",
+		"Warning InternalError 1 error occurred",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipelinerun", wantEvents, true)
+
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionFalse, ReasonPolicyViolation)
+	wantMessage := `PipelineRun foo/test-pipelinerun violates the policy: policy violation: pipeline task "build": step "build": image "docker.io/builder" does not start with any of the allowed prefixes registry.corp/; pipeline task "notify": step "notify": privileged containers are not allowed`
+	if got := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Message; got != wantMessage {
+		t.Errorf("Expected message %q but got %q", wantMessage, got)
+	}
+	if len(reconciledRun.Status.ChildReferences) != 0 {
+		t.Errorf("Expected no TaskRuns to be created but got %v", reconciledRun.Status.ChildReferences)
+	}
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Error listing TaskRuns: %v", err)
+	}
+	if len(taskRuns.Items) != 0 {
+		t.Errorf("Expected no TaskRuns to be created but got %d", len(taskRuns.Items))
+	}
+}
+
+func TestReconcilePipelineRunPolicyNotValidatedAfterFirstTaskRun(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipelinerun
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: Running
+  startTime: "2022-01-01T00:00:00Z"
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: test-pipelinerun-build
+    pipelineTaskName: build
+`)}
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: build
+    taskRef:
+      name: build-task
+`)}
+	ts := []*v1beta1.Task{parse.MustParseV1beta1Task(t, `
+metadata:
+  name: build-task
+  namespace: foo
+spec:
+  steps:
+  - name: build
+    image: docker.io/builder
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipelinerun-build", "foo", "test-pipelinerun", "test-pipeline", "build", false), `
+spec:
+  taskRef:
+    name: build-task
+status:
+  conditions:
+  - type: Succeeded
+    status: Unknown
+    reason: Running
+`)}
+	// The policy changed once the TaskRun of the PipelineRun was created.
+	cms := []*corev1.ConfigMap{withEmbeddedStatus(newFeatureFlagsConfigMap(), config.MinimalEmbeddedStatus), {
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetPolicyConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"allowed-image-prefixes": "- registry.corp/\n",
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		ConfigMaps:   cms,
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	if err := prt.TestAssets.Controller.Reconciler.Reconcile(prt.TestAssets.Ctx, "foo/test-pipelinerun"); controller.IsPermanentError(err) {
+		t.Fatalf("Unexpected permanent error reconciling the PipelineRun: %v", err)
+	}
+	reconciledRun, err := prt.TestAssets.Clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, "test-pipelinerun", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting PipelineRun: %v", err)
+	}
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
+)
+
+// applyPVCRetentionPolicies applies the retention policy of each volumeClaimTemplate workspace
+// binding of a completed PipelineRun: the PersistentVolumeClaims which are no longer needed are
+// deleted and the outcome is recorded in the PipelineRun status. Bindings without a policy, either
+// on the binding or in config-defaults, keep their claim until the PipelineRun is deleted.
+func (c *Reconciler) applyPVCRetentionPolicies(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	cfg := config.FromContextOrDefaults(ctx)
+	succeeded := pr.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+
+	applied := sets.NewString()
+	for _, vc := range pr.Status.VolumeClaims {
+		applied.Insert(vc.WorkspaceName)
+	}
+
+	owner := *kmeta.NewControllerRef(pr)
+	var toDelete []v1beta1.WorkspaceBinding
+	var volumeClaims []v1beta1.VolumeClaimStatus
+	for _, wb := range pr.Spec.Workspaces {
+		if wb.VolumeClaimTemplate == nil || applied.Has(wb.Name) {
+			continue
+		}
+		policy := wb.RetentionPolicy
+		if policy == "" {
+			policy = v1beta1.PVCRetentionPolicy(cfg.Defaults.DefaultPVCRetentionPolicy)
+		}
+		if policy == "" {
+			continue
+		}
+
+		outcome := v1beta1.VolumeClaimOutcomeRetained
+		if policy == v1beta1.PVCRetentionPolicyDelete || succeeded {
+			toDelete = append(toDelete, wb)
+			outcome = v1beta1.VolumeClaimOutcomeDeleted
+		}
+		volumeClaims = append(volumeClaims, v1beta1.VolumeClaimStatus{
+			WorkspaceName:   wb.Name,
+			ClaimName:       getClaimName(wb, owner),
+			RetentionPolicy: policy,
+			Outcome:         outcome,
+		})
+	}
+
+	if len(toDelete) > 0 {
+		if err := c.pvcHandler.DeletePersistentVolumeClaimsForWorkspaces(ctx, toDelete, owner, pr.Namespace); err != nil {
+			return err
+		}
+	}
+	pr.Status.VolumeClaims = append(pr.Status.VolumeClaims, volumeClaims...)
+	return nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestApplyPVCRetentionPolicies(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		policy        v1beta1.PVCRetentionPolicy
+		defaultPolicy string
+		succeeded     corev1.ConditionStatus
+		existing      []v1beta1.VolumeClaimStatus
+		wantDeleted   bool
+		wantStatus    []v1beta1.VolumeClaimStatus
+	}{{
+		name:      "no policy keeps the claim",
+		succeeded: corev1.ConditionFalse,
+	}, {
+		name:        "delete policy deletes the claim of a failed run",
+		policy:      v1beta1.PVCRetentionPolicyDelete,
+		succeeded:   corev1.ConditionFalse,
+		wantDeleted: true,
+		wantStatus: []v1beta1.VolumeClaimStatus{{
+			RetentionPolicy: v1beta1.PVCRetentionPolicyDelete,
+			Outcome:         v1beta1.VolumeClaimOutcomeDeleted,
+		}},
+	}, {
+		name:        "retain policy deletes the claim of a successful run",
+		policy:      v1beta1.PVCRetentionPolicyRetain,
+		succeeded:   corev1.ConditionTrue,
+		wantDeleted: true,
+		wantStatus: []v1beta1.VolumeClaimStatus{{
+			RetentionPolicy: v1beta1.PVCRetentionPolicyRetain,
+			Outcome:         v1beta1.VolumeClaimOutcomeDeleted,
+		}},
+	}, {
+		name:      "retain policy retains the claim of a failed run",
+		policy:    v1beta1.PVCRetentionPolicyRetain,
+		succeeded: corev1.ConditionFalse,
+		wantStatus: []v1beta1.VolumeClaimStatus{{
+			RetentionPolicy: v1beta1.PVCRetentionPolicyRetain,
+			Outcome:         v1beta1.VolumeClaimOutcomeRetained,
+		}},
+	}, {
+		name:          "default policy is used when the binding has none",
+		defaultPolicy: config.PVCRetentionPolicyDelete,
+		succeeded:     corev1.ConditionTrue,
+		wantDeleted:   true,
+		wantStatus: []v1beta1.VolumeClaimStatus{{
+			RetentionPolicy: v1beta1.PVCRetentionPolicyDelete,
+			Outcome:         v1beta1.VolumeClaimOutcomeDeleted,
+		}},
+	}, {
+		name:          "binding policy overrides the default policy",
+		policy:        v1beta1.PVCRetentionPolicyRetain,
+		defaultPolicy: config.PVCRetentionPolicyDelete,
+		succeeded:     corev1.ConditionFalse,
+		wantStatus: []v1beta1.VolumeClaimStatus{{
+			RetentionPolicy: v1beta1.PVCRetentionPolicyRetain,
+			Outcome:         v1beta1.VolumeClaimOutcomeRetained,
+		}},
+	}, {
+		name:      "policy is not applied twice",
+		policy:    v1beta1.PVCRetentionPolicyDelete,
+		succeeded: corev1.ConditionFalse,
+		existing: []v1beta1.VolumeClaimStatus{{
+			RetentionPolicy: v1beta1.PVCRetentionPolicyDelete,
+			Outcome:         v1beta1.VolumeClaimOutcomeDeleted,
+		}},
+		wantStatus: []v1beta1.VolumeClaimStatus{{
+			RetentionPolicy: v1beta1.PVCRetentionPolicyDelete,
+			Outcome:         v1beta1.VolumeClaimOutcomeDeleted,
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pipelinerun", Namespace: "foo", UID: "uid"},
+				Spec: v1beta1.PipelineRunSpec{
+					Workspaces: []v1beta1.WorkspaceBinding{{
+						Name:                "source",
+						VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc"}},
+						RetentionPolicy:     tc.policy,
+					}, {
+						Name:     "scratch",
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					}},
+				},
+			}
+			pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: tc.succeeded})
+			claimName := volumeclaim.GetPersistentVolumeClaimName(pr.Spec.Workspaces[0].VolumeClaimTemplate, pr.Spec.Workspaces[0], *kmeta.NewControllerRef(pr))
+			for i := range tc.existing {
+				tc.existing[i].WorkspaceName, tc.existing[i].ClaimName = "source", claimName
+			}
+			for i := range tc.wantStatus {
+				tc.wantStatus[i].WorkspaceName, tc.wantStatus[i].ClaimName = "source", claimName
+			}
+			pr.Status.VolumeClaims = tc.existing
+
+			kubeClient := fakek8s.NewSimpleClientset(&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: "foo"},
+			})
+			c := Reconciler{
+				KubeClientSet: kubeClient,
+				pvcHandler:    volumeclaim.NewPVCHandler(kubeClient, logtesting.TestLogger(t)),
+			}
+			defaults, err := config.NewDefaultsFromMap(map[string]string{"default-pvc-retention-policy": tc.defaultPolicy})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{Defaults: defaults})
+
+			if err := c.applyPVCRetentionPolicies(ctx, pr); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, err = kubeClient.CoreV1().PersistentVolumeClaims("foo").Get(ctx, claimName, metav1.GetOptions{})
+			if deleted := err != nil; deleted != tc.wantDeleted {
+				t.Errorf("expected PVC deleted to be %t, got %t", tc.wantDeleted, deleted)
+			}
+			if d := cmp.Diff(tc.wantStatus, pr.Status.VolumeClaims); d != "" {
+				t.Errorf("unexpected volume claims status %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/substitution"
+	"knative.dev/pkg/apis"
 )
 
 const (
@@ -141,11 +142,13 @@ func paramsFromPipelineRun(ctx context.Context, pr *v1beta1.PipelineRun) (map[st
 }
 
 func getContextReplacements(pipelineName string, pr *v1beta1.PipelineRun) map[string]string {
+	deadlineApproaching := pr.Status.GetCondition(apis.ConditionType(v1beta1.PipelineRunConditionDeadlineApproaching.String())).IsTrue()
 	return map[string]string{
-		"context.pipelineRun.name":      pr.Name,
-		"context.pipeline.name":         pipelineName,
-		"context.pipelineRun.namespace": pr.Namespace,
-		"context.pipelineRun.uid":       string(pr.ObjectMeta.UID),
+		"context.pipelineRun.name":                pr.Name,
+		"context.pipeline.name":                   pipelineName,
+		"context.pipelineRun.namespace":           pr.Namespace,
+		"context.pipelineRun.uid":                 string(pr.ObjectMeta.UID),
+		"context.pipelineRun.deadlineApproaching": strconv.FormatBool(deadlineApproaching),
 	}
 }
 
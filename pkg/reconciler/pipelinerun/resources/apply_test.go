@@ -26,8 +26,11 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 func TestApplyParameters(t *testing.T) {
@@ -2633,6 +2636,21 @@ func TestContext(t *testing.T) {
 		},
 		original: v1beta1.Param{Value: *v1beta1.NewStructuredValues("$(context.pipelineRun.uid)-1")},
 		expected: v1beta1.Param{Value: *v1beta1.NewStructuredValues("-1")},
+	}, {
+		description: "context.pipelineRun.deadlineApproaching with the DeadlineApproaching condition",
+		pr: &v1beta1.PipelineRun{
+			Status: v1beta1.PipelineRunStatus{Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionType(v1beta1.PipelineRunConditionDeadlineApproaching.String()),
+				Status: corev1.ConditionTrue,
+			}}}},
+		},
+		original: v1beta1.Param{Value: *v1beta1.NewStructuredValues("$(context.pipelineRun.deadlineApproaching)")},
+		expected: v1beta1.Param{Value: *v1beta1.NewStructuredValues("true")},
+	}, {
+		description: "context.pipelineRun.deadlineApproaching without the DeadlineApproaching condition",
+		pr:          &v1beta1.PipelineRun{},
+		original:    v1beta1.Param{Value: *v1beta1.NewStructuredValues("$(context.pipelineRun.deadlineApproaching)")},
+		expected:    v1beta1.Param{Value: *v1beta1.NewStructuredValues("false")},
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
 			orig := &v1beta1.Pipeline{
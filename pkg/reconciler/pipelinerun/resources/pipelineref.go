@@ -23,6 +23,8 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	rprp "github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/pipelinespec"
@@ -40,14 +42,15 @@ import (
 // GetPipelineFunc is a factory function that will use the given PipelineRef to return a valid GetPipeline function that
 // looks up the pipeline. It uses as context a k8s client, tekton client, namespace, and service account name to return
 // the pipeline. It knows whether it needs to look in the cluster or in a remote location to fetch the reference.
-func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, requester remoteresource.Requester, pipelineRun *v1beta1.PipelineRun) (rprp.GetPipeline, error) {
+// The verificationPolicies are used to verify the resolved pipeline when trusted resources are enabled.
+func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, requester remoteresource.Requester, pipelineRun *v1beta1.PipelineRun, verificationPolicies []*v1alpha1.VerificationPolicy) (rprp.GetPipeline, error) {
 	cfg := config.FromContextOrDefaults(ctx)
 	pr := pipelineRun.Spec.PipelineRef
 	namespace := pipelineRun.Namespace
 	// if the spec is already in the status, do not try to fetch it again, just use it as source of truth.
 	// Same for the Source field in the Status.Provenance.
 	if pipelineRun.Status.PipelineSpec != nil {
-		return func(_ context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, error) {
+		return func(_ context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			var configSource *v1beta1.ConfigSource
 			if pipelineRun.Status.Provenance != nil {
 				configSource = pipelineRun.Status.Provenance.ConfigSource
@@ -58,7 +61,7 @@ func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clien
 					Namespace: namespace,
 				},
 				Spec: *pipelineRun.Status.PipelineSpec,
-			}, configSource, nil
+			}, configSource, nil, nil
 		}, nil
 	}
 
@@ -66,34 +69,35 @@ func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clien
 	case cfg.FeatureFlags.EnableTektonOCIBundles && pr != nil && pr.Bundle != "":
 		// Return an inline function that implements GetTask by calling Resolver.Get with the specified task type and
 		// casting it to a PipelineObject.
-		return func(ctx context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, error) {
+		return func(ctx context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			// If there is a bundle url at all, construct an OCI resolver to fetch the pipeline.
 			kc, err := k8schain.New(ctx, k8s, k8schain.Options{
 				Namespace:          namespace,
 				ServiceAccountName: pipelineRun.Spec.ServiceAccountName,
 			})
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to get keychain: %w", err)
+				return nil, nil, nil, fmt.Errorf("failed to get keychain: %w", err)
 			}
 			resolver := oci.NewResolver(pr.Bundle, kc)
-			return resolvePipeline(ctx, resolver, name, k8s)
+			return resolvePipeline(ctx, resolver, name, k8s, verificationPolicies)
 		}, nil
 	case pr != nil && pr.Resolver != "" && requester != nil:
-		return func(ctx context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, error) {
+		return func(ctx context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			stringReplacements, arrayReplacements, objectReplacements := paramsFromPipelineRun(ctx, pipelineRun)
 			for k, v := range getContextReplacements("", pipelineRun) {
 				stringReplacements[k] = v
 			}
 			replacedParams := replaceParamValues(pr.Params, stringReplacements, arrayReplacements, objectReplacements)
 			resolver := resolution.NewResolver(requester, pipelineRun, string(pr.Resolver), "", "", replacedParams)
-			return resolvePipeline(ctx, resolver, name, k8s)
+			return resolvePipeline(ctx, resolver, name, k8s, verificationPolicies)
 		}, nil
 	default:
 		// Even if there is no task ref, we should try to return a local resolver.
 		local := &LocalPipelineRefResolver{
-			Namespace:    namespace,
-			Tektonclient: tekton,
-			K8sclient:    k8s,
+			Namespace:            namespace,
+			Tektonclient:         tekton,
+			K8sclient:            k8s,
+			VerificationPolicies: verificationPolicies,
 		}
 		return local.GetPipeline, nil
 	}
@@ -104,46 +108,53 @@ type LocalPipelineRefResolver struct {
 	Namespace    string
 	Tektonclient clientset.Interface
 	K8sclient    kubernetes.Interface
+	// VerificationPolicies are used to verify the resolved Pipeline when
+	// trusted resources are enabled.
+	VerificationPolicies []*v1alpha1.VerificationPolicy
 }
 
 // GetPipeline will resolve a Pipeline from the local cluster using a versioned Tekton client. It will
 // return an error if it can't find an appropriate Pipeline for any reason.
 // TODO: if we want to set source for in-cluster pipeline, set it here.
 // https://github.com/tektoncd/pipeline/issues/5522
-func (l *LocalPipelineRefResolver) GetPipeline(ctx context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, error) {
+func (l *LocalPipelineRefResolver) GetPipeline(ctx context.Context, name string) (v1beta1.PipelineObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 	// If we are going to resolve this reference locally, we need a namespace scope.
 	if l.Namespace == "" {
-		return nil, nil, fmt.Errorf("Must specify namespace to resolve reference to pipeline %s", name)
+		return nil, nil, nil, fmt.Errorf("Must specify namespace to resolve reference to pipeline %s", name)
 	}
 
 	pipeline, err := l.Tektonclient.TektonV1beta1().Pipelines(l.Namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	if err := verifyResolvedPipeline(ctx, pipeline, l.K8sclient); err != nil {
-		return nil, nil, err
+	verificationResult, err := verifyResolvedPipeline(ctx, pipeline, l.K8sclient, nil, l.VerificationPolicies)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	return pipeline, nil, nil
+	return pipeline, nil, verificationResult, nil
 }
 
 // resolvePipeline accepts an impl of remote.Resolver and attempts to
 // fetch a pipeline with given name. An error is returned if the
 // resolution doesn't work or the returned data isn't a valid
 // v1beta1.PipelineObject.
-func resolvePipeline(ctx context.Context, resolver remote.Resolver, name string, k8s kubernetes.Interface) (v1beta1.PipelineObject, *v1beta1.ConfigSource, error) {
+func resolvePipeline(ctx context.Context, resolver remote.Resolver, name string, k8s kubernetes.Interface, verificationPolicies []*v1alpha1.VerificationPolicy) (v1beta1.PipelineObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 	obj, source, err := resolver.Get(ctx, "pipeline", name)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	pipelineObj, err := readRuntimeObjectAsPipeline(ctx, obj)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to convert obj %s into Pipeline", obj.GetObjectKind().GroupVersionKind().String())
+		return nil, nil, nil, fmt.Errorf("failed to convert obj %s into Pipeline", obj.GetObjectKind().GroupVersionKind().String())
 	}
+	// The pipeline is verified as fetched rather than as converted, obj is a
+	// metav1.Object once it could be read as a pipeline.
 	// TODO(#5527): Consider move this function call to GetPipelineData
-	if err := verifyResolvedPipeline(ctx, pipelineObj, k8s); err != nil {
-		return nil, nil, err
+	verificationResult, err := verifyResolvedPipeline(ctx, obj.(metav1.Object), k8s, source, verificationPolicies)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	return pipelineObj, source, nil
+	return pipelineObj, source, verificationResult, nil
 }
 
 // readRuntimeObjectAsPipeline tries to convert a generic runtime.Object
@@ -152,25 +163,45 @@ func resolvePipeline(ctx context.Context, resolver remote.Resolver, name string,
 // PipelineObject or if there is an error validating or upgrading an
 // older PipelineObject into its v1beta1 equivalent.
 func readRuntimeObjectAsPipeline(ctx context.Context, obj runtime.Object) (v1beta1.PipelineObject, error) {
-	if pipeline, ok := obj.(v1beta1.PipelineObject); ok {
-		return pipeline, nil
+	switch obj := obj.(type) {
+	case v1beta1.PipelineObject:
+		return obj, nil
+	case *v1.Pipeline:
+		p := &v1beta1.Pipeline{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pipeline",
+				APIVersion: "tekton.dev/v1beta1",
+			},
+		}
+		if err := p.ConvertFrom(ctx, obj); err != nil {
+			return nil, err
+		}
+		return p, nil
 	}
 
 	return nil, errors.New("resource is not a pipeline")
 }
 
-// verifyResolvedPipeline verifies the resolved pipeline
-func verifyResolvedPipeline(ctx context.Context, pipeline v1beta1.PipelineObject, k8s kubernetes.Interface) error {
-	cfg := config.FromContextOrDefaults(ctx)
-	if cfg.FeatureFlags.ResourceVerificationMode == config.EnforceResourceVerificationMode || cfg.FeatureFlags.ResourceVerificationMode == config.WarnResourceVerificationMode {
-		if err := trustedresources.VerifyPipeline(ctx, pipeline, k8s); err != nil {
-			if cfg.FeatureFlags.ResourceVerificationMode == config.EnforceResourceVerificationMode {
-				return trustedresources.ErrorResourceVerificationFailed
-			}
-			logger := logging.FromContext(ctx)
-			logger.Warnf("trusted resources verification failed: %v", err)
-			return nil
-		}
+// verifyResolvedPipeline is the verification hook of the pipelines resolved
+// from any source: the cluster, a bundle or a remote resolver. It verifies the
+// pipeline as fetched, in its own API version, with the verificationPolicies
+// matching the URI of its configSource. It returns
+// ErrorResourceVerificationFailed if the verification fails and is enforced,
+// and otherwise logs the failure.
+func verifyResolvedPipeline(ctx context.Context, pipeline metav1.Object, k8s kubernetes.Interface, configSource *v1beta1.ConfigSource, verificationPolicies []*v1alpha1.VerificationPolicy) (*trustedresources.VerificationResult, error) {
+	var source string
+	if configSource != nil {
+		source = configSource.URI
+	}
+	result := trustedresources.VerifyResource(ctx, pipeline, k8s, source, verificationPolicies)
+	switch result.VerificationResultType {
+	case trustedresources.VerificationError:
+		return nil, fmt.Errorf("%w: %v", trustedresources.ErrorResourceVerificationFailed, result.Err)
+	case trustedresources.VerificationWarn:
+		logger := logging.FromContext(ctx)
+		logger.Warnf("trusted resources verification failed: %v", result.Err)
+	case trustedresources.VerificationSkip:
+		return nil, nil
 	}
-	return nil
+	return &result, nil
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-containerregistry/pkg/registry"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
@@ -104,7 +105,7 @@ func TestLocalPipelineRef(t *testing.T) {
 				Tektonclient: tektonclient,
 			}
 
-			resolvedPipeline, resolvedConfigSource, err := lc.GetPipeline(ctx, tc.ref.Name)
+			resolvedPipeline, resolvedConfigSource, _, err := lc.GetPipeline(ctx, tc.ref.Name)
 			if tc.wantErr && err == nil {
 				t.Fatal("Expected error but found nil instead")
 			} else if !tc.wantErr && err != nil {
@@ -194,7 +195,7 @@ func TestGetPipelineFunc(t *testing.T) {
 				},
 			})
 
-			_, err := test.CreateImage(u.Host+"/"+tc.name, tc.remotePipelines...)
+			imageRef, err := test.CreateImage(u.Host+"/"+tc.name, tc.remotePipelines...)
 			if err != nil {
 				t.Fatalf("failed to upload test image: %s", err.Error())
 			}
@@ -205,12 +206,12 @@ func TestGetPipelineFunc(t *testing.T) {
 					PipelineRef:        tc.ref,
 					ServiceAccountName: "default",
 				},
-			})
+			}, nil)
 			if err != nil {
 				t.Fatalf("failed to get pipeline fn: %s", err.Error())
 			}
 
-			pipeline, configSource, err := fn(ctx, tc.ref.Name)
+			pipeline, configSource, _, err := fn(ctx, tc.ref.Name)
 			if err != nil {
 				t.Fatalf("failed to call pipelinefn: %s", err.Error())
 			}
@@ -219,8 +220,19 @@ func TestGetPipelineFunc(t *testing.T) {
 				t.Error(diff)
 			}
 
-			if configSource != nil {
-				t.Errorf("expected configsource is nil, but got %v", configSource)
+			// pipelines fetched from a bundle have the source of the bundle image.
+			var wantSource *v1beta1.ConfigSource
+			if tc.ref.Bundle != "" {
+				repository, digest, _ := strings.Cut(imageRef, "@")
+				algorithm, hex, _ := strings.Cut(digest, ":")
+				wantSource = &v1beta1.ConfigSource{
+					URI:        repository,
+					Digest:     map[string]string{algorithm: hex},
+					EntryPoint: tc.ref.Name,
+				}
+			}
+			if d := cmp.Diff(wantSource, configSource); d != "" {
+				t.Errorf("configSource: %s", diff.PrintWantGot(d))
 			}
 		})
 	}
@@ -270,11 +282,11 @@ func TestGetPipelineFuncSpecAlreadyFetched(t *testing.T) {
 		Spec: pipelineSpec,
 	}
 
-	fn, err := resources.GetPipelineFunc(ctx, kubeclient, tektonclient, nil, pipelineRun)
+	fn, err := resources.GetPipelineFunc(ctx, kubeclient, tektonclient, nil, pipelineRun, nil)
 	if err != nil {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
-	actualPipeline, actualConfigSource, err := fn(ctx, name)
+	actualPipeline, actualConfigSource, _, err := fn(ctx, name)
 	if err != nil {
 		t.Fatalf("failed to call pipelinefn: %s", err.Error())
 	}
@@ -308,12 +320,12 @@ func TestGetPipelineFunc_RemoteResolution(t *testing.T) {
 			PipelineRef:        pipelineRef,
 			ServiceAccountName: "default",
 		},
-	})
+	}, nil)
 	if err != nil {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
 
-	resolvedPipeline, resolvedConfigSource, err := fn(ctx, pipelineRef.Name)
+	resolvedPipeline, resolvedConfigSource, _, err := fn(ctx, pipelineRef.Name)
 	if err != nil {
 		t.Fatalf("failed to call pipelinefn: %s", err.Error())
 	}
@@ -374,12 +386,12 @@ func TestGetPipelineFunc_RemoteResolution_ReplacedParams(t *testing.T) {
 				Value: *v1beta1.NewStructuredValues("bar"),
 			}},
 		},
-	})
+	}, nil)
 	if err != nil {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
 
-	resolvedPipeline, resolvedConfigSource, err := fn(ctx, pipelineRef.Name)
+	resolvedPipeline, resolvedConfigSource, _, err := fn(ctx, pipelineRef.Name)
 	if err != nil {
 		t.Fatalf("failed to call pipelinefn: %s", err.Error())
 	}
@@ -418,12 +430,12 @@ func TestGetPipelineFunc_RemoteResolution_ReplacedParams(t *testing.T) {
 				Value: *v1beta1.NewStructuredValues("banana"),
 			}},
 		},
-	})
+	}, nil)
 	if err != nil {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
 
-	_, _, err = fnNotMatching(ctx, pipelineRefNotMatching.Name)
+	_, _, _, err = fnNotMatching(ctx, pipelineRefNotMatching.Name)
 	if err == nil {
 		t.Fatal("expected error for non-matching params, did not get one")
 	}
@@ -446,11 +458,11 @@ func TestGetPipelineFunc_RemoteResolutionInvalidData(t *testing.T) {
 			PipelineRef:        pipelineRef,
 			ServiceAccountName: "default",
 		},
-	})
+	}, nil)
 	if err != nil {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
-	if _, _, err := fn(ctx, pipelineRef.Name); err == nil {
+	if _, _, _, err := fn(ctx, pipelineRef.Name); err == nil {
 		t.Fatalf("expected error due to invalid pipeline data but saw none")
 	}
 }
@@ -551,7 +563,7 @@ func TestLocalPipelineRef_TrustedResourceVerification_Success(t *testing.T) {
 				Tektonclient: tektonclient,
 			}
 
-			pipeline, source, err := lc.GetPipeline(ctx, tc.ref.Name)
+			pipeline, source, _, err := lc.GetPipeline(ctx, tc.ref.Name)
 			if err != nil {
 				t.Fatalf("Received unexpected error ( %#v )", err)
 			}
@@ -628,7 +640,7 @@ func TestLocalPipelineRef_TrustedResourceVerification_Error(t *testing.T) {
 				Tektonclient: tektonclient,
 			}
 
-			pipeline, source, err := lc.GetPipeline(ctx, tc.ref.Name)
+			pipeline, source, _, err := lc.GetPipeline(ctx, tc.ref.Name)
 			if err == nil || !errors.Is(err, tc.expectedErr) {
 				t.Fatalf("Expected error %v but found %v instead", tc.expectedErr, err)
 			}
@@ -735,12 +747,12 @@ func TestGetPipelineFunc_RemoteResolution_TrustedResourceVerification_Success(t
 					ServiceAccountName: "default",
 				},
 			}
-			fn, err := resources.GetPipelineFunc(ctx, nil, nil, tc.requester, pr)
+			fn, err := resources.GetPipelineFunc(ctx, nil, nil, tc.requester, pr, nil)
 			if err != nil {
 				t.Fatalf("failed to get pipeline fn: %s", err.Error())
 			}
 
-			resolvedPipeline, source, err := fn(ctx, pipelineRef.Name)
+			resolvedPipeline, source, _, err := fn(ctx, pipelineRef.Name)
 			if err != nil {
 				t.Fatalf("Received unexpected error ( %#v )", err)
 			}
@@ -817,12 +829,12 @@ func TestGetPipelineFunc_RemoteResolution_TrustedResourceVerification_Error(t *t
 					ServiceAccountName: "default",
 				},
 			}
-			fn, err := resources.GetPipelineFunc(ctx, nil, nil, tc.requester, pr)
+			fn, err := resources.GetPipelineFunc(ctx, nil, nil, tc.requester, pr, nil)
 			if err != nil {
 				t.Fatalf("failed to get pipeline fn: %s", err.Error())
 			}
 
-			resolvedPipeline, source, err := fn(ctx, pipelineRef.Name)
+			resolvedPipeline, source, _, err := fn(ctx, pipelineRef.Name)
 			if err == nil || !errors.Is(err, tc.expectedErr) {
 				t.Fatalf("Expected error %v but found %v instead", tc.expectedErr, err)
 			}
@@ -836,6 +848,110 @@ func TestGetPipelineFunc_RemoteResolution_TrustedResourceVerification_Error(t *t
 	}
 }
 
+func TestGetPipelineFunc_RemoteResolution_V1TrustedResourceVerification(t *testing.T) {
+	ctx := context.Background()
+	signer, secretpath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signedPipeline := &pipelinev1.Pipeline{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "Pipeline",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "v1-pipeline",
+			Namespace: "trusted-resources",
+		},
+		Spec: pipelinev1.PipelineSpec{
+			Tasks: []pipelinev1.PipelineTask{{
+				Name:    "task",
+				TaskRef: &pipelinev1.TaskRef{Name: "task"},
+			}},
+		},
+	}
+	if err := trustedresources.Sign(ctx, signedPipeline, signer); err != nil {
+		t.Fatal("fail to sign pipeline", err)
+	}
+	signedPipelineBytes, err := json.Marshal(signedPipeline)
+	if err != nil {
+		t.Fatal("fail to marshal pipeline", err)
+	}
+	// the v1 pipeline is resolved as its v1beta1 conversion.
+	expected := &v1beta1.Pipeline{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "Pipeline",
+		},
+	}
+	if err := expected.ConvertFrom(ctx, signedPipeline.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+
+	tamperedPipeline := signedPipeline.DeepCopy()
+	tamperedPipeline.Spec.Tasks[0].Name = "attack"
+	tamperedPipelineBytes, err := json.Marshal(tamperedPipeline)
+	if err != nil {
+		t.Fatal("fail to marshal pipeline", err)
+	}
+
+	pipelineRef := &v1beta1.PipelineRef{ResolverRef: v1beta1.ResolverRef{Resolver: "git"}}
+
+	testcases := []struct {
+		name        string
+		data        []byte
+		expected    runtime.Object
+		expectedErr error
+	}{{
+		name:     "signed v1 pipeline with enforce policy",
+		data:     signedPipelineBytes,
+		expected: expected,
+	}, {
+		name:        "tampered v1 pipeline with enforce policy",
+		data:        tamperedPipelineBytes,
+		expectedErr: trustedresources.ErrorResourceVerificationFailed,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx = test.SetupTrustedResourceConfig(ctx, secretpath, config.EnforceResourceVerificationMode)
+			resolved := test.NewResolvedResource(tc.data, nil, sampleConfigSource.DeepCopy(), nil)
+			requester := test.NewRequester(resolved, nil)
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "trusted-resources"},
+				Spec: v1beta1.PipelineRunSpec{
+					PipelineRef:        pipelineRef,
+					ServiceAccountName: "default",
+				},
+			}
+			fn, err := resources.GetPipelineFunc(ctx, nil, nil, requester, pr, nil)
+			if err != nil {
+				t.Fatalf("failed to get pipeline fn: %s", err.Error())
+			}
+
+			resolvedPipeline, source, vr, err := fn(ctx, pipelineRef.Name)
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("Expected error %v but found %v instead", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Received unexpected error ( %#v )", err)
+			}
+			if vr == nil || vr.VerificationResultType != trustedresources.VerificationPass {
+				t.Errorf("expected the verification to pass, got %v", vr)
+			}
+			if d := cmp.Diff(tc.expected, resolvedPipeline); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(sampleConfigSource, source); d != "" {
+				t.Errorf("configSources did not match: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func basePipeline(name string) *v1beta1.Pipeline {
 	return &v1beta1.Pipeline{
 		ObjectMeta: metav1.ObjectMeta{
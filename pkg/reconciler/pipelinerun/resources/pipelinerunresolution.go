@@ -780,7 +780,7 @@ func resolveTask(
 		} else {
 			// Following minimum status principle (TEP-0100), no need to propagate the source about PipelineTask up to PipelineRun status.
 			// Instead, the child TaskRun's status will be the place recording the source of individual task.
-			t, _, err = getTask(ctx, pipelineTask.TaskRef.Name)
+			t, _, _, err = getTask(ctx, pipelineTask.TaskRef.Name)
 			switch {
 			case errors.Is(err, remote.ErrorRequestInProgress):
 				return v1beta1.TaskSpec{}, "", "", err
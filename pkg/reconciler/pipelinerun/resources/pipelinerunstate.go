@@ -467,6 +467,15 @@ func (facts *PipelineRunFacts) GetFinalTasks() PipelineRunState {
 	return tasks
 }
 
+// TimedOutMessage returns the message of the condition of a PipelineRun that timed out,
+// either because it exceeded its timeout or because its deadline passed.
+func TimedOutMessage(ctx context.Context, pr *v1beta1.PipelineRun, c clock.PassiveClock) string {
+	if pr.HasPassedDeadline(c) {
+		return fmt.Sprintf("PipelineRun %q failed to finish by its deadline %s", pr.Name, pr.Spec.Deadline.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("PipelineRun %q failed to finish within %q", pr.Name, pr.PipelineTimeout(ctx).String())
+}
+
 // GetPipelineConditionStatus will return the Condition that the PipelineRun prName should be
 // updated with, based on the status of the TaskRuns in state.
 func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, pr *v1beta1.PipelineRun, logger *zap.SugaredLogger, c clock.PassiveClock) *apis.Condition {
@@ -480,7 +489,7 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 			Type:    apis.ConditionSucceeded,
 			Status:  corev1.ConditionFalse,
 			Reason:  v1beta1.PipelineRunReasonTimedOut.String(),
-			Message: fmt.Sprintf("PipelineRun %q failed to finish within %q", pr.Name, pr.PipelineTimeout(ctx).String()),
+			Message: TimedOutMessage(ctx, pr, c),
 		}
 	}
 
@@ -28,13 +28,15 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/clock"
 	"knative.dev/pkg/apis"
 )
 
-var timeoutTaskRunPatchBytes, timeoutRunPatchBytes []byte
+var timeoutTaskRunPatchBytes, timeoutRunPatchBytes, timeoutCustomRunPatchBytes []byte
 
 func init() {
 	var err error
@@ -66,10 +68,24 @@ func init() {
 	if err != nil {
 		log.Fatalf("failed to marshal Run timeout patch bytes: %v", err)
 	}
+	timeoutCustomRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{
+		{
+			Operation: "add",
+			Path:      "/spec/status",
+			Value:     v1beta1.CustomRunSpecStatusCancelled,
+		},
+		{
+			Operation: "add",
+			Path:      "/spec/statusMessage",
+			Value:     v1beta1.CustomRunCancelledByPipelineTimeoutMsg,
+		}})
+	if err != nil {
+		log.Fatalf("failed to marshal CustomRun timeout patch bytes: %v", err)
+	}
 }
 
 // timeoutPipelineRun marks the PipelineRun as timed out and any resolved TaskRun(s) too.
-func timeoutPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface) error {
+func timeoutPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface, c clock.PassiveClock) error {
 	errs := timeoutPipelineTasks(ctx, logger, pr, clientSet)
 
 	// If we successfully timed out all the TaskRuns and Runs, we can consider the PipelineRun timed out.
@@ -80,7 +96,7 @@ func timeoutPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1be
 			Type:    apis.ConditionSucceeded,
 			Status:  corev1.ConditionFalse,
 			Reason:  reason,
-			Message: fmt.Sprintf("PipelineRun %q failed to finish within %q", pr.Name, pr.PipelineTimeout(ctx).String()),
+			Message: resources.TimedOutMessage(ctx, pr, c),
 		})
 		// update pr completed time
 		pr.Status.CompletionTime = &metav1.Time{Time: time.Now()}
@@ -98,11 +114,32 @@ func timeoutPipelineRun(ctx context.Context, logger *zap.SugaredLogger, pr *v1be
 	return nil
 }
 
+// markDeadlineApproaching sets the DeadlineApproaching condition of the PipelineRun,
+// unless it is already set.
+func markDeadlineApproaching(pr *v1beta1.PipelineRun) {
+	conditionType := apis.ConditionType(v1beta1.PipelineRunConditionDeadlineApproaching.String())
+	if pr.Status.GetCondition(conditionType).IsTrue() {
+		return
+	}
+	pr.Status.SetCondition(&apis.Condition{
+		Type:     conditionType,
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   v1beta1.PipelineRunConditionDeadlineApproaching.String(),
+		Message:  fmt.Sprintf("PipelineRun %q must finish by its deadline %s", pr.Name, pr.Spec.Deadline.Format(time.RFC3339)),
+	})
+}
+
 func timeoutRun(ctx context.Context, runName string, namespace string, clientSet clientset.Interface) error {
 	_, err := clientSet.TektonV1alpha1().Runs(namespace).Patch(ctx, runName, types.JSONPatchType, timeoutRunPatchBytes, metav1.PatchOptions{}, "")
 	return err
 }
 
+func timeoutCustomRun(ctx context.Context, customRunName string, namespace string, clientSet clientset.Interface) error {
+	_, err := clientSet.TektonV1beta1().CustomRuns(namespace).Patch(ctx, customRunName, types.JSONPatchType, timeoutCustomRunPatchBytes, metav1.PatchOptions{}, "")
+	return err
+}
+
 // timeoutPipelineTaskRuns patches `TaskRun` and `Run` with canceled status and an appropriate message
 func timeoutPipelineTasks(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface) []string {
 	return timeoutPipelineTasksForTaskNames(ctx, logger, pr, clientSet, sets.NewString())
@@ -112,7 +149,7 @@ func timeoutPipelineTasks(ctx context.Context, logger *zap.SugaredLogger, pr *v1
 func timeoutPipelineTasksForTaskNames(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface, taskNames sets.String) []string {
 	errs := []string{}
 
-	trNames, runNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, pr.Status, taskNames)
+	trNames, runNames, customRunNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, pr.Status, taskNames)
 	if err != nil {
 		errs = append(errs, err.Error())
 	}
@@ -135,5 +172,14 @@ func timeoutPipelineTasksForTaskNames(ctx context.Context, logger *zap.SugaredLo
 		}
 	}
 
+	for _, customRunName := range customRunNames {
+		logger.Infof("cancelling CustomRun %s for timeout", customRunName)
+
+		if err := timeoutCustomRun(ctx, customRunName, pr.Namespace, clientSet); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to patch CustomRun `%s` with cancellation: %s", customRunName, err).Error())
+			continue
+		}
+	}
+
 	return errs
 }
@@ -204,7 +204,7 @@ func TestTimeoutPipelineRun(t *testing.T) {
 			defer cancel()
 			c, _ := test.SeedTestData(t, ctx, d)
 
-			err := timeoutPipelineRun(ctx, logtesting.TestLogger(t), tc.pipelineRun, c.Pipeline)
+			err := timeoutPipelineRun(ctx, logtesting.TestLogger(t), tc.pipelineRun, c.Pipeline, testClock)
 			if tc.wantErr {
 				if err == nil {
 					t.Error("expected an error, but did not get one")
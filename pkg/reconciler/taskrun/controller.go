@@ -23,6 +23,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	verificationpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/verificationpolicy"
 	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
 	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/taskrun"
 	resolutionclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
@@ -32,11 +33,13 @@ import (
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	limitrangeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/limitrange"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
 	filteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -53,8 +56,22 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		podInformer := filteredpodinformer.Get(ctx, v1beta1.ManagedByLabelKey)
 		resourceInformer := resourceinformer.Get(ctx)
 		limitrangeInformer := limitrangeinformer.Get(ctx)
+		namespaceInformer := namespaceinformer.Get(ctx)
 		resolutionInformer := resolutioninformer.Get(ctx)
-		configStore := config.NewStore(logger.Named("config-store"), taskrunmetrics.MetricsOnStore(logger))
+		verificationpolicyInformer := verificationpolicyinformer.Get(ctx)
+		admissionQueue, err := newAdmissionQueue(taskRunInformer.Lister())
+		if err != nil {
+			logger.Fatalf("Error creating the TaskRun queue: %v", err)
+		}
+
+		// The queued TaskRuns are enqueued when the scheduling config changes, since
+		// more of them may be able to run.
+		var impl *controller.Impl
+		configStore := config.NewStore(logger.Named("config-store"), taskrunmetrics.MetricsOnStore(logger), func(name string, _ interface{}) {
+			if name == config.GetSchedulingConfigName() && impl != nil {
+				admissionQueue.enqueueQueued(impl.EnqueueKey)
+			}
+		})
 		configStore.WatchConfigs(cmw)
 
 		entrypointCache, err := pod.NewEntrypointCache(kubeclientset)
@@ -62,22 +79,32 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			logger.Fatalf("Error creating entrypoint cache: %v", err)
 		}
 
+		spireClient := spire.GetControllerAPIClient(ctx)
+		if opts.SpireConfig.MockSpire {
+			spireClient = &spire.MockClient{}
+		}
+		spireClient.SetConfig(opts.SpireConfig)
+
 		c := &Reconciler{
-			KubeClientSet:       kubeclientset,
-			PipelineClientSet:   pipelineclientset,
-			Images:              opts.Images,
-			Clock:               clock,
-			taskRunLister:       taskRunInformer.Lister(),
-			resourceLister:      resourceInformer.Lister(),
-			limitrangeLister:    limitrangeInformer.Lister(),
-			cloudEventClient:    cloudeventclient.Get(ctx),
-			metrics:             taskrunmetrics.Get(ctx),
-			entrypointCache:     entrypointCache,
-			podLister:           podInformer.Lister(),
-			pvcHandler:          volumeclaim.NewPVCHandler(kubeclientset, logger),
-			resolutionRequester: resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
+			KubeClientSet:            kubeclientset,
+			PipelineClientSet:        pipelineclientset,
+			Images:                   opts.Images,
+			Clock:                    clock,
+			taskRunLister:            taskRunInformer.Lister(),
+			resourceLister:           resourceInformer.Lister(),
+			limitrangeLister:         limitrangeInformer.Lister(),
+			namespaceLister:          namespaceInformer.Lister(),
+			verificationPolicyLister: verificationpolicyInformer.Lister(),
+			cloudEventClient:         cloudeventclient.Get(ctx),
+			metrics:                  taskrunmetrics.Get(ctx),
+			entrypointCache:          entrypointCache,
+			podLister:                podInformer.Lister(),
+			pvcHandler:               volumeclaim.NewPVCHandler(kubeclientset, logger),
+			resolutionRequester:      resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
+			spireClient:              spireClient,
+			admissionQueue:           admissionQueue,
 		}
-		impl := taskrunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
+		impl = taskrunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
 				AgentName:   pipeline.TaskRunControllerName,
 				ConfigStore: configStore,
@@ -85,6 +112,7 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		})
 
 		taskRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+		taskRunInformer.Informer().AddEventHandler(admissionQueue.handler(impl.EnqueueKey))
 
 		podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1beta1.TaskRun{}),
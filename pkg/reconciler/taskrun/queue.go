@@ -0,0 +1,291 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+)
+
+// queueAdmittedAnnotation is the status annotation set on a TaskRun when it leaves the
+// queue, so that it counts as running even before its pod is created.
+const queueAdmittedAnnotation = "tekton.dev/queue-admitted"
+
+// admissionQueue decides which TaskRuns can create their pod when the number of
+// running TaskRuns is limited by max-running-taskruns. Queued TaskRuns are
+// ordered by priority, then by the fair share of the running TaskRuns of their
+// tenant given its weight, then by creation time.
+type admissionQueue struct {
+	mu sync.Mutex
+	// running and queued hold the TaskRuns of the informer which are not done,
+	// and count as running or wait in the queue.
+	running map[types.NamespacedName]*v1beta1.TaskRun
+	queued  map[types.NamespacedName]*v1beta1.TaskRun
+}
+
+// newAdmissionQueue returns a queue holding the TaskRuns already in the lister,
+// which is then kept up to date by its handler.
+func newAdmissionQueue(taskRunLister listers.TaskRunLister) (*admissionQueue, error) {
+	q := &admissionQueue{
+		running: map[types.NamespacedName]*v1beta1.TaskRun{},
+		queued:  map[types.NamespacedName]*v1beta1.TaskRun{},
+	}
+	taskRuns, err := taskRunLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, tr := range taskRuns {
+		q.update(tr)
+	}
+	return q, nil
+}
+
+// admit returns true if the TaskRun can create its pod, and records its admission
+// in its status. Otherwise it returns false, with a message holding the position
+// of the TaskRun in the queue.
+func (q *admissionQueue) admit(cfg *config.Scheduling, tr *v1beta1.TaskRun) (bool, string) {
+	if q == nil || cfg == nil || cfg.MaxRunningTaskRuns == 0 || isAdmitted(tr) {
+		return true, ""
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	key := tr.GetNamespacedName()
+	running := map[string]int{}
+	totalRunning := 0
+	for k, t := range q.running {
+		if k != key {
+			running[cfg.TenantOf(t.Namespace, t.Labels)]++
+			totalRunning++
+		}
+	}
+	candidates := []*v1beta1.TaskRun{tr}
+	for k, t := range q.queued {
+		if k != key {
+			candidates = append(candidates, t)
+		}
+	}
+
+	free := cfg.MaxRunningTaskRuns - totalRunning
+	position := queuePosition(cfg, running, candidates, key)
+	if position < free {
+		if tr.Status.Annotations == nil {
+			tr.Status.Annotations = map[string]string{}
+		}
+		tr.Status.Annotations[queueAdmittedAnnotation] = "true"
+		// The TaskRun counts as running until the informer has its updated status.
+		delete(q.queued, key)
+		q.running[key] = tr.DeepCopy()
+		return true, ""
+	}
+	queued := len(candidates)
+	if free > 0 {
+		queued -= free
+	}
+	return false, fmt.Sprintf("Waiting in the TaskRun queue at position %d of %d, %d of %d TaskRuns are running",
+		position-max(free, 0)+1, queued, totalRunning, cfg.MaxRunningTaskRuns)
+}
+
+// handler returns an event handler for the TaskRun informer, which keeps track of
+// the running and queued TaskRuns, and enqueues the queued TaskRuns when a running
+// TaskRun is done or deleted.
+func (q *admissionQueue) handler(enqueue func(types.NamespacedName)) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			q.update(obj)
+		},
+		UpdateFunc: func(_, newObj interface{}) {
+			if q.update(newObj) {
+				q.enqueueQueued(enqueue)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			tr, ok := obj.(*v1beta1.TaskRun)
+			if !ok {
+				return
+			}
+			q.mu.Lock()
+			_, wasRunning := q.running[tr.GetNamespacedName()]
+			delete(q.running, tr.GetNamespacedName())
+			delete(q.queued, tr.GetNamespacedName())
+			q.mu.Unlock()
+			if wasRunning {
+				q.enqueueQueued(enqueue)
+			}
+		},
+	}
+}
+
+// update records whether the TaskRun counts as running or waits in the queue,
+// and returns true if it stopped counting as running.
+func (q *admissionQueue) update(obj interface{}) bool {
+	tr, ok := obj.(*v1beta1.TaskRun)
+	if !ok {
+		return false
+	}
+	key := tr.GetNamespacedName()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, wasRunning := q.running[key]
+	delete(q.running, key)
+	delete(q.queued, key)
+	switch {
+	case tr.IsDone():
+	case isAdmitted(tr):
+		q.running[key] = tr
+		return false
+	case isQueued(tr):
+		q.queued[key] = tr
+	}
+	return wasRunning
+}
+
+// enqueueQueued enqueues the queued TaskRuns, so that they are admitted to the
+// free running slots and their position in the queue is updated.
+func (q *admissionQueue) enqueueQueued(enqueue func(types.NamespacedName)) {
+	q.mu.Lock()
+	keys := make([]types.NamespacedName, 0, len(q.queued))
+	for key := range q.queued {
+		keys = append(keys, key)
+	}
+	q.mu.Unlock()
+	for _, key := range keys {
+		enqueue(key)
+	}
+}
+
+// queuePosition returns the 0-based position of the TaskRun with the given key
+// among the candidates, by admitting them one at a time. The candidates of a
+// tenant leave the queue in order, so only the next candidate of each tenant
+// competes for each free slot.
+func queuePosition(cfg *config.Scheduling, running map[string]int, candidates []*v1beta1.TaskRun, key types.NamespacedName) int {
+	queues := &tenantQueues{cfg: cfg, running: copyCounts(running)}
+	byTenant := map[string][]*v1beta1.TaskRun{}
+	for _, t := range candidates {
+		tenant := cfg.TenantOf(t.Namespace, t.Labels)
+		byTenant[tenant] = append(byTenant[tenant], t)
+	}
+	for _, taskRuns := range byTenant {
+		taskRuns := taskRuns
+		sort.Slice(taskRuns, func(i, j int) bool {
+			return admittedBefore(cfg, queues.running, taskRuns[i], taskRuns[j])
+		})
+		queues.queues = append(queues.queues, taskRuns)
+	}
+	heap.Init(queues)
+
+	for position := 0; queues.Len() > 0; position++ {
+		taskRuns := queues.queues[0]
+		t := taskRuns[0]
+		if t.GetNamespacedName() == key {
+			return position
+		}
+		queues.running[cfg.TenantOf(t.Namespace, t.Labels)]++
+		if len(taskRuns) > 1 {
+			queues.queues[0] = taskRuns[1:]
+			heap.Fix(queues, 0)
+		} else {
+			heap.Pop(queues)
+		}
+	}
+	return 0
+}
+
+// tenantQueues is a heap of the queues of the tenants, ordered by the TaskRun at
+// the head of each queue.
+type tenantQueues struct {
+	cfg     *config.Scheduling
+	running map[string]int
+	queues  [][]*v1beta1.TaskRun
+}
+
+func (q *tenantQueues) Len() int { return len(q.queues) }
+
+func (q *tenantQueues) Less(i, j int) bool {
+	return admittedBefore(q.cfg, q.running, q.queues[i][0], q.queues[j][0])
+}
+
+func (q *tenantQueues) Swap(i, j int) { q.queues[i], q.queues[j] = q.queues[j], q.queues[i] }
+
+func (q *tenantQueues) Push(x interface{}) { q.queues = append(q.queues, x.([]*v1beta1.TaskRun)) }
+
+func (q *tenantQueues) Pop() interface{} {
+	last := q.queues[len(q.queues)-1]
+	q.queues = q.queues[:len(q.queues)-1]
+	return last
+}
+
+// admittedBefore returns true if the TaskRun a leaves the queue before b.
+func admittedBefore(cfg *config.Scheduling, running map[string]int, a, b *v1beta1.TaskRun) bool {
+	if pa, pb := cfg.Priorities[a.Spec.Priority].Value, cfg.Priorities[b.Spec.Priority].Value; pa != pb {
+		return pa > pb
+	}
+	ta, tb := cfg.TenantOf(a.Namespace, a.Labels), cfg.TenantOf(b.Namespace, b.Labels)
+	if ta != tb {
+		// The TaskRun of the tenant which would use the lowest share of the
+		// running TaskRuns given its weight goes first.
+		sa := float64(running[ta]+1) / float64(cfg.TenantWeight(ta))
+		sb := float64(running[tb]+1) / float64(cfg.TenantWeight(tb))
+		if sa != sb {
+			return sa < sb
+		}
+	}
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.GetNamespacedName().String() < b.GetNamespacedName().String()
+}
+
+// isAdmitted returns true if the TaskRun left the queue or never waited in it.
+func isAdmitted(tr *v1beta1.TaskRun) bool {
+	return tr.Status.PodName != "" || tr.Status.Annotations[queueAdmittedAnnotation] == "true"
+}
+
+func isQueued(tr *v1beta1.TaskRun) bool {
+	c := tr.Status.GetCondition(apis.ConditionSucceeded)
+	return c != nil && c.Reason == podconvert.ReasonQueued
+}
+
+func copyCounts(counts map[string]int) map[string]int {
+	c := make(map[string]int, len(counts))
+	for k, v := range counts {
+		c[k] = v
+	}
+	return c
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
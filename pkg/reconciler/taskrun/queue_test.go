@@ -0,0 +1,274 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+var queueNow = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func queueTaskRun(namespace, name string, age time.Duration, reason, podName, priority string) *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         namespace,
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(queueNow.Add(-age)),
+		},
+		Spec: v1beta1.TaskRunSpec{Priority: priority},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: reason,
+			}}},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{PodName: podName},
+		},
+	}
+}
+
+func runningTaskRun(namespace, name string) *v1beta1.TaskRun {
+	return queueTaskRun(namespace, name, time.Hour, v1beta1.TaskRunReasonRunning.String(), name+"-pod", "")
+}
+
+func queuedTaskRun(namespace, name string, age time.Duration, priority string) *v1beta1.TaskRun {
+	return queueTaskRun(namespace, name, age, podconvert.ReasonQueued, "", priority)
+}
+
+func newTestAdmissionQueue(t *testing.T, taskRuns ...*v1beta1.TaskRun) *admissionQueue {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, tr := range taskRuns {
+		if err := indexer.Add(tr); err != nil {
+			t.Fatalf("Error adding TaskRun to the indexer: %v", err)
+		}
+	}
+	q, err := newAdmissionQueue(listers.NewTaskRunLister(indexer))
+	if err != nil {
+		t.Fatalf("Error creating the admission queue: %v", err)
+	}
+	return q
+}
+
+func TestAdmissionQueueAdmit(t *testing.T) {
+	priorities := map[string]config.Priority{
+		"interactive": {Value: 100},
+		"batch":       {Value: 0},
+	}
+	for _, tc := range []struct {
+		name         string
+		cfg          *config.Scheduling
+		taskRuns     []*v1beta1.TaskRun
+		tr           *v1beta1.TaskRun
+		wantAdmitted bool
+		wantMessage  string
+	}{{
+		name:         "no queue",
+		cfg:          &config.Scheduling{},
+		taskRuns:     []*v1beta1.TaskRun{runningTaskRun("ns", "a")},
+		tr:           queueTaskRun("ns", "b", 0, "", "", ""),
+		wantAdmitted: true,
+	}, {
+		name:         "free slot",
+		cfg:          &config.Scheduling{MaxRunningTaskRuns: 2},
+		taskRuns:     []*v1beta1.TaskRun{runningTaskRun("ns", "a")},
+		tr:           queueTaskRun("ns", "b", 0, "", "", ""),
+		wantAdmitted: true,
+	}, {
+		name:        "no free slot",
+		cfg:         &config.Scheduling{MaxRunningTaskRuns: 1},
+		taskRuns:    []*v1beta1.TaskRun{runningTaskRun("ns", "a"), queuedTaskRun("ns", "b", time.Minute, "")},
+		tr:          queueTaskRun("ns", "c", 0, "", "", ""),
+		wantMessage: "Waiting in the TaskRun queue at position 2 of 2, 1 of 1 TaskRuns are running",
+	}, {
+		name:        "older TaskRun first",
+		cfg:         &config.Scheduling{MaxRunningTaskRuns: 2},
+		taskRuns:    []*v1beta1.TaskRun{runningTaskRun("ns", "a"), queuedTaskRun("ns", "b", time.Minute, "")},
+		tr:          queueTaskRun("ns", "c", 0, "", "", ""),
+		wantMessage: "Waiting in the TaskRun queue at position 1 of 1, 1 of 2 TaskRuns are running",
+	}, {
+		name:         "higher priority first",
+		cfg:          &config.Scheduling{MaxRunningTaskRuns: 2, Priorities: priorities},
+		taskRuns:     []*v1beta1.TaskRun{runningTaskRun("ns", "a"), queuedTaskRun("ns", "b", time.Minute, "batch")},
+		tr:           queueTaskRun("ns", "c", 0, "", "", "interactive"),
+		wantAdmitted: true,
+	}, {
+		name: "tenant with the lowest share first",
+		cfg:  &config.Scheduling{MaxRunningTaskRuns: 3},
+		taskRuns: []*v1beta1.TaskRun{
+			runningTaskRun("batch", "a"), runningTaskRun("batch", "b"),
+			queuedTaskRun("batch", "c", time.Minute, ""),
+		},
+		tr:           queueTaskRun("interactive", "d", 0, "", "", ""),
+		wantAdmitted: true,
+	}, {
+		name: "tenant weights",
+		cfg: &config.Scheduling{
+			MaxRunningTaskRuns: 4,
+			TenantWeights:      map[string]int{"batch": 3},
+		},
+		taskRuns: []*v1beta1.TaskRun{
+			runningTaskRun("batch", "a"), runningTaskRun("batch", "b"), runningTaskRun("interactive", "c"),
+			queuedTaskRun("batch", "d", time.Minute, ""),
+		},
+		tr:          queueTaskRun("interactive", "e", 0, "", "", ""),
+		wantMessage: "Waiting in the TaskRun queue at position 1 of 1, 3 of 4 TaskRuns are running",
+	}, {
+		name: "tenant label",
+		cfg: &config.Scheduling{
+			MaxRunningTaskRuns: 3,
+			TenantLabel:        "example.com/team",
+		},
+		taskRuns: func() []*v1beta1.TaskRun {
+			a, b, c := runningTaskRun("ns", "a"), runningTaskRun("ns", "b"), queuedTaskRun("ns", "c", time.Minute, "")
+			a.Labels = map[string]string{"example.com/team": "batch"}
+			b.Labels = map[string]string{"example.com/team": "batch"}
+			c.Labels = map[string]string{"example.com/team": "batch"}
+			return []*v1beta1.TaskRun{a, b, c}
+		}(),
+		tr: func() *v1beta1.TaskRun {
+			tr := queueTaskRun("ns", "d", 0, "", "", "")
+			tr.Labels = map[string]string{"example.com/team": "interactive"}
+			return tr
+		}(),
+		wantAdmitted: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			q := newTestAdmissionQueue(t, tc.taskRuns...)
+			admitted, message := q.admit(tc.cfg, tc.tr)
+			if admitted != tc.wantAdmitted {
+				t.Errorf("admit() admitted = %t, want %t", admitted, tc.wantAdmitted)
+			}
+			if d := cmp.Diff(tc.wantMessage, message); d != "" {
+				t.Errorf("admit() message %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestAdmissionQueuePosition(t *testing.T) {
+	cfg := &config.Scheduling{
+		MaxRunningTaskRuns: 1,
+		TenantWeights:      map[string]int{"batch": 2},
+	}
+	// The running TaskRun is of the interactive tenant, so the batch TaskRuns go first
+	// while the batch tenant has less than twice as many as the interactive tenant.
+	taskRuns := []*v1beta1.TaskRun{
+		runningTaskRun("interactive", "a"),
+		queuedTaskRun("interactive", "b", 5*time.Minute, ""),
+		queuedTaskRun("batch", "c", 4*time.Minute, ""),
+		queuedTaskRun("batch", "d", 3*time.Minute, ""),
+		queuedTaskRun("batch", "e", 2*time.Minute, ""),
+	}
+	q := newTestAdmissionQueue(t, taskRuns...)
+	for _, tc := range []struct {
+		tr           *v1beta1.TaskRun
+		wantPosition int
+	}{
+		{tr: taskRuns[2], wantPosition: 1},
+		{tr: taskRuns[3], wantPosition: 2},
+		{tr: taskRuns[4], wantPosition: 3},
+		{tr: taskRuns[1], wantPosition: 4},
+	} {
+		_, message := q.admit(cfg, tc.tr)
+		wantMessage := fmt.Sprintf("Waiting in the TaskRun queue at position %d of 4, 1 of 1 TaskRuns are running", tc.wantPosition)
+		if d := cmp.Diff(wantMessage, message); d != "" {
+			t.Errorf("admit(%s) message %s", tc.tr.Name, diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestAdmissionQueueAdmittedCountAsRunning(t *testing.T) {
+	cfg := &config.Scheduling{MaxRunningTaskRuns: 1}
+	a := queueTaskRun("ns", "a", time.Minute, "", "", "")
+	b := queueTaskRun("ns", "b", 0, "", "", "")
+	q := newTestAdmissionQueue(t, a, b)
+
+	if admitted, _ := q.admit(cfg, a); !admitted {
+		t.Fatal("admit(a) = false, want true")
+	}
+	if a.Status.Annotations[queueAdmittedAnnotation] != "true" {
+		t.Fatalf("expected the admission of a to be recorded in its status, got %v", a.Status.Annotations)
+	}
+	// a has no pod yet, but it was admitted.
+	if admitted, _ := q.admit(cfg, b); admitted {
+		t.Fatal("admit(b) = true, want false")
+	}
+	// a is not queued again, for instance when the creation of its pod failed.
+	if admitted, _ := q.admit(cfg, a); !admitted {
+		t.Fatal("admit(a) = false, want true")
+	}
+
+	// The admission is read from the status of a once the informer has it.
+	q = newTestAdmissionQueue(t, a, b)
+	if admitted, _ := q.admit(cfg, b); admitted {
+		t.Fatal("admit(b) = true, want false")
+	}
+}
+
+func TestAdmissionQueueHandler(t *testing.T) {
+	running := runningTaskRun("ns", "a")
+	done := running.DeepCopy()
+	done.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	queued := queuedTaskRun("ns", "b", 0, "")
+
+	for _, tc := range []struct {
+		name         string
+		event        func(cache.ResourceEventHandler)
+		wantEnqueued []types.NamespacedName
+	}{{
+		name:         "running TaskRun done",
+		event:        func(h cache.ResourceEventHandler) { h.OnUpdate(running, done) },
+		wantEnqueued: []types.NamespacedName{queued.GetNamespacedName()},
+	}, {
+		name:         "running TaskRun deleted",
+		event:        func(h cache.ResourceEventHandler) { h.OnDelete(cache.DeletedFinalStateUnknown{Obj: running}) },
+		wantEnqueued: []types.NamespacedName{queued.GetNamespacedName()},
+	}, {
+		name:  "running TaskRun updated",
+		event: func(h cache.ResourceEventHandler) { h.OnUpdate(running, running) },
+	}, {
+		name:  "queued TaskRun deleted",
+		event: func(h cache.ResourceEventHandler) { h.OnDelete(queued) },
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			q := newTestAdmissionQueue(t, running, queued)
+			var enqueued []types.NamespacedName
+			tc.event(q.handler(func(key types.NamespacedName) {
+				enqueued = append(enqueued, key)
+			}))
+			if d := cmp.Diff(tc.wantEnqueued, enqueued); d != "" {
+				t.Errorf("unexpected enqueued TaskRuns %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -24,6 +24,8 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	"github.com/tektoncd/pipeline/pkg/remote"
@@ -57,11 +59,12 @@ func GetTaskKind(taskrun *v1beta1.TaskRun) v1beta1.TaskKind {
 // also requires a kubeclient, tektonclient, namespace, and service account in case it needs to find that task in
 // cluster or authorize against an external repositroy. It will figure out whether it needs to look in the cluster or in
 // a remote image to fetch the  reference. It will also return the "kind" of the task being referenced.
-func GetTaskFuncFromTaskRun(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, requester remoteresource.Requester, taskrun *v1beta1.TaskRun) (GetTask, error) {
+// The verificationPolicies are used to verify the resolved task when trusted resources are enabled.
+func GetTaskFuncFromTaskRun(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, requester remoteresource.Requester, taskrun *v1beta1.TaskRun, verificationPolicies []*v1alpha1.VerificationPolicy) (GetTask, error) {
 	// if the spec is already in the status, do not try to fetch it again, just use it as source of truth.
 	// Same for the Source field in the Status.Provenance.
 	if taskrun.Status.TaskSpec != nil {
-		return func(_ context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, error) {
+		return func(_ context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			var configsource *v1beta1.ConfigSource
 			if taskrun.Status.Provenance != nil {
 				configsource = taskrun.Status.Provenance.ConfigSource
@@ -72,18 +75,19 @@ func GetTaskFuncFromTaskRun(ctx context.Context, k8s kubernetes.Interface, tekto
 					Namespace: taskrun.Namespace,
 				},
 				Spec: *taskrun.Status.TaskSpec,
-			}, configsource, nil
+			}, configsource, nil, nil
 		}, nil
 	}
-	return GetTaskFunc(ctx, k8s, tekton, requester, taskrun, taskrun.Spec.TaskRef, taskrun.Name, taskrun.Namespace, taskrun.Spec.ServiceAccountName)
+	return GetTaskFunc(ctx, k8s, tekton, requester, taskrun, taskrun.Spec.TaskRef, taskrun.Name, taskrun.Namespace, taskrun.Spec.ServiceAccountName, verificationPolicies)
 }
 
 // GetTaskFunc is a factory function that will use the given TaskRef as context to return a valid GetTask function. It
 // also requires a kubeclient, tektonclient, namespace, and service account in case it needs to find that task in
 // cluster or authorize against an external repositroy. It will figure out whether it needs to look in the cluster or in
 // a remote image to fetch the  reference. It will also return the "kind" of the task being referenced.
+// The verificationPolicies are used to verify the resolved task when trusted resources are enabled.
 func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset.Interface, requester remoteresource.Requester,
-	owner kmeta.OwnerRefable, tr *v1beta1.TaskRef, trName string, namespace, saName string) (GetTask, error) {
+	owner kmeta.OwnerRefable, tr *v1beta1.TaskRef, trName string, namespace, saName string, verificationPolicies []*v1alpha1.VerificationPolicy) (GetTask, error) {
 	cfg := config.FromContextOrDefaults(ctx)
 	kind := v1beta1.NamespacedTaskKind
 	if tr != nil && tr.Kind != "" {
@@ -94,23 +98,23 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 	case cfg.FeatureFlags.EnableTektonOCIBundles && tr != nil && tr.Bundle != "":
 		// Return an inline function that implements GetTask by calling Resolver.Get with the specified task type and
 		// casting it to a TaskObject.
-		return func(ctx context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, error) {
+		return func(ctx context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			// If there is a bundle url at all, construct an OCI resolver to fetch the task.
 			kc, err := k8schain.New(ctx, k8s, k8schain.Options{
 				Namespace:          namespace,
 				ServiceAccountName: saName,
 			})
 			if err != nil {
-				return nil, nil, fmt.Errorf("failed to get keychain: %w", err)
+				return nil, nil, nil, fmt.Errorf("failed to get keychain: %w", err)
 			}
 			resolver := oci.NewResolver(tr.Bundle, kc)
 
-			return resolveTask(ctx, resolver, name, kind, k8s)
+			return resolveTask(ctx, resolver, name, kind, k8s, verificationPolicies)
 		}, nil
 	case tr != nil && tr.Resolver != "" && requester != nil:
 		// Return an inline function that implements GetTask by calling Resolver.Get with the specified task type and
 		// casting it to a TaskObject.
-		return func(ctx context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, error) {
+		return func(ctx context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 			var replacedParams []v1beta1.Param
 			if ownerAsTR, ok := owner.(*v1beta1.TaskRun); ok {
 				stringReplacements, arrayReplacements := paramsFromTaskRun(ctx, ownerAsTR)
@@ -125,16 +129,17 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 				replacedParams = append(replacedParams, tr.Params...)
 			}
 			resolver := resolution.NewResolver(requester, owner, string(tr.Resolver), trName, namespace, replacedParams)
-			return resolveTask(ctx, resolver, name, kind, k8s)
+			return resolveTask(ctx, resolver, name, kind, k8s, verificationPolicies)
 		}, nil
 
 	default:
 		// Even if there is no task ref, we should try to return a local resolver.
 		local := &LocalTaskRefResolver{
-			Namespace:    namespace,
-			Kind:         kind,
-			Tektonclient: tekton,
-			K8sclient:    k8s,
+			Namespace:            namespace,
+			Kind:                 kind,
+			Tektonclient:         tekton,
+			K8sclient:            k8s,
+			VerificationPolicies: verificationPolicies,
 		}
 		return local.GetTask, nil
 	}
@@ -144,22 +149,28 @@ func GetTaskFunc(ctx context.Context, k8s kubernetes.Interface, tekton clientset
 // fetch a task with given name. An error is returned if the
 // remoteresource doesn't work or the returned data isn't a valid
 // v1beta1.TaskObject.
-func resolveTask(ctx context.Context, resolver remote.Resolver, name string, kind v1beta1.TaskKind, k8s kubernetes.Interface) (v1beta1.TaskObject, *v1beta1.ConfigSource, error) {
+func resolveTask(ctx context.Context, resolver remote.Resolver, name string, kind v1beta1.TaskKind, k8s kubernetes.Interface, verificationPolicies []*v1alpha1.VerificationPolicy) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 	// Because the resolver will only return references with the same kind (eg ClusterTask), this will ensure we
 	// don't accidentally return a Task with the same name but different kind.
 	obj, configSource, err := resolver.Get(ctx, strings.TrimSuffix(strings.ToLower(string(kind)), "s"), name)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	taskObj, err := readRuntimeObjectAsTask(ctx, obj)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to convert obj %s into Task", obj.GetObjectKind().GroupVersionKind().String())
+		return nil, nil, nil, fmt.Errorf("failed to convert obj %s into Task", obj.GetObjectKind().GroupVersionKind().String())
+	}
+	// The task is verified as fetched rather than as converted.
+	meta, ok := obj.(metav1.Object)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("failed to read the metadata of obj %s", obj.GetObjectKind().GroupVersionKind().String())
 	}
 	// TODO(#5527): Consider move this function call to GetTaskData
-	if err := verifyResolvedTask(ctx, taskObj, k8s); err != nil {
-		return nil, nil, err
+	verificationResult, err := verifyResolvedTask(ctx, meta, k8s, configSource, verificationPolicies)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	return taskObj, configSource, nil
+	return taskObj, configSource, verificationResult, nil
 }
 
 // readRuntimeObjectAsTask tries to convert a generic runtime.Object
@@ -168,8 +179,20 @@ func resolveTask(ctx context.Context, resolver remote.Resolver, name string, kin
 // TaskObject or if there is an error validating or upgrading an
 // older TaskObject into its v1beta1 equivalent.
 func readRuntimeObjectAsTask(ctx context.Context, obj runtime.Object) (v1beta1.TaskObject, error) {
-	if task, ok := obj.(v1beta1.TaskObject); ok {
-		return task, nil
+	switch obj := obj.(type) {
+	case v1beta1.TaskObject:
+		return obj, nil
+	case *v1.Task:
+		t := &v1beta1.Task{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Task",
+				APIVersion: "tekton.dev/v1beta1",
+			},
+		}
+		if err := t.ConvertFrom(ctx, obj); err != nil {
+			return nil, err
+		}
+		return t, nil
 	}
 	return nil, errors.New("resource is not a task")
 }
@@ -180,33 +203,41 @@ type LocalTaskRefResolver struct {
 	Kind         v1beta1.TaskKind
 	Tektonclient clientset.Interface
 	K8sclient    kubernetes.Interface
+	// VerificationPolicies are used to verify the resolved Task when
+	// trusted resources are enabled.
+	VerificationPolicies []*v1alpha1.VerificationPolicy
 }
 
 // GetTask will resolve either a Task or ClusterTask from the local cluster using a versioned Tekton client. It will
 // return an error if it can't find an appropriate Task for any reason.
 // TODO: if we want to set source for in-cluster task, set it here.
 // https://github.com/tektoncd/pipeline/issues/5522
-func (l *LocalTaskRefResolver) GetTask(ctx context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, error) {
+func (l *LocalTaskRefResolver) GetTask(ctx context.Context, name string) (v1beta1.TaskObject, *v1beta1.ConfigSource, *trustedresources.VerificationResult, error) {
 	if l.Kind == v1beta1.ClusterTaskKind {
 		task, err := l.Tektonclient.TektonV1beta1().ClusterTasks().Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
+		}
+		verificationResult, err := verifyResolvedTask(ctx, task, l.K8sclient, nil, l.VerificationPolicies)
+		if err != nil {
+			return nil, nil, nil, err
 		}
-		return task, nil, nil
+		return task, nil, verificationResult, nil
 	}
 
 	// If we are going to resolve this reference locally, we need a namespace scope.
 	if l.Namespace == "" {
-		return nil, nil, fmt.Errorf("must specify namespace to resolve reference to task %s", name)
+		return nil, nil, nil, fmt.Errorf("must specify namespace to resolve reference to task %s", name)
 	}
 	task, err := l.Tektonclient.TektonV1beta1().Tasks(l.Namespace).Get(ctx, name, metav1.GetOptions{})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	if err := verifyResolvedTask(ctx, task, l.K8sclient); err != nil {
-		return nil, nil, err
+	verificationResult, err := verifyResolvedTask(ctx, task, l.K8sclient, nil, l.VerificationPolicies)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	return task, nil, nil
+	return task, nil, verificationResult, nil
 }
 
 // IsGetTaskErrTransient returns true if an error returned by GetTask is retryable.
@@ -214,18 +245,25 @@ func IsGetTaskErrTransient(err error) bool {
 	return strings.Contains(err.Error(), errEtcdLeaderChange)
 }
 
-// verifyResolvedTask verifies the resolved task
-func verifyResolvedTask(ctx context.Context, task v1beta1.TaskObject, k8s kubernetes.Interface) error {
-	cfg := config.FromContextOrDefaults(ctx)
-	if cfg.FeatureFlags.ResourceVerificationMode == config.EnforceResourceVerificationMode || cfg.FeatureFlags.ResourceVerificationMode == config.WarnResourceVerificationMode {
-		if err := trustedresources.VerifyTask(ctx, task, k8s); err != nil {
-			if cfg.FeatureFlags.ResourceVerificationMode == config.EnforceResourceVerificationMode {
-				return trustedresources.ErrorResourceVerificationFailed
-			}
-			logger := logging.FromContext(ctx)
-			logger.Warnf("trusted resources verification failed: %v", err)
-			return nil
-		}
+// verifyResolvedTask is the verification hook of the tasks resolved from any
+// source: the cluster, a bundle or a remote resolver. It verifies the task as
+// fetched, in its own API version, with the verificationPolicies matching the
+// URI of its configSource. It returns ErrorResourceVerificationFailed if the
+// verification fails and is enforced, and otherwise logs the failure.
+func verifyResolvedTask(ctx context.Context, task metav1.Object, k8s kubernetes.Interface, configSource *v1beta1.ConfigSource, verificationPolicies []*v1alpha1.VerificationPolicy) (*trustedresources.VerificationResult, error) {
+	var source string
+	if configSource != nil {
+		source = configSource.URI
+	}
+	result := trustedresources.VerifyResource(ctx, task, k8s, source, verificationPolicies)
+	switch result.VerificationResultType {
+	case trustedresources.VerificationError:
+		return nil, fmt.Errorf("%w: %v", trustedresources.ErrorResourceVerificationFailed, result.Err)
+	case trustedresources.VerificationWarn:
+		logger := logging.FromContext(ctx)
+		logger.Warnf("trusted resources verification failed: %v", result.Err)
+	case trustedresources.VerificationSkip:
+		return nil, nil
 	}
-	return nil
+	return &result, nil
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-containerregistry/pkg/registry"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
@@ -212,7 +213,7 @@ func TestLocalTaskRef(t *testing.T) {
 				Tektonclient: tektonclient,
 			}
 
-			task, configSource, err := lc.GetTask(ctx, tc.ref.Name)
+			task, configSource, _, err := lc.GetTask(ctx, tc.ref.Name)
 			if tc.wantErr && err == nil {
 				t.Fatal("Expected error but found nil instead")
 			} else if !tc.wantErr && err != nil {
@@ -434,7 +435,7 @@ func TestGetTaskFunc(t *testing.T) {
 				},
 			})
 
-			_, err := test.CreateImage(u.Host+"/"+tc.name, tc.remoteTasks...)
+			imageRef, err := test.CreateImage(u.Host+"/"+tc.name, tc.remoteTasks...)
 			if err != nil {
 				t.Fatalf("failed to upload test image: %s", err.Error())
 			}
@@ -445,12 +446,12 @@ func TestGetTaskFunc(t *testing.T) {
 					TaskRef: tc.ref,
 				},
 			}
-			fn, err := resources.GetTaskFunc(ctx, kubeclient, tektonclient, nil, trForFunc, tc.ref, "", "default", "default")
+			fn, err := resources.GetTaskFunc(ctx, kubeclient, tektonclient, nil, trForFunc, tc.ref, "", "default", "default", nil)
 			if err != nil {
 				t.Fatalf("failed to get task fn: %s", err.Error())
 			}
 
-			task, configSource, err := fn(ctx, tc.ref.Name)
+			task, configSource, _, err := fn(ctx, tc.ref.Name)
 			if err != nil {
 				t.Fatalf("failed to call taskfn: %s", err.Error())
 			}
@@ -459,9 +460,20 @@ func TestGetTaskFunc(t *testing.T) {
 				t.Error(diff)
 			}
 
-			// local cluster task and  bundle task have empty source for now. This may be changed in future.
-			if configSource != nil {
-				t.Errorf("expected configsource is nil, but got %v", configSource)
+			// local tasks and cluster tasks have empty source for now, bundle
+			// tasks have the source of the bundle image.
+			var wantSource *v1beta1.ConfigSource
+			if tc.ref.Bundle != "" {
+				repository, digest, _ := strings.Cut(imageRef, "@")
+				algorithm, hex, _ := strings.Cut(digest, ":")
+				wantSource = &v1beta1.ConfigSource{
+					URI:        repository,
+					Digest:     map[string]string{algorithm: hex},
+					EntryPoint: tc.ref.Name,
+				}
+			}
+			if d := cmp.Diff(wantSource, configSource); d != "" {
+				t.Errorf("configSource: %s", diff.PrintWantGot(d))
 			}
 		})
 	}
@@ -515,11 +527,11 @@ echo hello
 		Spec: TaskSpec,
 	}
 
-	fn, err := resources.GetTaskFuncFromTaskRun(ctx, kubeclient, tektonclient, nil, TaskRun)
+	fn, err := resources.GetTaskFuncFromTaskRun(ctx, kubeclient, tektonclient, nil, TaskRun, nil)
 	if err != nil {
 		t.Fatalf("failed to get Task fn: %s", err.Error())
 	}
-	actualTask, actualConfigSource, err := fn(ctx, name)
+	actualTask, actualConfigSource, _, err := fn(ctx, name)
 	if err != nil {
 		t.Fatalf("failed to call Taskfn: %s", err.Error())
 	}
@@ -554,12 +566,12 @@ func TestGetTaskFunc_RemoteResolution(t *testing.T) {
 			ServiceAccountName: "default",
 		},
 	}
-	fn, err := resources.GetTaskFunc(ctx, nil, nil, requester, tr, tr.Spec.TaskRef, "", "default", "default")
+	fn, err := resources.GetTaskFunc(ctx, nil, nil, requester, tr, tr.Spec.TaskRef, "", "default", "default", nil)
 	if err != nil {
 		t.Fatalf("failed to get task fn: %s", err.Error())
 	}
 
-	resolvedTask, resolvedConfigSource, err := fn(ctx, taskRef.Name)
+	resolvedTask, resolvedConfigSource, _, err := fn(ctx, taskRef.Name)
 	if err != nil {
 		t.Fatalf("failed to call pipelinefn: %s", err.Error())
 	}
@@ -621,12 +633,12 @@ func TestGetTaskFunc_RemoteResolution_ReplacedParams(t *testing.T) {
 			}},
 		},
 	}
-	fn, err := resources.GetTaskFunc(ctx, nil, nil, requester, tr, tr.Spec.TaskRef, "", "default", "default")
+	fn, err := resources.GetTaskFunc(ctx, nil, nil, requester, tr, tr.Spec.TaskRef, "", "default", "default", nil)
 	if err != nil {
 		t.Fatalf("failed to get task fn: %s", err.Error())
 	}
 
-	resolvedTask, resolvedConfigSource, err := fn(ctx, taskRef.Name)
+	resolvedTask, resolvedConfigSource, _, err := fn(ctx, taskRef.Name)
 	if err != nil {
 		t.Fatalf("failed to call pipelinefn: %s", err.Error())
 	}
@@ -666,12 +678,12 @@ func TestGetTaskFunc_RemoteResolution_ReplacedParams(t *testing.T) {
 			}},
 		},
 	}
-	fnNotMatching, err := resources.GetTaskFunc(ctx, nil, nil, requester, trNotMatching, trNotMatching.Spec.TaskRef, "", "default", "default")
+	fnNotMatching, err := resources.GetTaskFunc(ctx, nil, nil, requester, trNotMatching, trNotMatching.Spec.TaskRef, "", "default", "default", nil)
 	if err != nil {
 		t.Fatalf("failed to get task fn: %s", err.Error())
 	}
 
-	_, _, err = fnNotMatching(ctx, taskRefNotMatching.Name)
+	_, _, _, err = fnNotMatching(ctx, taskRefNotMatching.Name)
 	if err == nil {
 		t.Fatal("expected error for non-matching params, did not get one")
 	}
@@ -695,11 +707,11 @@ func TestGetPipelineFunc_RemoteResolutionInvalidData(t *testing.T) {
 			ServiceAccountName: "default",
 		},
 	}
-	fn, err := resources.GetTaskFunc(ctx, nil, nil, requester, tr, tr.Spec.TaskRef, "", "default", "default")
+	fn, err := resources.GetTaskFunc(ctx, nil, nil, requester, tr, tr.Spec.TaskRef, "", "default", "default", nil)
 	if err != nil {
 		t.Fatalf("failed to get pipeline fn: %s", err.Error())
 	}
-	if _, _, err := fn(ctx, taskRef.Name); err == nil {
+	if _, _, _, err := fn(ctx, taskRef.Name); err == nil {
 		t.Fatalf("expected error due to invalid pipeline data but saw none")
 	}
 }
@@ -797,7 +809,7 @@ func TestLocalTaskRef_TrustedResourceVerification_Success(t *testing.T) {
 				Tektonclient: tektonclient,
 			}
 
-			task, source, err := lc.GetTask(ctx, tc.ref.Name)
+			task, source, _, err := lc.GetTask(ctx, tc.ref.Name)
 			if err != nil {
 				t.Fatalf("Received unexpected error %#v", err)
 			}
@@ -839,7 +851,13 @@ func TestLocalTaskRef_TrustedResourceVerification_Error(t *testing.T) {
 	}
 	tamperedTask.Annotations["random"] = "attack"
 
-	tektonclient := fake.NewSimpleClientset(signedTask, unsignedTask, tamperedTask)
+	unsignedClusterTask := &v1beta1.ClusterTask{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "ClusterTask"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster-task"},
+		Spec:       unsignedTask.Spec,
+	}
+
+	tektonclient := fake.NewSimpleClientset(signedTask, unsignedTask, tamperedTask, unsignedClusterTask)
 	testcases := []struct {
 		name                     string
 		ref                      *v1beta1.TaskRef
@@ -863,6 +881,15 @@ func TestLocalTaskRef_TrustedResourceVerification_Error(t *testing.T) {
 			resourceVerificationMode: config.EnforceResourceVerificationMode,
 			expected:                 nil,
 			expectedErr:              trustedresources.ErrorResourceVerificationFailed,
+		}, {
+			name: "unsigned cluster task with enforce policy",
+			ref: &v1beta1.TaskRef{
+				Name: "test-cluster-task",
+				Kind: v1beta1.ClusterTaskKind,
+			},
+			resourceVerificationMode: config.EnforceResourceVerificationMode,
+			expected:                 nil,
+			expectedErr:              trustedresources.ErrorResourceVerificationFailed,
 		},
 	}
 	for _, tc := range testcases {
@@ -874,7 +901,7 @@ func TestLocalTaskRef_TrustedResourceVerification_Error(t *testing.T) {
 				Tektonclient: tektonclient,
 			}
 
-			task, source, err := lc.GetTask(ctx, tc.ref.Name)
+			task, source, _, err := lc.GetTask(ctx, tc.ref.Name)
 			if err == nil || !errors.Is(err, tc.expectedErr) {
 				t.Fatalf("Expected error %v but found %v instead", tc.expectedErr, err)
 			}
@@ -979,12 +1006,12 @@ func TestGetTaskFunc_RemoteResolution_TrustedResourceVerification_Success(t *tes
 					ServiceAccountName: "default",
 				},
 			}
-			fn, err := resources.GetTaskFunc(ctx, nil, nil, tc.requester, tr, tr.Spec.TaskRef, "", "default", "default")
+			fn, err := resources.GetTaskFunc(ctx, nil, nil, tc.requester, tr, tr.Spec.TaskRef, "", "default", "default", nil)
 			if err != nil {
 				t.Fatalf("failed to get task fn: %s", err.Error())
 			}
 
-			resolvedTask, source, err := fn(ctx, taskRef.Name)
+			resolvedTask, source, _, err := fn(ctx, taskRef.Name)
 
 			if err != nil {
 				t.Fatalf("Received unexpected error ( %#v )", err)
@@ -1063,12 +1090,12 @@ func TestGetTaskFunc_RemoteResolution_TrustedResourceVerification_Error(t *testi
 					ServiceAccountName: "default",
 				},
 			}
-			fn, err := resources.GetTaskFunc(ctx, nil, nil, tc.requester, tr, tr.Spec.TaskRef, "", "default", "default")
+			fn, err := resources.GetTaskFunc(ctx, nil, nil, tc.requester, tr, tr.Spec.TaskRef, "", "default", "default", nil)
 			if err != nil {
 				t.Fatalf("failed to get task fn: %s", err.Error())
 			}
 
-			resolvedTask, source, err := fn(ctx, taskRef.Name)
+			resolvedTask, source, _, err := fn(ctx, taskRef.Name)
 
 			if err == nil || !errors.Is(err, tc.expectedErr) {
 				t.Fatalf("Expected error %v but found %v instead", tc.expectedErr, err)
@@ -1085,6 +1112,110 @@ func TestGetTaskFunc_RemoteResolution_TrustedResourceVerification_Error(t *testi
 	}
 }
 
+func TestGetTaskFunc_RemoteResolution_V1TrustedResourceVerification(t *testing.T) {
+	ctx := context.Background()
+	signer, secretpath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signedTask := &pipelinev1.Task{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "Task",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "v1-task",
+			Namespace: "trusted-resources",
+		},
+		Spec: pipelinev1.TaskSpec{
+			Steps: []pipelinev1.Step{{
+				Image:  "ubuntu",
+				Script: "echo hello",
+			}},
+		},
+	}
+	if err := trustedresources.Sign(ctx, signedTask, signer); err != nil {
+		t.Fatal("fail to sign task", err)
+	}
+	signedTaskBytes, err := json.Marshal(signedTask)
+	if err != nil {
+		t.Fatal("fail to marshal task", err)
+	}
+	// the v1 task is resolved as its v1beta1 conversion.
+	expected := &v1beta1.Task{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "Task",
+		},
+	}
+	if err := expected.ConvertFrom(ctx, signedTask.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+
+	tamperedTask := signedTask.DeepCopy()
+	tamperedTask.Spec.Steps[0].Script = "echo attack"
+	tamperedTaskBytes, err := json.Marshal(tamperedTask)
+	if err != nil {
+		t.Fatal("fail to marshal task", err)
+	}
+
+	taskRef := &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{Resolver: "git"}}
+
+	testcases := []struct {
+		name        string
+		data        []byte
+		expected    runtime.Object
+		expectedErr error
+	}{{
+		name:     "signed v1 task with enforce policy",
+		data:     signedTaskBytes,
+		expected: expected,
+	}, {
+		name:        "tampered v1 task with enforce policy",
+		data:        tamperedTaskBytes,
+		expectedErr: trustedresources.ErrorResourceVerificationFailed,
+	}}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx = test.SetupTrustedResourceConfig(ctx, secretpath, config.EnforceResourceVerificationMode)
+			resolved := test.NewResolvedResource(tc.data, nil, sampleConfigSource.DeepCopy(), nil)
+			requester := test.NewRequester(resolved, nil)
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "trusted-resources"},
+				Spec: v1beta1.TaskRunSpec{
+					TaskRef:            taskRef,
+					ServiceAccountName: "default",
+				},
+			}
+			fn, err := resources.GetTaskFunc(ctx, nil, nil, requester, tr, tr.Spec.TaskRef, "", "default", "default", nil)
+			if err != nil {
+				t.Fatalf("failed to get task fn: %s", err.Error())
+			}
+
+			resolvedTask, source, vr, err := fn(ctx, taskRef.Name)
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Fatalf("Expected error %v but found %v instead", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Received unexpected error ( %#v )", err)
+			}
+			if vr == nil || vr.VerificationResultType != trustedresources.VerificationPass {
+				t.Errorf("expected the verification to pass, got %v", vr)
+			}
+			if d := cmp.Diff(tc.expected, resolvedTask); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(sampleConfigSource, source); d != "" {
+				t.Errorf("configSources did not match: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 // This is missing the kind and apiVersion because those are added by
 // the MustParse helpers from the test package.
 var taskYAMLString = `
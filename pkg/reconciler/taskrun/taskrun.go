@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -33,12 +34,16 @@ import (
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/taskrun"
+	alphalisters "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
 	resourcelisters "github.com/tektoncd/pipeline/pkg/client/resource/listers/resource/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/internal/affinityassistant"
 	"github.com/tektoncd/pipeline/pkg/internal/computeresources"
+	"github.com/tektoncd/pipeline/pkg/internal/hermetic"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/policy"
+	"github.com/tektoncd/pipeline/pkg/provenance"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
@@ -46,6 +51,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
 	_ "github.com/tektoncd/pipeline/pkg/taskrunmetrics/fake" // Make sure the taskrunmetrics are setup
 	"github.com/tektoncd/pipeline/pkg/trustedresources"
@@ -76,15 +82,19 @@ type Reconciler struct {
 	Clock             clock.PassiveClock
 
 	// listers index properties about resources
-	taskRunLister       listers.TaskRunLister
-	resourceLister      resourcelisters.PipelineResourceLister
-	limitrangeLister    corev1Listers.LimitRangeLister
-	podLister           corev1Listers.PodLister
-	cloudEventClient    cloudevent.CEClient
-	entrypointCache     podconvert.EntrypointCache
-	metrics             *taskrunmetrics.Recorder
-	pvcHandler          volumeclaim.PvcHandler
-	resolutionRequester resolution.Requester
+	taskRunLister            listers.TaskRunLister
+	resourceLister           resourcelisters.PipelineResourceLister
+	limitrangeLister         corev1Listers.LimitRangeLister
+	namespaceLister          corev1Listers.NamespaceLister
+	podLister                corev1Listers.PodLister
+	verificationPolicyLister alphalisters.VerificationPolicyLister
+	cloudEventClient         cloudevent.CEClient
+	entrypointCache          podconvert.EntrypointCache
+	metrics                  *taskrunmetrics.Recorder
+	pvcHandler               volumeclaim.PvcHandler
+	resolutionRequester      resolution.Requester
+	admissionQueue           *admissionQueue
+	spireClient              spire.ControllerAPIClient
 }
 
 // Check that our Reconciler implements taskrunreconciler.Interface
@@ -105,6 +115,13 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 	// Read the initial condition
 	before := tr.Status.GetCondition(apis.ConditionSucceeded)
 
+	// Verify the status against the signature of the previous reconcile before anything
+	// changes it, and sign the status written back by this one.
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableSpire {
+		statusErr := c.verifySpireStatus(ctx, tr)
+		defer c.signSpireStatus(ctx, tr, statusErr)
+	}
+
 	// If the TaskRun is just starting, this will also set the starttime,
 	// from which the timeout will immediately begin counting down.
 	if !tr.HasStarted() {
@@ -145,6 +162,11 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 			return err
 		}
 
+		if err := c.recordProvenance(ctx, tr); err != nil {
+			logger.Errorf("Failed to sign the SLSA provenance of TaskRun %q: %v", tr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
+		}
+
 		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil)
 	}
 
@@ -159,10 +181,18 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 	// accordingly.
 	if tr.HasTimedOut(ctx, c.Clock) {
 		message := fmt.Sprintf("TaskRun %q failed to finish within %q", tr.Name, tr.GetTimeout(ctx))
+		if tr.HasPassedDeadline(c.Clock) {
+			message = fmt.Sprintf("TaskRun %q failed to finish by its deadline %s", tr.Name, tr.Spec.Deadline.Format(time.RFC3339))
+		}
 		err := c.failTaskRun(ctx, tr, v1beta1.TaskRunReasonTimedOut, message)
 		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
 	}
 
+	// Warn that the deadline of the TaskRun is approaching
+	if tr.IsDeadlineApproaching(ctx, c.Clock) {
+		markDeadlineApproaching(tr)
+	}
+
 	// Check for Pod Failures
 	if failed, reason, message := c.checkPodFailed(tr); failed {
 		err := c.failTaskRun(ctx, tr, reason, message)
@@ -179,6 +209,13 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 		return c.finishReconcileUpdateEmitEvents(ctx, tr, nil, err)
 	}
 
+	// Sign the provenance propagated by prepare, so that it can be verified
+	// alongside the results of the TaskRun.
+	if err := c.signProvenance(ctx, tr); err != nil {
+		logger.Errorf("Failed to sign the provenance of TaskRun %q: %v", tr.Name, err)
+		return c.finishReconcileUpdateEmitEvents(ctx, tr, nil, err)
+	}
+
 	// Store the condition before reconcile
 	before = tr.Status.GetCondition(apis.ConditionSucceeded)
 
@@ -197,11 +234,112 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 		// Compute the time since the task started.
 		elapsed := c.Clock.Since(tr.Status.StartTime.Time)
 		// Snooze this resource until the timeout has elapsed.
-		return controller.NewRequeueAfter(tr.GetTimeout(ctx) - elapsed)
+		waitTime := tr.GetTimeout(ctx) - elapsed
+		if tr.Spec.Deadline != nil {
+			// Wake up earlier if the deadline is approaching or passes before then, or at
+			// all when there is no timeout to wait for.
+			untilDeadline := tr.Spec.Deadline.Sub(c.Clock.Now())
+			threshold := config.FromContextOrDefaults(ctx).Defaults.DefaultDeadlineWarningThreshold
+			for _, d := range []time.Duration{untilDeadline - threshold, untilDeadline} {
+				if d > 0 && (waitTime <= 0 || d < waitTime) {
+					waitTime = d
+				}
+			}
+		}
+		return controller.NewRequeueAfter(waitTime)
 	}
 	return nil
 }
 
+// verifySpireStatus verifies the status of the TaskRun against the signature of the controller,
+// unless the TaskRun has not started yet and so its status was never signed.
+func (c *Reconciler) verifySpireStatus(ctx context.Context, tr *v1beta1.TaskRun) error {
+	if !tr.HasStarted() {
+		return nil
+	}
+	return c.spireClient.VerifyStatusInternalAnnotation(ctx, tr, logging.FromContext(ctx))
+}
+
+// signSpireStatus signs the status of the TaskRun with the controller SVID. Once the TaskRun is done,
+// it sets the spire verified annotation to "true" if its results and its status passed the spire
+// verification. The annotation is set to "false" as soon as either fails, and is never reset.
+func (c *Reconciler) signSpireStatus(ctx context.Context, tr *v1beta1.TaskRun, statusErr error) {
+	logger := logging.FromContext(ctx)
+	verified := ""
+	switch {
+	case statusErr != nil:
+		logger.Errorf("TaskRun %q failed the spire verification of its status: %v", tr.Name, statusErr)
+		verified = "false"
+	case tr.IsDone() && tr.Status.Annotations[spire.VerifiedAnnotation] == "":
+		resultsVerified := tr.Status.GetCondition(apis.ConditionType(v1beta1.TaskRunConditionResultsVerified.String()))
+		verified = strconv.FormatBool(!resultsVerified.IsFalse())
+	}
+	if verified != "" {
+		if tr.Status.Annotations == nil {
+			tr.Status.Annotations = map[string]string{}
+		}
+		tr.Status.Annotations[spire.VerifiedAnnotation] = verified
+	}
+	if err := c.spireClient.AppendStatusInternalAnnotation(ctx, tr); err != nil {
+		logger.Errorf("Failed to sign the status of TaskRun %q: %v", tr.Name, err)
+	}
+}
+
+// spireEntryTTL returns the TTL of the spire entry of the pod of the TaskRun, in seconds as
+// CreateEntries expects it: the timeout of the TaskRun, or a day when it has none.
+func spireEntryTTL(ctx context.Context, tr *v1beta1.TaskRun) time.Duration {
+	timeout := tr.GetTimeout(ctx)
+	if timeout <= 0 {
+		timeout = 24 * time.Hour
+	}
+	return time.Duration(timeout / time.Second)
+}
+
+// markDeadlineApproaching sets the DeadlineApproaching condition of the TaskRun,
+// unless it is already set.
+func markDeadlineApproaching(tr *v1beta1.TaskRun) {
+	conditionType := apis.ConditionType(v1beta1.TaskRunConditionDeadlineApproaching.String())
+	if tr.Status.GetCondition(conditionType).IsTrue() {
+		return
+	}
+	tr.Status.SetCondition(&apis.Condition{
+		Type:     conditionType,
+		Status:   corev1.ConditionTrue,
+		Severity: apis.ConditionSeverityWarning,
+		Reason:   v1beta1.TaskRunConditionDeadlineApproaching.String(),
+		Message:  fmt.Sprintf("TaskRun %q must finish by its deadline %s", tr.Name, tr.Spec.Deadline.Format(time.RFC3339)),
+	})
+}
+
+// markTrustedResourcesVerified sets the TrustedResourcesVerified condition of
+// the TaskRun from the verification result of the resolved Task, if it was
+// verified.
+func markTrustedResourcesVerified(tr *v1beta1.TaskRun, taskMeta *resolutionutil.ResolvedObjectMeta) {
+	vr := taskMeta.VerificationResult
+	if vr == nil {
+		return
+	}
+	condition := &apis.Condition{
+		Type:   apis.ConditionType(v1beta1.TaskRunConditionTrustedResourcesVerified.String()),
+		Reason: v1beta1.TaskRunConditionTrustedResourcesVerified.String(),
+	}
+	switch vr.VerificationResultType {
+	case trustedresources.VerificationPass:
+		condition.Status = corev1.ConditionTrue
+		if len(vr.MatchedPolicies) > 0 {
+			condition.Message = fmt.Sprintf("Task %q was verified with the VerificationPolicies %s", taskMeta.Name, strings.Join(vr.MatchedPolicies, ", "))
+		} else {
+			condition.Message = fmt.Sprintf("Task %q was verified with the public keys of config-trusted-resources", taskMeta.Name)
+		}
+	case trustedresources.VerificationWarn:
+		condition.Status = corev1.ConditionFalse
+		condition.Message = fmt.Sprintf("Task %q failed verification: %v", taskMeta.Name, vr.Err)
+	default:
+		return
+	}
+	tr.Status.SetCondition(condition)
+}
+
 func (c *Reconciler) checkPodFailed(tr *v1beta1.TaskRun) (bool, v1beta1.TaskRunReason, string) {
 	for _, step := range tr.Status.Steps {
 		if step.Waiting != nil && step.Waiting.Reason == "ImagePullBackOff" {
@@ -240,6 +378,9 @@ func (c *Reconciler) durationAndCountMetrics(ctx context.Context, tr *v1beta1.Ta
 			if err := metrics.CloudEvents(ctx, tr); err != nil {
 				logger.Warnf("Failed to log the metrics : %v", err)
 			}
+			if err := metrics.StepResourceUsage(ctx, tr); err != nil {
+				logger.Warnf("Failed to log the metrics : %v", err)
+			}
 		}(c.metrics)
 	}
 }
@@ -310,6 +451,51 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1
 	return merr
 }
 
+// recordProvenance stores the SLSA provenance statement of the completed TaskRun in its status
+// annotations when enabled, and signs it with the controller SVID when spire is enabled. The statement
+// is regenerated from the status, and any statement set in the metadata annotations, which users can
+// write, is removed. A statement exceeding the maximum annotation size is not recorded.
+func (c *Reconciler) recordProvenance(ctx context.Context, tr *v1beta1.TaskRun) error {
+	provenance.StripAnnotations(tr.Annotations)
+	cfg := config.FromContextOrDefaults(ctx)
+	if !cfg.FeatureFlags.EnableSLSAProvenance {
+		return nil
+	}
+	statement, err := provenance.ForTaskRun(tr).Annotation()
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Not recording the SLSA provenance of TaskRun %q: %v", tr.Name, err)
+		provenance.StripAnnotations(tr.Status.Annotations)
+		return nil
+	}
+	signatureKey := provenance.AnnotationKey + spire.AnnotationSignatureSuffix
+	if tr.Status.Annotations[provenance.AnnotationKey] == statement {
+		if _, ok := tr.Status.Annotations[signatureKey]; ok || !cfg.FeatureFlags.EnableSpire {
+			return nil
+		}
+	}
+	if tr.Status.Annotations == nil {
+		tr.Status.Annotations = map[string]string{}
+	}
+	tr.Status.Annotations[provenance.AnnotationKey] = statement
+	delete(tr.Status.Annotations, signatureKey)
+	if !cfg.FeatureFlags.EnableSpire {
+		return nil
+	}
+	return c.spireClient.AppendAnnotationSignature(ctx, tr.Status.Annotations, provenance.AnnotationKey)
+}
+
+// signProvenance signs the provenance of the TaskRun with the controller SVID when
+// spire is enabled, unless the current provenance is signed already.
+func (c *Reconciler) signProvenance(ctx context.Context, tr *v1beta1.TaskRun) error {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableSpire || tr.Status.Provenance == nil {
+		return nil
+	}
+	if err := spire.CheckProvenanceAnnotation(tr); err == nil {
+		return nil
+	}
+	return c.spireClient.AppendProvenanceAnnotation(ctx, tr)
+}
+
 // `prepare` fetches resources the taskrun depends on, runs validation and conversion
 // It may report errors back to Reconcile, it updates the taskrun status in case of
 // error but it does not sync updates back to etcd. It does not emit events.
@@ -322,7 +508,11 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 	logger := logging.FromContext(ctx)
 	tr.SetDefaults(ctx)
 
-	getTaskfunc, err := resources.GetTaskFuncFromTaskRun(ctx, c.KubeClientSet, c.PipelineClientSet, c.resolutionRequester, tr)
+	vp, err := c.verificationPolicyLister.VerificationPolicies(tr.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list VerificationPolicies from namespace %s with error %w", tr.Namespace, err)
+	}
+	getTaskfunc, err := resources.GetTaskFuncFromTaskRun(ctx, c.KubeClientSet, c.PipelineClientSet, c.resolutionRequester, tr, vp)
 	if err != nil {
 		logger.Errorf("Failed to fetch task reference %s: %v", tr.Spec.TaskRef.Name, err)
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedResolution, err)
@@ -351,6 +541,7 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		if err := storeTaskSpecAndMergeMeta(ctx, tr, taskSpec, taskMeta); err != nil {
 			logger.Errorf("Failed to store TaskSpec on TaskRun.Statusfor taskrun %s: %v", tr.Name, err)
 		}
+		markTrustedResourcesVerified(tr, taskMeta)
 	}
 
 	inputs := []v1beta1.TaskResourceBinding{}
@@ -519,6 +710,21 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 	}
 
 	if pod == nil {
+		// Validate the TaskSpec against the policy before the pod is created, so that
+		// the pods already running are not failed by changes to the policy.
+		if err := c.validatePolicy(ctx, tr, rtr, ts); errors.Is(err, policy.ErrorPolicyViolation) {
+			logger.Errorf("TaskRun %s/%s violates the policy: %v", tr.Namespace, tr.Name, err)
+			tr.Status.MarkResourceFailed(podconvert.ReasonPolicyViolation, err)
+			return controller.NewPermanentError(err)
+		} else if err != nil {
+			return err
+		}
+		if admitted, message := c.admissionQueue.admit(config.FromContextOrDefaults(ctx).Scheduling, tr); !admitted {
+			logger.Infof("TaskRun %s/%s is queued: %s", tr.Namespace, tr.Name, message)
+			// The TaskRun is enqueued again when a running TaskRun is done.
+			tr.Status.MarkResourceOngoing(podconvert.ReasonQueued, message)
+			return nil
+		}
 		pod, err = c.createPod(ctx, ts, tr, rtr, workspaceVolumes)
 		if err != nil {
 			newErr := c.handlePodCreationError(tr, err)
@@ -531,6 +737,9 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 		recorder.Eventf(tr, corev1.EventTypeWarning, podconvert.ReasonExceededNodeResources, "Insufficient resources to schedule pod %q", pod.Name)
 	}
 
+	if err := podconvert.UpdateSidecarsReady(ctx, c.KubeClientSet, *pod); err != nil {
+		return err
+	}
 	if podconvert.SidecarsReady(pod.Status) {
 		if err := podconvert.UpdateReady(ctx, c.KubeClientSet, *pod); err != nil {
 			return err
@@ -540,12 +749,31 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 		}
 	}
 
+	var spireAPI spire.ControllerAPIClient
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableSpire {
+		spireAPI = c.spireClient
+		// Register the pod with SPIRE once it is scheduled, so that its steps can get
+		// the SVID of the TaskRun to sign their results.
+		if pod.Spec.NodeName != "" && (pod.Status.Phase == corev1.PodPending || pod.Status.Phase == corev1.PodRunning) {
+			if err := c.spireClient.CreateEntries(ctx, tr, pod, spireEntryTTL(ctx, tr)); err != nil {
+				logger.Errorf("Failed to register the pod of TaskRun %q with spire: %v", tr.Name, err)
+				return err
+			}
+		}
+	}
+
 	// Convert the Pod's status to the equivalent TaskRun Status.
-	tr.Status, err = podconvert.MakeTaskRunStatus(logger, *tr, pod)
+	tr.Status, err = podconvert.MakeTaskRunStatus(ctx, logger, *tr, pod, spireAPI)
 	if err != nil {
 		return err
 	}
 
+	if spireAPI != nil && tr.IsDone() {
+		if err := c.spireClient.DeleteEntry(ctx, tr, pod); err != nil {
+			logger.Warnf("Failed to delete the spire entry of TaskRun %q: %v", tr.Name, err)
+		}
+	}
+
 	if err := validateTaskRunResults(tr, rtr.TaskSpec); err != nil {
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
 		return err
@@ -555,6 +783,23 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 	return nil
 }
 
+// validatePolicy validates the TaskSpec of the TaskRun against the policy of the context,
+// with its bound PipelineResources substituted as well as its parameters, contexts, results
+// and workspaces, so that the images set by variables are validated too.
+func (c *Reconciler) validatePolicy(ctx context.Context, tr *v1beta1.TaskRun, rtr *resources.ResolvedTaskResources, ts *v1beta1.TaskSpec) error {
+	inputResources, err := resourceImplBinding(rtr.Inputs, c.Images)
+	if err != nil {
+		return err
+	}
+	outputResources, err := resourceImplBinding(rtr.Outputs, c.Images)
+	if err != nil {
+		return err
+	}
+	ts = resources.ApplyResources(ts, inputResources, "inputs")
+	ts = resources.ApplyResources(ts, outputResources, "outputs")
+	return policy.ValidateTaskSpec(ctx, tr.Namespace, ts)
+}
+
 func (c *Reconciler) updateTaskRunWithDefaultWorkspaces(ctx context.Context, tr *v1beta1.TaskRun, taskSpec *v1beta1.TaskSpec) error {
 	configMap := config.FromContextOrDefaults(ctx)
 	defaults := configMap.Defaults
@@ -608,6 +853,7 @@ func (c *Reconciler) updateLabelsAndAnnotations(ctx context.Context, tr *v1beta1
 		newTr = newTr.DeepCopy()
 		newTr.Labels = kmap.Union(newTr.Labels, tr.Labels)
 		newTr.Annotations = kmap.Union(newTr.Annotations, tr.Annotations)
+		provenance.StripAnnotations(newTr.Annotations)
 		return c.PipelineClientSet.TektonV1beta1().TaskRuns(tr.Namespace).Update(ctx, newTr, metav1.UpdateOptions{})
 	}
 	return newTr, nil
@@ -761,15 +1007,29 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1beta1.TaskSpec, tr *v1
 		Images:          c.Images,
 		KubeClient:      c.KubeClientSet,
 		EntrypointCache: c.entrypointCache,
+		NamespaceLister: c.namespaceLister,
 	}
 	pod, err := podbuilder.Build(ctx, tr, *ts,
 		computeresources.NewTransformer(ctx, tr.Namespace, c.limitrangeLister),
 		affinityassistant.NewTransformer(ctx, tr.Annotations),
+		hermetic.NewTransformer(ctx, tr),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("translating TaskSpec to Pod: %w", err)
 	}
 
+	// The pods of hermetic TaskRuns are isolated from the network before they are created.
+	if hermetic.Enabled(ctx, tr) {
+		if _, err := c.KubeClientSet.NetworkingV1().NetworkPolicies(tr.Namespace).Create(ctx, hermetic.NetworkPolicy(tr), metav1.CreateOptions{}); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return nil, fmt.Errorf("creating the NetworkPolicy of the hermetic TaskRun: %w", err)
+		}
+	}
+
+	// The PriorityClass of the priority of the TaskRun, unless the pod template sets one.
+	if scheduling := config.FromContextOrDefaults(ctx).Scheduling; scheduling != nil && tr.Spec.Priority != "" && pod.Spec.PriorityClassName == "" {
+		pod.Spec.PriorityClassName = scheduling.Priorities[tr.Spec.Priority].PriorityClassName
+	}
+
 	// Stash the podname in case there's create conflict so that we can try
 	// to fetch it.
 	podName := pod.Name
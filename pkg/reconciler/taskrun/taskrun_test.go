@@ -19,6 +19,7 @@ package taskrun
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http/httptest"
@@ -36,15 +37,19 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	alpha "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/provenance"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/spire"
+	spireconfig "github.com/tektoncd/pipeline/pkg/spire/config"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -63,6 +68,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	clock "k8s.io/utils/clock/testing"
 	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 	cminformer "knative.dev/pkg/configmap/informer"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
@@ -473,7 +479,7 @@ func getRunName(tr *v1beta1.TaskRun) string {
 func getTaskRunController(t *testing.T, d test.Data) (test.Assets, func()) {
 	t.Helper()
 	names.TestingSeed()
-	return initializeTaskRunControllerAssets(t, d, pipeline.Options{Images: images})
+	return initializeTaskRunControllerAssets(t, d, pipeline.Options{Images: images, SpireConfig: spireconfig.SpireConfig{MockSpire: true}})
 }
 
 func initializeTaskRunControllerAssets(t *testing.T, d test.Data, opts pipeline.Options) (test.Assets, func()) {
@@ -2371,6 +2377,32 @@ status:
 			wantEvents: []string{
 				"Warning Failed ",
 			},
+		}, {
+			name: "taskrun with passed deadline",
+			taskRun: parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-deadline
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+  timeout: 1h
+  deadline: "2021-12-31T23:59:50Z"
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+  startTime: "2021-12-31T23:59:45Z"
+`),
+			expectedStatus: &apis.Condition{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Reason:  "TaskRunTimeout",
+				Message: `TaskRun "test-taskrun-deadline" failed to finish by its deadline 2021-12-31T23:59:50Z`,
+			},
+			wantEvents: []string{
+				"Warning Failed ",
+			},
 		}}
 
 	for _, tc := range testcases {
@@ -4164,6 +4196,257 @@ spec:
 	}
 }
 
+func Test_signProvenance(t *testing.T) {
+	provenance := &v1beta1.Provenance{
+		ConfigSource: &v1beta1.ConfigSource{
+			URI:        "https://abc.com.git",
+			Digest:     map[string]string{"sha1": "xyz"},
+			EntryPoint: "foo/bar",
+		},
+	}
+
+	for _, tc := range []struct {
+		name       string
+		flag       string
+		provenance *v1beta1.Provenance
+		wantSigned bool
+	}{{
+		name:       "spire enabled signs the provenance",
+		flag:       "enable-spire",
+		provenance: provenance,
+		wantSigned: true,
+	}, {
+		name:       "spire enabled without provenance",
+		flag:       "enable-spire",
+		provenance: nil,
+		wantSigned: false,
+	}, {
+		name:       "spire disabled",
+		flag:       "enable-provenance-in-status",
+		provenance: provenance,
+		wantSigned: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := ttesting.EnableFeatureFlagField(context.Background(), t, tc.flag)
+			spireClient := &spire.MockClient{}
+			c := &Reconciler{spireClient: spireClient}
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "foo"},
+				Status: v1beta1.TaskRunStatus{
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						Provenance: tc.provenance.DeepCopy(),
+					},
+				},
+			}
+
+			if err := c.signProvenance(ctx, tr); err != nil {
+				t.Fatalf("signProvenance() = %v", err)
+			}
+			_, signed := tr.Status.Annotations[spire.TaskRunProvenanceHashAnnotation]
+			if signed != tc.wantSigned {
+				t.Fatalf("expected the provenance signed to be %t but got %t", tc.wantSigned, signed)
+			}
+			if !tc.wantSigned {
+				return
+			}
+			if err := spireClient.VerifyProvenanceAnnotation(ctx, tr); err != nil {
+				t.Errorf("VerifyProvenanceAnnotation() = %v", err)
+			}
+
+			// The signed provenance is not signed again, and tampering with it fails the verification.
+			hash := tr.Status.Annotations[spire.TaskRunProvenanceHashAnnotation]
+			if err := c.signProvenance(ctx, tr); err != nil {
+				t.Fatalf("signProvenance() = %v", err)
+			}
+			if d := cmp.Diff(hash, tr.Status.Annotations[spire.TaskRunProvenanceHashAnnotation]); d != "" {
+				t.Errorf("provenance signed again %s", diff.PrintWantGot(d))
+			}
+			tr.Status.Provenance.ConfigSource.EntryPoint = "foo/baz"
+			if err := spireClient.VerifyProvenanceAnnotation(ctx, tr); err == nil {
+				t.Error("expected the verification of the tampered provenance to fail")
+			}
+		})
+	}
+}
+
+func Test_signSpireStatus(t *testing.T) {
+	resultsVerified := func(status corev1.ConditionStatus) apis.Condition {
+		return apis.Condition{
+			Type:   apis.ConditionType(v1beta1.TaskRunConditionResultsVerified.String()),
+			Status: status,
+		}
+	}
+	succeeded := apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}
+	running := apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}
+
+	for _, tc := range []struct {
+		name         string
+		conditions   duckv1beta1.Conditions
+		statusErr    error
+		wantVerified string
+	}{{
+		name:         "running taskrun is signed without a verified marker",
+		conditions:   duckv1beta1.Conditions{running},
+		wantVerified: "",
+	}, {
+		name:         "done taskrun is verified",
+		conditions:   duckv1beta1.Conditions{succeeded, resultsVerified(corev1.ConditionTrue)},
+		wantVerified: "true",
+	}, {
+		name:         "done taskrun with results that failed the verification",
+		conditions:   duckv1beta1.Conditions{succeeded, resultsVerified(corev1.ConditionFalse)},
+		wantVerified: "false",
+	}, {
+		name:         "status that failed the verification",
+		conditions:   duckv1beta1.Conditions{running},
+		statusErr:    errors.New("signature was not able to be verified"),
+		wantVerified: "false",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			spireClient := &spire.MockClient{}
+			c := &Reconciler{spireClient: spireClient}
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "foo"},
+				Status: v1beta1.TaskRunStatus{
+					Status: duckv1beta1.Status{Conditions: tc.conditions},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						StartTime: &metav1.Time{Time: now},
+					},
+				},
+			}
+
+			c.signSpireStatus(ctx, tr, tc.statusErr)
+			if d := cmp.Diff(tc.wantVerified, tr.Status.Annotations[spire.VerifiedAnnotation]); d != "" {
+				t.Errorf("unexpected verified marker %s", diff.PrintWantGot(d))
+			}
+			if err := c.verifySpireStatus(ctx, tr); err != nil {
+				t.Errorf("verifySpireStatus() = %v", err)
+			}
+
+			// Tampering with the signed status fails the verification.
+			tr.Status.PodName = "tampered"
+			if err := c.verifySpireStatus(ctx, tr); err == nil {
+				t.Error("expected the verification of the tampered status to fail")
+			}
+		})
+	}
+}
+
+func Test_verifySpireStatusNotStarted(t *testing.T) {
+	c := &Reconciler{spireClient: &spire.MockClient{}}
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "foo"}}
+	if err := c.verifySpireStatus(context.Background(), tr); err != nil {
+		t.Errorf("verifySpireStatus() = %v", err)
+	}
+}
+
+func Test_recordProvenance(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		flags      map[string]string
+		forged     bool
+		wantRecord bool
+		wantSigned bool
+	}{{
+		name:       "slsa provenance enabled",
+		flags:      map[string]string{"enable-slsa-provenance": "true"},
+		wantRecord: true,
+	}, {
+		name:       "slsa provenance and spire enabled",
+		flags:      map[string]string{"enable-slsa-provenance": "true", "enable-spire": "true"},
+		wantRecord: true,
+		wantSigned: true,
+	}, {
+		name:  "slsa provenance disabled",
+		flags: map[string]string{"enable-spire": "true"},
+	}, {
+		name:       "forged slsa provenance",
+		flags:      map[string]string{"enable-slsa-provenance": "true", "enable-spire": "true"},
+		forged:     true,
+		wantRecord: true,
+		wantSigned: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			featureFlags, err := config.NewFeatureFlagsFromMap(tc.flags)
+			if err != nil {
+				t.Fatalf("NewFeatureFlagsFromMap() = %v", err)
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: featureFlags})
+			spireClient := &spire.MockClient{}
+			c := &Reconciler{spireClient: spireClient}
+			tr := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: foo
+  namespace: foo
+spec:
+  taskRef:
+    name: foo-task
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  steps:
+  - name: build
+    imageID: docker-pullable://gcr.io/foo/builder@sha256:111
+  taskResults:
+  - name: IMAGE_URL
+    value: gcr.io/foo/bar
+  - name: IMAGE_DIGEST
+    value: sha256:222
+`)
+
+			if tc.forged {
+				// A statement set by users in the metadata annotations or in the status annotations
+				// of the TaskRun is replaced by the one generated from the status.
+				tr.Annotations = map[string]string{
+					provenance.AnnotationKey:                                   `{"subject":[{"name":"gcr.io/foo/evil"}]}`,
+					provenance.AnnotationKey + spire.AnnotationSignatureSuffix: "forged",
+				}
+				tr.Status.Annotations = map[string]string{
+					provenance.AnnotationKey:                                   `{"subject":[{"name":"gcr.io/foo/evil"}]}`,
+					provenance.AnnotationKey + spire.AnnotationSignatureSuffix: "forged",
+				}
+			}
+
+			if err := c.recordProvenance(ctx, tr); err != nil {
+				t.Fatalf("recordProvenance() = %v", err)
+			}
+			if len(tr.Annotations) != 0 {
+				t.Errorf("expected the provenance to be removed from the metadata annotations but got %v", tr.Annotations)
+			}
+			statement, recorded := tr.Status.Annotations[provenance.AnnotationKey]
+			if recorded != tc.wantRecord {
+				t.Fatalf("expected the provenance recorded to be %t but got %t", tc.wantRecord, recorded)
+			}
+			_, signed := tr.Status.Annotations[provenance.AnnotationKey+spire.AnnotationSignatureSuffix]
+			if signed != tc.wantSigned {
+				t.Fatalf("expected the provenance signed to be %t but got %t", tc.wantSigned, signed)
+			}
+			if !tc.wantRecord {
+				return
+			}
+			if d := cmp.Diff(provenance.ForTaskRun(tr), mustUnmarshalStatement(t, statement)); d != "" {
+				t.Errorf("unexpected provenance %s", diff.PrintWantGot(d))
+			}
+			if tc.wantSigned {
+				if err := spireClient.VerifyAnnotationSignature(ctx, tr.Status.Annotations, provenance.AnnotationKey); err != nil {
+					t.Errorf("VerifyAnnotationSignature() = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func mustUnmarshalStatement(t *testing.T, annotation string) *provenance.Statement {
+	t.Helper()
+	s := &provenance.Statement{}
+	if err := json.Unmarshal([]byte(annotation), s); err != nil {
+		t.Fatalf("failed to unmarshal the provenance statement: %v", err)
+	}
+	return s
+}
+
 func Test_storeTaskSpec_metadata(t *testing.T) {
 	taskrunlabels := map[string]string{"lbl1": "value1", "lbl2": "value2"}
 	taskrunannotations := map[string]string{"io.annotation.1": "value1", "io.annotation.2": "value2"}
@@ -5195,12 +5478,12 @@ status:
 		{
 			name:          "unsigned task fails verification",
 			task:          []*v1beta1.Task{ts},
-			expectedError: fmt.Errorf("1 error occurred:\n\t* error when listing tasks for taskRun test-taskrun: resource verification failed"),
+			expectedError: fmt.Errorf("1 error occurred:\n\t* error when listing tasks for taskRun test-taskrun: resource verification failed: Task test-task in namespace foo fails verification: signature is missing"),
 		},
 		{
 			name:          "modified task fails verification",
 			task:          []*v1beta1.Task{tamperedTask},
-			expectedError: fmt.Errorf("1 error occurred:\n\t* error when listing tasks for taskRun test-taskrun: resource verification failed"),
+			expectedError: fmt.Errorf("1 error occurred:\n\t* error when listing tasks for taskRun test-taskrun: resource verification failed: Task test-task in namespace foo fails verification"),
 		},
 	}
 
@@ -5232,3 +5515,488 @@ status:
 		})
 	}
 }
+
+func TestReconcile_verifyResolvedTask_VerificationPolicy(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ts := parse.MustParseV1beta1Task(t, `
+metadata:
+  name: test-task
+  namespace: foo
+spec:
+  steps:
+  - script: echo hello
+    image: myimage
+    name: mycontainer
+`)
+	tr := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+`)
+
+	signer, keypath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyData, err := os.ReadFile(keypath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherKeypath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyData, err := os.ReadFile(otherKeypath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedTask, err := test.GetSignedTask(ts, signer, "test-task")
+	if err != nil {
+		t.Fatal("fail to sign task", err)
+	}
+
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"resource-verification-mode": "enforce",
+		},
+	}}
+	policy := func(key []byte, mode alpha.ModeType) *alpha.VerificationPolicy {
+		return &alpha.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "local-tasks", Namespace: "foo"},
+			Spec: alpha.VerificationPolicySpec{
+				Resources:   []alpha.ResourcePattern{{Pattern: ".*"}},
+				Authorities: []alpha.Authority{{Name: "key", Key: &alpha.KeyRef{Data: string(key)}}},
+				Mode:        mode,
+			},
+		}
+	}
+
+	for _, tc := range []struct {
+		name          string
+		policy        *alpha.VerificationPolicy
+		wantCondition apis.Condition
+	}{{
+		name:   "task signed with the key of the matching policy",
+		policy: policy(keyData, alpha.ModeEnforce),
+		wantCondition: apis.Condition{
+			Type:    apis.ConditionType(v1beta1.TaskRunConditionTrustedResourcesVerified.String()),
+			Status:  corev1.ConditionTrue,
+			Reason:  v1beta1.TaskRunConditionTrustedResourcesVerified.String(),
+			Message: `Task "test-task" was verified with the VerificationPolicies local-tasks`,
+		},
+	}, {
+		name:   "task failing the verification of a policy in warn mode",
+		policy: policy(otherKeyData, alpha.ModeWarn),
+		wantCondition: apis.Condition{
+			Type:    apis.ConditionType(v1beta1.TaskRunConditionTrustedResourcesVerified.String()),
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.TaskRunConditionTrustedResourcesVerified.String(),
+			Message: `Task "test-task" failed verification: VerificationPolicy local-tasks: Task test-task in namespace foo fails verification`,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				TaskRuns:             []*v1beta1.TaskRun{tr},
+				Tasks:                []*v1beta1.Task{signedTask},
+				ConfigMaps:           cms,
+				VerificationPolicies: []*alpha.VerificationPolicy{tc.policy},
+			}
+
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, tr.Spec.ServiceAccountName, tr.Namespace)
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr))
+			if ok, _ := controller.IsRequeueKey(err); !ok {
+				t.Errorf("Error reconciling TaskRun. Got error %v", err)
+			}
+
+			reconciledRun, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("getting updated taskrun: %v", err)
+			}
+			if reconciledRun.IsDone() {
+				t.Errorf("Expected TaskRun to be running but it is done: %v", reconciledRun.Status.Conditions)
+			}
+			got := reconciledRun.Status.GetCondition(tc.wantCondition.Type)
+			if got == nil {
+				t.Fatalf("Expected TrustedResourcesVerified condition, got conditions %v", reconciledRun.Status.Conditions)
+			}
+			if d := cmp.Diff(tc.wantCondition, *got, cmpopts.IgnoreFields(apis.Condition{}, "LastTransitionTime", "Severity")); d != "" {
+				t.Errorf("TrustedResourcesVerified condition %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileQueuedTaskRun(t *testing.T) {
+	runningTaskRun := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-running
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+status:
+  conditions:
+  - reason: Running
+    status: Unknown
+    type: Succeeded
+  podName: test-taskrun-running-pod
+`)
+	taskRun := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-queued
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+`)
+	d := test.Data{
+		Tasks:    []*v1beta1.Task{simpleTask},
+		TaskRuns: []*v1beta1.TaskRun{runningTaskRun, taskRun},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetSchedulingConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"max-running-taskruns": "1",
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, "default", "foo")
+
+	err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+	if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Fatalf("Expected the queued TaskRun to be requeued but got %v", err)
+	}
+
+	tr, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting TaskRun: %v", err)
+	}
+	condition := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if condition.Reason != podconvert.ReasonQueued {
+		t.Errorf("Expected reason %s but got %s", podconvert.ReasonQueued, condition.Reason)
+	}
+	wantMessage := "Waiting in the TaskRun queue at position 1 of 1, 1 of 1 TaskRuns are running"
+	if condition.Message != wantMessage {
+		t.Errorf("Expected message %q but got %q", wantMessage, condition.Message)
+	}
+	if tr.Status.PodName != "" {
+		t.Errorf("Expected no pod to be created for the queued TaskRun but got %s", tr.Status.PodName)
+	}
+}
+
+func TestReconcileTaskRunPriorityClass(t *testing.T) {
+	taskRun := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-priority
+  namespace: foo
+spec:
+  priority: interactive
+  taskRef:
+    name: test-task
+`)
+	d := test.Data{
+		Tasks:    []*v1beta1.Task{simpleTask},
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"enable-api-fields": config.AlphaAPIFields,
+			},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetSchedulingConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"priorities": "interactive:\n  value: 100\n  priorityClassName: tekton-interactive\n",
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, "default", "foo")
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		if ok, _ := controller.IsRequeueKey(err); !ok {
+			t.Fatalf("Expected no error reconciling valid TaskRun but got %v", err)
+		}
+	}
+
+	tr, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting TaskRun: %v", err)
+	}
+	pod, err := testAssets.Clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, tr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting Pod: %v", err)
+	}
+	if pod.Spec.PriorityClassName != "tekton-interactive" {
+		t.Errorf("Expected PriorityClass tekton-interactive but got %q", pod.Spec.PriorityClassName)
+	}
+}
+
+func TestReconcileHermeticTaskRun(t *testing.T) {
+	taskRun := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-hermetic
+  namespace: foo
+spec:
+  executionMode: hermetic
+  taskRef:
+    name: test-task
+`)
+	d := test.Data{
+		Tasks:    []*v1beta1.Task{simpleTask},
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"enable-api-fields": config.AlphaAPIFields,
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, "default", "foo")
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err != nil {
+		if ok, _ := controller.IsRequeueKey(err); !ok {
+			t.Fatalf("Expected no error reconciling valid TaskRun but got %v", err)
+		}
+	}
+
+	np, err := testAssets.Clients.Kube.NetworkingV1().NetworkPolicies(taskRun.Namespace).Get(testAssets.Ctx, "test-taskrun-hermetic-hermetic", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting the NetworkPolicy of the hermetic TaskRun: %v", err)
+	}
+	if d := cmp.Diff(map[string]string{pipeline.TaskRunLabelKey: taskRun.Name}, np.Spec.PodSelector.MatchLabels); d != "" {
+		t.Errorf("NetworkPolicy pod selector %s", diff.PrintWantGot(d))
+	}
+
+	tr, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting TaskRun: %v", err)
+	}
+	pod, err := testAssets.Clients.Kube.CoreV1().Pods(taskRun.Namespace).Get(testAssets.Ctx, tr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Error getting Pod: %v", err)
+	}
+	for _, c := range pod.Spec.Containers {
+		sc := c.SecurityContext
+		if sc == nil || sc.ReadOnlyRootFilesystem == nil || !*sc.ReadOnlyRootFilesystem {
+			t.Errorf("Expected the root filesystem of step %s to be read-only", c.Name)
+		}
+		if sc == nil || sc.Capabilities == nil || cmp.Diff([]corev1.Capability{"ALL"}, sc.Capabilities.Drop) != "" {
+			t.Errorf("Expected all the capabilities of step %s to be dropped", c.Name)
+		}
+	}
+}
+
+func TestReconcileTaskRunDeadlineApproaching(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		timeout string
+	}{{
+		name:    "default timeout",
+		timeout: "",
+	}, {
+		name:    "no timeout",
+		timeout: "0s",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-deadline-approaching
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+  deadline: "2022-01-01T00:05:00Z"
+`)
+			if tc.timeout != "" {
+				timeout, err := time.ParseDuration(tc.timeout)
+				if err != nil {
+					t.Fatal(err)
+				}
+				taskRun.Spec.Timeout = &metav1.Duration{Duration: timeout}
+			}
+			d := test.Data{
+				Tasks:    []*v1beta1.Task{simpleTask},
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+					Data: map[string]string{
+						"default-deadline-warning-threshold": "10m",
+					},
+				}},
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, "default", "foo")
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			if ok, delay := controller.IsRequeueKey(err); !ok || delay != 5*time.Minute {
+				t.Fatalf("Expected the TaskRun to be requeued when its deadline passes but got %v", err)
+			}
+
+			tr, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting TaskRun: %v", err)
+			}
+			wantCondition := &apis.Condition{
+				Type:     apis.ConditionType(v1beta1.TaskRunConditionDeadlineApproaching.String()),
+				Status:   corev1.ConditionTrue,
+				Severity: apis.ConditionSeverityWarning,
+				Reason:   v1beta1.TaskRunConditionDeadlineApproaching.String(),
+				Message:  `TaskRun "test-taskrun-deadline-approaching" must finish by its deadline 2022-01-01T00:05:00Z`,
+			}
+			condition := tr.Status.GetCondition(apis.ConditionType(v1beta1.TaskRunConditionDeadlineApproaching.String()))
+			if d := cmp.Diff(wantCondition, condition, ignoreLastTransitionTime); d != "" {
+				t.Errorf("Unexpected DeadlineApproaching condition %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconcileTaskRunPolicyViolation(t *testing.T) {
+	task := parse.MustParseV1beta1Task(t, `
+metadata:
+  name: test-task-image-param
+  namespace: foo
+spec:
+  params:
+  - name: image
+    type: string
+  steps:
+  - name: build
+    image: $(params.image)
+    script: echo build
+`)
+	resourceTask := parse.MustParseV1beta1Task(t, `
+metadata:
+  name: test-task-image-resource
+  namespace: foo
+spec:
+  resources:
+    inputs:
+    - name: builder
+      type: image
+  steps:
+  - name: build
+    image: $(resources.inputs.builder.url)
+    script: echo build
+`)
+	for _, tc := range []struct {
+		name          string
+		taskRun       string
+		podCreated    bool
+		wantViolation bool
+		wantMessage   string
+	}{{
+		name: "allowed image",
+		taskRun: `
+  params:
+  - name: image
+    value: registry.corp/builder@sha256:7a1a0b4ec0b3b6d4a4d1e3f0c2e1c5e3e9a7b7d0d0f7e0a4a3d6a8b6e8e3a4b2
+  taskRef:
+    name: test-task-image-param
+`,
+	}, {
+		name: "image from a parameter not allowed",
+		taskRun: `
+  params:
+  - name: image
+    value: docker.io/builder:latest
+  taskRef:
+    name: test-task-image-param
+`,
+		wantViolation: true,
+		wantMessage:   `policy violation: step "build": image "docker.io/builder:latest" does not start with any of the allowed prefixes registry.corp/; step "build": image "docker.io/builder:latest" is not pinned by digest`,
+	}, {
+		name: "image from a resource not allowed",
+		taskRun: `
+  resources:
+    inputs:
+    - name: builder
+      resourceSpec:
+        type: image
+        params:
+        - name: url
+          value: docker.io/builder:latest
+  taskRef:
+    name: test-task-image-resource
+`,
+		wantViolation: true,
+		wantMessage:   `policy violation: step "build": image "docker.io/builder:latest" does not start with any of the allowed prefixes registry.corp/; step "build": image "docker.io/builder:latest" is not pinned by digest`,
+	}, {
+		name: "pod created before the policy",
+		taskRun: `
+  params:
+  - name: image
+    value: docker.io/builder:latest
+  taskRef:
+    name: test-task-image-param
+status:
+  podName: test-taskrun-policy-pod
+`,
+		podCreated: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-policy
+  namespace: foo
+spec:`+tc.taskRun)
+			d := test.Data{
+				Tasks:    []*v1beta1.Task{task, resourceTask},
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetPolicyConfigName(), Namespace: system.Namespace()},
+					Data: map[string]string{
+						"allowed-image-prefixes": "- registry.corp/\n",
+						"require-image-digest":   "true",
+					},
+				}},
+			}
+			if tc.podCreated {
+				pod, err := makePod(taskRun, task)
+				if err != nil {
+					t.Fatalf("MakePod: %v", err)
+				}
+				d.Pods = []*corev1.Pod{pod}
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, "default", "foo")
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			if tc.wantViolation && !controller.IsPermanentError(err) {
+				t.Errorf("Expected a permanent error reconciling the TaskRun but got %v", err)
+			}
+
+			tr, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Error getting TaskRun: %v", err)
+			}
+			condition := tr.Status.GetCondition(apis.ConditionSucceeded)
+			if gotViolation := condition.Reason == podconvert.ReasonPolicyViolation; gotViolation != tc.wantViolation {
+				t.Fatalf("Expected policy violation %t but got condition %#v", tc.wantViolation, condition)
+			}
+			if tc.wantViolation {
+				if condition.Status != corev1.ConditionFalse {
+					t.Errorf("Expected the TaskRun to fail but got condition %#v", condition)
+				}
+				if condition.Message != tc.wantMessage {
+					t.Errorf("Expected message %q but got %q", tc.wantMessage, condition.Message)
+				}
+			}
+		})
+	}
+}
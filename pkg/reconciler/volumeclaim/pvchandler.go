@@ -39,6 +39,7 @@ const (
 // PvcHandler is used to create PVCs for workspaces
 type PvcHandler interface {
 	CreatePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error
+	DeletePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error
 }
 
 type defaultPVCHandler struct {
@@ -75,6 +76,22 @@ func (c *defaultPVCHandler) CreatePersistentVolumeClaimsForWorkspaces(ctx contex
 	return errorutils.NewAggregate(errs)
 }
 
+// DeletePersistentVolumeClaimsForWorkspaces deletes the PVCs created by CreatePersistentVolumeClaimsForWorkspaces
+// for the volumeClaimTemplate workspaces in wb. PVCs which no longer exist are ignored.
+func (c *defaultPVCHandler) DeletePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error {
+	var errs []error
+	for _, claim := range getPersistentVolumeClaims(wb, ownerReference, namespace) {
+		err := c.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Delete(ctx, claim.Name, metav1.DeleteOptions{})
+		switch {
+		case err == nil:
+			c.logger.Infof("Deleted PersistentVolumeClaim %s in namespace %s", claim.Name, claim.Namespace)
+		case !apierrors.IsNotFound(err):
+			errs = append(errs, fmt.Errorf("failed to delete PVC %s: %s", claim.Name, err))
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
 func getPersistentVolumeClaims(workspaceBindings []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) map[string]*corev1.PersistentVolumeClaim {
 	claims := make(map[string]*corev1.PersistentVolumeClaim)
 	for _, workspaceBinding := range workspaceBindings {
@@ -159,3 +159,69 @@ func TestCreatePersistentVolumeClaimsForWorkspacesWithoutMetadata(t *testing.T)
 		t.Fatalf("unexpected PVC name on created PVC; exptected: %s got: %s", expectedPVCName, pvc.Name)
 	}
 }
+
+// TestDeletePersistentVolumeClaimsForWorkspaces tests that given workspaces with volumeClaimTemplate,
+// the PVCs created for them are deleted, and that PVCs which are already gone are ignored.
+func TestDeletePersistentVolumeClaimsForWorkspaces(t *testing.T) {
+
+	// given
+
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name: "myws1",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pvc1",
+			},
+		},
+	}, {
+		Name: "bring-my-own-pvc",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: "myown",
+		},
+	}, {
+		Name: "myws2",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "pvc2",
+			},
+		},
+	}}
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	ownerRef := metav1.OwnerReference{UID: types.UID("pipelinerun1")}
+	namespace := "ns"
+	existingPVCName := GetPersistentVolumeClaimName(workspaces[0].VolumeClaimTemplate, workspaces[0], ownerRef)
+	fakekubeclient := fakek8s.NewSimpleClientset(
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: existingPVCName, Namespace: namespace}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "myown", Namespace: namespace}},
+	)
+	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+
+	// when
+
+	if err := pvcHandler.DeletePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, namespace); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// that
+
+	if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, existingPVCName, metav1.GetOptions{}); err == nil {
+		t.Fatalf("expected PVC %s to be deleted", existingPVCName)
+	}
+	if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, "myown", metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected PVC myown not to be deleted, but got: %v", err)
+	}
+
+	deleteActions := 0
+	for _, action := range fakekubeclient.Fake.Actions() {
+		if action.GetVerb() == "delete" {
+			deleteActions++
+		}
+	}
+	expectedNumberOfDeleteActions := 2
+	if deleteActions != expectedNumberOfDeleteActions {
+		t.Fatalf("unexpected number of 'delete' PVC actions; expected: %d got: %d", expectedNumberOfDeleteActions, deleteActions)
+	}
+}
@@ -89,7 +89,9 @@ func (o *Resolver) List(ctx context.Context) ([]remote.ResolvedObject, error) {
 	return contents, nil
 }
 
-// Get retrieves a specific object with the given Kind and name
+// Get retrieves a specific object with the given Kind and name, and the
+// ConfigSource of the object: the repository of the bundle, the digest of the
+// bundle image and the name of the object.
 func (o *Resolver) Get(ctx context.Context, kind, name string) (runtime.Object, *v1beta1.ConfigSource, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, o.timeout)
 	defer cancel()
@@ -98,6 +100,11 @@ func (o *Resolver) Get(ctx context.Context, kind, name string) (runtime.Object,
 		return nil, nil, err
 	}
 
+	h, err := img.Digest()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not get the image digest: %w", err)
+	}
+
 	manifest, err := img.Manifest()
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not parse image manifest: %w", err)
@@ -126,13 +133,23 @@ func (o *Resolver) Get(ctx context.Context, kind, name string) (runtime.Object,
 		lName := l.Annotations[TitleAnnotation]
 
 		if kind == lKind && name == lName {
+			source := &v1beta1.ConfigSource{
+				URI: o.repository(),
+				Digest: map[string]string{
+					h.Algorithm: h.Hex,
+				},
+				EntryPoint: name,
+			}
 			obj, err := readTarLayer(layerMap[l.Digest.String()])
 			if err != nil {
 				// This could still be a raw layer so try to read it as that instead.
 				obj, err := readRawLayer(layers[idx])
-				return obj, nil, err
+				if err != nil {
+					return nil, nil, err
+				}
+				return obj, source, nil
 			}
-			return obj, nil, nil
+			return obj, source, nil
 		}
 	}
 	return nil, nil, fmt.Errorf("could not find object in image with kind: %s and name: %s", kind, name)
@@ -147,6 +164,16 @@ func (o *Resolver) retrieveImage(ctx context.Context) (v1.Image, error) {
 	return ociremote.Image(imgRef, ociremote.WithAuthFromKeychain(o.keychain), ociremote.WithContext(ctx))
 }
 
+// repository returns the name of the repository of the image, without its tag
+// or digest, as the bundle resolver reports it in the ConfigSource.
+func (o *Resolver) repository() string {
+	imgRef, err := imgname.ParseReference(o.imageReference)
+	if err != nil {
+		return o.imageReference
+	}
+	return imgRef.Context().Name()
+}
+
 // checkImageCompliance will perform common checks to ensure the Tekton Bundle is compliant to our spec.
 func (o *Resolver) checkImageCompliance(manifest *v1.Manifest) error {
 	// Check the manifest's layers to ensure there are a maximum of 10.
@@ -212,8 +212,15 @@ func TestOCIResolver(t *testing.T) {
 					t.Error(diff.PrintWantGot(d))
 				}
 
-				if source != nil {
-					t.Errorf("expected source is nil, but received %v", source)
+				repository, digest, _ := strings.Cut(ref, "@")
+				algorithm, hex, _ := strings.Cut(digest, ":")
+				wantSource := &v1beta1.ConfigSource{
+					URI:        repository,
+					Digest:     map[string]string{algorithm: hex},
+					EntryPoint: test.GetObjectName(obj),
+				}
+				if d := cmp.Diff(wantSource, source); d != "" {
+					t.Errorf("source: %s", diff.PrintWantGot(d))
 				}
 			}
 		})
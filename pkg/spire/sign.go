@@ -24,6 +24,7 @@ import (
 	"encoding/base64"
 	"encoding/pem"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
@@ -116,41 +117,110 @@ func getManifest(results []v1beta1.PipelineResourceResult) string {
 
 // AppendStatusInternalAnnotation creates the status annotations which are used by the controller to verify the status hash
 func (sc *spireControllerAPIClient) AppendStatusInternalAnnotation(ctx context.Context, tr *v1beta1.TaskRun) error {
-	err := sc.setupClient(ctx)
+	// Add status hash
+	currentHash, err := hashTaskrunStatusInternal(tr)
 	if err != nil {
 		return err
 	}
 
-	// Add status hash
-	currentHash, err := hashTaskrunStatusInternal(tr)
+	svid, sig, err := sc.signHash(ctx, currentHash)
 	if err != nil {
 		return err
 	}
+	if tr.Status.Annotations == nil {
+		tr.Status.Annotations = map[string]string{}
+	}
+	tr.Status.Annotations[controllerSvidAnnotation] = svid
+	tr.Status.Annotations[TaskRunStatusHashAnnotation] = currentHash
+	tr.Status.Annotations[taskRunStatusHashSigAnnotation] = sig
+	return nil
+}
 
-	// Sign with controller private key
-	xsvid, err := sc.fetchControllerSVID(ctx)
+// AppendPipelineRunResultsAnnotation signs the hash of the PipelineRun results with the controller SVID,
+// and stores the hash, its signature and the controller SVID in the PipelineRun status annotations
+func (sc *spireControllerAPIClient) AppendPipelineRunResultsAnnotation(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	currentHash, err := hashPipelineRunResults(pr)
+	if err != nil {
+		return err
+	}
+
+	svid, sig, err := sc.signHash(ctx, currentHash)
+	if err != nil {
+		return err
+	}
+	if pr.Status.Annotations == nil {
+		pr.Status.Annotations = map[string]string{}
+	}
+	pr.Status.Annotations[controllerSvidAnnotation] = svid
+	pr.Status.Annotations[PipelineRunResultsHashAnnotation] = currentHash
+	pr.Status.Annotations[pipelineRunResultsHashSigAnnotation] = sig
+	return nil
+}
+
+// AppendProvenanceAnnotation signs the hash of the TaskRun provenance with the controller SVID,
+// and stores the hash, its signature and the controller SVID in the TaskRun status annotations
+func (sc *spireControllerAPIClient) AppendProvenanceAnnotation(ctx context.Context, tr *v1beta1.TaskRun) error {
+	currentHash, err := hashTaskRunProvenance(tr)
+	if err != nil {
+		return err
+	}
+
+	svid, sig, err := sc.signHash(ctx, currentHash)
 	if err != nil {
 		return err
 	}
+	if tr.Status.Annotations == nil {
+		tr.Status.Annotations = map[string]string{}
+	}
+	tr.Status.Annotations[controllerSvidAnnotation] = svid
+	tr.Status.Annotations[TaskRunProvenanceHashAnnotation] = currentHash
+	tr.Status.Annotations[taskRunProvenanceHashSigAnnotation] = sig
+	return nil
+}
+
+// AppendAnnotationSignature signs the value of the annotation key with the controller SVID, and stores
+// its signature and the controller SVID in the annotations
+func (sc *spireControllerAPIClient) AppendAnnotationSignature(ctx context.Context, annotations map[string]string, key string) error {
+	value, ok := annotations[key]
+	if !ok {
+		return fmt.Errorf("no annotation found for %s", key)
+	}
 
-	sig, err := signWithKey(xsvid, currentHash)
+	svid, sig, err := sc.signHash(ctx, value)
 	if err != nil {
 		return err
 	}
+	annotations[controllerSvidAnnotation] = svid
+	annotations[key+AnnotationSignatureSuffix] = sig
+	return nil
+}
+
+// signHash signs the hash with the controller private key, and returns the PEM encoded controller SVID
+// and the base64 encoded signature
+func (sc *spireControllerAPIClient) signHash(ctx context.Context, hash string) (string, string, error) {
+	err := sc.setupClient(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	// Sign with controller private key
+	xsvid, err := sc.fetchControllerSVID(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	sig, err := signWithKey(xsvid, hash)
+	if err != nil {
+		return "", "", err
+	}
 
 	if len(xsvid.Certificates) == 0 {
-		return errors.New("returned controller svid does not have certificates")
+		return "", "", errors.New("returned controller svid does not have certificates")
 	}
 	// Store Controller SVID
 	p := pem.EncodeToMemory(&pem.Block{
 		Bytes: xsvid.Certificates[0].Raw,
 		Type:  "CERTIFICATE",
 	})
-	if tr.Status.Annotations == nil {
-		tr.Status.Annotations = map[string]string{}
-	}
-	tr.Status.Annotations[controllerSvidAnnotation] = string(p)
-	tr.Status.Annotations[TaskRunStatusHashAnnotation] = currentHash
-	tr.Status.Annotations[taskRunStatusHashSigAnnotation] = base64.StdEncoding.EncodeToString(sig)
-	return nil
+	return string(p), base64.StdEncoding.EncodeToString(sig), nil
 }
@@ -19,7 +19,7 @@ limitations under the License.
 // for the various TaskRuns that it instantiates. The TaskRun is able to attest to the Spire agent
 // and obtains the valid SVID (SPIFFE Verifiable Identity Document) to sign the TaskRun results.
 // Separately, the pipeline controller SVID is used to sign the TaskRun Status to validate no modification
-// during the TaskRun execution. Each TaskRun result and status is verified and validated once the
+// during the TaskRun execution, as well as the TaskRun provenance and the PipelineRun results. Each TaskRun result and status is verified and validated once the
 // TaskRun execution is completed. Tekton Chains will also validate the results and status before
 // signing and creating attestation for the TaskRun.
 package spire
@@ -41,8 +41,18 @@ const (
 	taskRunStatusHashSigAnnotation = "tekton.dev/status-hash-sig"
 	// controllerSvidAnnotation TaskRun status annotation controller SVID Key
 	controllerSvidAnnotation = "tekton.dev/controller-svid"
-	// VerifiedAnnotation TaskRun status annotation get set when status annotations fails spire checks.
-	// not set if spire checks pass
+	// PipelineRunResultsHashAnnotation PipelineRun status annotation Hash Key of the PipelineRun results
+	PipelineRunResultsHashAnnotation = "tekton.dev/results-hash"
+	// pipelineRunResultsHashSigAnnotation PipelineRun status annotation results hash signature Key
+	pipelineRunResultsHashSigAnnotation = "tekton.dev/results-hash-sig"
+	// TaskRunProvenanceHashAnnotation TaskRun status annotation Hash Key of the TaskRun provenance
+	TaskRunProvenanceHashAnnotation = "tekton.dev/provenance-hash"
+	// taskRunProvenanceHashSigAnnotation TaskRun status annotation provenance hash signature Key
+	taskRunProvenanceHashSigAnnotation = "tekton.dev/provenance-hash-sig"
+	// AnnotationSignatureSuffix is the suffix of the annotations that contain the signature of another annotation
+	AnnotationSignatureSuffix = "-sig"
+	// VerifiedAnnotation TaskRun status annotation set by the controller once the TaskRun is done, to "true"
+	// if its results and status passed the spire checks, and to "false" otherwise
 	VerifiedAnnotation = "tekton.dev/spire-verified"
 	// KeySVID key used by TaskRun SVID
 	KeySVID = "SVID"
@@ -58,11 +68,17 @@ const (
 
 // ControllerAPIClient interface maps to the spire controller API to interact with spire
 type ControllerAPIClient interface {
+	AppendAnnotationSignature(ctx context.Context, annotations map[string]string, key string) error
+	AppendPipelineRunResultsAnnotation(ctx context.Context, pr *v1beta1.PipelineRun) error
+	AppendProvenanceAnnotation(ctx context.Context, tr *v1beta1.TaskRun) error
 	AppendStatusInternalAnnotation(ctx context.Context, tr *v1beta1.TaskRun) error
 	CheckSpireVerifiedFlag(tr *v1beta1.TaskRun) bool
 	Close() error
 	CreateEntries(ctx context.Context, tr *v1beta1.TaskRun, pod *corev1.Pod, ttl time.Duration) error
 	DeleteEntry(ctx context.Context, tr *v1beta1.TaskRun, pod *corev1.Pod) error
+	VerifyAnnotationSignature(ctx context.Context, annotations map[string]string, key string) error
+	VerifyPipelineRunResultsAnnotation(ctx context.Context, pr *v1beta1.PipelineRun) error
+	VerifyProvenanceAnnotation(ctx context.Context, tr *v1beta1.TaskRun) error
 	VerifyStatusInternalAnnotation(ctx context.Context, tr *v1beta1.TaskRun, logger *zap.SugaredLogger) error
 	VerifyTaskRunResults(ctx context.Context, prs []v1beta1.PipelineResourceResult, tr *v1beta1.TaskRun) error
 	SetConfig(c spireconfig.SpireConfig)
@@ -40,6 +40,9 @@ func withFakeControllerClient(ctx context.Context, cfg *rest.Config) context.Con
 	return context.WithValue(ctx, controllerKey{}, &spireControllerAPIClient{})
 }
 
+var _ ControllerAPIClient = (*MockClient)(nil)
+var _ EntrypointerAPIClient = (*MockClient)(nil)
+
 // MockClient is a client used for mocking the this package for unit testing
 // other tekton components that use the spire entrypointer or controller client.
 //
@@ -58,6 +61,9 @@ type MockClient struct {
 	// This only take effect on Verify functions:
 	// - VerifyStatusInternalAnnotationOverride
 	// - VerifyTaskRunResultsOverride
+	// - VerifyPipelineRunResultsAnnotationOverride
+	// - VerifyProvenanceAnnotationOverride
+	// - VerifyAnnotationSignatureOverride
 	VerifyAlwaysReturns *bool
 
 	// VerifyStatusInternalAnnotationOverride contains the function to overwrite a call to VerifyStatusInternalAnnotation
@@ -66,9 +72,27 @@ type MockClient struct {
 	// VerifyTaskRunResultsOverride contains the function to overwrite a call to VerifyTaskRunResults
 	VerifyTaskRunResultsOverride func(ctx context.Context, prs []v1beta1.PipelineResourceResult, tr *v1beta1.TaskRun) error
 
+	// AppendAnnotationSignatureOverride contains the function to overwrite a call to AppendAnnotationSignature
+	AppendAnnotationSignatureOverride func(ctx context.Context, annotations map[string]string, key string) error
+
+	// VerifyAnnotationSignatureOverride contains the function to overwrite a call to VerifyAnnotationSignature
+	VerifyAnnotationSignatureOverride func(ctx context.Context, annotations map[string]string, key string) error
+
 	// AppendStatusInternalAnnotationOverride  contains the function to overwrite a call to AppendStatusInternalAnnotation
 	AppendStatusInternalAnnotationOverride func(ctx context.Context, tr *v1beta1.TaskRun) error
 
+	// AppendPipelineRunResultsAnnotationOverride contains the function to overwrite a call to AppendPipelineRunResultsAnnotation
+	AppendPipelineRunResultsAnnotationOverride func(ctx context.Context, pr *v1beta1.PipelineRun) error
+
+	// AppendProvenanceAnnotationOverride contains the function to overwrite a call to AppendProvenanceAnnotation
+	AppendProvenanceAnnotationOverride func(ctx context.Context, tr *v1beta1.TaskRun) error
+
+	// VerifyPipelineRunResultsAnnotationOverride contains the function to overwrite a call to VerifyPipelineRunResultsAnnotation
+	VerifyPipelineRunResultsAnnotationOverride func(ctx context.Context, pr *v1beta1.PipelineRun) error
+
+	// VerifyProvenanceAnnotationOverride contains the function to overwrite a call to VerifyProvenanceAnnotation
+	VerifyProvenanceAnnotationOverride func(ctx context.Context, tr *v1beta1.TaskRun) error
+
 	// CheckSpireVerifiedFlagOverride contains the function to overwrite a call to CheckSpireVerifiedFlag
 	CheckSpireVerifiedFlagOverride func(tr *v1beta1.TaskRun) bool
 
@@ -93,6 +117,20 @@ func (*MockClient) GetIdentity(tr *v1beta1.TaskRun) string {
 	return fmt.Sprintf("/ns/%v/taskrun/%v", tr.Namespace, tr.Name)
 }
 
+// AppendAnnotationSignature creates the annotations which are used to verify the value of the annotation key
+func (sc *MockClient) AppendAnnotationSignature(ctx context.Context, annotations map[string]string, key string) error {
+	if sc.AppendAnnotationSignatureOverride != nil {
+		return sc.AppendAnnotationSignatureOverride(ctx, annotations, key)
+	}
+	value, ok := annotations[key]
+	if !ok {
+		return errors.Errorf("no annotation found for %s", key)
+	}
+	annotations[controllerSvidAnnotation] = controllerSvid
+	annotations[key+AnnotationSignatureSuffix] = sc.mockSign(value, "controller")
+	return nil
+}
+
 // AppendStatusInternalAnnotation creates the status annotations which are used by the controller to verify the status hash
 func (sc *MockClient) AppendStatusInternalAnnotation(ctx context.Context, tr *v1beta1.TaskRun) error {
 	if sc.AppendStatusInternalAnnotationOverride != nil {
@@ -113,16 +151,51 @@ func (sc *MockClient) AppendStatusInternalAnnotation(ctx context.Context, tr *v1
 	return nil
 }
 
-// CheckSpireVerifiedFlag checks if the verified status annotation is set which would result in spire verification failed
+// AppendPipelineRunResultsAnnotation creates the status annotations which are used to verify the PipelineRun results
+func (sc *MockClient) AppendPipelineRunResultsAnnotation(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	if sc.AppendPipelineRunResultsAnnotationOverride != nil {
+		return sc.AppendPipelineRunResultsAnnotationOverride(ctx, pr)
+	}
+	currentHash, err := hashPipelineRunResults(pr)
+	if err != nil {
+		return err
+	}
+
+	if pr.Status.Annotations == nil {
+		pr.Status.Annotations = map[string]string{}
+	}
+	pr.Status.Annotations[controllerSvidAnnotation] = controllerSvid
+	pr.Status.Annotations[PipelineRunResultsHashAnnotation] = currentHash
+	pr.Status.Annotations[pipelineRunResultsHashSigAnnotation] = sc.mockSign(currentHash, "controller")
+	return nil
+}
+
+// AppendProvenanceAnnotation creates the status annotations which are used to verify the TaskRun provenance
+func (sc *MockClient) AppendProvenanceAnnotation(ctx context.Context, tr *v1beta1.TaskRun) error {
+	if sc.AppendProvenanceAnnotationOverride != nil {
+		return sc.AppendProvenanceAnnotationOverride(ctx, tr)
+	}
+	currentHash, err := hashTaskRunProvenance(tr)
+	if err != nil {
+		return err
+	}
+
+	if tr.Status.Annotations == nil {
+		tr.Status.Annotations = map[string]string{}
+	}
+	tr.Status.Annotations[controllerSvidAnnotation] = controllerSvid
+	tr.Status.Annotations[TaskRunProvenanceHashAnnotation] = currentHash
+	tr.Status.Annotations[taskRunProvenanceHashSigAnnotation] = sc.mockSign(currentHash, "controller")
+	return nil
+}
+
+// CheckSpireVerifiedFlag checks that the verified status annotation was set to "true" by the controller
 func (sc *MockClient) CheckSpireVerifiedFlag(tr *v1beta1.TaskRun) bool {
 	if sc.CheckSpireVerifiedFlagOverride != nil {
 		return sc.CheckSpireVerifiedFlagOverride(tr)
 	}
 
-	if _, ok := tr.Status.Annotations[VerifiedAnnotation]; !ok {
-		return true
-	}
-	return false
+	return tr.Status.Annotations[VerifiedAnnotation] == "true"
 }
 
 // CreateEntries adds entries to the dictionary of entries that mock the SPIRE server datastore
@@ -157,10 +230,6 @@ func (sc *MockClient) VerifyStatusInternalAnnotation(ctx context.Context, tr *v1
 		return errors.New("failed to verify from mock VerifyAlwaysReturns")
 	}
 
-	if !sc.CheckSpireVerifiedFlag(tr) {
-		return errors.New("annotation tekton.dev/not-verified = yes failed spire verification")
-	}
-
 	annotations := tr.Status.Annotations
 
 	// Verify annotations are there
@@ -185,6 +254,59 @@ func (sc *MockClient) VerifyStatusInternalAnnotation(ctx context.Context, tr *v1
 	return nil
 }
 
+// VerifyPipelineRunResultsAnnotation checks that the PipelineRun results annotations are valid by the mocked spire client
+func (sc *MockClient) VerifyPipelineRunResultsAnnotation(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	if sc.VerifyPipelineRunResultsAnnotationOverride != nil {
+		return sc.VerifyPipelineRunResultsAnnotationOverride(ctx, pr)
+	}
+	currentHash, err := hashPipelineRunResults(pr)
+	if err != nil {
+		return err
+	}
+	return sc.mockVerifySignedHash(pr.Status.Annotations, PipelineRunResultsHashAnnotation, pipelineRunResultsHashSigAnnotation, currentHash)
+}
+
+// VerifyProvenanceAnnotation checks that the TaskRun provenance annotations are valid by the mocked spire client
+func (sc *MockClient) VerifyProvenanceAnnotation(ctx context.Context, tr *v1beta1.TaskRun) error {
+	if sc.VerifyProvenanceAnnotationOverride != nil {
+		return sc.VerifyProvenanceAnnotationOverride(ctx, tr)
+	}
+	currentHash, err := hashTaskRunProvenance(tr)
+	if err != nil {
+		return err
+	}
+	return sc.mockVerifySignedHash(tr.Status.Annotations, TaskRunProvenanceHashAnnotation, taskRunProvenanceHashSigAnnotation, currentHash)
+}
+
+// VerifyAnnotationSignature checks that the signature of the annotation key is valid by the mocked spire client
+func (sc *MockClient) VerifyAnnotationSignature(ctx context.Context, annotations map[string]string, key string) error {
+	if sc.VerifyAnnotationSignatureOverride != nil {
+		return sc.VerifyAnnotationSignatureOverride(ctx, annotations, key)
+	}
+	return sc.mockVerifySignedHash(annotations, key, key+AnnotationSignatureSuffix, annotations[key])
+}
+
+func (sc *MockClient) mockVerifySignedHash(annotations map[string]string, hashKey, sigKey, current string) error {
+	if sc.VerifyAlwaysReturns != nil {
+		if *sc.VerifyAlwaysReturns {
+			return nil
+		}
+		return errors.New("failed to verify from mock VerifyAlwaysReturns")
+	}
+
+	if annotations[controllerSvidAnnotation] != controllerSvid {
+		return errors.New("svid annotation missing")
+	}
+	hash, ok := annotations[hashKey]
+	if !ok {
+		return errors.Errorf("no annotation hash found for %s", hashKey)
+	}
+	if !sc.mockVerify(hash, annotations[sigKey], "controller") {
+		return errors.New("signature was not able to be verified")
+	}
+	return checkHashAnnotation(annotations, hashKey, current)
+}
+
 // VerifyTaskRunResults checks that all the TaskRun results are valid by the mocked spire client
 func (sc *MockClient) VerifyTaskRunResults(ctx context.Context, prs []v1beta1.PipelineResourceResult, tr *v1beta1.TaskRun) error {
 	if sc.VerifyTaskRunResultsOverride != nil {
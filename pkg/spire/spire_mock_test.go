@@ -64,17 +64,21 @@ func TestSpireMock_CheckSpireVerifiedFlag(t *testing.T) {
 	trs := testTaskRuns()
 	tr := trs[0]
 
-	if !cc.CheckSpireVerifiedFlag(tr) {
-		t.Fatalf("verified flag should be unset")
+	if cc.CheckSpireVerifiedFlag(tr) {
+		t.Fatalf("TaskRun without the verified flag should not be verified")
 	}
 
 	if tr.Status.Status.Annotations == nil {
 		tr.Status.Status.Annotations = map[string]string{}
 	}
-	tr.Status.Status.Annotations[VerifiedAnnotation] = "no"
-
+	tr.Status.Status.Annotations[VerifiedAnnotation] = "false"
 	if cc.CheckSpireVerifiedFlag(tr) {
-		t.Fatalf("verified flag should be set")
+		t.Fatalf("TaskRun with the verified flag set to false should not be verified")
+	}
+
+	tr.Status.Status.Annotations[VerifiedAnnotation] = "true"
+	if !cc.CheckSpireVerifiedFlag(tr) {
+		t.Fatalf("TaskRun with the verified flag set to true should be verified")
 	}
 }
 
@@ -552,6 +556,69 @@ func TestSpireMock_TaskRunResultsSignTamper(t *testing.T) {
 	}
 }
 
+// Simple pipeline run results and task run provenance sign/verify
+func TestSpireMock_PipelineRunResultsAndProvenanceSign(t *testing.T) {
+	spireMockClient := &MockClient{}
+	var (
+		cc ControllerAPIClient = spireMockClient
+	)
+
+	ctx := context.Background()
+
+	pr := &v1beta1.PipelineRun{
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineResults: []v1beta1.PipelineRunResult{{
+					Name:  "digest",
+					Value: *v1beta1.NewStructuredValues("sha256:abc"),
+				}},
+			},
+		},
+	}
+	if err := cc.AppendPipelineRunResultsAnnotation(ctx, pr); err != nil {
+		t.Fatalf("failed to sign PipelineRun results: %v", err)
+	}
+	if err := cc.VerifyPipelineRunResultsAnnotation(ctx, pr); err != nil {
+		t.Fatalf("failed to verify PipelineRun results: %v", err)
+	}
+	pr.Status.PipelineResults[0].Value = *v1beta1.NewStructuredValues("tampered")
+	if err := cc.VerifyPipelineRunResultsAnnotation(ctx, pr); err == nil {
+		t.Fatal("verification of the tampered PipelineRun results should fail")
+	}
+
+	tr := testTaskRuns()[0]
+	tr.Status.Provenance = &v1beta1.Provenance{
+		ConfigSource: &v1beta1.ConfigSource{URI: "abc.com", Digest: map[string]string{"sha1": "a123"}},
+	}
+	if err := cc.AppendProvenanceAnnotation(ctx, tr); err != nil {
+		t.Fatalf("failed to sign TaskRun provenance: %v", err)
+	}
+	if err := cc.VerifyProvenanceAnnotation(ctx, tr); err != nil {
+		t.Fatalf("failed to verify TaskRun provenance: %v", err)
+	}
+	tr.Status.Provenance.ConfigSource.URI = "tampered.com"
+	if err := cc.VerifyProvenanceAnnotation(ctx, tr); err == nil {
+		t.Fatal("verification of the tampered TaskRun provenance should fail")
+	}
+}
+
+func TestSpireMock_AnnotationSign(t *testing.T) {
+	var cc ControllerAPIClient = &MockClient{}
+	ctx := context.Background()
+
+	annotations := map[string]string{"tekton.dev/statement": `{"subject":[]}`}
+	if err := cc.AppendAnnotationSignature(ctx, annotations, "tekton.dev/statement"); err != nil {
+		t.Fatalf("failed to sign annotation: %v", err)
+	}
+	if err := cc.VerifyAnnotationSignature(ctx, annotations, "tekton.dev/statement"); err != nil {
+		t.Fatalf("failed to verify annotation: %v", err)
+	}
+	annotations["tekton.dev/statement"] = `{"subject":["tampered"]}`
+	if err := cc.VerifyAnnotationSignature(ctx, annotations, "tekton.dev/statement"); err == nil {
+		t.Fatal("verification of the tampered annotation should fail")
+	}
+}
+
 func objectMeta(name, ns string) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
 		Name:        name,
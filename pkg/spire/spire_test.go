@@ -18,6 +18,7 @@ package spire
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"testing"
 
@@ -105,17 +106,21 @@ func TestSpire_CheckSpireVerifiedFlag(t *testing.T) {
 	trs := testTaskRuns()
 	tr := trs[0]
 
-	if !cc.CheckSpireVerifiedFlag(tr) {
-		t.Fatalf("verified flag should be unset")
+	if cc.CheckSpireVerifiedFlag(tr) {
+		t.Fatalf("TaskRun without the verified flag should not be verified")
 	}
 
 	if tr.Status.Status.Annotations == nil {
 		tr.Status.Status.Annotations = map[string]string{}
 	}
-	tr.Status.Status.Annotations[VerifiedAnnotation] = "no"
-
+	tr.Status.Status.Annotations[VerifiedAnnotation] = "false"
 	if cc.CheckSpireVerifiedFlag(tr) {
-		t.Fatalf("verified flag should be set")
+		t.Fatalf("TaskRun with the verified flag set to false should not be verified")
+	}
+
+	tr.Status.Status.Annotations[VerifiedAnnotation] = "true"
+	if !cc.CheckSpireVerifiedFlag(tr) {
+		t.Fatalf("TaskRun with the verified flag set to true should be verified")
 	}
 }
 
@@ -265,13 +270,6 @@ func TestSpire_CheckTamper(t *testing.T) {
 			},
 			verify: false,
 		},
-		{
-			desc: "set temper flag",
-			setAnnotations: map[string]string{
-				VerifiedAnnotation: "no",
-			},
-			verify: false,
-		},
 		{
 			desc:         "tamper status",
 			modifyStatus: true,
@@ -668,6 +666,208 @@ func TestSpire_TaskRunResultsSignTamper(t *testing.T) {
 	}
 }
 
+func TestSpire_PipelineRunResultsSign(t *testing.T) {
+	ctx, _ := ttesting.SetupDefaultContext(t)
+
+	ca := test.NewCA(t, td)
+	wl := fakeworkloadapi.New(t)
+	defer wl.Stop()
+
+	wl.SetX509Bundles(ca.X509Bundle())
+
+	resp := &fakeworkloadapi.X509SVIDResponse{
+		Bundle: ca.X509Bundle(),
+		SVIDs:  makeX509SVIDs(ca, controllerID),
+	}
+	wl.SetX509SVIDResponse(resp)
+
+	cfg := &config.SpireConfig{}
+	cfg.SocketPath = wl.Addr()
+	cfg.TrustDomain = trustDomain
+
+	cc := GetControllerAPIClient(ctx)
+	cc.SetConfig(*cfg)
+	defer cc.Close()
+
+	roots := x509.NewCertPool()
+	for _, c := range ca.X509Authorities() {
+		roots.AddCert(c)
+	}
+	otherRoots := x509.NewCertPool()
+	for _, c := range test.NewCA(t, td).X509Authorities() {
+		otherRoots.AddCert(c)
+	}
+
+	tests := []struct {
+		desc   string
+		modify func(pr *v1beta1.PipelineRun)
+		roots  *x509.CertPool
+		verify bool
+	}{{
+		desc:   "untampered results",
+		roots:  roots,
+		verify: true,
+	}, {
+		desc: "tampered result value",
+		modify: func(pr *v1beta1.PipelineRun) {
+			pr.Status.PipelineResults[0].Value = *v1beta1.NewStructuredValues("tampered")
+		},
+		roots:  roots,
+		verify: false,
+	}, {
+		desc: "added result",
+		modify: func(pr *v1beta1.PipelineRun) {
+			pr.Status.PipelineResults = append(pr.Status.PipelineResults, v1beta1.PipelineRunResult{Name: "extra", Value: *v1beta1.NewStructuredValues("value")})
+		},
+		roots:  roots,
+		verify: false,
+	}, {
+		desc: "tampered signature",
+		modify: func(pr *v1beta1.PipelineRun) {
+			pr.Status.Annotations[pipelineRunResultsHashSigAnnotation] = "change-sig"
+		},
+		roots:  roots,
+		verify: false,
+	}, {
+		desc: "missing svid",
+		modify: func(pr *v1beta1.PipelineRun) {
+			delete(pr.Status.Annotations, controllerSvidAnnotation)
+		},
+		roots:  roots,
+		verify: false,
+	}, {
+		desc:   "untrusted svid",
+		roots:  otherRoots,
+		verify: false,
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{
+				Status: v1beta1.PipelineRunStatus{
+					PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+						PipelineResults: []v1beta1.PipelineRunResult{{
+							Name:  "digest",
+							Value: *v1beta1.NewStructuredValues("sha256:abc"),
+						}},
+					},
+				},
+			}
+			if err := cc.AppendPipelineRunResultsAnnotation(ctx, pr); err != nil {
+				t.Fatalf("failed to sign PipelineRun results: %v", err)
+			}
+			if tt.modify != nil {
+				tt.modify(pr)
+			}
+
+			err := VerifyPipelineRunResults(pr, tt.roots)
+			if (err == nil) != tt.verify {
+				t.Errorf("VerifyPipelineRunResults() got err %v, want verification %t", err, tt.verify)
+			}
+			if tt.roots == roots {
+				err := cc.VerifyPipelineRunResultsAnnotation(ctx, pr)
+				if (err == nil) != tt.verify {
+					t.Errorf("VerifyPipelineRunResultsAnnotation() got err %v, want verification %t", err, tt.verify)
+				}
+			}
+		})
+	}
+}
+
+func TestSpire_ProvenanceSign(t *testing.T) {
+	ctx, _ := ttesting.SetupDefaultContext(t)
+
+	ca := test.NewCA(t, td)
+	wl := fakeworkloadapi.New(t)
+	defer wl.Stop()
+
+	wl.SetX509Bundles(ca.X509Bundle())
+
+	resp := &fakeworkloadapi.X509SVIDResponse{
+		Bundle: ca.X509Bundle(),
+		SVIDs:  makeX509SVIDs(ca, controllerID),
+	}
+	wl.SetX509SVIDResponse(resp)
+
+	cfg := &config.SpireConfig{}
+	cfg.SocketPath = wl.Addr()
+	cfg.TrustDomain = trustDomain
+
+	cc := GetControllerAPIClient(ctx)
+	cc.SetConfig(*cfg)
+	defer cc.Close()
+
+	tr := testTaskRuns()[0]
+	tr.Status.Provenance = &v1beta1.Provenance{
+		ConfigSource: &v1beta1.ConfigSource{
+			URI:        "git+https://github.com/tektoncd/catalog.git",
+			Digest:     map[string]string{"sha1": "a123"},
+			EntryPoint: "task/git-clone/0.7/git-clone.yaml",
+		},
+	}
+	if err := cc.AppendProvenanceAnnotation(ctx, tr); err != nil {
+		t.Fatalf("failed to sign TaskRun provenance: %v", err)
+	}
+	if err := cc.VerifyProvenanceAnnotation(ctx, tr); err != nil {
+		t.Fatalf("failed to verify TaskRun provenance: %v", err)
+	}
+
+	// the status changes after the provenance is signed, e.g. with the steps
+	// and results, without affecting its signature.
+	tr.Status.TaskRunResults = []v1beta1.TaskRunResult{{Name: "result", Value: *v1beta1.NewStructuredValues("value")}}
+	if err := cc.VerifyProvenanceAnnotation(ctx, tr); err != nil {
+		t.Fatalf("failed to verify TaskRun provenance after a status update: %v", err)
+	}
+
+	tr.Status.Provenance.ConfigSource.Digest["sha1"] = "tampered"
+	if err := cc.VerifyProvenanceAnnotation(ctx, tr); err == nil {
+		t.Fatal("verification of the tampered TaskRun provenance should fail")
+	}
+}
+
+func TestSpire_AnnotationSign(t *testing.T) {
+	ctx, _ := ttesting.SetupDefaultContext(t)
+
+	ca := test.NewCA(t, td)
+	wl := fakeworkloadapi.New(t)
+	defer wl.Stop()
+
+	wl.SetX509Bundles(ca.X509Bundle())
+
+	resp := &fakeworkloadapi.X509SVIDResponse{
+		Bundle: ca.X509Bundle(),
+		SVIDs:  makeX509SVIDs(ca, controllerID),
+	}
+	wl.SetX509SVIDResponse(resp)
+
+	cfg := &config.SpireConfig{}
+	cfg.SocketPath = wl.Addr()
+	cfg.TrustDomain = trustDomain
+
+	cc := GetControllerAPIClient(ctx)
+	cc.SetConfig(*cfg)
+	defer cc.Close()
+
+	annotations := map[string]string{"tekton.dev/statement": `{"subject":[]}`}
+	if err := cc.AppendAnnotationSignature(ctx, annotations, "tekton.dev/missing"); err == nil {
+		t.Fatal("signing a missing annotation should fail")
+	}
+	if err := cc.AppendAnnotationSignature(ctx, annotations, "tekton.dev/statement"); err != nil {
+		t.Fatalf("failed to sign annotation: %v", err)
+	}
+	if err := cc.VerifyAnnotationSignature(ctx, annotations, "tekton.dev/statement"); err != nil {
+		t.Fatalf("failed to verify annotation: %v", err)
+	}
+	if err := VerifyAnnotation(annotations, "tekton.dev/statement", x509.NewCertPool()); err == nil {
+		t.Fatal("verification of the annotation against untrusted roots should fail")
+	}
+
+	annotations["tekton.dev/statement"] = `{"subject":["tampered"]}`
+	if err := cc.VerifyAnnotationSignature(ctx, annotations, "tekton.dev/statement"); err == nil {
+		t.Fatal("verification of the tampered annotation should fail")
+	}
+}
+
 func makeX509SVIDs(ca *test.CA, ids ...spiffeid.ID) []*x509svid.SVID {
 	svids := []*x509svid.SVID{}
 	for _, id := range ids {
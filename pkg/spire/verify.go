@@ -96,10 +96,6 @@ func (sc *spireControllerAPIClient) VerifyStatusInternalAnnotation(ctx context.C
 		return err
 	}
 
-	if !sc.CheckSpireVerifiedFlag(tr) {
-		return errors.New("annotation tekton.dev/not-verified = yes failed spire verification")
-	}
-
 	annotations := tr.Status.Annotations
 
 	// get trust bundle from spire server
@@ -142,12 +138,112 @@ func (sc *spireControllerAPIClient) VerifyStatusInternalAnnotation(ctx context.C
 	return nil
 }
 
-// CheckSpireVerifiedFlag checks if the verified status annotation is set which would result in spire verification failed
-func (sc *spireControllerAPIClient) CheckSpireVerifiedFlag(tr *v1beta1.TaskRun) bool {
-	if _, ok := tr.Status.Annotations[VerifiedAnnotation]; !ok {
-		return true
+// VerifyPipelineRunResultsAnnotation verifies the signature of the PipelineRun results against the SPIRE trust bundle
+func (sc *spireControllerAPIClient) VerifyPipelineRunResultsAnnotation(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	err := sc.setupClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	trust, err := getTrustBundle(ctx, sc.workloadAPI)
+	if err != nil {
+		return err
+	}
+	return VerifyPipelineRunResults(pr, trust)
+}
+
+// VerifyProvenanceAnnotation verifies the signature of the TaskRun provenance against the SPIRE trust bundle
+func (sc *spireControllerAPIClient) VerifyProvenanceAnnotation(ctx context.Context, tr *v1beta1.TaskRun) error {
+	err := sc.setupClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	trust, err := getTrustBundle(ctx, sc.workloadAPI)
+	if err != nil {
+		return err
+	}
+	return VerifyTaskRunProvenance(tr, trust)
+}
+
+// VerifyAnnotationSignature verifies the signature of the annotation key against the SPIRE trust bundle
+func (sc *spireControllerAPIClient) VerifyAnnotationSignature(ctx context.Context, annotations map[string]string, key string) error {
+	err := sc.setupClient(ctx)
+	if err != nil {
+		return err
 	}
-	return false
+
+	trust, err := getTrustBundle(ctx, sc.workloadAPI)
+	if err != nil {
+		return err
+	}
+	return VerifyAnnotation(annotations, key, trust)
+}
+
+// VerifyAnnotation verifies that the value of the annotation key was signed by a controller SVID
+// trusted by the roots.
+func VerifyAnnotation(annotations map[string]string, key string, roots *x509.CertPool) error {
+	return verifySignedHash(annotations, key, key+AnnotationSignatureSuffix, annotations[key], roots)
+}
+
+// VerifyPipelineRunResults verifies that the PipelineRun results were signed by a controller SVID
+// trusted by the roots, and that they have not been modified since. It lets consumers of PipelineRuns,
+// e.g. Tekton Chains, verify the results with the trust bundle of their SPIRE agent.
+func VerifyPipelineRunResults(pr *v1beta1.PipelineRun, roots *x509.CertPool) error {
+	current, err := hashPipelineRunResults(pr)
+	if err != nil {
+		return err
+	}
+	return verifySignedHash(pr.Status.Annotations, PipelineRunResultsHashAnnotation, pipelineRunResultsHashSigAnnotation, current, roots)
+}
+
+// VerifyTaskRunProvenance verifies that the TaskRun provenance was signed by a controller SVID
+// trusted by the roots, and that it has not been modified since.
+func VerifyTaskRunProvenance(tr *v1beta1.TaskRun, roots *x509.CertPool) error {
+	current, err := hashTaskRunProvenance(tr)
+	if err != nil {
+		return err
+	}
+	return verifySignedHash(tr.Status.Annotations, TaskRunProvenanceHashAnnotation, taskRunProvenanceHashSigAnnotation, current, roots)
+}
+
+// verifySignedHash verifies the controller SVID of the annotations against the roots, the signature of
+// the hash stored in the hashKey annotation, and that the stored hash matches the current one
+func verifySignedHash(annotations map[string]string, hashKey, sigKey, current string, roots *x509.CertPool) error {
+	svid, ok := annotations[controllerSvidAnnotation]
+	if !ok {
+		return errors.New("No SVID found")
+	}
+	block, _ := pem.Decode([]byte(svid))
+	if block == nil {
+		return errors.New("invalid SVID: no PEM block found")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("invalid SVID: %w", err)
+	}
+	if err := verifyCertificateTrust(cert, roots); err != nil {
+		return err
+	}
+
+	signature, ok := annotations[sigKey]
+	if !ok {
+		return fmt.Errorf("no signature found for %s", sigKey)
+	}
+	hash, ok := annotations[hashKey]
+	if !ok {
+		return fmt.Errorf("no annotation hash found for %s", hashKey)
+	}
+	if err := verifySignature(cert.PublicKey, signature, hash); err != nil {
+		return err
+	}
+	return checkHashAnnotation(annotations, hashKey, current)
+}
+
+// CheckSpireVerifiedFlag checks that the verified status annotation was set to "true" by the controller,
+// once the results and the status of the TaskRun passed the spire checks
+func (sc *spireControllerAPIClient) CheckSpireVerifiedFlag(tr *v1beta1.TaskRun) bool {
+	return tr.Status.Annotations[VerifiedAnnotation] == "true"
 }
 
 func hashTaskrunStatusInternal(tr *v1beta1.TaskRun) (string, error) {
@@ -158,6 +254,22 @@ func hashTaskrunStatusInternal(tr *v1beta1.TaskRun) (string, error) {
 	return fmt.Sprintf("%x", sha256.Sum256(s)), nil
 }
 
+func hashPipelineRunResults(pr *v1beta1.PipelineRun) (string, error) {
+	s, err := json.Marshal(pr.Status.PipelineResults)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(s)), nil
+}
+
+func hashTaskRunProvenance(tr *v1beta1.TaskRun) (string, error) {
+	s, err := json.Marshal(tr.Status.Provenance)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(s)), nil
+}
+
 // CheckStatusInternalAnnotation ensures that the internal status annotation hash and current status hash match
 func CheckStatusInternalAnnotation(tr *v1beta1.TaskRun) error {
 	// get stored hash of status
@@ -178,6 +290,35 @@ func CheckStatusInternalAnnotation(tr *v1beta1.TaskRun) error {
 	return nil
 }
 
+// CheckPipelineRunResultsAnnotation ensures that the PipelineRun results annotation hash and current results hash match
+func CheckPipelineRunResultsAnnotation(pr *v1beta1.PipelineRun) error {
+	current, err := hashPipelineRunResults(pr)
+	if err != nil {
+		return err
+	}
+	return checkHashAnnotation(pr.Status.Annotations, PipelineRunResultsHashAnnotation, current)
+}
+
+// CheckProvenanceAnnotation ensures that the TaskRun provenance annotation hash and current provenance hash match
+func CheckProvenanceAnnotation(tr *v1beta1.TaskRun) error {
+	current, err := hashTaskRunProvenance(tr)
+	if err != nil {
+		return err
+	}
+	return checkHashAnnotation(tr.Status.Annotations, TaskRunProvenanceHashAnnotation, current)
+}
+
+func checkHashAnnotation(annotations map[string]string, hashKey, current string) error {
+	hash, ok := annotations[hashKey]
+	if !ok {
+		return fmt.Errorf("no annotation hash found for %s", hashKey)
+	}
+	if hash != current {
+		return fmt.Errorf("current hash and stored annotation hash %s does not match! Annotation Hash: %s, Current Hash: %s", hashKey, hash, current)
+	}
+	return nil
+}
+
 func getSVID(resultMap map[string]v1beta1.PipelineResourceResult) (*x509.Certificate, error) {
 	svid, ok := resultMap[KeySVID]
 	if !ok {
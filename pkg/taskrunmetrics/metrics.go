@@ -48,6 +48,7 @@ var (
 	namespaceTag   = tag.MustNewKey("namespace")
 	statusTag      = tag.MustNewKey("status")
 	podTag         = tag.MustNewKey("pod")
+	stepTag        = tag.MustNewKey("step")
 
 	trDurationView      *view.View
 	prTRDurationView    *view.View
@@ -55,6 +56,8 @@ var (
 	runningTRsCountView *view.View
 	podLatencyView      *view.View
 	cloudEventsView     *view.View
+	stepMemoryView      *view.View
+	stepCPUView         *view.View
 
 	trDuration = stats.Float64(
 		"taskrun_duration_seconds",
@@ -81,6 +84,14 @@ var (
 	cloudEvents = stats.Int64("cloudevent_count",
 		"number of cloud events sent including retries",
 		stats.UnitDimensionless)
+
+	stepPeakMemory = stats.Int64("taskrun_step_peak_memory_bytes",
+		"The peak memory usage of the taskrun's steps",
+		stats.UnitBytes)
+
+	stepCPUTime = stats.Float64("taskrun_step_cpu_seconds",
+		"The CPU time used by the taskrun's steps in seconds",
+		stats.UnitDimensionless)
 )
 
 // Recorder is used to actually record TaskRun metrics
@@ -214,6 +225,18 @@ func viewRegister(cfg *config.Metrics) error {
 		Aggregation: view.Sum(),
 		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, append(trunTag, prunTag...)...),
 	}
+	stepMemoryView = &view.View{
+		Description: stepPeakMemory.Description(),
+		Measure:     stepPeakMemory,
+		Aggregation: view.LastValue(),
+		TagKeys:     append([]tag.Key{namespaceTag, stepTag}, trunTag...),
+	}
+	stepCPUView = &view.View{
+		Description: stepCPUTime.Description(),
+		Measure:     stepCPUTime,
+		Aggregation: view.LastValue(),
+		TagKeys:     append([]tag.Key{namespaceTag, stepTag}, trunTag...),
+	}
 	return view.Register(
 		trDurationView,
 		prTRDurationView,
@@ -221,6 +244,8 @@ func viewRegister(cfg *config.Metrics) error {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		stepMemoryView,
+		stepCPUView,
 	)
 }
 
@@ -232,6 +257,8 @@ func viewUnregister() {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		stepMemoryView,
+		stepCPUView,
 	)
 }
 
@@ -478,6 +505,43 @@ func (r *Recorder) CloudEvents(ctx context.Context, tr *v1beta1.TaskRun) error {
 	return nil
 }
 
+// StepResourceUsage logs the peak memory usage and the CPU time of the steps of a TaskRun,
+// as reported in their StepState. Steps with no resource usage are skipped.
+func (r *Recorder) StepResourceUsage(ctx context.Context, tr *v1beta1.TaskRun) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	taskName := "anonymous"
+	if tr.Spec.TaskRef != nil {
+		taskName = tr.Spec.TaskRef.Name
+	}
+
+	for _, step := range tr.Status.Steps {
+		if step.ResourceUsage == nil {
+			continue
+		}
+		ctx, err := tag.New(
+			ctx,
+			append([]tag.Mutator{tag.Insert(namespaceTag, tr.Namespace),
+				tag.Insert(stepTag, step.Name)},
+				r.insertTaskTag(taskName, tr.Name)...)...)
+		if err != nil {
+			return err
+		}
+		if step.ResourceUsage.PeakMemory != nil {
+			metrics.Record(ctx, stepPeakMemory.M(step.ResourceUsage.PeakMemory.Value()))
+		}
+		if step.ResourceUsage.CPUTime != nil {
+			metrics.Record(ctx, stepCPUTime.M(step.ResourceUsage.CPUTime.Seconds()))
+		}
+	}
+	return nil
+}
+
 // IsPartOfPipeline return true if TaskRun is a part of a Pipeline.
 // It also return the name of Pipeline and PipelineRun
 func IsPartOfPipeline(tr *v1beta1.TaskRun) (bool, string, string) {
@@ -31,6 +31,7 @@ import (
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -493,6 +494,46 @@ func TestRecordPodLatency(t *testing.T) {
 
 }
 
+func TestRecordStepResourceUsage(t *testing.T) {
+	unregisterMetrics()
+
+	peakMemory := resource.MustParse("256Mi")
+	taskRun := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun", Namespace: "foo"},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "task-1"},
+		},
+		Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			Steps: []v1beta1.StepState{{
+				Name: "build",
+				ResourceUsage: &v1beta1.StepResourceUsage{
+					PeakMemory: &peakMemory,
+					CPUTime:    &metav1.Duration{Duration: 1500 * time.Millisecond},
+				},
+			}, {
+				Name: "no-usage",
+			}},
+		}},
+	}
+
+	ctx := getConfigContext()
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+	if err := metrics.StepResourceUsage(ctx, taskRun); err != nil {
+		t.Errorf("StepResourceUsage: %v", err)
+	}
+	tags := map[string]string{
+		"step":      "build",
+		"task":      "task-1",
+		"taskrun":   "test-taskrun",
+		"namespace": "foo",
+	}
+	metricstest.CheckLastValueData(t, "taskrun_step_peak_memory_bytes", tags, 256*1024*1024)
+	metricstest.CheckLastValueData(t, "taskrun_step_cpu_seconds", tags, 1.5)
+}
+
 func TestTaskRunIsOfPipelinerun(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -690,7 +731,7 @@ func TestRecordCloudEvents(t *testing.T) {
 }
 
 func unregisterMetrics() {
-	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count")
+	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count", "taskrun_step_peak_memory_bytes", "taskrun_step_cpu_seconds")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
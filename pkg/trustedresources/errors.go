@@ -17,5 +17,9 @@ package trustedresources
 
 import "errors"
 
-// ErrorResourceVerificationFailed is returned when trusted resources fails verification.
-var ErrorResourceVerificationFailed = errors.New("resource verification failed")
+var (
+	// ErrorResourceVerificationFailed is returned when trusted resources fails verification.
+	ErrorResourceVerificationFailed = errors.New("resource verification failed")
+	// ErrNoMatchedPolicies is returned when no VerificationPolicy matches the source of a resource.
+	ErrNoMatchedPolicies = errors.New("no matching policies")
+)
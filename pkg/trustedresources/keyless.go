@@ -0,0 +1,326 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+)
+
+const (
+	// CertificateAnnotation is the key of the base64 encoded PEM certificate
+	// chain of a keyless signature in annotation map, signing certificate first.
+	CertificateAnnotation = "tekton.dev/certificate"
+	// TransparencyLogEntryAnnotation is the key of the base64 encoded JSON
+	// TransparencyLogEntry of a keyless signature in annotation map.
+	TransparencyLogEntryAnnotation = "tekton.dev/tlog-entry"
+)
+
+var (
+	// oidIssuerV1 and oidIssuerV2 are the extensions of Fulcio certificates
+	// recording the OIDC issuer of the signer, as a raw string and as a DER
+	// encoded UTF8String respectively.
+	oidIssuerV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// TransparencyLogEntry is the proof that a keyless signature was included in
+// a transparency log.
+type TransparencyLogEntry struct {
+	// LogIndex is the index of the entry in the log.
+	LogIndex int64 `json:"logIndex"`
+	// IntegratedTime is the unix time the entry was integrated in the log,
+	// at which the signing certificate must be valid.
+	IntegratedTime int64 `json:"integratedTime"`
+	// TreeSize is the size of the log tree the inclusion proof is for.
+	TreeSize int64 `json:"treeSize"`
+	// RootHash is the root hash of the log tree of size TreeSize.
+	RootHash []byte `json:"rootHash"`
+	// Hashes is the RFC 6962 inclusion proof of the entry, from the leaf up.
+	Hashes [][]byte `json:"hashes"`
+	// SignedEntryTimestamp is the signature of the log over the
+	// SignedEntryTimestampPayload of the entry.
+	SignedEntryTimestamp []byte `json:"signedEntryTimestamp"`
+}
+
+// TransparencyLogEntryBody is the content of the log entry of a keyless
+// signature, hashed as a leaf of the log tree.
+type TransparencyLogEntryBody struct {
+	// Digest is the SHA256 digest of the signed resource.
+	Digest []byte `json:"digest"`
+	// Signature is the signature of the resource.
+	Signature []byte `json:"signature"`
+	// Certificate is the PEM encoded signing certificate.
+	Certificate []byte `json:"certificate"`
+}
+
+// SignedEntryTimestampPayload is the payload the log signs to attest that an
+// entry was integrated at a given time in a tree with the given root hash.
+type SignedEntryTimestampPayload struct {
+	LogIndex       int64  `json:"logIndex"`
+	IntegratedTime int64  `json:"integratedTime"`
+	TreeSize       int64  `json:"treeSize"`
+	RootHash       []byte `json:"rootHash"`
+}
+
+// verifyKeyless verifies the keyless signature of the resource: the signing
+// certificate stored in the annotations must chain up to the CA roots, be
+// issued to one of the identities and, if a transparency log is configured,
+// the signature must be included in it. The signature is then verified with
+// the public key of the signing certificate.
+func verifyKeyless(resource interface{}, sig []byte, annotations map[string]string, keyless *v1alpha1.KeylessRef) error {
+	encodedChain, ok := annotations[CertificateAnnotation]
+	if !ok {
+		return fmt.Errorf("certificate is missing")
+	}
+	rawChain, err := base64.StdEncoding.DecodeString(encodedChain)
+	if err != nil {
+		return fmt.Errorf("decoding certificate: %w", err)
+	}
+	chain, err := cryptoutils.UnmarshalCertificatesFromPEM(rawChain)
+	if err != nil {
+		return fmt.Errorf("parsing certificate: %w", err)
+	}
+	if len(chain) == 0 {
+		return fmt.Errorf("certificate is missing")
+	}
+	cert := chain[0]
+
+	digest, err := digestOf(resource)
+	if err != nil {
+		return err
+	}
+
+	// Short-lived certificates have usually expired by the time the resource
+	// is verified, so they are validated at the time the signature was
+	// integrated in the transparency log when there is one.
+	verifiedAt := time.Now()
+	if keyless.TLog != nil {
+		leafCert, err := cryptoutils.MarshalCertificateToPEM(cert)
+		if err != nil {
+			return err
+		}
+		body := TransparencyLogEntryBody{Digest: digest, Signature: sig, Certificate: leafCert}
+		entry, err := verifyTransparencyLogEntry(annotations, body, keyless.TLog)
+		if err != nil {
+			return err
+		}
+		verifiedAt = time.Unix(entry.IntegratedTime, 0)
+	}
+
+	roots := x509.NewCertPool()
+	rootCerts, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(keyless.CARoots))
+	if err != nil {
+		return fmt.Errorf("parsing CA roots: %w", err)
+	}
+	for _, c := range rootCerts {
+		roots.AddCert(c)
+	}
+	intermediates := x509.NewCertPool()
+	for _, c := range chain[1:] {
+		intermediates.AddCert(c)
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   verifiedAt,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("validating certificate: %w", err)
+	}
+	if err := matchIdentities(cert, keyless.Identities); err != nil {
+		return err
+	}
+
+	verifier, err := signature.LoadVerifier(cert.PublicKey, crypto.SHA256)
+	if err != nil {
+		return err
+	}
+	return verifier.VerifySignature(bytes.NewReader(sig), bytes.NewReader(digest))
+}
+
+// matchIdentities checks that the subject alternative names and the issuer of
+// the certificate match one of the identities.
+func matchIdentities(cert *x509.Certificate, identities []v1alpha1.Identity) error {
+	var subjects []string
+	subjects = append(subjects, cert.EmailAddresses...)
+	for _, u := range cert.URIs {
+		subjects = append(subjects, u.String())
+	}
+	issuer, err := issuerOf(cert)
+	if err != nil {
+		return err
+	}
+	for _, id := range identities {
+		subjectRe, err := regexp.Compile("^(?:" + id.Subject + ")$")
+		if err != nil {
+			return fmt.Errorf("invalid subject %q: %w", id.Subject, err)
+		}
+		issuerRe, err := regexp.Compile("^(?:" + id.Issuer + ")$")
+		if err != nil {
+			return fmt.Errorf("invalid issuer %q: %w", id.Issuer, err)
+		}
+		if id.Issuer != "" && !issuerRe.MatchString(issuer) {
+			continue
+		}
+		for _, s := range subjects {
+			if subjectRe.MatchString(s) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("certificate identity %v issued by %q matches none of the identities", subjects, issuer)
+}
+
+// issuerOf returns the OIDC issuer recorded in the extensions of a Fulcio
+// certificate, if any.
+func issuerOf(cert *x509.Certificate) (string, error) {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV2) {
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err != nil {
+				return "", fmt.Errorf("parsing certificate issuer: %w", err)
+			}
+			return issuer, nil
+		}
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV1) {
+			return string(ext.Value), nil
+		}
+	}
+	return "", nil
+}
+
+// verifyTransparencyLogEntry checks that the log entry stored in the
+// annotations is signed by the transparency log and that it proves the
+// inclusion of the body in the log.
+func verifyTransparencyLogEntry(annotations map[string]string, body TransparencyLogEntryBody, tlog *v1alpha1.TLogRef) (*TransparencyLogEntry, error) {
+	encoded, ok := annotations[TransparencyLogEntryAnnotation]
+	if !ok {
+		return nil, fmt.Errorf("transparency log entry is missing")
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decoding transparency log entry: %w", err)
+	}
+	entry := &TransparencyLogEntry{}
+	if err := json.Unmarshal(raw, entry); err != nil {
+		return nil, fmt.Errorf("parsing transparency log entry: %w", err)
+	}
+
+	verifier, err := verifierForPEM([]byte(tlog.PublicKey), crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("transparency log public key: %w", err)
+	}
+	payload, err := json.Marshal(SignedEntryTimestampPayload{
+		LogIndex:       entry.LogIndex,
+		IntegratedTime: entry.IntegratedTime,
+		TreeSize:       entry.TreeSize,
+		RootHash:       entry.RootHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := verifier.VerifySignature(bytes.NewReader(entry.SignedEntryTimestamp), bytes.NewReader(payload)); err != nil {
+		return nil, fmt.Errorf("verifying signed entry timestamp: %w", err)
+	}
+
+	leaf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyInclusion(entry.LogIndex, entry.TreeSize, leafHash(leaf), entry.Hashes, entry.RootHash); err != nil {
+		return nil, fmt.Errorf("verifying inclusion in the transparency log: %w", err)
+	}
+	return entry, nil
+}
+
+// leafHash returns the RFC 6962 hash of a leaf of a Merkle tree.
+func leafHash(leaf []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0})
+	h.Write(leaf)
+	return h.Sum(nil)
+}
+
+// nodeHash returns the RFC 6962 hash of an interior node of a Merkle tree.
+func nodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// verifyInclusion verifies the RFC 6962 inclusion proof of the leaf at index
+// in the tree of the given size, as described in RFC 9162 section 2.1.3.2.
+func verifyInclusion(index, size int64, leafHash []byte, proof [][]byte, rootHash []byte) error {
+	if index < 0 || index >= size {
+		return fmt.Errorf("index %d is out of the tree of size %d", index, size)
+	}
+	fn, sn := index, size-1
+	r := leafHash
+	for _, p := range proof {
+		if sn == 0 {
+			return errors.New("inclusion proof is too long")
+		}
+		if fn%2 == 1 || fn == sn {
+			r = nodeHash(p, r)
+			for fn%2 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = nodeHash(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 {
+		return errors.New("inclusion proof is too short")
+	}
+	if !bytes.Equal(r, rootHash) {
+		return errors.New("computed root hash does not match the root hash of the tree")
+	}
+	return nil
+}
+
+// digestOf returns the SHA256 digest of the json marshalled object, which is
+// what is signed.
+func digestOf(obj interface{}) ([]byte, error) {
+	ts, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	h.Write(ts)
+	return h.Sum(nil), nil
+}
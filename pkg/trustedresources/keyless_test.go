@@ -0,0 +1,343 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	test "github.com/tektoncd/pipeline/test"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	signerEmail  = "release@tekton.dev"
+	signerIssuer = "https://accounts.google.com"
+	source       = "https://github.com/tektoncd/catalog.git"
+)
+
+func TestVerifyTask_Keyless(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	ctx = test.SetupTrustedResourceConfig(ctx, "", config.EnforceResourceVerificationMode)
+
+	ca, err := test.NewKeylessCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCA, err := test.NewKeylessCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tlog, err := test.NewTransparencyLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherTLog, err := test.NewTransparencyLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	signedAt := now.Add(-time.Hour)
+	// Certificates valid for ten minutes, as issued by Fulcio.
+	signer, chain, err := ca.IssueCertificate(signerEmail, signerIssuer, now.Add(-5*time.Minute), now.Add(5*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiredSigner, expiredChain, err := ca.IssueCertificate(signerEmail, signerIssuer, signedAt.Add(-5*time.Minute), signedAt.Add(5*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uriSigner, uriChain, err := ca.IssueCertificate("https://github.com/tektoncd/catalog/.github/workflows/release.yaml@refs/heads/main", "https://token.actions.githubusercontent.com", now.Add(-5*time.Minute), now.Add(5*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	untrustedSigner, untrustedChain, err := otherCA.IssueCertificate(signerEmail, signerIssuer, now.Add(-5*time.Minute), now.Add(5*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unsignedTask := test.GetUnsignedTask("test-task")
+	signedTask, err := test.GetKeylessSignedTask(unsignedTask, signer, chain, nil, now, "signed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	loggedTask, err := test.GetKeylessSignedTask(unsignedTask, signer, chain, tlog, now, "signed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiredTask, err := test.GetKeylessSignedTask(unsignedTask, expiredSigner, expiredChain, nil, signedAt, "signed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiredLoggedTask, err := test.GetKeylessSignedTask(unsignedTask, expiredSigner, expiredChain, tlog, signedAt, "signed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lateLoggedTask, err := test.GetKeylessSignedTask(unsignedTask, expiredSigner, expiredChain, tlog, now, "signed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherLoggedTask, err := test.GetKeylessSignedTask(unsignedTask, signer, chain, otherTLog, now, "signed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uriSignedTask, err := test.GetKeylessSignedTask(unsignedTask, uriSigner, uriChain, nil, now, "signed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	untrustedTask, err := test.GetKeylessSignedTask(unsignedTask, untrustedSigner, untrustedChain, nil, now, "signed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tamperedTask := signedTask.DeepCopy()
+	tamperedTask.Annotations["foo"] = "baz"
+	// The entry of another signature in the log doesn't prove the inclusion
+	// of this one.
+	swappedEntryTask := loggedTask.DeepCopy()
+	swappedEntryTask.Annotations[TransparencyLogEntryAnnotation] = expiredLoggedTask.Annotations[TransparencyLogEntryAnnotation]
+	noCertificateTask := signedTask.DeepCopy()
+	delete(noCertificateTask.Annotations, CertificateAnnotation)
+
+	identities := []v1alpha1.Identity{{Subject: signerEmail, Issuer: signerIssuer}}
+	keyless := v1alpha1.KeylessRef{CARoots: string(ca.RootPEM), Identities: identities}
+	keylessWithTLog := v1alpha1.KeylessRef{CARoots: string(ca.RootPEM), Identities: identities, TLog: &v1alpha1.TLogRef{PublicKey: string(tlog.PublicKeyPEM)}}
+
+	tcs := []struct {
+		name    string
+		task    *v1beta1.Task
+		keyless v1alpha1.KeylessRef
+		wantErr string
+	}{{
+		name:    "certificate issued to the identity",
+		task:    signedTask,
+		keyless: keyless,
+	}, {
+		name:    "certificate issued to a URI matching the identity",
+		task:    uriSignedTask,
+		keyless: v1alpha1.KeylessRef{CARoots: string(ca.RootPEM), Identities: []v1alpha1.Identity{{Subject: "https://github.com/tektoncd/.*", Issuer: "https://token.actions.githubusercontent.com"}}},
+	}, {
+		name:    "certificate issued to another identity",
+		task:    uriSignedTask,
+		keyless: keyless,
+		wantErr: "matches none of the identities",
+	}, {
+		name:    "certificate issued by another issuer",
+		task:    signedTask,
+		keyless: v1alpha1.KeylessRef{CARoots: string(ca.RootPEM), Identities: []v1alpha1.Identity{{Subject: signerEmail, Issuer: "https://github.com/login/oauth"}}},
+		wantErr: "matches none of the identities",
+	}, {
+		name:    "subject must match entirely",
+		task:    signedTask,
+		keyless: v1alpha1.KeylessRef{CARoots: string(ca.RootPEM), Identities: []v1alpha1.Identity{{Subject: "release@tekton"}}},
+		wantErr: "matches none of the identities",
+	}, {
+		name:    "certificate issued by an untrusted CA",
+		task:    untrustedTask,
+		keyless: keyless,
+		wantErr: "validating certificate",
+	}, {
+		name:    "expired certificate without transparency log",
+		task:    expiredTask,
+		keyless: keyless,
+		wantErr: "validating certificate",
+	}, {
+		name:    "expired certificate integrated in the transparency log while valid",
+		task:    expiredLoggedTask,
+		keyless: keylessWithTLog,
+	}, {
+		name:    "expired certificate integrated in the transparency log once expired",
+		task:    lateLoggedTask,
+		keyless: keylessWithTLog,
+		wantErr: "validating certificate",
+	}, {
+		name:    "signature included in the transparency log",
+		task:    loggedTask,
+		keyless: keylessWithTLog,
+	}, {
+		name:    "signature not included in the transparency log",
+		task:    signedTask,
+		keyless: keylessWithTLog,
+		wantErr: "transparency log entry is missing",
+	}, {
+		name:    "signature included in another transparency log",
+		task:    otherLoggedTask,
+		keyless: keylessWithTLog,
+		wantErr: "verifying signed entry timestamp",
+	}, {
+		name:    "entry of another signature",
+		task:    swappedEntryTask,
+		keyless: keylessWithTLog,
+		wantErr: "verifying inclusion in the transparency log",
+	}, {
+		name:    "tampered task",
+		task:    tamperedTask,
+		keyless: keyless,
+		wantErr: "invalid signature",
+	}, {
+		name:    "missing certificate",
+		task:    noCertificateTask,
+		keyless: keyless,
+		wantErr: "certificate is missing",
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			keyless := tc.keyless
+			policy := &v1alpha1.VerificationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "keyless", Namespace: namespace},
+				Spec: v1alpha1.VerificationPolicySpec{
+					Resources:   []v1alpha1.ResourcePattern{{Pattern: source}},
+					Authorities: []v1alpha1.Authority{{Name: "fulcio", Keyless: &keyless}},
+				},
+			}
+			vr := VerifyTask(ctx, tc.task, nil, source, []*v1alpha1.VerificationPolicy{policy})
+			if tc.wantErr == "" {
+				if vr.VerificationResultType != VerificationPass {
+					t.Fatalf("VerifyTask() got result type %d, want %d, err: %v", vr.VerificationResultType, VerificationPass, vr.Err)
+				}
+				return
+			}
+			if vr.VerificationResultType != VerificationError {
+				t.Fatalf("VerifyTask() got result type %d, want %d", vr.VerificationResultType, VerificationError)
+			}
+			if !strings.Contains(vr.Err.Error(), tc.wantErr) {
+				t.Errorf("VerifyTask() got err %v, want it to contain %q", vr.Err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyPipeline_Keyless(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	ctx = test.SetupTrustedResourceConfig(ctx, "", config.EnforceResourceVerificationMode)
+
+	ca, err := test.NewKeylessCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tlog, err := test.NewTransparencyLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	signer, chain, err := ca.IssueCertificate(signerEmail, signerIssuer, now.Add(-5*time.Minute), now.Add(5*time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedPipeline, err := test.GetKeylessSignedPipeline(test.GetUnsignedPipeline("test-pipeline"), signer, chain, tlog, now, "signed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tamperedPipeline := signedPipeline.DeepCopy()
+	tamperedPipeline.Spec.Tasks[0].Name = "tampered"
+
+	policy := &v1alpha1.VerificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "keyless", Namespace: namespace},
+		Spec: v1alpha1.VerificationPolicySpec{
+			Resources: []v1alpha1.ResourcePattern{{Pattern: source}},
+			Authorities: []v1alpha1.Authority{{
+				Name: "fulcio",
+				Keyless: &v1alpha1.KeylessRef{
+					CARoots:    string(ca.RootPEM),
+					Identities: []v1alpha1.Identity{{Subject: signerEmail, Issuer: signerIssuer}},
+					TLog:       &v1alpha1.TLogRef{PublicKey: string(tlog.PublicKeyPEM)},
+				},
+			}},
+		},
+	}
+
+	tcs := []struct {
+		name     string
+		pipeline *v1beta1.Pipeline
+		wantType VerificationResultType
+	}{{
+		name:     "signed pipeline passes verification",
+		pipeline: signedPipeline,
+		wantType: VerificationPass,
+	}, {
+		name:     "tampered pipeline fails verification",
+		pipeline: tamperedPipeline,
+		wantType: VerificationError,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			vr := VerifyPipeline(ctx, tc.pipeline, nil, source, []*v1alpha1.VerificationPolicy{policy})
+			if vr.VerificationResultType != tc.wantType {
+				t.Fatalf("VerifyPipeline() got result type %d, want %d, err: %v", vr.VerificationResultType, tc.wantType, vr.Err)
+			}
+		})
+	}
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	tlog, err := test.NewTransparencyLog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 17; i++ {
+		leaf := []byte(fmt.Sprintf("leaf-%d", i))
+		raw, err := tlog.Append(leaf, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry := TransparencyLogEntry{}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			t.Fatal(err)
+		}
+		if err := verifyInclusion(entry.LogIndex, entry.TreeSize, leafHash(leaf), entry.Hashes, entry.RootHash); err != nil {
+			t.Errorf("verifyInclusion() of leaf %d in tree of size %d: %v", entry.LogIndex, entry.TreeSize, err)
+		}
+		if err := verifyInclusion(entry.LogIndex, entry.TreeSize, leafHash([]byte("other")), entry.Hashes, entry.RootHash); err == nil {
+			t.Errorf("verifyInclusion() of another leaf in tree of size %d should fail", entry.TreeSize)
+		}
+		if len(entry.Hashes) > 0 {
+			if err := verifyInclusion(entry.LogIndex, entry.TreeSize, leafHash(leaf), entry.Hashes[1:], entry.RootHash); err == nil {
+				t.Errorf("verifyInclusion() with a truncated proof in tree of size %d should fail", entry.TreeSize)
+			}
+		}
+	}
+}
+
+func TestPrepareObjectMeta_Keyless(t *testing.T) {
+	meta := metav1.ObjectMeta{
+		Name: "test-task",
+		Annotations: map[string]string{
+			SignatureAnnotation:            base64.StdEncoding.EncodeToString([]byte("sig")),
+			CertificateAnnotation:          "cert",
+			TransparencyLogEntryAnnotation: "entry",
+			"foo":                          "bar",
+		},
+	}
+	got, _, err := prepareObjectMeta(meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Annotations) != 1 || got.Annotations["foo"] != "bar" {
+		t.Errorf("prepareObjectMeta() got annotations %v, want only the foo annotation", got.Annotations)
+	}
+}
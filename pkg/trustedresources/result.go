@@ -0,0 +1,45 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+// VerificationResultType indicates the result of verifying a Task or Pipeline.
+type VerificationResultType int
+
+const (
+	// VerificationSkip means the verification was skipped, as the
+	// resource-verification-mode is "skip".
+	VerificationSkip VerificationResultType = iota
+	// VerificationPass means the resource passed the verification.
+	VerificationPass
+	// VerificationWarn means the resource failed the verification, which only
+	// logs warnings in "warn" mode.
+	VerificationWarn
+	// VerificationError means the resource failed the verification, which
+	// fails the run in "enforce" mode.
+	VerificationError
+)
+
+// VerificationResult is the result of verifying a Task or Pipeline.
+type VerificationResult struct {
+	VerificationResultType VerificationResultType
+	// MatchedPolicies are the names of the VerificationPolicies matching the
+	// source of the resource. It is empty when the keys of the
+	// config-trusted-resources ConfigMap were used.
+	MatchedPolicies []string
+	// Err is the error of a failed verification.
+	Err error
+}
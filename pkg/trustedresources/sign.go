@@ -0,0 +1,101 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Sign signs the Task or Pipeline with the signer and sets its signature
+// annotation, replacing any previous signature. The resource is canonicalized
+// as VerifyTask and VerifyPipeline do, so that the signature passes their
+// verification. Resources are signed in their own API version, and pass the
+// verification in the other API version too.
+func Sign(ctx context.Context, obj metav1.Object, signer signature.Signer) error {
+	var canonical interface{}
+	switch o := obj.(type) {
+	case *v1beta1.Task:
+		canonical = canonicalTask(canonicalObjectMeta(o.ObjectMeta), o.Spec)
+	case *v1beta1.Pipeline:
+		canonical = canonicalPipeline(canonicalObjectMeta(o.ObjectMeta), o.Spec)
+	case *v1.Task:
+		canonical = canonicalV1Task(canonicalObjectMeta(o.ObjectMeta), o.Spec)
+	case *v1.Pipeline:
+		canonical = canonicalV1Pipeline(canonicalObjectMeta(o.ObjectMeta), o.Spec)
+	default:
+		return fmt.Errorf("signing %T is not supported, only Tasks and Pipelines can be signed", obj)
+	}
+
+	digest, err := digestOf(canonical)
+	if err != nil {
+		return err
+	}
+	sig, err := signer.SignMessage(bytes.NewReader(digest))
+	if err != nil {
+		return err
+	}
+
+	annotations := map[string]string{}
+	for k, v := range obj.GetAnnotations() {
+		annotations[k] = v
+	}
+	// the certificate chain of a previous keyless signature no longer applies.
+	delete(annotations, CertificateAnnotation)
+	delete(annotations, TransparencyLogEntryAnnotation)
+	annotations[SignatureAnnotation] = base64.StdEncoding.EncodeToString(sig)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// LoadSigner returns the signer of the private key referenced by keyRef:
+// either the url of a key in a KMS whose provider is registered, or the path
+// of a PEM encoded private key file. pf provides the password of encrypted
+// private key files.
+func LoadSigner(ctx context.Context, keyRef string, hashAlgorithm crypto.Hash, pf cryptoutils.PassFunc) (signature.Signer, error) {
+	signer, err := kms.Get(ctx, keyRef, hashAlgorithm)
+	if err == nil {
+		return signer, nil
+	}
+	var notFound *kms.ProviderNotFoundError
+	if !errors.As(err, &notFound) {
+		return nil, err
+	}
+
+	raw, err := os.ReadFile(filepath.Clean(keyRef))
+	if err != nil {
+		return nil, err
+	}
+	key, err := cryptoutils.UnmarshalPEMToPrivateKey(raw, pf)
+	if err != nil {
+		return nil, fmt.Errorf("pem to private key: %w", err)
+	}
+	return signature.LoadSigner(key, hashAlgorithm)
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"context"
+	"crypto"
+	"crypto/elliptic"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/trustedresources/softkms"
+	test "github.com/tektoncd/pipeline/test"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+func TestSign(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	signer, keypath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = test.SetupTrustedResourceConfig(ctx, keypath, config.EnforceResourceVerificationMode)
+
+	v1Task := &v1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "v1-task", Namespace: namespace, Labels: map[string]string{"app": "test"}},
+		Spec: v1.TaskSpec{
+			Params: []v1.ParamSpec{{Name: "message", Type: v1.ParamTypeString}},
+			Steps:  []v1.Step{{Name: "echo", Image: "ubuntu", Script: "echo $(params.message)"}},
+		},
+	}
+	v1Pipeline := &v1.Pipeline{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Pipeline"},
+		ObjectMeta: metav1.ObjectMeta{Name: "v1-pipeline", Namespace: namespace},
+		Spec: v1.PipelineSpec{
+			Tasks: []v1.PipelineTask{{Name: "task", TaskRef: &v1.TaskRef{Name: "v1-task"}}},
+		},
+	}
+	previouslySignedTask, err := test.GetSignedTask(test.GetUnsignedTask("test-task"), signer, "signed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previouslySignedTask.Spec.Description = "updated after signing"
+	appliedTask := test.GetUnsignedTask("applied-task")
+	appliedTask.Annotations["kubectl.kubernetes.io/last-applied-configuration"] = "{}"
+
+	for _, tc := range []struct {
+		name string
+		obj  metav1.Object
+	}{{
+		name: "v1beta1 task",
+		obj:  test.GetUnsignedTask("test-task"),
+	}, {
+		name: "re-signed v1beta1 task",
+		obj:  previouslySignedTask,
+	}, {
+		name: "v1beta1 task with the last applied configuration",
+		obj:  appliedTask,
+	}, {
+		name: "v1beta1 pipeline",
+		obj:  test.GetUnsignedPipeline("test-pipeline"),
+	}, {
+		name: "v1 task",
+		obj:  v1Task,
+	}, {
+		name: "v1 pipeline",
+		obj:  v1Pipeline,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := Sign(ctx, tc.obj, signer); err != nil {
+				t.Fatalf("Sign() got err %v", err)
+			}
+			if vr := verifySigned(ctx, t, tc.obj); vr.VerificationResultType != VerificationPass {
+				t.Fatalf("Verification of the signed %T got result type %d, err: %v", tc.obj, vr.VerificationResultType, vr.Err)
+			}
+		})
+	}
+
+	unsupported := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun"}}
+	if err := Sign(ctx, unsupported, signer); err == nil {
+		t.Error("Sign() of a TaskRun should fail")
+	}
+}
+
+func TestSign_SoftwareKMS(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	ctx = test.SetupTrustedResourceConfig(ctx, "", config.EnforceResourceVerificationMode)
+
+	keyRef := softkms.ReferenceScheme + filepath.Join(t.TempDir(), "signer.key")
+	sv, err := softkms.LoadSignerVerifier(keyRef, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sv.CreateKey(ctx, sv.DefaultAlgorithm()); err != nil {
+		t.Fatal(err)
+	}
+	signer, err := LoadSigner(ctx, keyRef, crypto.SHA256, nil)
+	if err != nil {
+		t.Fatalf("LoadSigner() got err %v", err)
+	}
+
+	task := test.GetUnsignedTask("test-task")
+	if err := Sign(ctx, task, signer); err != nil {
+		t.Fatalf("Sign() got err %v", err)
+	}
+	policy := &v1alpha1.VerificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "kms", Namespace: namespace},
+		Spec: v1alpha1.VerificationPolicySpec{
+			Resources:   []v1alpha1.ResourcePattern{{Pattern: ".*"}},
+			Authorities: []v1alpha1.Authority{{Name: "kms", Key: &v1alpha1.KeyRef{KMS: keyRef}}},
+		},
+	}
+	if vr := VerifyTask(ctx, task, nil, "", []*v1alpha1.VerificationPolicy{policy}); vr.VerificationResultType != VerificationPass {
+		t.Fatalf("VerifyTask() got result type %d, err: %v", vr.VerificationResultType, vr.Err)
+	}
+}
+
+func TestLoadSigner_EncryptedKeyFile(t *testing.T) {
+	ctx := context.Background()
+	password := []byte("password")
+	priv, pub, err := cryptoutils.GeneratePEMEncodedECDSAKeyPair(elliptic.P256(), cryptoutils.StaticPasswordFunc(password))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "cosign.key")
+	if err := os.WriteFile(keyFile, priv, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := LoadSigner(ctx, keyFile, crypto.SHA256, cryptoutils.StaticPasswordFunc(password))
+	if err != nil {
+		t.Fatalf("LoadSigner() got err %v", err)
+	}
+	task := test.GetUnsignedTask("test-task")
+	if err := Sign(ctx, task, signer); err != nil {
+		t.Fatalf("Sign() got err %v", err)
+	}
+	verifier, err := verifierForPEM(pub, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm, sig, err := prepareObjectMeta(task.ObjectMeta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	canonical := canonicalTask(tm, task.Spec)
+	if err := VerifyInterface(&canonical, verifier, sig); err != nil {
+		t.Errorf("VerifyInterface() got err %v", err)
+	}
+
+	if _, err := LoadSigner(ctx, keyFile, crypto.SHA256, cryptoutils.StaticPasswordFunc([]byte("wrong"))); err == nil {
+		t.Error("LoadSigner() with the wrong password should fail")
+	}
+	if _, err := LoadSigner(ctx, filepath.Join(t.TempDir(), "missing.key"), crypto.SHA256, nil); err == nil {
+		t.Error("LoadSigner() of a missing key file should fail")
+	}
+}
+
+// verifySigned verifies the signed Task or Pipeline in its own API version
+// and in its conversion to the other API version.
+func verifySigned(ctx context.Context, t *testing.T, obj metav1.Object) VerificationResult {
+	t.Helper()
+	if vr := VerifyResource(ctx, obj, nil, "", nil); vr.VerificationResultType != VerificationPass {
+		return vr
+	}
+	var converted metav1.Object
+	switch o := obj.(type) {
+	case *v1beta1.Task:
+		task := &v1.Task{}
+		if err := o.ConvertTo(ctx, task); err != nil {
+			t.Fatal(err)
+		}
+		converted = task
+	case *v1beta1.Pipeline:
+		pipeline := &v1.Pipeline{}
+		if err := o.ConvertTo(ctx, pipeline); err != nil {
+			t.Fatal(err)
+		}
+		converted = pipeline
+	case *v1.Task:
+		task := &v1beta1.Task{}
+		if err := task.ConvertFrom(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+		converted = task
+	case *v1.Pipeline:
+		pipeline := &v1beta1.Pipeline{}
+		if err := pipeline.ConvertFrom(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+		converted = pipeline
+	default:
+		t.Fatalf("unexpected %T", obj)
+	}
+	return VerifyResource(ctx, converted, nil, "", nil)
+}
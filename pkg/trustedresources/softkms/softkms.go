@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package softkms provides a software KMS, keeping its keys in PEM encoded
+// files. It stands in for cloud and hardware KMSs to sign and verify resources
+// with KMS key references, e.g. in tests or in local setups. Importing the
+// package registers its provider for the softkms:// key references.
+package softkms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
+)
+
+// ReferenceScheme is the scheme of the key references of the software KMS,
+// followed by the path of the key file, e.g. softkms:///etc/keys/signer.key
+const ReferenceScheme = "softkms://"
+
+// algorithmECDSAP256 is the only algorithm of the keys created by the software KMS.
+const algorithmECDSAP256 = "ecdsa-p256"
+
+func init() {
+	kms.AddProvider(ReferenceScheme, func(ctx context.Context, keyResourceID string, hashFunc crypto.Hash, _ ...signature.RPCOption) (kms.SignerVerifier, error) {
+		return LoadSignerVerifier(keyResourceID, hashFunc)
+	})
+}
+
+// SignerVerifier signs and verifies with the key of a software KMS key file.
+type SignerVerifier struct {
+	path     string
+	hashFunc crypto.Hash
+}
+
+var _ kms.SignerVerifier = (*SignerVerifier)(nil)
+
+// LoadSignerVerifier returns the SignerVerifier of the key referenced by
+// keyResourceID. The key file doesn't need to exist until the key is used,
+// so that it can be created with CreateKey.
+func LoadSignerVerifier(keyResourceID string, hashFunc crypto.Hash) (*SignerVerifier, error) {
+	if !strings.HasPrefix(keyResourceID, ReferenceScheme) {
+		return nil, fmt.Errorf("invalid software KMS key reference %q, must start with %s", keyResourceID, ReferenceScheme)
+	}
+	path := strings.TrimPrefix(keyResourceID, ReferenceScheme)
+	if path == "" {
+		return nil, fmt.Errorf("invalid software KMS key reference %q, the path of the key is missing", keyResourceID)
+	}
+	return &SignerVerifier{path: filepath.Clean(path), hashFunc: hashFunc}, nil
+}
+
+// signerVerifier loads the key file.
+func (s *SignerVerifier) signerVerifier() (signature.SignerVerifier, error) {
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := cryptoutils.UnmarshalPEMToPrivateKey(raw, cryptoutils.SkipPassword)
+	if err != nil {
+		return nil, fmt.Errorf("pem to private key: %w", err)
+	}
+	return signature.LoadSignerVerifier(key, s.hashFunc)
+}
+
+// SignMessage implements signature.Signer
+func (s *SignerVerifier) SignMessage(message io.Reader, opts ...signature.SignOption) ([]byte, error) {
+	sv, err := s.signerVerifier()
+	if err != nil {
+		return nil, err
+	}
+	return sv.SignMessage(message, opts...)
+}
+
+// VerifySignature implements signature.Verifier
+func (s *SignerVerifier) VerifySignature(sig, message io.Reader, opts ...signature.VerifyOption) error {
+	sv, err := s.signerVerifier()
+	if err != nil {
+		return err
+	}
+	return sv.VerifySignature(sig, message, opts...)
+}
+
+// PublicKey implements signature.PublicKeyProvider
+func (s *SignerVerifier) PublicKey(opts ...signature.PublicKeyOption) (crypto.PublicKey, error) {
+	sv, err := s.signerVerifier()
+	if err != nil {
+		return nil, err
+	}
+	return sv.PublicKey(opts...)
+}
+
+// CreateKey creates the key file with a new key of the algorithm if it
+// doesn't exist yet, and returns the public key.
+func (s *SignerVerifier) CreateKey(_ context.Context, algorithm string) (crypto.PublicKey, error) {
+	if algorithm != algorithmECDSAP256 {
+		return nil, fmt.Errorf("unsupported algorithm %q, supported algorithms: %s", algorithm, algorithmECDSAP256)
+	}
+	if _, err := os.Stat(s.path); err == nil {
+		return s.PublicKey()
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := cryptoutils.MarshalPrivateKeyToPEM(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(s.path, raw, 0600); err != nil {
+		return nil, err
+	}
+	return key.Public(), nil
+}
+
+// CryptoSigner returns a crypto.Signer with the key.
+func (s *SignerVerifier) CryptoSigner(_ context.Context, _ func(error)) (crypto.Signer, crypto.SignerOpts, error) {
+	sv, err := s.signerVerifier()
+	if err != nil {
+		return nil, nil, err
+	}
+	signer, ok := sv.(crypto.Signer)
+	if !ok {
+		return nil, nil, fmt.Errorf("key of type %T can't be used as a crypto.Signer", sv)
+	}
+	return signer, s.hashFunc, nil
+}
+
+// SupportedAlgorithms returns the algorithms of the keys CreateKey can create.
+func (s *SignerVerifier) SupportedAlgorithms() []string {
+	return []string{algorithmECDSAP256}
+}
+
+// DefaultAlgorithm returns the algorithm of the keys CreateKey creates by default.
+func (s *SignerVerifier) DefaultAlgorithm() string {
+	return algorithmECDSAP256
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package softkms
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"path/filepath"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
+)
+
+func TestSignerVerifier(t *testing.T) {
+	ctx := context.Background()
+	keyRef := ReferenceScheme + filepath.Join(t.TempDir(), "signer.key")
+
+	sv, err := kms.Get(ctx, keyRef, crypto.SHA256)
+	if err != nil {
+		t.Fatalf("kms.Get() got err %v", err)
+	}
+	if _, err := sv.SignMessage(bytes.NewReader([]byte("message"))); err == nil {
+		t.Fatal("SignMessage() should fail before the key is created")
+	}
+
+	pub, err := sv.CreateKey(ctx, sv.DefaultAlgorithm())
+	if err != nil {
+		t.Fatalf("CreateKey() got err %v", err)
+	}
+	// Creating the key again returns the existing key.
+	again, err := sv.CreateKey(ctx, sv.DefaultAlgorithm())
+	if err != nil {
+		t.Fatalf("CreateKey() got err %v", err)
+	}
+	if err := cryptoutils.EqualKeys(pub, again); err != nil {
+		t.Errorf("CreateKey() replaced the existing key: %v", err)
+	}
+	if _, err := sv.CreateKey(ctx, "rsa-2048"); err == nil {
+		t.Error("CreateKey() with an unsupported algorithm should fail")
+	}
+
+	sig, err := sv.SignMessage(bytes.NewReader([]byte("message")))
+	if err != nil {
+		t.Fatalf("SignMessage() got err %v", err)
+	}
+	if err := sv.VerifySignature(bytes.NewReader(sig), bytes.NewReader([]byte("message"))); err != nil {
+		t.Errorf("VerifySignature() got err %v", err)
+	}
+	if err := sv.VerifySignature(bytes.NewReader(sig), bytes.NewReader([]byte("tampered"))); err == nil {
+		t.Error("VerifySignature() of a tampered message should fail")
+	}
+	if _, _, err := sv.CryptoSigner(ctx, nil); err != nil {
+		t.Errorf("CryptoSigner() got err %v", err)
+	}
+}
+
+func TestLoadSignerVerifier_InvalidReference(t *testing.T) {
+	for _, ref := range []string{"gcpkms://projects/p/keys/k", ReferenceScheme} {
+		if _, err := LoadSignerVerifier(ref, crypto.SHA256); err == nil {
+			t.Errorf("LoadSignerVerifier(%q) should fail", ref)
+		}
+	}
+}
@@ -20,21 +20,26 @@ import (
 	"bytes"
 	"context"
 	"crypto"
-	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -47,76 +52,293 @@ const (
 
 // VerifyInterface get the checksum of json marshalled object and verify it.
 func VerifyInterface(obj interface{}, verifier signature.Verifier, signature []byte) error {
-	ts, err := json.Marshal(obj)
+	digest, err := digestOf(obj)
 	if err != nil {
 		return err
 	}
 
-	h := sha256.New()
-	h.Write(ts)
-
-	if err := verifier.VerifySignature(bytes.NewReader(signature), bytes.NewReader(h.Sum(nil))); err != nil {
+	if err := verifier.VerifySignature(bytes.NewReader(signature), bytes.NewReader(digest)); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// VerifyTask verifies the signature and public key against task
-func VerifyTask(ctx context.Context, taskObj v1beta1.TaskObject, k8s kubernetes.Interface) error {
-	tm, signature, err := prepareObjectMeta(taskObj.TaskMetadata())
+// VerifyTask verifies the signature and public key against task. source is
+// the URI of the ConfigSource the task was resolved from, matched against the
+// resource patterns of the VerificationPolicies to select the keys the task
+// can be signed with. Without VerificationPolicies, the keys of the
+// config-trusted-resources ConfigMap are used for tasks from any source.
+func VerifyTask(ctx context.Context, taskObj v1beta1.TaskObject, k8s kubernetes.Interface, source string, policies []*v1alpha1.VerificationPolicy) VerificationResult {
+	tm, sig, err := prepareObjectMeta(taskObj.TaskMetadata())
+	task := canonicalTask(tm, taskObj.TaskSpec())
 	if err != nil {
-		return err
-	}
-	task := v1beta1.Task{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "tekton.dev/v1beta1",
-			Kind:       "Task"},
-		ObjectMeta: tm,
-		Spec:       taskObj.TaskSpec(),
+		err = fmt.Errorf("Task %s in namespace %s fails verification: %w", task.Name, task.Namespace, err)
 	}
-	verifiers, err := getVerifiers(ctx, k8s)
+	return verifyResource(ctx, canonicalForms(ctx, &task), sig, err, taskObj.TaskMetadata().Annotations, k8s, source, policies)
+}
+
+// VerifyPipeline verifies the signature and public key against pipeline. As
+// for VerifyTask, source selects the VerificationPolicies to verify with.
+func VerifyPipeline(ctx context.Context, pipelineObj v1beta1.PipelineObject, k8s kubernetes.Interface, source string, policies []*v1alpha1.VerificationPolicy) VerificationResult {
+	pm, sig, err := prepareObjectMeta(pipelineObj.PipelineMetadata())
+	pipeline := canonicalPipeline(pm, pipelineObj.PipelineSpec())
 	if err != nil {
-		return err
+		err = fmt.Errorf("Pipeline %s in namespace %s fails verification: %w", pipeline.Name, pipeline.Namespace, err)
 	}
-	for _, verifier := range verifiers {
-		if err := VerifyInterface(task, verifier, signature); err == nil {
-			return nil
+	return verifyResource(ctx, canonicalForms(ctx, &pipeline), sig, err, pipelineObj.PipelineMetadata().Annotations, k8s, source, policies)
+}
+
+// VerifyResource verifies the signature of a v1beta1 or v1 Task or Pipeline,
+// as VerifyTask and VerifyPipeline do. Resources are verified in the API
+// version they are given in, and in their conversion to the other API
+// version, so that a resource signed as a v1 Task passes the verification
+// when it is fetched as a v1beta1 Task, and the other way around, unless the
+// conversion loses fields.
+func VerifyResource(ctx context.Context, obj metav1.Object, k8s kubernetes.Interface, source string, policies []*v1alpha1.VerificationPolicy) VerificationResult {
+	switch o := obj.(type) {
+	case v1beta1.TaskObject:
+		return VerifyTask(ctx, o, k8s, source, policies)
+	case v1beta1.PipelineObject:
+		return VerifyPipeline(ctx, o, k8s, source, policies)
+	case *v1.Task:
+		tm, sig, err := prepareObjectMeta(o.ObjectMeta)
+		task := canonicalV1Task(tm, o.Spec)
+		if err != nil {
+			err = fmt.Errorf("Task %s in namespace %s fails verification: %w", task.Name, task.Namespace, err)
 		}
+		return verifyResource(ctx, canonicalForms(ctx, &task), sig, err, o.Annotations, k8s, source, policies)
+	case *v1.Pipeline:
+		pm, sig, err := prepareObjectMeta(o.ObjectMeta)
+		pipeline := canonicalV1Pipeline(pm, o.Spec)
+		if err != nil {
+			err = fmt.Errorf("Pipeline %s in namespace %s fails verification: %w", pipeline.Name, pipeline.Namespace, err)
+		}
+		return verifyResource(ctx, canonicalForms(ctx, &pipeline), sig, err, o.Annotations, k8s, source, policies)
+	default:
+		err := fmt.Errorf("%T %s in namespace %s fails verification: only Tasks and Pipelines can be verified", obj, obj.GetName(), obj.GetNamespace())
+		return verifyResource(ctx, []metav1.Object{obj}, nil, err, obj.GetAnnotations(), k8s, source, policies)
 	}
-	return fmt.Errorf("Task %s in namespace %s fails verification", task.Name, task.Namespace)
 }
 
-// VerifyPipeline verifies the signature and public key against pipeline
-func VerifyPipeline(ctx context.Context, pipelineObj v1beta1.PipelineObject, k8s kubernetes.Interface) error {
-	pm, signature, err := prepareObjectMeta(pipelineObj.PipelineMetadata())
-	if err != nil {
+// verifyResource verifies the signature of the resource, with the keys of the
+// VerificationPolicies matching its source, or the keys of the
+// config-trusted-resources ConfigMap if there are no VerificationPolicies.
+// resources are the canonical forms of the resource the signature can be
+// computed over, the first one in the API version the resource was fetched in.
+// signatureErr is the error extracting the signature of the resource, if any.
+// annotations are the annotations of the resource as signed, holding the
+// certificate chain of keyless signatures.
+func verifyResource(ctx context.Context, resources []metav1.Object, sig []byte, signatureErr error, annotations map[string]string, k8s kubernetes.Interface, source string, policies []*v1alpha1.VerificationPolicy) VerificationResult {
+	mode := config.FromContextOrDefaults(ctx).FeatureFlags.ResourceVerificationMode
+	if mode != config.EnforceResourceVerificationMode && mode != config.WarnResourceVerificationMode {
+		return VerificationResult{VerificationResultType: VerificationSkip}
+	}
+	failed := func(matched []string, err error, enforced bool) VerificationResult {
+		if enforced && mode == config.EnforceResourceVerificationMode {
+			return VerificationResult{VerificationResultType: VerificationError, MatchedPolicies: matched, Err: err}
+		}
+		return VerificationResult{VerificationResultType: VerificationWarn, MatchedPolicies: matched, Err: err}
+	}
+	verify := func(verifiers []signature.Verifier, keyless []v1alpha1.Authority) error {
+		if signatureErr != nil {
+			return signatureErr
+		}
+		for _, resource := range resources {
+			for _, verifier := range verifiers {
+				if err := VerifyInterface(resource, verifier, sig); err == nil {
+					return nil
+				}
+			}
+		}
+		var keylessErrs []string
+		for _, a := range keyless {
+			var keylessErr error
+			for i, resource := range resources {
+				err := verifyKeyless(resource, sig, annotations, a.Keyless)
+				if err == nil {
+					return nil
+				}
+				// report the failure of the API version the resource was fetched in.
+				if i == 0 {
+					keylessErr = err
+				}
+			}
+			keylessErrs = append(keylessErrs, fmt.Sprintf("authority %s: %v", a.Name, keylessErr))
+		}
+		resource := resources[0]
+		err := fmt.Errorf("%s %s in namespace %s fails verification", kindOf(resource), resource.GetName(), resource.GetNamespace())
+		if len(keylessErrs) > 0 {
+			err = fmt.Errorf("%w: %s", err, strings.Join(keylessErrs, "; "))
+		}
 		return err
 	}
-	pipeline := v1beta1.Pipeline{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "tekton.dev/v1beta1",
-			Kind:       "Pipeline"},
-		ObjectMeta: pm,
-		Spec:       pipelineObj.PipelineSpec(),
+
+	if len(policies) == 0 {
+		verifiers, err := getVerifiers(ctx, k8s)
+		if err == nil {
+			err = verify(verifiers, nil)
+		}
+		if err != nil {
+			return failed(nil, err, true)
+		}
+		return VerificationResult{VerificationResultType: VerificationPass}
 	}
-	verifiers, err := getVerifiers(ctx, k8s)
+
+	matched, err := matchPolicies(source, policies)
 	if err != nil {
-		return err
+		return failed(nil, err, true)
+	}
+	var matchedNames, warnings []string
+	for _, p := range matched {
+		matchedNames = append(matchedNames, p.Name)
+	}
+	for _, p := range matched {
+		verifiers, err := verifiersForPolicy(ctx, k8s, p)
+		if err == nil {
+			err = verify(verifiers, keylessAuthorities(p))
+		}
+		if err != nil {
+			err = fmt.Errorf("VerificationPolicy %s: %w", p.Name, err)
+			if p.Spec.Mode != v1alpha1.ModeWarn && mode == config.EnforceResourceVerificationMode {
+				return failed(matchedNames, err, true)
+			}
+			warnings = append(warnings, err.Error())
+		}
+	}
+	if len(warnings) > 0 {
+		return failed(matchedNames, errors.New(strings.Join(warnings, "; ")), false)
+	}
+	return VerificationResult{VerificationResultType: VerificationPass, MatchedPolicies: matchedNames}
+}
+
+// matchPolicies returns the VerificationPolicies with a resource pattern
+// matching the source. It returns ErrNoMatchedPolicies if there are none.
+func matchPolicies(source string, policies []*v1alpha1.VerificationPolicy) ([]*v1alpha1.VerificationPolicy, error) {
+	var matched []*v1alpha1.VerificationPolicy
+	for _, p := range policies {
+		for _, r := range p.Spec.Resources {
+			re, err := regexp.Compile("^(?:" + r.Pattern + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("VerificationPolicy %s has an invalid resource pattern %q: %w", p.Name, r.Pattern, err)
+			}
+			if re.MatchString(source) {
+				matched = append(matched, p)
+				break
+			}
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("%w: no VerificationPolicy matches source %q", ErrNoMatchedPolicies, source)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched, nil
+}
+
+// verifiersForPolicy returns the verifiers of the keys of the authorities of
+// the VerificationPolicy.
+func verifiersForPolicy(ctx context.Context, k8s kubernetes.Interface, policy *v1alpha1.VerificationPolicy) ([]signature.Verifier, error) {
+	verifiers := []signature.Verifier{}
+	for _, a := range policy.Spec.Authorities {
+		if a.Key == nil {
+			continue
+		}
+		hashAlgorithm, err := hashAlgorithmOf(a.Key.HashAlgorithm)
+		if err != nil {
+			return nil, fmt.Errorf("authority %s: %w", a.Name, err)
+		}
+		switch {
+		case a.Key.SecretRef != nil:
+			namespace := a.Key.SecretRef.Namespace
+			if namespace == "" {
+				namespace = policy.Namespace
+			}
+			v, err := verifierForKeyRef(ctx, fmt.Sprintf("%s%s/%s", keyReference, namespace, a.Key.SecretRef.Name), hashAlgorithm, k8s)
+			if err != nil {
+				return nil, fmt.Errorf("authority %s: %w", a.Name, err)
+			}
+			verifiers = append(verifiers, v...)
+		case a.Key.Data != "":
+			v, err := verifierForPEM([]byte(a.Key.Data), hashAlgorithm)
+			if err != nil {
+				return nil, fmt.Errorf("authority %s: %w", a.Name, err)
+			}
+			verifiers = append(verifiers, v)
+		case a.Key.KMS != "":
+			v, err := kms.Get(ctx, a.Key.KMS, hashAlgorithm)
+			if err != nil {
+				return nil, fmt.Errorf("authority %s: %w", a.Name, err)
+			}
+			verifiers = append(verifiers, v)
+		}
+	}
+	if len(verifiers) == 0 && len(keylessAuthorities(policy)) == 0 {
+		return nil, fmt.Errorf("no public keys are found in the authorities")
 	}
+	return verifiers, nil
+}
 
-	for _, verifier := range verifiers {
-		if err := VerifyInterface(pipeline, verifier, signature); err == nil {
-			return nil
+// keylessAuthorities returns the authorities of the VerificationPolicy
+// verifying keyless signatures.
+func keylessAuthorities(policy *v1alpha1.VerificationPolicy) []v1alpha1.Authority {
+	var authorities []v1alpha1.Authority
+	for _, a := range policy.Spec.Authorities {
+		if a.Keyless != nil {
+			authorities = append(authorities, a)
 		}
 	}
+	return authorities
+}
 
-	return fmt.Errorf("Pipeline %s in namespace %s fails verification", pipeline.Name, pipeline.Namespace)
+// hashAlgorithmOf returns the hash algorithm of a key of a VerificationPolicy,
+// defaulting to SHA256.
+func hashAlgorithmOf(algorithm v1alpha1.HashAlgorithm) (crypto.Hash, error) {
+	switch strings.ToLower(string(algorithm)) {
+	case "", "sha256":
+		return crypto.SHA256, nil
+	case "sha224":
+		return crypto.SHA224, nil
+	case "sha384":
+		return crypto.SHA384, nil
+	case "sha512":
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported hash algorithm %q", algorithm)
+	}
+}
+
+// kindOf returns the kind of the Task or Pipeline being verified.
+func kindOf(resource metav1.Object) string {
+	if o, ok := resource.(interface{ GetObjectKind() schema.ObjectKind }); ok {
+		return o.GetObjectKind().GroupVersionKind().Kind
+	}
+	return "Resource"
 }
 
 // prepareObjectMeta will remove annotations not configured from user side -- "kubectl-client-side-apply" and "kubectl.kubernetes.io/last-applied-configuration"
-// to avoid verification failure and extract the signature.
+// to avoid verification failure and extract the signature. The annotations of
+// keyless signatures are removed as well.
 func prepareObjectMeta(in metav1.ObjectMeta) (metav1.ObjectMeta, []byte, error) {
+	out := canonicalObjectMeta(in)
+
+	// signature should be contained in annotation
+	sig, ok := in.Annotations[SignatureAnnotation]
+	if !ok {
+		return out, nil, fmt.Errorf("signature is missing")
+	}
+	// extract signature
+	signature, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return out, nil, err
+	}
+
+	return out, signature, nil
+}
+
+// canonicalObjectMeta returns the metadata of a resource as signed, without the
+// fields populated by the system and the annotations added by other components
+// or after signing.
+func canonicalObjectMeta(in metav1.ObjectMeta) metav1.ObjectMeta {
 	out := metav1.ObjectMeta{}
 
 	// exclude the fields populated by system.
@@ -142,19 +364,137 @@ func prepareObjectMeta(in metav1.ObjectMeta) (metav1.ObjectMeta, []byte, error)
 	delete(out.Annotations, "kubectl-client-side-apply")
 	delete(out.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
 
-	// signature should be contained in annotation
-	sig, ok := in.Annotations[SignatureAnnotation]
-	if !ok {
-		return out, nil, fmt.Errorf("signature is missing")
+	// the signature, and the certificate chain and the transparency log entry
+	// of keyless signatures, are added after signing.
+	delete(out.Annotations, SignatureAnnotation)
+	delete(out.Annotations, CertificateAnnotation)
+	delete(out.Annotations, TransparencyLogEntryAnnotation)
+
+	return out
+}
+
+// canonicalTask returns the Task whose json encoding is signed, given the
+// canonical metadata and the spec of a task.
+func canonicalTask(meta metav1.ObjectMeta, spec v1beta1.TaskSpec) v1beta1.Task {
+	return v1beta1.Task{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "Task"},
+		ObjectMeta: meta,
+		Spec:       spec,
 	}
-	// extract signature
-	signature, err := base64.StdEncoding.DecodeString(sig)
-	if err != nil {
-		return out, nil, err
+}
+
+// canonicalPipeline returns the Pipeline whose json encoding is signed, given
+// the canonical metadata and the spec of a pipeline.
+func canonicalPipeline(meta metav1.ObjectMeta, spec v1beta1.PipelineSpec) v1beta1.Pipeline {
+	return v1beta1.Pipeline{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1beta1",
+			Kind:       "Pipeline"},
+		ObjectMeta: meta,
+		Spec:       spec,
 	}
-	delete(out.Annotations, SignatureAnnotation)
+}
 
-	return out, signature, nil
+// canonicalV1Task returns the v1 Task whose json encoding is signed, given the
+// canonical metadata and the spec of a v1 task.
+func canonicalV1Task(meta metav1.ObjectMeta, spec v1.TaskSpec) v1.Task {
+	return v1.Task{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "Task"},
+		ObjectMeta: meta,
+		Spec:       spec,
+	}
+}
+
+// canonicalV1Pipeline returns the v1 Pipeline whose json encoding is signed,
+// given the canonical metadata and the spec of a v1 pipeline.
+func canonicalV1Pipeline(meta metav1.ObjectMeta, spec v1.PipelineSpec) v1.Pipeline {
+	return v1.Pipeline{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "tekton.dev/v1",
+			Kind:       "Pipeline"},
+		ObjectMeta: meta,
+		Spec:       spec,
+	}
+}
+
+// canonicalForms returns the canonical resource, followed by its conversion to
+// the other API version if it can be converted without losing any field. The
+// conversion of a resource signed in one API version and fetched in the other
+// one, e.g. a v1 Task served by the v1beta1 API, is the resource as signed. A
+// resource whose conversion loses fields, e.g. fields added to a signed v1 Task
+// served by the v1beta1 API which don't exist in v1, is only verified in the API
+// version it was fetched in, so that the lost fields can't escape verification.
+func canonicalForms(ctx context.Context, canonical metav1.Object) []metav1.Object {
+	forms := []metav1.Object{canonical}
+	switch o := canonical.(type) {
+	case *v1beta1.Task:
+		converted, roundTrip := &v1.Task{}, &v1beta1.Task{}
+		if err := o.DeepCopy().ConvertTo(ctx, converted); err != nil {
+			break
+		}
+		if err := roundTrip.ConvertFrom(ctx, converted.DeepCopy()); err != nil {
+			break
+		}
+		if original := canonicalTask(roundTrip.ObjectMeta, roundTrip.Spec); sameJSON(o, &original) {
+			task := canonicalV1Task(converted.ObjectMeta, converted.Spec)
+			forms = append(forms, &task)
+		}
+	case *v1beta1.Pipeline:
+		converted, roundTrip := &v1.Pipeline{}, &v1beta1.Pipeline{}
+		if err := o.DeepCopy().ConvertTo(ctx, converted); err != nil {
+			break
+		}
+		if err := roundTrip.ConvertFrom(ctx, converted.DeepCopy()); err != nil {
+			break
+		}
+		if original := canonicalPipeline(roundTrip.ObjectMeta, roundTrip.Spec); sameJSON(o, &original) {
+			pipeline := canonicalV1Pipeline(converted.ObjectMeta, converted.Spec)
+			forms = append(forms, &pipeline)
+		}
+	case *v1.Task:
+		converted, roundTrip := &v1beta1.Task{}, &v1.Task{}
+		if err := converted.ConvertFrom(ctx, o.DeepCopy()); err != nil {
+			break
+		}
+		if err := converted.DeepCopy().ConvertTo(ctx, roundTrip); err != nil {
+			break
+		}
+		if original := canonicalV1Task(roundTrip.ObjectMeta, roundTrip.Spec); sameJSON(o, &original) {
+			task := canonicalTask(converted.ObjectMeta, converted.Spec)
+			forms = append(forms, &task)
+		}
+	case *v1.Pipeline:
+		converted, roundTrip := &v1beta1.Pipeline{}, &v1.Pipeline{}
+		if err := converted.ConvertFrom(ctx, o.DeepCopy()); err != nil {
+			break
+		}
+		if err := converted.DeepCopy().ConvertTo(ctx, roundTrip); err != nil {
+			break
+		}
+		if original := canonicalV1Pipeline(roundTrip.ObjectMeta, roundTrip.Spec); sameJSON(o, &original) {
+			pipeline := canonicalPipeline(converted.ObjectMeta, converted.Spec)
+			forms = append(forms, &pipeline)
+		}
+	}
+	return forms
+}
+
+// sameJSON returns true if a and b have the same json encoding, i.e. the same
+// signature payload.
+func sameJSON(a, b interface{}) bool {
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aJSON, bJSON)
 }
 
 // getVerifiers get all verifiers from configmap
@@ -188,11 +528,10 @@ func verifierForKeyRef(ctx context.Context, keyRef string, hashAlgorithm crypto.
 			return nil, err
 		}
 		for _, raw := range s.Data {
-			pubKey, err := cryptoutils.UnmarshalPEMToPublicKey(raw)
+			v, err := verifierForPEM(raw, hashAlgorithm)
 			if err != nil {
-				return nil, fmt.Errorf("pem to public key: %w", err)
+				return nil, err
 			}
-			v, _ := signature.LoadVerifier(pubKey, hashAlgorithm)
 			verifiers = append(verifiers, v)
 		}
 		if len(verifiers) == 0 {
@@ -207,23 +546,32 @@ func verifierForKeyRef(ctx context.Context, keyRef string, hashAlgorithm crypto.
 	}
 
 	// PEM encoded file.
-	pubKey, err := cryptoutils.UnmarshalPEMToPublicKey(raw)
+	v, err := verifierForPEM(raw, hashAlgorithm)
 	if err != nil {
-		return nil, fmt.Errorf("pem to public key: %w", err)
+		return nil, err
 	}
-	v, _ := signature.LoadVerifier(pubKey, hashAlgorithm)
 	verifiers = append(verifiers, v)
 
 	return verifiers, nil
 }
 
-func getKeyPairSecret(ctx context.Context, k8sRef string, k8s kubernetes.Interface) (*v1.Secret, error) {
+// verifierForPEM loads the PEM encoded public key and returns a verifier
+// using the provided hash algorithm
+func verifierForPEM(raw []byte, hashAlgorithm crypto.Hash) (signature.Verifier, error) {
+	pubKey, err := cryptoutils.UnmarshalPEMToPublicKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("pem to public key: %w", err)
+	}
+	return signature.LoadVerifier(pubKey, hashAlgorithm)
+}
+
+func getKeyPairSecret(ctx context.Context, k8sRef string, k8s kubernetes.Interface) (*corev1.Secret, error) {
 	namespace, name, err := parseRef(k8sRef)
 	if err != nil {
 		return nil, err
 	}
 
-	var s *v1.Secret
+	var s *corev1.Secret
 	if s, err = k8s.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
 		return nil, errors.Wrap(err, "checking if secret exists")
 	}
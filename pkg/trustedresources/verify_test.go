@@ -19,6 +19,7 @@ package trustedresources
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
@@ -27,6 +28,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	test "github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -150,9 +153,8 @@ func TestVerifyTask_Success(t *testing.T) {
 		t.Fatal("fail to sign task", err)
 	}
 
-	err = VerifyTask(ctx, signedTask, nil)
-	if err != nil {
-		t.Fatalf("verifyTaskRun() get err %v", err)
+	if vr := VerifyTask(ctx, signedTask, nil, "", nil); vr.VerificationResultType != VerificationPass {
+		t.Fatalf("VerifyTask() get err %v", vr.Err)
 	}
 
 }
@@ -191,9 +193,8 @@ func TestVerifyTask_Error(t *testing.T) {
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			err := VerifyTask(ctx, tc.task, nil)
-			if err == nil {
-				t.Fatalf("verifyTaskRun() expects to get err but got nil")
+			if vr := VerifyTask(ctx, tc.task, nil, "", nil); vr.VerificationResultType != VerificationError {
+				t.Fatalf("VerifyTask() expects to get err but got %v", vr)
 			}
 		})
 	}
@@ -217,9 +218,8 @@ func TestVerifyPipeline_Success(t *testing.T) {
 		t.Fatal("fail to sign task", err)
 	}
 
-	err = VerifyPipeline(ctx, signedPipeline, nil)
-	if err != nil {
-		t.Fatalf("VerifyPipeline() get err %v", err)
+	if vr := VerifyPipeline(ctx, signedPipeline, nil, "", nil); vr.VerificationResultType != VerificationPass {
+		t.Fatalf("VerifyPipeline() get err %v", vr.Err)
 	}
 
 }
@@ -258,15 +258,102 @@ func TestVerifyPipeline_Error(t *testing.T) {
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			err := VerifyPipeline(ctx, tc.pipeline, nil)
-			if err == nil {
-				t.Fatalf("VerifyPipeline() expects to get err but got nil")
+			if vr := VerifyPipeline(ctx, tc.pipeline, nil, "", nil); vr.VerificationResultType != VerificationError {
+				t.Fatalf("VerifyPipeline() expects to get err but got %v", vr)
 			}
 		})
 	}
 
 }
 
+func TestVerifyResource_V1(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+
+	signer, keypath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx = test.SetupTrustedResourceConfig(ctx, keypath, config.EnforceResourceVerificationMode)
+
+	signedTask := &pipelinev1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "v1-task", Namespace: namespace},
+		Spec: pipelinev1.TaskSpec{
+			Steps: []pipelinev1.Step{{Name: "echo", Image: "ubuntu", Script: "echo hello"}},
+		},
+	}
+	if err := Sign(ctx, signedTask, signer); err != nil {
+		t.Fatal(err)
+	}
+	signedPipeline := &pipelinev1.Pipeline{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Pipeline"},
+		ObjectMeta: metav1.ObjectMeta{Name: "v1-pipeline", Namespace: namespace},
+		Spec: pipelinev1.PipelineSpec{
+			Tasks: []pipelinev1.PipelineTask{{Name: "task", TaskRef: &pipelinev1.TaskRef{Name: "v1-task"}}},
+		},
+	}
+	if err := Sign(ctx, signedPipeline, signer); err != nil {
+		t.Fatal(err)
+	}
+	// a v1 Task served by the v1beta1 API.
+	servedTask := &v1beta1.Task{}
+	if err := servedTask.ConvertFrom(ctx, signedTask.DeepCopy()); err != nil {
+		t.Fatal(err)
+	}
+	// a v1 Task served by the v1beta1 API with a field lost in the conversion to v1.
+	lossyTask := servedTask.DeepCopy()
+	lossyTask.Spec.Steps[0].DeprecatedLifecycle = &v1.Lifecycle{
+		PostStart: &v1.LifecycleHandler{Exec: &v1.ExecAction{Command: []string{"echo", "attack"}}},
+	}
+	tamperedTask := signedTask.DeepCopy()
+	tamperedTask.Spec.Steps[0].Script = "echo attack"
+	unsignedTask := signedTask.DeepCopy()
+	delete(unsignedTask.Annotations, SignatureAnnotation)
+
+	tcs := []struct {
+		name     string
+		resource metav1.Object
+		want     VerificationResultType
+	}{{
+		name:     "signed v1 task passes",
+		resource: signedTask,
+		want:     VerificationPass,
+	}, {
+		name:     "signed v1 pipeline passes",
+		resource: signedPipeline,
+		want:     VerificationPass,
+	}, {
+		name:     "v1 task fetched as v1beta1 passes",
+		resource: servedTask,
+		want:     VerificationPass,
+	}, {
+		name:     "v1 task fetched as v1beta1 with fields lost in the conversion fails",
+		resource: lossyTask,
+		want:     VerificationError,
+	}, {
+		name:     "tampered v1 task fails",
+		resource: tamperedTask,
+		want:     VerificationError,
+	}, {
+		name:     "unsigned v1 task fails",
+		resource: unsignedTask,
+		want:     VerificationError,
+	}, {
+		name:     "taskrun can't be verified",
+		resource: &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: namespace}},
+		want:     VerificationError,
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if vr := VerifyResource(ctx, tc.resource, nil, "", nil); vr.VerificationResultType != tc.want {
+				t.Fatalf("VerifyResource() got result type %d, want %d, err: %v", vr.VerificationResultType, tc.want, vr.Err)
+			}
+		})
+	}
+}
+
 func TestVerifyTask_SecretRef(t *testing.T) {
 	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
 
@@ -321,13 +408,207 @@ func TestVerifyTask_SecretRef(t *testing.T) {
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			err := VerifyTask(ctx, tc.task, kubeclient)
-			if (err != nil) != tc.wantErr {
-				t.Fatalf("verifyTaskRun() get err %v, wantErr %t", err, tc.wantErr)
+			vr := VerifyTask(ctx, tc.task, kubeclient, "", nil)
+			if (vr.VerificationResultType == VerificationError) != tc.wantErr {
+				t.Fatalf("VerifyTask() get err %v, wantErr %t", vr.Err, tc.wantErr)
+			}
+		})
+	}
+
+}
+
+func TestVerifyTask_VerificationPolicy(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+
+	signer, keypath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyData, err := ioutil.ReadFile(filepath.Clean(keypath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherKeypath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyData, err := ioutil.ReadFile(filepath.Clean(otherKeypath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := &v1.Secret{
+		Data: map[string][]byte{"cosign.pub": keyData},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "verification-secrets",
+			Namespace: namespace}}
+	kubeclient := fakek8s.NewSimpleClientset(secret)
+
+	unsignedTask := test.GetUnsignedTask("test-task")
+	signedTask, err := test.GetSignedTask(unsignedTask, signer, "signed")
+	if err != nil {
+		t.Fatal("fail to sign task", err)
+	}
+
+	policy := func(name, pattern string, mode v1alpha1.ModeType, key v1alpha1.KeyRef) *v1alpha1.VerificationPolicy {
+		return &v1alpha1.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha1.VerificationPolicySpec{
+				Resources:   []v1alpha1.ResourcePattern{{Pattern: pattern}},
+				Authorities: []v1alpha1.Authority{{Name: "key", Key: &key}},
+				Mode:        mode,
+			},
+		}
+	}
+	teamA := policy("team-a", "https://github.com/team-a/.*", "", v1alpha1.KeyRef{Data: string(keyData)})
+	teamASecret := policy("team-a-secret", "https://github.com/team-a/.*", "", v1alpha1.KeyRef{SecretRef: &v1.SecretReference{Name: "verification-secrets"}})
+	teamB := policy("team-b", "https://github.com/team-b/.*", "", v1alpha1.KeyRef{Data: string(otherKeyData)})
+	teamBWarn := policy("team-b-warn", "https://github.com/team-b/.*", v1alpha1.ModeWarn, v1alpha1.KeyRef{Data: string(otherKeyData)})
+
+	tcs := []struct {
+		name        string
+		mode        string
+		source      string
+		policies    []*v1alpha1.VerificationPolicy
+		wantType    VerificationResultType
+		wantMatched []string
+		wantErr     error
+	}{{
+		name:        "signed with the key of the matching policy",
+		mode:        config.EnforceResourceVerificationMode,
+		source:      "https://github.com/team-a/catalog.git",
+		policies:    []*v1alpha1.VerificationPolicy{teamA, teamB},
+		wantType:    VerificationPass,
+		wantMatched: []string{"team-a"},
+	}, {
+		name:        "signed with the secret key of the matching policy",
+		mode:        config.EnforceResourceVerificationMode,
+		source:      "https://github.com/team-a/catalog.git",
+		policies:    []*v1alpha1.VerificationPolicy{teamASecret},
+		wantType:    VerificationPass,
+		wantMatched: []string{"team-a-secret"},
+	}, {
+		name:        "all the matching policies must pass",
+		mode:        config.EnforceResourceVerificationMode,
+		source:      "https://github.com/team-a/catalog.git",
+		policies:    []*v1alpha1.VerificationPolicy{teamASecret, teamA},
+		wantType:    VerificationPass,
+		wantMatched: []string{"team-a", "team-a-secret"},
+	}, {
+		name:        "signed with the key of another team",
+		mode:        config.EnforceResourceVerificationMode,
+		source:      "https://github.com/team-b/catalog.git",
+		policies:    []*v1alpha1.VerificationPolicy{teamA, teamB},
+		wantType:    VerificationError,
+		wantMatched: []string{"team-b"},
+	}, {
+		name:        "policy in warn mode",
+		mode:        config.EnforceResourceVerificationMode,
+		source:      "https://github.com/team-b/catalog.git",
+		policies:    []*v1alpha1.VerificationPolicy{teamA, teamBWarn},
+		wantType:    VerificationWarn,
+		wantMatched: []string{"team-b-warn"},
+	}, {
+		name:        "failing policy with the warn resource-verification-mode",
+		mode:        config.WarnResourceVerificationMode,
+		source:      "https://github.com/team-b/catalog.git",
+		policies:    []*v1alpha1.VerificationPolicy{teamA, teamB},
+		wantType:    VerificationWarn,
+		wantMatched: []string{"team-b"},
+	}, {
+		name:     "no matching policy",
+		mode:     config.EnforceResourceVerificationMode,
+		source:   "https://github.com/team-c/catalog.git",
+		policies: []*v1alpha1.VerificationPolicy{teamA, teamB},
+		wantType: VerificationError,
+		wantErr:  ErrNoMatchedPolicies,
+	}, {
+		name:     "pattern matching only part of the source",
+		mode:     config.EnforceResourceVerificationMode,
+		source:   "https://evil.example.com/https://github.com/team-b/catalog.git",
+		policies: []*v1alpha1.VerificationPolicy{teamA, teamBWarn},
+		wantType: VerificationError,
+		wantErr:  ErrNoMatchedPolicies,
+	}, {
+		name:     "skip mode",
+		mode:     config.SkipResourceVerificationMode,
+		source:   "https://github.com/team-b/catalog.git",
+		policies: []*v1alpha1.VerificationPolicy{teamA, teamB},
+		wantType: VerificationSkip,
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := test.SetupTrustedResourceConfig(ctx, keypath, tc.mode)
+			vr := VerifyTask(ctx, signedTask, kubeclient, tc.source, tc.policies)
+			if vr.VerificationResultType != tc.wantType {
+				t.Fatalf("VerifyTask() got result type %d, want %d, err: %v", vr.VerificationResultType, tc.wantType, vr.Err)
+			}
+			if d := cmp.Diff(tc.wantMatched, vr.MatchedPolicies); d != "" {
+				t.Errorf("Matched policies %s", diff.PrintWantGot(d))
+			}
+			if tc.wantErr != nil && !errors.Is(vr.Err, tc.wantErr) {
+				t.Errorf("VerifyTask() got err %v, want %v", vr.Err, tc.wantErr)
 			}
 		})
 	}
+}
+
+func TestVerifyPipeline_VerificationPolicy(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+
+	signer, keypath, err := test.GetSignerFromFile(ctx, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyData, err := ioutil.ReadFile(filepath.Clean(keypath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx = test.SetupTrustedResourceConfig(ctx, keypath, config.EnforceResourceVerificationMode)
+
+	unsignedPipeline := test.GetUnsignedPipeline("test-pipeline")
+	signedPipeline, err := test.GetSignedPipeline(unsignedPipeline, signer, "signed")
+	if err != nil {
+		t.Fatal("fail to sign pipeline", err)
+	}
+	tamperedPipeline := signedPipeline.DeepCopy()
+	tamperedPipeline.Annotations["random"] = "attack"
 
+	policies := []*v1alpha1.VerificationPolicy{{
+		ObjectMeta: metav1.ObjectMeta{Name: "catalog", Namespace: namespace},
+		Spec: v1alpha1.VerificationPolicySpec{
+			Resources:   []v1alpha1.ResourcePattern{{Pattern: "gcr.io/tekton-releases/catalog/upstream/.*"}},
+			Authorities: []v1alpha1.Authority{{Name: "key", Key: &v1alpha1.KeyRef{Data: string(keyData), HashAlgorithm: "sha256"}}},
+		},
+	}}
+
+	tcs := []struct {
+		name     string
+		pipeline v1beta1.PipelineObject
+		wantType VerificationResultType
+	}{{
+		name:     "signed pipeline passes verification",
+		pipeline: signedPipeline,
+		wantType: VerificationPass,
+	}, {
+		name:     "tampered pipeline fails verification",
+		pipeline: tamperedPipeline,
+		wantType: VerificationError,
+	}, {
+		name:     "unsigned pipeline fails verification",
+		pipeline: unsignedPipeline,
+		wantType: VerificationError,
+	}}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			vr := VerifyPipeline(ctx, tc.pipeline, nil, "gcr.io/tekton-releases/catalog/upstream/git-clone", policies)
+			if vr.VerificationResultType != tc.wantType {
+				t.Fatalf("VerifyPipeline() got result type %d, want %d, err: %v", vr.VerificationResultType, tc.wantType, vr.Err)
+			}
+		})
+	}
 }
 
 func TestPrepareObjectMeta(t *testing.T) {
@@ -33,6 +33,7 @@ import (
 	informersv1beta1 "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1beta1"
 	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
 	fakeruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/run/fake"
+	fakeverificationpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/verificationpolicy/fake"
 	fakeclustertaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/clustertask/fake"
 	fakecustomruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/customrun/fake"
 	fakepipelineinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipeline/fake"
@@ -62,6 +63,7 @@ import (
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	fakeconfigmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 	fakelimitrangeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/limitrange/fake"
+	fakenamespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace/fake"
 	fakefilteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered/fake"
 	fakeserviceaccountinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount/fake"
 	"knative.dev/pkg/controller"
@@ -85,6 +87,7 @@ type Data struct {
 	ServiceAccounts         []*corev1.ServiceAccount
 	LimitRange              []*corev1.LimitRange
 	ResolutionRequests      []*resolutionv1alpha1.ResolutionRequest
+	VerificationPolicies    []*v1alpha1.VerificationPolicy
 	ExpectedCloudEventCount int
 }
 
@@ -99,19 +102,21 @@ type Clients struct {
 
 // Informers holds references to informers which are useful for reconciler tests.
 type Informers struct {
-	PipelineRun       informersv1beta1.PipelineRunInformer
-	Pipeline          informersv1beta1.PipelineInformer
-	TaskRun           informersv1beta1.TaskRunInformer
-	Run               informersv1alpha1.RunInformer
-	CustomRun         informersv1beta1.CustomRunInformer
-	Task              informersv1beta1.TaskInformer
-	ClusterTask       informersv1beta1.ClusterTaskInformer
-	PipelineResource  resourceinformersv1alpha1.PipelineResourceInformer
-	Pod               coreinformers.PodInformer
-	ConfigMap         coreinformers.ConfigMapInformer
-	ServiceAccount    coreinformers.ServiceAccountInformer
-	LimitRange        coreinformers.LimitRangeInformer
-	ResolutionRequest resolutioninformersv1alpha1.ResolutionRequestInformer
+	PipelineRun        informersv1beta1.PipelineRunInformer
+	Pipeline           informersv1beta1.PipelineInformer
+	TaskRun            informersv1beta1.TaskRunInformer
+	Run                informersv1alpha1.RunInformer
+	CustomRun          informersv1beta1.CustomRunInformer
+	Task               informersv1beta1.TaskInformer
+	ClusterTask        informersv1beta1.ClusterTaskInformer
+	PipelineResource   resourceinformersv1alpha1.PipelineResourceInformer
+	Pod                coreinformers.PodInformer
+	ConfigMap          coreinformers.ConfigMapInformer
+	ServiceAccount     coreinformers.ServiceAccountInformer
+	LimitRange         coreinformers.LimitRangeInformer
+	Namespace          coreinformers.NamespaceInformer
+	ResolutionRequest  resolutioninformersv1alpha1.ResolutionRequestInformer
+	VerificationPolicy informersv1alpha1.VerificationPolicyInformer
 }
 
 // Assets holds references to the controller, logs, clients, and informers.
@@ -183,19 +188,21 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 	PrependResourceVersionReactor(&c.Pipeline.Fake)
 
 	i := Informers{
-		PipelineRun:       fakepipelineruninformer.Get(ctx),
-		Pipeline:          fakepipelineinformer.Get(ctx),
-		TaskRun:           faketaskruninformer.Get(ctx),
-		Run:               fakeruninformer.Get(ctx),
-		CustomRun:         fakecustomruninformer.Get(ctx),
-		Task:              faketaskinformer.Get(ctx),
-		ClusterTask:       fakeclustertaskinformer.Get(ctx),
-		PipelineResource:  fakeresourceinformer.Get(ctx),
-		Pod:               fakefilteredpodinformer.Get(ctx, v1beta1.ManagedByLabelKey),
-		ConfigMap:         fakeconfigmapinformer.Get(ctx),
-		ServiceAccount:    fakeserviceaccountinformer.Get(ctx),
-		LimitRange:        fakelimitrangeinformer.Get(ctx),
-		ResolutionRequest: fakeresolutionrequestinformer.Get(ctx),
+		PipelineRun:        fakepipelineruninformer.Get(ctx),
+		Pipeline:           fakepipelineinformer.Get(ctx),
+		TaskRun:            faketaskruninformer.Get(ctx),
+		Run:                fakeruninformer.Get(ctx),
+		CustomRun:          fakecustomruninformer.Get(ctx),
+		Task:               faketaskinformer.Get(ctx),
+		ClusterTask:        fakeclustertaskinformer.Get(ctx),
+		PipelineResource:   fakeresourceinformer.Get(ctx),
+		Pod:                fakefilteredpodinformer.Get(ctx, v1beta1.ManagedByLabelKey),
+		ConfigMap:          fakeconfigmapinformer.Get(ctx),
+		ServiceAccount:     fakeserviceaccountinformer.Get(ctx),
+		LimitRange:         fakelimitrangeinformer.Get(ctx),
+		Namespace:          fakenamespaceinformer.Get(ctx),
+		ResolutionRequest:  fakeresolutionrequestinformer.Get(ctx),
+		VerificationPolicy: fakeverificationpolicyinformer.Get(ctx),
 	}
 
 	// Attach reactors that add resource mutations to the appropriate
@@ -264,6 +271,7 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Kube.PrependReactor("*", "namespaces", AddToInformer(t, i.Namespace.Informer().GetIndexer()))
 	for _, n := range d.Namespaces {
 		n := n.DeepCopy() // Avoid assumptions that the informer's copy is modified.
 		if _, err := c.Kube.CoreV1().Namespaces().Create(ctx, n, metav1.CreateOptions{}); err != nil {
@@ -291,6 +299,13 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Pipeline.PrependReactor("*", "verificationpolicies", AddToInformer(t, i.VerificationPolicy.Informer().GetIndexer()))
+	for _, vp := range d.VerificationPolicies {
+		vp := vp.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().VerificationPolicies(vp.Namespace).Create(ctx, vp, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
 	c.Pipeline.ClearActions()
 	c.Kube.ClearActions()
 	c.ResolutionRequests.ClearActions()
@@ -340,7 +355,7 @@ func PrependResourceVersionReactor(f *ktesting.Fake) {
 
 // EnsureConfigurationConfigMapsExist makes sure all the configmaps exists.
 func EnsureConfigurationConfigMapsExist(d *Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists, trustedresourcesExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists, trustedresourcesExists, schedulingExists, policyExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -360,6 +375,12 @@ func EnsureConfigurationConfigMapsExist(d *Data) {
 		if cm.Name == config.GetTrustedResourcesConfigName() {
 			trustedresourcesExists = true
 		}
+		if cm.Name == config.GetSchedulingConfigName() {
+			schedulingExists = true
+		}
+		if cm.Name == config.GetPolicyConfigName() {
+			policyExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -397,4 +418,16 @@ func EnsureConfigurationConfigMapsExist(d *Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !schedulingExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetSchedulingConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !policyExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetPolicyConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
@@ -158,6 +158,14 @@ func TestEnsureConfigurationConfigMapsExist(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{Name: config.GetTrustedResourcesConfigName(), Namespace: system.Namespace()},
 		Data:       map[string]string{},
 	})
+	expected.ConfigMaps = append(expected.ConfigMaps, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetSchedulingConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{},
+	})
+	expected.ConfigMaps = append(expected.ConfigMaps, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetPolicyConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{},
+	})
 
 	EnsureConfigurationConfigMapsExist(&d)
 	if d := cmp.Diff(expected, d); d != "" {
@@ -28,6 +28,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 )
 
 // TestHermeticTaskRun make sure that the hermetic execution mode actually drops network from a TaskRun step
@@ -77,7 +78,14 @@ func TestHermeticTaskRun(t *testing.T) {
 				t.Fatalf("Failed to create TaskRun `%s`: %s", regularTaskRun.Name, err)
 			}
 			if err := WaitForTaskRunState(ctx, c, hermeticTaskRunName, Failed(hermeticTaskRunName), "Failed"); err != nil {
-				t.Errorf("Error waiting for TaskRun %s to fail: %s", hermeticTaskRunName, err)
+				t.Fatalf("Error waiting for TaskRun %s to fail: %s", hermeticTaskRunName, err)
+			}
+			tr, err := c.V1beta1TaskRunClient.Get(ctx, hermeticTaskRunName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Failed to get TaskRun `%s`: %s", hermeticTaskRunName, err)
+			}
+			if reason := tr.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != v1beta1.TaskRunReasonHermeticStepFailed.String() {
+				t.Errorf("Expected TaskRun %s to fail with reason %s but got %s", hermeticTaskRunName, v1beta1.TaskRunReasonHermeticStepFailed, reason)
 			}
 		})
 	}
@@ -0,0 +1,330 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+const (
+	// certificateAnnotation is the key of the certificate chain of a keyless signature in annotation map
+	certificateAnnotation = "tekton.dev/certificate"
+	// tlogEntryAnnotation is the key of the transparency log entry of a keyless signature in annotation map
+	tlogEntryAnnotation = "tekton.dev/tlog-entry"
+)
+
+// oidIssuer is the extension of Fulcio certificates recording the OIDC issuer of the signer.
+var oidIssuer = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+
+// KeylessCA is an in-memory certificate authority issuing short-lived signing
+// certificates to signer identities, standing in for Fulcio in tests.
+type KeylessCA struct {
+	// RootPEM is the PEM encoded root certificate of the CA.
+	RootPEM []byte
+
+	root *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// NewKeylessCA returns a KeylessCA with a newly generated root certificate.
+func NewKeylessCA() (*KeylessCA, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "tekton-test-ca"},
+		NotBefore:             time.Now().Add(-24 * time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	root, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	rootPEM, err := cryptoutils.MarshalCertificateToPEM(root)
+	if err != nil {
+		return nil, err
+	}
+	return &KeylessCA{RootPEM: rootPEM, root: root, key: key}, nil
+}
+
+// IssueCertificate returns a signer with a newly generated key and the PEM
+// encoded certificate chain of the key, issued to the subject (an email or a
+// URI) authenticated by the OIDC issuer, and valid between notBefore and notAfter.
+func (ca *KeylessCA) IssueCertificate(subject, issuer string, notBefore, notAfter time.Time) (signature.SignerVerifier, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	issuerValue, err := asn1.Marshal(issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:    big.NewInt(time.Now().UnixNano()),
+		NotBefore:       notBefore,
+		NotAfter:        notAfter,
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		ExtraExtensions: []pkix.Extension{{Id: oidIssuer, Value: issuerValue}},
+	}
+	if u, err := url.Parse(subject); err == nil && u.Scheme != "" {
+		template.URIs = []*url.URL{u}
+	} else {
+		template.EmailAddresses = []string{subject}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.root, key.Public(), ca.key)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	chain, err := cryptoutils.MarshalCertificateToPEM(cert)
+	if err != nil {
+		return nil, nil, err
+	}
+	sv, err := signature.LoadSignerVerifier(key, crypto.SHA256)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sv, chain, nil
+}
+
+// TransparencyLog is an in-memory RFC 6962 transparency log, standing in for
+// Rekor in tests.
+type TransparencyLog struct {
+	// PublicKeyPEM is the PEM encoded public key signing the entry timestamps.
+	PublicKeyPEM []byte
+
+	signer signature.Signer
+	leaves [][]byte
+}
+
+// tlogEntry mirrors trustedresources.TransparencyLogEntry.
+type tlogEntry struct {
+	LogIndex             int64    `json:"logIndex"`
+	IntegratedTime       int64    `json:"integratedTime"`
+	TreeSize             int64    `json:"treeSize"`
+	RootHash             []byte   `json:"rootHash"`
+	Hashes               [][]byte `json:"hashes"`
+	SignedEntryTimestamp []byte   `json:"signedEntryTimestamp"`
+}
+
+// tlogEntryBody mirrors trustedresources.TransparencyLogEntryBody.
+type tlogEntryBody struct {
+	Digest      []byte `json:"digest"`
+	Signature   []byte `json:"signature"`
+	Certificate []byte `json:"certificate"`
+}
+
+// signedEntryTimestampPayload mirrors trustedresources.SignedEntryTimestampPayload.
+type signedEntryTimestampPayload struct {
+	LogIndex       int64  `json:"logIndex"`
+	IntegratedTime int64  `json:"integratedTime"`
+	TreeSize       int64  `json:"treeSize"`
+	RootHash       []byte `json:"rootHash"`
+}
+
+// NewTransparencyLog returns an empty TransparencyLog with a newly generated key.
+func NewTransparencyLog() (*TransparencyLog, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := cryptoutils.MarshalPublicKeyToPEM(key.Public())
+	if err != nil {
+		return nil, err
+	}
+	signer, err := signature.LoadSigner(key, crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	return &TransparencyLog{PublicKeyPEM: pub, signer: signer}, nil
+}
+
+// Append integrates the leaf in the log at the given time, and returns the
+// JSON encoded entry proving its inclusion.
+func (l *TransparencyLog) Append(leaf []byte, integratedTime time.Time) ([]byte, error) {
+	l.leaves = append(l.leaves, leaf)
+	index := len(l.leaves) - 1
+	entry := tlogEntry{
+		LogIndex:       int64(index),
+		IntegratedTime: integratedTime.Unix(),
+		TreeSize:       int64(len(l.leaves)),
+		RootHash:       merkleTreeHash(l.leaves),
+		Hashes:         inclusionProof(index, l.leaves),
+	}
+	payload, err := json.Marshal(signedEntryTimestampPayload{
+		LogIndex:       entry.LogIndex,
+		IntegratedTime: entry.IntegratedTime,
+		TreeSize:       entry.TreeSize,
+		RootHash:       entry.RootHash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	entry.SignedEntryTimestamp, err = l.signer.SignMessage(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(entry)
+}
+
+// merkleTreeHash returns the RFC 6962 Merkle Tree Hash of the leaves.
+func merkleTreeHash(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		h := sha256.Sum256(nil)
+		return h[:]
+	case 1:
+		return hashChildren([]byte{0}, leaves[0], nil)
+	}
+	k := largestPowerOfTwoBelow(len(leaves))
+	return hashChildren([]byte{1}, merkleTreeHash(leaves[:k]), merkleTreeHash(leaves[k:]))
+}
+
+// inclusionProof returns the RFC 6962 audit path of the leaf at index m.
+func inclusionProof(m int, leaves [][]byte) [][]byte {
+	if len(leaves) <= 1 {
+		return nil
+	}
+	k := largestPowerOfTwoBelow(len(leaves))
+	if m < k {
+		return append(inclusionProof(m, leaves[:k]), merkleTreeHash(leaves[k:]))
+	}
+	return append(inclusionProof(m-k, leaves[k:]), merkleTreeHash(leaves[:k]))
+}
+
+func largestPowerOfTwoBelow(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+func hashChildren(prefix, left, right []byte) []byte {
+	h := sha256.New()
+	h.Write(prefix)
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// GetKeylessSignedTask signs the given task with the signer of a certificate
+// issued by a KeylessCA, renames it with given name and stores the certificate
+// chain in its annotations. If tlog is not nil, the signature is also
+// appended to it at integratedTime and the log entry stored in the annotations.
+func GetKeylessSignedTask(unsigned *v1beta1.Task, signer signature.Signer, chain []byte, tlog *TransparencyLog, integratedTime time.Time, name string) (*v1beta1.Task, error) {
+	signedTask := unsigned.DeepCopy()
+	signedTask.Name = name
+	if signedTask.Annotations == nil {
+		signedTask.Annotations = map[string]string{}
+	}
+	annotations, err := keylessAnnotations(signedTask, signer, chain, tlog, integratedTime)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range annotations {
+		signedTask.Annotations[k] = v
+	}
+	return signedTask, nil
+}
+
+// GetKeylessSignedPipeline signs the given pipeline as GetKeylessSignedTask does.
+func GetKeylessSignedPipeline(unsigned *v1beta1.Pipeline, signer signature.Signer, chain []byte, tlog *TransparencyLog, integratedTime time.Time, name string) (*v1beta1.Pipeline, error) {
+	signedPipeline := unsigned.DeepCopy()
+	signedPipeline.Name = name
+	if signedPipeline.Annotations == nil {
+		signedPipeline.Annotations = map[string]string{}
+	}
+	annotations, err := keylessAnnotations(signedPipeline, signer, chain, tlog, integratedTime)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range annotations {
+		signedPipeline.Annotations[k] = v
+	}
+	return signedPipeline, nil
+}
+
+// keylessAnnotations returns the signature, certificate and transparency log
+// entry annotations of the keyless signature of the object.
+func keylessAnnotations(obj interface{}, signer signature.Signer, chain []byte, tlog *TransparencyLog, integratedTime time.Time) (map[string]string, error) {
+	sig, err := signInterface(signer, obj)
+	if err != nil {
+		return nil, err
+	}
+	annotations := map[string]string{
+		signatureAnnotation:   base64.StdEncoding.EncodeToString(sig),
+		certificateAnnotation: base64.StdEncoding.EncodeToString(chain),
+	}
+	if tlog == nil {
+		return annotations, nil
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(b)
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(chain)
+	if err != nil {
+		return nil, err
+	}
+	leafCert, err := cryptoutils.MarshalCertificateToPEM(certs[0])
+	if err != nil {
+		return nil, err
+	}
+	leaf, err := json.Marshal(tlogEntryBody{Digest: digest[:], Signature: sig, Certificate: leafCert})
+	if err != nil {
+		return nil, err
+	}
+	entry, err := tlog.Append(leaf, integratedTime)
+	if err != nil {
+		return nil, err
+	}
+	annotations[tlogEntryAnnotation] = base64.StdEncoding.EncodeToString(entry)
+	return annotations, nil
+}